@@ -0,0 +1,42 @@
+package ktf
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+)
+
+// -----------------------------------------------------------------------------
+// Addons - Base Command
+// -----------------------------------------------------------------------------
+
+func init() { //nolint:gochecknoinits
+	rootCmd.AddCommand(addonsCmd)
+}
+
+var addonsCmd = &cobra.Command{
+	Use:   "addons",
+	Short: "list the addons which can be deployed to testing environments and their options",
+	Run: func(cmd *cobra.Command, args []string) {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0) //nolint:gomnd
+		for _, name := range clusters.RegisteredAddons() {
+			factory, _ := clusters.LookupAddon(name)
+			fmt.Fprintf(w, "%s\t%s\n", name, factory.Description)
+			for _, option := range factory.Options {
+				details := option.Description
+				switch {
+				case option.Required:
+					details += " (required)"
+				case option.Default != "":
+					details += fmt.Sprintf(" (default: %q)", option.Default)
+				}
+				fmt.Fprintf(w, "  %s.%s\t%s\n", name, option.Name, details)
+			}
+		}
+		cobra.CheckErr(w.Flush())
+	},
+}
@@ -3,22 +3,21 @@ package ktf
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/blang/semver/v4"
 	"github.com/spf13/cobra"
 
-	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/argocd"
-	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/certmanager"
-	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/httpbin"
-	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/istio"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	_ "github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/builtin"
 	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/kong"
-	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/kongargo"
-	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/kuma"
-	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/metallb"
 	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/registry"
 	"github.com/kong/kubernetes-testing-framework/pkg/clusters/types/kind"
 	"github.com/kong/kubernetes-testing-framework/pkg/environments"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/timings"
 )
 
 // -----------------------------------------------------------------------------
@@ -52,7 +51,10 @@ func init() { //nolint:gochecknoinits
 	environmentsCreateCmd.PersistentFlags().Bool("ipv6-only", false, "only use IPv6")
 
 	// addon configurations
-	environmentsCreateCmd.PersistentFlags().StringArray("addon", nil, "name of an addon to deploy to the testing environment's cluster")
+	environmentsCreateCmd.PersistentFlags().StringArray("addon", nil, "name of an addon to deploy to the testing environment's cluster (see \"ktf addons\")")
+	environmentsCreateCmd.PersistentFlags().Int("addon-concurrency", environments.DefaultAddonDeployConcurrency, "maximum number of addons to deploy concurrently (0 for no limit)")
+	environmentsCreateCmd.PersistentFlags().String("timings-report", "", "path of a JSON file to write the durations of the cluster build, addon deployments and readiness wait to")
+	environmentsCreateCmd.PersistentFlags().StringArray("addon-option", nil, "option for an addon to deploy, as <addon>.<option>=<value> (see \"ktf addons\")")
 	environmentsCreateCmd.PersistentFlags().Bool("kong-disable-controller", false, "indicate whether the kong addon should have the controller disabled (proxy only)")
 	environmentsCreateCmd.PersistentFlags().Bool("kong-admin-service-loadbalancer", false, "indicate whether the kong addon should deploy the proxy admin service as a LoadBalancer type")
 	environmentsCreateCmd.PersistentFlags().String("kong-ingress-controller-image", "", "use a specific ingress controller container image for the Gateway (proxy)")
@@ -75,6 +77,10 @@ var environmentsCreateCmd = &cobra.Command{
 		deployAddons, err := cmd.PersistentFlags().GetStringArray("addon")
 		cobra.CheckErr(err)
 
+		// check how many addons may be deployed concurrently
+		addonConcurrency, err := cmd.PersistentFlags().GetInt("addon-concurrency")
+		cobra.CheckErr(err)
+
 		// verify whether the environment was flagged to use a generated name
 		useGeneratedName, err := cmd.PersistentFlags().GetBool("generate-name")
 		cobra.CheckErr(err)
@@ -92,7 +98,7 @@ var environmentsCreateCmd = &cobra.Command{
 		cobra.CheckErr(err)
 
 		// setup the new environment
-		builder := environments.NewBuilder()
+		builder := environments.NewBuilder().WithAddonDeployConcurrency(addonConcurrency)
 		if !useGeneratedName {
 			builder = builder.WithName(name)
 		}
@@ -111,9 +117,26 @@ var environmentsCreateCmd = &cobra.Command{
 		// configure any addons that need to be deployed with the environment's cluster
 		callbacks := configureAddons(cmd, builder, deployAddons)
 
+		timingsReport, err := cmd.PersistentFlags().GetString("timings-report")
+		cobra.CheckErr(err)
+		collector := timings.CollectForCluster(builder.Name)
+		var reportOnce sync.Once
+		reportTimings := func() { reportOnce.Do(func() { writeTimingsReport(collector, timingsReport) }) }
+		defer reportTimings()
+		// cobra.CheckErr exits without running deferred functions, so the
+		// timings of failed environments are reported before checking errors.
+		checkErr := func(err error) {
+			if err != nil {
+				reportTimings()
+			}
+			cobra.CheckErr(err)
+		}
+
 		fmt.Printf("building new environment %s\n", builder.Name)
+		unsubscribe := clusters.SubscribeClusterEvents(builder.Name, printProgress)
 		env, err := builder.Build(ctx)
-		cobra.CheckErr(err)
+		unsubscribe()
+		checkErr(err)
 
 		addons := env.Cluster().ListAddons()
 		for _, addon := range addons {
@@ -121,7 +144,7 @@ var environmentsCreateCmd = &cobra.Command{
 		}
 
 		fmt.Println("waiting for environment to become ready (this can take some time)...")
-		cobra.CheckErr(<-env.WaitForReady(ctx))
+		checkErr(<-env.WaitForReady(ctx))
 
 		fmt.Printf("environment %s was created successfully!\n", env.Name())
 		for _, callback := range callbacks {
@@ -130,6 +153,34 @@ var environmentsCreateCmd = &cobra.Command{
 	},
 }
 
+// writeTimingsReport stops collecting timings and writes their report to the
+// file at the given path, if any.
+func writeTimingsReport(collector *timings.Collector, path string) {
+	collector.Stop()
+	if path == "" {
+		return
+	}
+	if err := collector.WriteReport(path); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write timings report to %s: %v\n", path, err)
+		return
+	}
+	fmt.Printf("timings were reported to %s\n", path)
+}
+
+// printProgress reports the progress of the creation of an environment.
+func printProgress(event clusters.Event) {
+	switch {
+	case event.Err != nil:
+		fmt.Printf("%s failed after %s: %v\n", event.Type, event.Duration.Round(time.Second), event.Err)
+	case event.Type == clusters.EventClusterCreated:
+		fmt.Printf("cluster %s was created in %s\n", event.Cluster, event.Duration.Round(time.Second))
+	case event.Type == clusters.EventAddonDeploying:
+		fmt.Printf("deploying addon %s...\n", event.Addon)
+	case event.Type == clusters.EventAddonDeployed:
+		fmt.Printf("addon %s was deployed in %s\n", event.Addon, event.Duration.Round(time.Second))
+	}
+}
+
 func configureAddons(cmd *cobra.Command, builder *environments.Builder, addons []string) []func() {
 	invalid, dedup := make([]string, 0), make(map[string]bool)
 	// sometimes some addons which are configured for need to do something AFTER
@@ -137,38 +188,19 @@ func configureAddons(cmd *cobra.Command, builder *environments.Builder, addons [
 	// logging messages.
 	callbacks := make([]func(), 0)
 
+	options, err := addonOptions(cmd)
+	cobra.CheckErr(err)
+
 	for _, addon := range addons {
 		// load any valid addons, and check for invalid addons
-		switch addon {
-		case "metallb":
-			builder = builder.WithAddons(metallb.New())
-		case "kong":
-			builder = configureKongAddon(cmd, builder)
-		case "istio":
-			istioAddon := istio.NewBuilder().
-				WithGrafana().
-				WithJaeger().
-				WithKiali().
-				WithPrometheus().
-				Build()
-			builder = builder.WithAddons(istioAddon)
-		case "httpbin":
-			builder = builder.WithAddons(httpbin.New())
-		case "cert-manager":
-			builder = builder.WithAddons(certmanager.New())
-		case "kuma":
-			builder = builder.WithAddons(kuma.New())
-		case "argocd":
-			argoAddon := argocd.NewBuilder().Build()
-			builder = builder.WithAddons(argoAddon)
-		case "kong-argo":
-			kongArgoAddon := kongargo.NewBuilder().Build()
-			builder = builder.WithAddons(kongArgoAddon)
-		case "registry":
-			registryAddon := registry.NewBuilder().
-				WithServiceTypeLoadBalancer().
-				Build()
-			builder = builder.WithAddons(registryAddon)
+		name := clusters.AddonName(addon)
+		if _, ok := clusters.LookupAddon(name); ok {
+			builder = builder.WithRegisteredAddon(name, options[name])
+		} else {
+			invalid = append(invalid, addon)
+		}
+
+		if name == registry.AddonName {
 			registryInfoCallback := func() {
 				fmt.Printf(`
 Registry Addon HELP:
@@ -201,11 +233,9 @@ Afterwards you should be able to push images to the registry, e.g.:
 
 Images pushed this way should be immediately usable in pod configurations
 on the cluster as the certificate is automatically configured on the nodes.
-`, registryAddon.Namespace(), registryAddon.Namespace())
+`, registry.Namespace, registry.Namespace)
 			}
 			callbacks = append(callbacks, registryInfoCallback)
-		default:
-			invalid = append(invalid, addon)
 		}
 
 		// fail if any duplicate addons were provided
@@ -219,67 +249,52 @@ on the cluster as the certificate is automatically configured on the nodes.
 		cobra.CheckErr(fmt.Errorf("%d addons were invalid: %s", len(invalid), invalid))
 	}
 
-	return callbacks
-}
-
-func configureKongAddon(cmd *cobra.Command, envBuilder *environments.Builder) *environments.Builder {
-	builder := kong.NewBuilder()
-
-	disableController, err := cmd.PersistentFlags().GetBool("kong-disable-controller")
-	cobra.CheckErr(err)
-
-	if disableController {
-		builder.WithControllerDisabled()
+	for name := range options {
+		if !dedup[string(name)] {
+			cobra.CheckErr(fmt.Errorf("options were provided for addon %s which is not deployed", name))
+		}
 	}
 
-	customGatewayImage, err := cmd.PersistentFlags().GetString("kong-gateway-image")
-	cobra.CheckErr(err)
+	return callbacks
+}
 
-	if customGatewayImage != "" {
-		imageParts := strings.Split(customGatewayImage, ":")
-		if len(imageParts) == 1 {
-			imageParts = append(imageParts, "latest")
+// addonOptions collects the options for addons provided with --addon-option
+// (as "<addon>.<option>=<value>") and the legacy kong flags.
+func addonOptions(cmd *cobra.Command) (map[clusters.AddonName]clusters.AddonOptions, error) {
+	options := make(map[clusters.AddonName]clusters.AddonOptions)
+	set := func(addon clusters.AddonName, option, value string) {
+		if options[addon] == nil {
+			options[addon] = make(clusters.AddonOptions)
 		}
-		if len(imageParts) != 2 { //nolint:gomnd
-			cobra.CheckErr(fmt.Errorf("malformed --kong-gateway-image: %s", customGatewayImage))
-		}
-		builder.WithProxyImage(imageParts[0], imageParts[1])
+		options[addon][option] = value
 	}
 
-	customControllerImage, err := cmd.PersistentFlags().GetString("kong-ingress-controller-image")
-	cobra.CheckErr(err)
-
-	if customControllerImage != "" {
-		imageParts := strings.Split(customControllerImage, ":")
-		if len(imageParts) == 1 {
-			imageParts = append(imageParts, "latest")
+	for flag, option := range map[string]string{
+		"kong-disable-controller":         "disable-controller",
+		"kong-admin-service-loadbalancer": "admin-service-loadbalancer",
+		"kong-ingress-controller-image":   "controller-image",
+		"kong-gateway-image":              "gateway-image",
+		"kong-dbmode":                     "dbmode",
+	} {
+		if f := cmd.PersistentFlags().Lookup(flag); f != nil && f.Changed {
+			set(kong.AddonName, option, f.Value.String())
 		}
-		if len(imageParts) != 2 { //nolint:gomnd
-			cobra.CheckErr(fmt.Errorf("malformed --kong-ingress-controller-image: %s", customControllerImage))
-		}
-		builder.WithControllerImage(imageParts[0], imageParts[1])
 	}
 
-	enableAdminSvcLB, err := cmd.PersistentFlags().GetBool("kong-admin-service-loadbalancer")
-	cobra.CheckErr(err)
-
-	if enableAdminSvcLB {
-		builder.WithProxyAdminServiceTypeLoadBalancer()
+	values, err := cmd.PersistentFlags().GetStringArray("addon-option")
+	if err != nil {
+		return nil, err
 	}
-
-	dbmode, err := cmd.PersistentFlags().GetString("kong-dbmode")
-	cobra.CheckErr(err)
-
-	switch dbmode {
-	case "off":
-		builder.WithDBLess()
-	case "postgres":
-		builder.WithPostgreSQL()
-	default:
-		cobra.CheckErr(fmt.Errorf("%s is not a valid dbmode for kong, supported modes are \"off\" (DBLESS) or \"postgres\"", dbmode))
+	for _, value := range values {
+		key, optionValue, ok := strings.Cut(value, "=")
+		addon, option, hasAddon := strings.Cut(key, ".")
+		if !ok || !hasAddon || addon == "" || option == "" {
+			return nil, fmt.Errorf("malformed --addon-option %s, expected <addon>.<option>=<value>", value)
+		}
+		set(clusters.AddonName(addon), option, optionValue)
 	}
 
-	return envBuilder.WithAddons(builder.Build())
+	return options, nil
 }
 
 // -----------------------------------------------------------------------------
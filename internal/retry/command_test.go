@@ -3,9 +3,11 @@ package retry_test
 import (
 	"bytes"
 	"context"
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/go-logr/logr/funcr"
 	"github.com/stretchr/testify/require"
 
 	"github.com/kong/kubernetes-testing-framework/internal/retry"
@@ -70,3 +72,22 @@ func TestDo(t *testing.T) {
 	// Alternative would be to wait long enough so that we're it ran but that
 	// would unnecessarily make the tests longer.
 }
+
+func TestFunc(t *testing.T) {
+	var logs []string
+	logger := funcr.New(func(prefix, args string) { logs = append(logs, args) }, funcr.Options{})
+
+	attempts := 0
+	err := retry.Func(context.Background(), logger, "testing", func() error {
+		attempts++
+		if attempts < 2 {
+			return errors.New("not yet")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, attempts)
+	require.Len(t, logs, 1, "the failed attempt should be logged")
+	require.Contains(t, logs[0], `"operation"="testing"`)
+	require.Contains(t, logs[0], `"error"="not yet"`)
+}
@@ -0,0 +1,25 @@
+package retry
+
+import (
+	"context"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/go-logr/logr"
+)
+
+// Func runs the function until it succeeds, with the same attempts and delay
+// as commands. The failed attempts are logged to the logger with the
+// description, e.g. "installing release kong/kong".
+func Func(ctx context.Context, logger logr.Logger, description string, f func() error) error {
+	return retry.Do(f,
+		retry.Context(ctx),
+		retry.Delay(retryWait),
+		retry.Attempts(retryCount),
+		retry.DelayType(retry.FixedDelay),
+		retry.OnRetry(func(n uint, err error) {
+			if err != nil {
+				logger.Info("retrying after error", "operation", description, "attempt", n+1, "error", err.Error())
+			}
+		}),
+	)
+}
@@ -0,0 +1,150 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apiwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+// -----------------------------------------------------------------------------
+// Transient Errors
+// -----------------------------------------------------------------------------
+
+// ErrorClass is the class of a transient error, which is likely to go away if
+// the failed operation is retried.
+type ErrorClass string
+
+const (
+	// NotTransient is the class of errors which are not transient, i.e. which
+	// fail operations for good.
+	NotTransient ErrorClass = ""
+
+	// RateLimited is the class of errors of throttled requests.
+	RateLimited ErrorClass = "RateLimited"
+
+	// Conflict is the class of errors of concurrent modifications, e.g. the
+	// update of an object modified since it was read.
+	Conflict ErrorClass = "Conflict"
+
+	// WebhookNotReady is the class of errors of admission webhooks which can't
+	// be called yet, e.g. right after their deployment.
+	WebhookNotReady ErrorClass = "WebhookNotReady"
+
+	// Unavailable is the class of errors of servers which are temporarily
+	// unavailable or timed out, e.g. while a control plane starts.
+	Unavailable ErrorClass = "Unavailable"
+)
+
+// DefaultBackoff is the backoff between the attempts of OnTransient: starting
+// at 500ms, doubling up to 10s, with 20% jitter, for up to 10 attempts.
+var DefaultBackoff = apiwait.Backoff{
+	Duration: 500 * time.Millisecond, //nolint:gomnd
+	Factor:   2,                      //nolint:gomnd
+	Jitter:   0.2,                    //nolint:gomnd
+	Steps:    10,                     //nolint:gomnd
+	Cap:      10 * time.Second,       //nolint:gomnd
+}
+
+// Classify provides the class of the error, NotTransient if it isn't known to
+// be transient. Kubernetes API errors, gRPC errors (e.g. of the GKE API) and
+// refused or reset connections are classified.
+func Classify(err error) ErrorClass {
+	switch {
+	case err == nil:
+		return NotTransient
+	case apierrors.IsTooManyRequests(err):
+		return RateLimited
+	case apierrors.IsConflict(err):
+		return Conflict
+	case apierrors.IsInternalError(err) && isWebhookError(err):
+		return WebhookNotReady
+	case apierrors.IsServiceUnavailable(err), apierrors.IsServerTimeout(err), apierrors.IsTimeout(err):
+		return Unavailable
+	case errors.Is(err, syscall.ECONNREFUSED), errors.Is(err, syscall.ECONNRESET):
+		return Unavailable
+	}
+
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.ResourceExhausted:
+			return RateLimited
+		case codes.Aborted:
+			return Conflict
+		case codes.Unavailable:
+			return Unavailable
+		}
+	}
+	return NotTransient
+}
+
+// IsTransient indicates whether the error is likely to go away if the failed
+// operation is retried.
+func IsTransient(err error) bool {
+	return Classify(err) != NotTransient
+}
+
+// OnTransient runs the function until it succeeds or fails with an error which
+// isn't transient (see Classify), with DefaultBackoff between attempts. The
+// retries are logged to the logger with the description, e.g. "labeling
+// namespace kong".
+func OnTransient(ctx context.Context, logger logr.Logger, description string, f func(ctx context.Context) error) error {
+	return OnTransientWithBackoff(ctx, logger, DefaultBackoff, description, f)
+}
+
+// OnTransientWithBackoff is OnTransient with the given backoff, whose Steps is
+// the maximum number of attempts.
+func OnTransientWithBackoff(ctx context.Context, logger logr.Logger, backoff apiwait.Backoff, description string, f func(ctx context.Context) error) error {
+	for {
+		err := f(ctx)
+		class := Classify(err)
+		if class == NotTransient {
+			return err
+		}
+		if backoff.Steps <= 1 {
+			return fmt.Errorf("gave up %s after transient errors: %w", description, err)
+		}
+		delay := backoff.Step()
+		logger.Info("retrying after transient error", "operation", description, "class", class, "delay", delay, "error", err.Error())
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("context done while retrying %s: %w", description, errors.Join(ctx.Err(), err))
+		case <-time.After(delay):
+		}
+	}
+}
+
+// Poll checks the condition at the given interval until it's met, tolerating
+// transient errors (which are logged to the logger), until it fails with an
+// error which isn't transient or the context is done. The description is
+// used in errors and logs, e.g. "waiting for job ktf-system/istio".
+func Poll(ctx context.Context, logger logr.Logger, interval time.Duration, description string, condition func(ctx context.Context) (bool, error)) error {
+	err := apiwait.PollUntilContextCancel(ctx, interval, true, func(ctx context.Context) (bool, error) {
+		done, err := condition(ctx)
+		if class := Classify(err); class != NotTransient {
+			logger.Info("polling after transient error", "operation", description, "class", class, "error", err.Error())
+			return false, nil
+		}
+		return done, err
+	})
+	if err != nil && ctx.Err() != nil {
+		return fmt.Errorf("context done while %s: %w", description, ctx.Err())
+	}
+	return err
+}
+
+// isWebhookError indicates whether the error is a failure to call an admission
+// webhook, e.g. because its Service has no endpoints yet.
+func isWebhookError(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "failed calling webhook") || strings.Contains(msg, "no endpoints available for service")
+}
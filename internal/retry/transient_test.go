@@ -0,0 +1,134 @@
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apiwait "k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/kong/kubernetes-testing-framework/internal/retry"
+)
+
+func TestClassify(t *testing.T) {
+	namespaces := schema.GroupResource{Resource: "namespaces"}
+	for _, tc := range []struct {
+		name string
+		err  error
+		want retry.ErrorClass
+	}{
+		{name: "no error", err: nil, want: retry.NotTransient},
+		{name: "unknown error", err: errors.New("boom"), want: retry.NotTransient},
+		{name: "not found", err: apierrors.NewNotFound(namespaces, "kong"), want: retry.NotTransient},
+		{name: "too many requests", err: apierrors.NewTooManyRequests("slow down", 1), want: retry.RateLimited},
+		{name: "conflict", err: apierrors.NewConflict(namespaces, "kong", errors.New("modified")), want: retry.Conflict},
+		{
+			name: "webhook not ready",
+			err:  apierrors.NewInternalError(errors.New(`failed calling webhook "validations.kong.konghq.com": no endpoints available for service "kong-validation-webhook"`)),
+			want: retry.WebhookNotReady,
+		},
+		{name: "other internal error", err: apierrors.NewInternalError(errors.New("boom")), want: retry.NotTransient},
+		{name: "service unavailable", err: apierrors.NewServiceUnavailable("starting"), want: retry.Unavailable},
+		{name: "connection refused", err: fmt.Errorf("dial: %w", syscall.ECONNREFUSED), want: retry.Unavailable},
+		{name: "gRPC resource exhausted", err: status.Error(codes.ResourceExhausted, "quota"), want: retry.RateLimited},
+		{name: "gRPC unavailable", err: status.Error(codes.Unavailable, "down"), want: retry.Unavailable},
+		{name: "gRPC invalid argument", err: status.Error(codes.InvalidArgument, "bad"), want: retry.NotTransient},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, retry.Classify(tc.err))
+			assert.Equal(t, tc.want != retry.NotTransient, retry.IsTransient(tc.err))
+		})
+	}
+}
+
+func TestOnTransient(t *testing.T) {
+	backoff := apiwait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 3}
+	transient := apierrors.NewConflict(schema.GroupResource{Resource: "namespaces"}, "kong", errors.New("modified"))
+
+	t.Run("transient errors are retried", func(t *testing.T) {
+		attempts := 0
+		err := retry.OnTransientWithBackoff(context.Background(), logr.Discard(), backoff, "testing", func(context.Context) error {
+			attempts++
+			if attempts < 3 {
+				return transient
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("other errors aren't retried", func(t *testing.T) {
+		attempts := 0
+		err := retry.OnTransientWithBackoff(context.Background(), logr.Discard(), backoff, "testing", func(context.Context) error {
+			attempts++
+			return errors.New("boom")
+		})
+		require.EqualError(t, err, "boom")
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("gives up after the backoff steps", func(t *testing.T) {
+		attempts := 0
+		err := retry.OnTransientWithBackoff(context.Background(), logr.Discard(), backoff, "testing", func(context.Context) error {
+			attempts++
+			return transient
+		})
+		require.ErrorIs(t, err, transient)
+		assert.Contains(t, err.Error(), "gave up testing")
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("stops when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := retry.OnTransientWithBackoff(ctx, logr.Discard(), apiwait.Backoff{Duration: time.Hour, Steps: 3}, "testing", func(context.Context) error { return transient })
+		require.ErrorIs(t, err, context.Canceled)
+		require.ErrorIs(t, err, transient)
+	})
+}
+
+func TestPoll(t *testing.T) {
+	transient := apierrors.NewServiceUnavailable("starting")
+
+	t.Run("transient errors are tolerated", func(t *testing.T) {
+		checks := 0
+		err := retry.Poll(context.Background(), logr.Discard(), time.Millisecond, "testing", func(context.Context) (bool, error) {
+			checks++
+			if checks < 2 {
+				return false, transient
+			}
+			return checks == 3, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, checks)
+	})
+
+	t.Run("other errors stop polling", func(t *testing.T) {
+		checks := 0
+		err := retry.Poll(context.Background(), logr.Discard(), time.Millisecond, "testing", func(context.Context) (bool, error) {
+			checks++
+			return false, errors.New("boom")
+		})
+		require.EqualError(t, err, "boom")
+		assert.Equal(t, 1, checks)
+	})
+
+	t.Run("stops when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := retry.Poll(ctx, logr.Discard(), time.Hour, "testing", func(context.Context) (bool, error) { return false, nil })
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Contains(t, err.Error(), "context done while testing")
+	})
+}
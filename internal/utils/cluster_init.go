@@ -10,6 +10,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/kong/kubernetes-testing-framework/internal/retry"
 	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
 )
 
@@ -28,32 +29,31 @@ const (
 // regardless of which cluster type (e.g. kind, gke). This includes the creation
 // of some special administrative namespaces and service accounts.
 func ClusterInitHooks(ctx context.Context, cluster clusters.Cluster) error {
+	logger := clusters.Logger(cluster)
+
 	// create the admin namespace if it doesn't already exist
-	namespace, err := cluster.Client().CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: AdminNamespace}}, metav1.CreateOptions{})
-	if err != nil {
-		if !errors.IsAlreadyExists(err) { // tolerate the namespace already existing
-			return err
+	err := retry.OnTransient(ctx, logger, "creating namespace "+AdminNamespace, func(ctx context.Context) error {
+		_, err := cluster.Client().CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: AdminNamespace}}, metav1.CreateOptions{})
+		if errors.IsAlreadyExists(err) { // tolerate the namespace already existing
+			return nil
 		}
+		return err
+	})
+	if err != nil {
+		return err
 	}
 
 	// wait for the default service account to be available
-	var defaultSAFound bool
 	var defaultSA *corev1.ServiceAccount
-	for !defaultSAFound {
-		select {
-		case <-ctx.Done():
-			return fmt.Errorf("context completed before cluster init hooks could finish: %w", ctx.Err())
-		default:
-			defaultSA, err = cluster.Client().CoreV1().ServiceAccounts(namespace.Name).Get(ctx, "default", metav1.GetOptions{})
-			if err != nil {
-				if errors.IsNotFound(err) {
-					time.Sleep(time.Second)
-					continue // try again if its not there yet
-				}
-				return err // don't tolerate any errors except 404
-			}
-			defaultSAFound = true
+	err = retry.Poll(ctx, logger, time.Second, "waiting for the default service account of namespace "+AdminNamespace, func(ctx context.Context) (bool, error) {
+		defaultSA, err = cluster.Client().CoreV1().ServiceAccounts(AdminNamespace).Get(ctx, "default", metav1.GetOptions{})
+		if errors.IsNotFound(err) { // try again if its not there yet
+			return false, nil
 		}
+		return err == nil, err
+	})
+	if err != nil {
+		return fmt.Errorf("cluster init hooks could not finish: %w", err)
 	}
 
 	// give the default SA in this namespace cluster admin
@@ -72,12 +72,11 @@ func ClusterInitHooks(ctx context.Context, cluster clusters.Cluster) error {
 			Namespace: defaultSA.Namespace,
 		}},
 	}
-	_, err = cluster.Client().RbacV1().ClusterRoleBindings().Create(ctx, &crb, metav1.CreateOptions{})
-	if err != nil {
-		if !errors.IsAlreadyExists(err) { // tolerate the crb already existing
-			return err
+	return retry.OnTransient(ctx, logger, "creating cluster role binding "+AdminBinding, func(ctx context.Context) error {
+		_, err := cluster.Client().RbacV1().ClusterRoleBindings().Create(ctx, &crb, metav1.CreateOptions{})
+		if errors.IsAlreadyExists(err) { // tolerate the crb already existing
+			return nil
 		}
-	}
-
-	return nil
+		return err
+	})
 }
@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/cli/values"
+	"helm.sh/helm/v3/pkg/getter"
+
+	"github.com/kong/kubernetes-testing-framework/internal/retry"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/helm"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/images"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/versions"
+)
+
+// HelmRelease describes a Helm chart release deployed by an addon.
+type HelmRelease struct {
+	// RepoName is the local name of the chart repository, e.g. "bitnami".
+	RepoName string
+
+	// RepoURL is the URL of the chart repository.
+	RepoURL string
+
+	// Chart is the chart reference, e.g. "bitnami/minio".
+	Chart string
+
+	// Name is the name of the release.
+	Name string
+
+	// Namespace is the namespace the release is deployed to, it's created if needed.
+	Namespace string
+
+	// Version pins the chart version, the latest is used when empty.
+	Version string
+
+	// ChartVersion selects the chart version when Version is empty, versions
+	// which aren't exact are resolved using the index of the chart repository.
+	ChartVersion versions.Selector
+
+	// Args are the values of the release as helm arguments, "--set",
+	// "--set-string", "--set-file" and "--values" are supported.
+	Args []string
+
+	// SkipCRDs skips the installation of the CRDs of the chart.
+	SkipCRDs bool
+}
+
+// HelmInstall installs (or upgrades) the provided release on the cluster,
+// retrying on failure. The images of the chart are pulled from the configured
+// registry mirrors, see images.Mirror.
+func HelmInstall(ctx context.Context, cluster clusters.Cluster, release HelmRelease) error {
+	values, err := helmValues(release.Args)
+	if err != nil {
+		return fmt.Errorf("invalid arguments for release %s: %w", release.Name, err)
+	}
+	version, err := resolveChartVersion(ctx, release)
+	if err != nil {
+		return err
+	}
+
+	// charts of repositories are referred to by their name in the repository,
+	// other references (e.g. OCI references) are used as they are.
+	chart := release.Chart
+	if release.RepoURL != "" {
+		chart = strings.TrimPrefix(chart, release.RepoName+"/")
+	}
+
+	c := helm.NewClient(cluster)
+	// Sometimes installing fails. Just in case this happens, retry.
+	return retry.Func(ctx, clusters.Logger(cluster), fmt.Sprintf("installing release %s/%s", release.Namespace, release.Name), func() error {
+		_, err := c.Upgrade(ctx, helm.Release{
+			Name:         release.Name,
+			Namespace:    release.Namespace,
+			Chart:        chart,
+			RepoURL:      release.RepoURL,
+			Version:      version,
+			Values:       values,
+			SkipCRDs:     release.SkipCRDs,
+			PostRenderer: mirrorPostRenderer{},
+		})
+		return err
+	})
+}
+
+// mirrorPostRenderer rewrites the images of the rendered manifests of charts
+// to be pulled from the configured registry mirrors, see images.Mirror.
+type mirrorPostRenderer struct{}
+
+func (mirrorPostRenderer) Run(rendered *bytes.Buffer) (*bytes.Buffer, error) {
+	manifest, err := images.MirrorManifest(rendered.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewBuffer(manifest), nil
+}
+
+// helmValues provides the values set by the "--set", "--set-string",
+// "--set-file" and "--values" arguments of a release.
+func helmValues(args []string) (map[string]interface{}, error) {
+	var opts values.Options
+	for i := 0; i < len(args); i += 2 {
+		if i+1 >= len(args) {
+			return nil, fmt.Errorf("missing value for argument %s", args[i])
+		}
+		switch flag, value := args[i], args[i+1]; flag {
+		case "--set":
+			opts.Values = append(opts.Values, value)
+		case "--set-string":
+			opts.StringValues = append(opts.StringValues, value)
+		case "--set-file":
+			opts.FileValues = append(opts.FileValues, value)
+		case "--values", "-f":
+			opts.ValueFiles = append(opts.ValueFiles, value)
+		default:
+			return nil, fmt.Errorf("unsupported argument %s", flag)
+		}
+	}
+	return opts.MergeValues(getter.All(cli.New()))
+}
+
+// resolveChartVersion provides the version of the chart to install for the
+// provided release, or an empty string for the latest version.
+func resolveChartVersion(ctx context.Context, release HelmRelease) (string, error) {
+	if release.Version != "" || release.ChartVersion.IsLatest() {
+		return release.Version, nil
+	}
+
+	chart := strings.TrimPrefix(release.Chart, release.RepoName+"/")
+	version, err := release.ChartVersion.Resolve(ctx, versions.HelmChart(release.RepoURL, chart))
+	if err != nil {
+		return "", fmt.Errorf("could not resolve version of chart %s: %w", release.Chart, err)
+	}
+	return version.String(), nil
+}
+
+// HelmUninstall removes the named release from the cluster, tolerating the
+// release not being present.
+func HelmUninstall(ctx context.Context, cluster clusters.Cluster, name, namespace string) error {
+	c := helm.NewClient(cluster)
+	return retry.Func(ctx, clusters.Logger(cluster), fmt.Sprintf("uninstalling release %s/%s", namespace, name), func() error {
+		return c.Uninstall(ctx, name, namespace)
+	})
+}
@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/images"
+)
+
+// MirroredManifest provides what to apply (e.g. with "kubectl apply -f") for
+// the manifest at the given URL so that its images are pulled from the
+// configured registry mirrors, see images.Mirror. That's the URL itself if no
+// mirror is configured, otherwise the manifest is downloaded, rewritten and
+// written to a temporary file which is removed by the provided cleanup.
+func MirroredManifest(ctx context.Context, url string) (string, func(), error) {
+	if len(images.RegistryMirrors()) == 0 {
+		return url, func() {}, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("could not download manifest %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", nil, fmt.Errorf("could not download manifest %s: unexpected status %s", url, resp.Status)
+	}
+	manifest, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", nil, fmt.Errorf("could not download manifest %s: %w", url, err)
+	}
+	if manifest, err = images.MirrorManifest(manifest); err != nil {
+		return "", nil, fmt.Errorf("invalid manifest %s: %w", url, err)
+	}
+
+	f, err := os.CreateTemp(os.TempDir(), "ktf-manifest-*.yaml")
+	if err != nil {
+		return "", nil, err
+	}
+	defer f.Close()
+	cleanup := func() { os.Remove(f.Name()) }
+	if _, err := f.Write(manifest); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return f.Name(), cleanup, nil
+}
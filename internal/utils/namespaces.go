@@ -12,7 +12,7 @@ import (
 	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
 )
 
-// IsNamespaceAvailable checks for all Daemonsets, Deployment and Services
+// IsNamespaceAvailable checks for all Daemonsets, Deployments, StatefulSets and Services
 // in a given namespace to see if they are available (ready for minimum number
 // of seconds).
 //
@@ -57,6 +57,20 @@ func IsNamespaceAvailable(ctx context.Context, cluster clusters.Cluster, namespa
 		}
 	}
 
+	// check statefulsets for availability
+	var statefulsets *appsv1.StatefulSetList
+	statefulsets, err = cluster.Client().AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return
+	}
+
+	for i := 0; i < len(statefulsets.Items); i++ {
+		statefulset := &(statefulsets.Items[i])
+		if statefulset.Spec.Replicas != nil && statefulset.Status.ReadyReplicas != *statefulset.Spec.Replicas {
+			waitForObjects = append(waitForObjects, statefulset)
+		}
+	}
+
 	// check services for availability
 	var services *corev1.ServiceList
 	services, err = cluster.Client().CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
@@ -71,9 +85,9 @@ func IsNamespaceAvailable(ctx context.Context, cluster clusters.Cluster, namespa
 		}
 	}
 
-	// if there are no daemonsets or deployments present we can't consider this ready yet
-	// the expectation is that at least one (of any type) exists.
-	if (len(daemonsets.Items) + len(deployments.Items)) == 0 {
+	// if there are no daemonsets, deployments or statefulsets present we can't consider
+	// this ready yet the expectation is that at least one (of any type) exists.
+	if (len(daemonsets.Items) + len(deployments.Items) + len(statefulsets.Items)) == 0 {
 		return
 	}
 
@@ -0,0 +1,152 @@
+package clusters
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// -----------------------------------------------------------------------------
+// Public Types - Addon Registry
+// -----------------------------------------------------------------------------
+
+// AddonOption describes an option accepted by a registered addon.
+type AddonOption struct {
+	// Name is the name of the option, e.g. "dbmode".
+	Name string
+
+	// Description describes the option for users, e.g. in the ktf CLI.
+	Description string
+
+	// Default is the value used when the option isn't provided.
+	Default string
+
+	// Required indicates the option must be provided, Default is ignored.
+	Required bool
+}
+
+// AddonOptions are the option values used to construct a registered addon,
+// keyed by option name.
+type AddonOptions map[string]string
+
+// AddonFactory constructs an addon registered by name, so that addons (including
+// ones shipped by other repositories) can be deployed by name from the
+// environments builder and the ktf CLI.
+type AddonFactory struct {
+	// Description describes the addon for users, e.g. in the ktf CLI.
+	Description string
+
+	// Options are the options accepted by New.
+	Options []AddonOption
+
+	// New constructs the addon given values for its options. Only declared
+	// options are provided, with the defaults of the ones not provided by the
+	// caller filled in.
+	New func(options AddonOptions) (Addon, error)
+}
+
+// -----------------------------------------------------------------------------
+// Public Functions - Addon Registry
+// -----------------------------------------------------------------------------
+
+var (
+	addonRegistryLock sync.RWMutex
+	addonRegistry     = make(map[AddonName]AddonFactory)
+)
+
+// RegisterAddon makes an addon available by name. It's intended to be called
+// from the init function of the package providing the addon and panics if an
+// addon is registered twice under the same name or without a constructor.
+func RegisterAddon(name AddonName, factory AddonFactory) {
+	addonRegistryLock.Lock()
+	defer addonRegistryLock.Unlock()
+
+	if factory.New == nil {
+		panic(fmt.Sprintf("addon %s registered without a constructor", name))
+	}
+	if _, ok := addonRegistry[name]; ok {
+		panic(fmt.Sprintf("addon %s registered twice", name))
+	}
+	addonRegistry[name] = factory
+}
+
+// RegisteredAddons provides the names of all registered addons, sorted.
+func RegisteredAddons() []AddonName {
+	addonRegistryLock.RLock()
+	defer addonRegistryLock.RUnlock()
+
+	names := make([]AddonName, 0, len(addonRegistry))
+	for name := range addonRegistry {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
+
+// LookupAddon provides the factory of a registered addon.
+func LookupAddon(name AddonName) (AddonFactory, bool) {
+	addonRegistryLock.RLock()
+	defer addonRegistryLock.RUnlock()
+
+	factory, ok := addonRegistry[name]
+	return factory, ok
+}
+
+// NewAddon constructs a registered addon given values for its options. Unknown
+// options and missing required options are reported as errors.
+func NewAddon(name AddonName, options AddonOptions) (Addon, error) {
+	factory, ok := LookupAddon(name)
+	if !ok {
+		return nil, fmt.Errorf("addon %s is not registered", name)
+	}
+
+	values, err := factory.resolveOptions(options)
+	if err != nil {
+		return nil, fmt.Errorf("invalid options for addon %s: %w", name, err)
+	}
+
+	addon, err := factory.New(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct addon %s: %w", name, err)
+	}
+	return addon, nil
+}
+
+// -----------------------------------------------------------------------------
+// Private Functions - Addon Registry
+// -----------------------------------------------------------------------------
+
+// resolveOptions validates the provided option values against the declared
+// options and fills in defaults.
+func (f AddonFactory) resolveOptions(options AddonOptions) (AddonOptions, error) {
+	declared := make(map[string]AddonOption, len(f.Options))
+	for _, option := range f.Options {
+		declared[option.Name] = option
+	}
+
+	var unknown []string
+	for name := range options {
+		if _, ok := declared[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown options: %s", strings.Join(unknown, ", "))
+	}
+
+	values := make(AddonOptions, len(f.Options))
+	for _, option := range f.Options {
+		value, ok := options[option.Name]
+		switch {
+		case ok:
+			values[option.Name] = value
+		case option.Required:
+			return nil, fmt.Errorf("option %s is required", option.Name)
+		default:
+			values[option.Name] = option.Default
+		}
+	}
+	return values, nil
+}
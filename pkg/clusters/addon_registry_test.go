@@ -0,0 +1,47 @@
+package clusters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddonRegistry(t *testing.T) {
+	var constructedWith AddonOptions
+	t.Cleanup(func() {
+		addonRegistryLock.Lock()
+		defer addonRegistryLock.Unlock()
+		delete(addonRegistry, "test-registry-addon")
+	})
+	RegisterAddon("test-registry-addon", AddonFactory{
+		Description: "test addon",
+		Options: []AddonOption{
+			{Name: "mode", Default: "fast"},
+			{Name: "endpoint", Required: true},
+		},
+		New: func(options AddonOptions) (Addon, error) {
+			constructedWith = options
+			return fakeAddon{name: "test-registry-addon"}, nil
+		},
+	})
+
+	assert.Contains(t, RegisteredAddons(), AddonName("test-registry-addon"))
+	assert.Panics(t, func() {
+		RegisterAddon("test-registry-addon", AddonFactory{New: func(AddonOptions) (Addon, error) { return nil, nil }})
+	})
+
+	addon, err := NewAddon("test-registry-addon", AddonOptions{"endpoint": "localhost"})
+	require.NoError(t, err)
+	assert.Equal(t, AddonName("test-registry-addon"), addon.Name())
+	assert.Equal(t, AddonOptions{"mode": "fast", "endpoint": "localhost"}, constructedWith)
+
+	_, err = NewAddon("test-registry-addon", nil)
+	require.EqualError(t, err, "invalid options for addon test-registry-addon: option endpoint is required")
+
+	_, err = NewAddon("test-registry-addon", AddonOptions{"endpoint": "localhost", "speed": "1", "color": "red"})
+	require.EqualError(t, err, "invalid options for addon test-registry-addon: unknown options: color, speed")
+
+	_, err = NewAddon("test-unregistered-addon", nil)
+	require.EqualError(t, err, "addon test-unregistered-addon is not registered")
+}
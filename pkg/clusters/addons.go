@@ -2,7 +2,13 @@ package clusters
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
 
+	"github.com/blang/semver/v4"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -30,7 +36,8 @@ type Addon interface {
 	// own dependencies to deploy as needed.
 	Deploy(ctx context.Context, cluster Cluster) error
 
-	// Delete removes the addon component from the given cluster.
+	// Delete removes the addon component from the given cluster. Deleting an
+	// addon which isn't (or is only partially) deployed is not an error.
 	Delete(ctx context.Context, cluster Cluster) error
 
 	// DumpDiagnostics gathers and returns diagnostic information for an addon. Its return map is a map of string
@@ -43,3 +50,210 @@ type Addon interface {
 	// If the addon has failed unrecoverably, it will provide an error.
 	Ready(ctx context.Context, cluster Cluster) (waitingForObjects []runtime.Object, ready bool, err error)
 }
+
+// UpgradeableAddon is an Addon which can be upgraded in place, so that tests
+// can deploy one version, exercise it and verify that its state survives an
+// upgrade to another version.
+type UpgradeableAddon interface {
+	Addon
+
+	// Upgrade upgrades the addon component deployed to the given cluster to
+	// the provided version, retaining the rest of its configuration. Which
+	// component the version refers to (e.g. the chart or the application) is
+	// documented by the implementations. Like Deploy it doesn't wait for the
+	// addon to be ready.
+	Upgrade(ctx context.Context, cluster Cluster, version semver.Version) error
+}
+
+// -----------------------------------------------------------------------------
+// Public Functions - Cluster Addons
+// -----------------------------------------------------------------------------
+
+// UpgradeAddon upgrades the named addon deployed to the cluster to the
+// provided version, if the addon supports upgrades.
+func UpgradeAddon(ctx context.Context, cluster Cluster, name AddonName, version semver.Version) error {
+	addon, err := cluster.GetAddon(name)
+	if err != nil {
+		return err
+	}
+	upgradeable, ok := addon.(UpgradeableAddon)
+	if !ok {
+		return fmt.Errorf("addon %s does not support upgrades", name)
+	}
+	if err := upgradeable.Upgrade(ctx, cluster, version); err != nil {
+		return fmt.Errorf("failed to upgrade addon %s to %s: %w", name, version, err)
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// Public Functions - Cluster Addons Dependencies
+// -----------------------------------------------------------------------------
+
+// SortAddonsByDependencies orders the provided addons so that every addon comes
+// after the addons it depends on, addons without an order between them are
+// sorted by name. Dependencies which are not part of the provided addons are
+// ignored, see MissingAddonDependencies. An error is returned if the
+// dependencies are cyclic.
+func SortAddonsByDependencies(ctx context.Context, cluster Cluster, addons Addons) ([]Addon, error) {
+	// collect the dependencies within the provided addons
+	dependencies := make(map[AddonName][]AddonName, len(addons))
+	for name, addon := range addons {
+		for _, dependency := range addon.Dependencies(ctx, cluster) {
+			if _, ok := addons[dependency]; ok {
+				dependencies[name] = append(dependencies[name], dependency)
+			}
+		}
+	}
+
+	names := make([]AddonName, 0, len(addons))
+	for name := range addons {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+
+	// depth-first topological sort, tracking the addons on the current path
+	// to detect cycles.
+	sorted := make([]Addon, 0, len(addons))
+	visited := make(map[AddonName]bool, len(addons))
+	onPath := make(map[AddonName]bool)
+	var visit func(name AddonName, path []AddonName) error
+	visit = func(name AddonName, path []AddonName) error {
+		if visited[name] {
+			return nil
+		}
+		path = append(path, name)
+		if onPath[name] {
+			cycle := make([]string, 0, len(path))
+			for _, n := range path {
+				cycle = append(cycle, string(n))
+			}
+			return fmt.Errorf("addon dependencies are cyclic: %s", strings.Join(cycle, " -> "))
+		}
+		onPath[name] = true
+
+		deps := dependencies[name]
+		sort.Slice(deps, func(i, j int) bool { return deps[i] < deps[j] })
+		for _, dependency := range deps {
+			if err := visit(dependency, path); err != nil {
+				return err
+			}
+		}
+
+		onPath[name] = false
+		visited[name] = true
+		sorted = append(sorted, addons[name])
+		return nil
+	}
+	for _, name := range names {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return sorted, nil
+}
+
+// MissingAddonDependencies provides the dependencies of the provided addons
+// which are not part of them, mapped to the names of the addons needing them.
+func MissingAddonDependencies(ctx context.Context, cluster Cluster, addons Addons) map[AddonName][]AddonName {
+	missing := make(map[AddonName][]AddonName)
+	for name, addon := range addons {
+		for _, dependency := range addon.Dependencies(ctx, cluster) {
+			if _, ok := addons[dependency]; !ok {
+				missing[dependency] = append(missing[dependency], name)
+			}
+		}
+	}
+	for _, neededBy := range missing {
+		sort.Slice(neededBy, func(i, j int) bool { return neededBy[i] < neededBy[j] })
+	}
+	return missing
+}
+
+// DeployAddons deploys the provided addons to the cluster, deploying addons
+// which don't depend on each other concurrently with at most maxConcurrent
+// deployments at a time (unbounded if it's not positive). Each addon is only
+// deployed once the addons it depends on amongst the provided ones have been
+// deployed. After a deployment fails no further deployments are started, the
+// ones in progress are completed and all failures are returned.
+func DeployAddons(ctx context.Context, cluster Cluster, addons Addons, maxConcurrent int) error {
+	sorted, err := SortAddonsByDependencies(ctx, cluster, addons)
+	if err != nil {
+		return err
+	}
+	if maxConcurrent <= 0 {
+		maxConcurrent = len(sorted)
+	}
+
+	deployed := make(map[AddonName]chan struct{}, len(sorted))
+	for _, addon := range sorted {
+		deployed[addon.Name()] = make(chan struct{})
+	}
+
+	var (
+		wg       sync.WaitGroup
+		lock     sync.Mutex
+		errs     []error
+		failed   = make(chan struct{})
+		failOnce sync.Once
+		slots    = make(chan struct{}, maxConcurrent)
+	)
+	fail := func(err error) {
+		lock.Lock()
+		errs = append(errs, err)
+		lock.Unlock()
+		failOnce.Do(func() { close(failed) })
+	}
+
+	// the addons are started in dependency order, so that when concurrency is
+	// limited the deployment slots go to addons whose dependencies come first.
+	for _, addon := range sorted {
+		addon := addon
+		var dependencies []AddonName
+		for _, dependency := range addon.Dependencies(ctx, cluster) {
+			if _, ok := deployed[dependency]; ok {
+				dependencies = append(dependencies, dependency)
+			}
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, dependency := range dependencies {
+				select {
+				case <-deployed[dependency]:
+				case <-failed:
+					return
+				case <-ctx.Done():
+					fail(fmt.Errorf("context done before addon %s could be deployed: %w", addon.Name(), ctx.Err()))
+					return
+				}
+			}
+
+			select {
+			case slots <- struct{}{}:
+			case <-failed:
+				return
+			case <-ctx.Done():
+				fail(fmt.Errorf("context done before addon %s could be deployed: %w", addon.Name(), ctx.Err()))
+				return
+			}
+			defer func() { <-slots }()
+
+			select {
+			case <-failed:
+				return
+			default:
+			}
+			if err := cluster.DeployAddon(ctx, addon); err != nil {
+				fail(fmt.Errorf("failed to deploy addon %s: %w", addon.Name(), err))
+				return
+			}
+			close(deployed[addon.Name()])
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
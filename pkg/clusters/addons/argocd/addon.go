@@ -15,6 +15,7 @@ import (
 	"k8s.io/client-go/dynamic"
 
 	"github.com/kong/kubernetes-testing-framework/internal/retry"
+	"github.com/kong/kubernetes-testing-framework/internal/utils"
 	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
 )
 
@@ -37,7 +38,7 @@ type Addon struct {
 	name      string
 	namespace string
 	version   string
-	client    *dynamic.DynamicClient
+	client    dynamic.Interface
 }
 
 func New() clusters.Addon {
@@ -119,16 +120,22 @@ func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
 
 	defer os.Remove(kubeconfig.Name())
 
+	manifest, cleanup, err := utils.MirroredManifest(ctx, fmt.Sprintf(manifestURL, a.version))
+	if err != nil {
+		return fmt.Errorf("could not get ArgoCD manifest: %w", err)
+	}
+	defer cleanup()
+
 	deployArgs := []string{
 		"--kubeconfig", kubeconfig.Name(),
-		"apply", "-n", a.namespace, "-f", fmt.Sprintf(manifestURL, a.version),
+		"apply", "-n", a.namespace, "-f", manifest,
 	}
 
 	if err := retry.Command("kubectl", deployArgs...).WithStdout(io.Discard).Do(ctx); err != nil {
 		return fmt.Errorf("could not deploy ArgoCD: %w", err)
 	}
 
-	dynamicClient, err := dynamic.NewForConfig(cluster.Config())
+	dynamicClient, err := cluster.DynamicClient()
 	if err != nil {
 		return fmt.Errorf("could not create client: %w", err)
 	}
@@ -138,20 +145,7 @@ func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
 }
 
 func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
-	for {
-		select {
-		case <-ctx.Done():
-			return fmt.Errorf("context completed before addon could be deleted: %w", ctx.Err())
-		default:
-			if err := cluster.Client().CoreV1().Namespaces().
-				Delete(ctx, a.namespace, metav1.DeleteOptions{}); err != nil {
-				if errors.IsNotFound(err) {
-					return nil
-				}
-				return fmt.Errorf("could not delete namespace: %w", err)
-			}
-		}
-	}
+	return clusters.DeleteNamespace(ctx, cluster, a.namespace)
 }
 
 func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) ([]runtime.Object, bool, error) {
@@ -0,0 +1,129 @@
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// -----------------------------------------------------------------------------
+// ArgoCD Addon - Applications
+// -----------------------------------------------------------------------------
+
+const (
+	// SyncStatusSynced is the sync status of an Application whose live state
+	// matches its source.
+	SyncStatusSynced = "Synced"
+
+	// HealthStatusHealthy is the health status of an Application whose
+	// resources are all healthy.
+	HealthStatusHealthy = "Healthy"
+
+	// DefaultProject is the AppProject which exists in every ArgoCD install.
+	DefaultProject = "default"
+
+	applicationPollInterval = time.Second
+)
+
+// ApplicationSource is the git repository (or Helm chart repository) that an
+// Application deploys from.
+type ApplicationSource struct {
+	// RepoURL is the URL of the git (or Helm chart) repository.
+	RepoURL string
+
+	// Path is the directory within the git repository containing the
+	// manifests (or Kustomization, or chart).
+	Path string
+
+	// TargetRevision is the git revision (or chart version) to deploy,
+	// the default branch is used if empty.
+	TargetRevision string
+}
+
+// NewApplication generates an (unstructured) Application in the addon's
+// namespace which deploys the given source into the destination namespace of
+// the cluster ArgoCD runs in, using the default AppProject. The Application
+// syncs automatically and creates the destination namespace if needed.
+func (a *Addon) NewApplication(name string, source ApplicationSource, destinationNamespace string) *unstructured.Unstructured {
+	app := &unstructured.Unstructured{}
+	app.SetUnstructuredContent(map[string]interface{}{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Application",
+		"metadata": map[string]interface{}{
+			"name":       name,
+			"namespace":  a.namespace,
+			"finalizers": []interface{}{"resources-finalizer.argocd.argoproj.io"},
+		},
+		"spec": map[string]interface{}{
+			"project": DefaultProject,
+			"source": map[string]interface{}{
+				"repoURL":        source.RepoURL,
+				"path":           source.Path,
+				"targetRevision": source.TargetRevision,
+			},
+			"destination": map[string]interface{}{
+				"server":    DefaultServer,
+				"namespace": destinationNamespace,
+			},
+			"syncPolicy": map[string]interface{}{
+				"automated": map[string]interface{}{
+					"prune":    true,
+					"selfHeal": true,
+				},
+				"syncOptions": []interface{}{"CreateNamespace=true"},
+			},
+		},
+	})
+	return app
+}
+
+// GetApplication retrieves the (unstructured) Application with the given name.
+func (a *Addon) GetApplication(ctx context.Context, name string) (*unstructured.Unstructured, error) {
+	if a.client == nil {
+		return nil, fmt.Errorf("the %s addon has not been deployed", AddonName)
+	}
+	return a.client.Resource(applicationGVR()).Namespace(a.namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+// WaitForApplicationSync waits until the Application with the given name is
+// both synced and healthy, or until the context is done. If the context is
+// done first the last observed status is reported in the error.
+func (a *Addon) WaitForApplicationSync(ctx context.Context, name string) error {
+	if a.client == nil {
+		return fmt.Errorf("the %s addon has not been deployed", AddonName)
+	}
+
+	ticker := time.NewTicker(applicationPollInterval)
+	defer ticker.Stop()
+
+	var syncStatus, healthStatus string
+	for {
+		app, err := a.GetApplication(ctx, name)
+		if err == nil {
+			syncStatus, healthStatus = applicationStatus(app)
+			if syncStatus == SyncStatusSynced && healthStatus == HealthStatusHealthy {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return fmt.Errorf("application %s did not sync: %w", name, err)
+			}
+			return fmt.Errorf("application %s did not sync (sync status: %q, health status: %q): %w",
+				name, syncStatus, healthStatus, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// applicationStatus provides the sync and health statuses of an Application.
+func applicationStatus(app *unstructured.Unstructured) (syncStatus, healthStatus string) {
+	syncStatus, _, _ = unstructured.NestedString(app.Object, "status", "sync", "status")
+	healthStatus, _, _ = unstructured.NestedString(app.Object, "status", "health", "status")
+	return syncStatus, healthStatus
+}
@@ -0,0 +1,34 @@
+package argocd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestNewApplication(t *testing.T) {
+	addon := NewBuilder().Build()
+	app := addon.NewApplication("test", ApplicationSource{
+		RepoURL: "https://github.com/kong/example",
+		Path:    "manifests",
+	}, "kong")
+
+	assert.Equal(t, DefaultNamespace, app.GetNamespace())
+	repoURL, _, _ := unstructured.NestedString(app.Object, "spec", "source", "repoURL")
+	assert.Equal(t, "https://github.com/kong/example", repoURL)
+	destination, _, _ := unstructured.NestedString(app.Object, "spec", "destination", "namespace")
+	assert.Equal(t, "kong", destination)
+
+	syncStatus, healthStatus := applicationStatus(app)
+	assert.Empty(t, syncStatus)
+	assert.Empty(t, healthStatus)
+
+	app.Object["status"] = map[string]interface{}{
+		"sync":   map[string]interface{}{"status": SyncStatusSynced},
+		"health": map[string]interface{}{"status": "Progressing"},
+	}
+	syncStatus, healthStatus = applicationStatus(app)
+	assert.Equal(t, SyncStatusSynced, syncStatus)
+	assert.Equal(t, "Progressing", healthStatus)
+}
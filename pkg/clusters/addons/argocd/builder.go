@@ -38,7 +38,8 @@ func (b *Builder) Build() *Addon {
 	if b.version == nil {
 		version = "stable"
 	} else {
-		version = b.version.String()
+		// ArgoCD release tags are prefixed with "v"
+		version = "v" + b.version.String()
 	}
 	return &Addon{
 		name:      b.name,
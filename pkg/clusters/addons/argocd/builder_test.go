@@ -0,0 +1,19 @@
+package argocd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilderVersion(t *testing.T) {
+	addon := NewBuilder().Build()
+	assert.Equal(t, "https://raw.githubusercontent.com/argoproj/argo-cd/stable/manifests/core-install.yaml",
+		fmt.Sprintf(manifestURL, addon.version))
+
+	addon = NewBuilder().WithVersion(semver.MustParse("2.9.3")).Build()
+	assert.Equal(t, "https://raw.githubusercontent.com/argoproj/argo-cd/v2.9.3/manifests/core-install.yaml",
+		fmt.Sprintf(manifestURL, addon.version), "release tags are prefixed with v")
+}
@@ -0,0 +1,247 @@
+// Package builtin registers the addons provided by this repository with the
+// addon registry (see clusters.RegisterAddon) so they can be deployed by name,
+// e.g. with the ktf CLI. Import it for its side effects:
+//
+//	import _ "github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/builtin"
+package builtin
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/argocd"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/calico"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/certmanager"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/cilium"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/dex"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/echo"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/envoygateway"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/externaldns"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/grafana"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/grpcbin"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/httpbin"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/istio"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/kafka"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/keda"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/keycloak"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/knative"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/kong"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/kongargo"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/kubestatemetrics"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/kuma"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/kwok"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/kyverno"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/linkerd"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/loadimage"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/loki"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/metallb"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/metricsserver"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/minio"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/prometheus"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/registry"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/streamecho"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/tracing"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/vault"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/websocket"
+)
+
+func init() { //nolint:gochecknoinits
+	// addons without options
+	for name, addon := range map[clusters.AddonName]struct {
+		description string
+		new         func() clusters.Addon
+	}{
+		argocd.AddonName:        {"Argo CD", argocd.New},
+		calico.AddonName:        {"Calico CNI", func() clusters.Addon { return calico.New() }},
+		certmanager.AddonName:   {"cert-manager", certmanager.New},
+		cilium.AddonName:        {"Cilium CNI", func() clusters.Addon { return cilium.New() }},
+		dex.AddonName:           {"Dex OIDC provider", func() clusters.Addon { return dex.New() }},
+		echo.AddonName:          {"echo server", func() clusters.Addon { return echo.New() }},
+		envoygateway.AddonName:  {"Envoy Gateway", func() clusters.Addon { return envoygateway.New() }},
+		externaldns.AddonName:   {"ExternalDNS", func() clusters.Addon { return externaldns.New() }},
+		grafana.AddonName:       {"Grafana", func() clusters.Addon { return grafana.New() }},
+		grpcbin.AddonName:       {"gRPC test server", func() clusters.Addon { return grpcbin.New() }},
+		httpbin.AddonName:       {"HTTP test server", func() clusters.Addon { return httpbin.New() }},
+		kafka.AddonName:         {"Kafka (Strimzi)", func() clusters.Addon { return kafka.New() }},
+		keda.AddonName:          {"KEDA", func() clusters.Addon { return keda.New() }},
+		keycloak.AddonName:      {"Keycloak", func() clusters.Addon { return keycloak.New() }},
+		knative.AddonName:       {"Knative Serving", knative.New},
+		kongargo.AddonName:      {"Kong deployed with Argo CD", kongargo.New},
+		kuma.AddonName:          {"Kuma service mesh", func() clusters.Addon { return kuma.New() }},
+		kwok.AddonName:          {"KWOK simulated nodes", func() clusters.Addon { return kwok.New() }},
+		kyverno.AddonName:       {"Kyverno", func() clusters.Addon { return kyverno.New() }},
+		linkerd.AddonName:       {"Linkerd service mesh", func() clusters.Addon { return linkerd.New() }},
+		loki.AddonName:          {"Loki", func() clusters.Addon { return loki.New() }},
+		metallb.AddonName:       {"MetalLB load balancer (kind only)", metallb.New},
+		metricsserver.AddonName: {"metrics-server", func() clusters.Addon { return metricsserver.New() }},
+		minio.AddonName:         {"MinIO object storage", func() clusters.Addon { return minio.New() }},
+		prometheus.AddonName:    {"Prometheus", func() clusters.Addon { return prometheus.New() }},
+		streamecho.AddonName:    {"TCP/UDP echo server", func() clusters.Addon { return streamecho.New() }},
+		tracing.AddonName:       {"distributed tracing backend", func() clusters.Addon { return tracing.New() }},
+		vault.AddonName:         {"Vault", func() clusters.Addon { return vault.New() }},
+		websocket.AddonName:     {"WebSocket echo server", func() clusters.Addon { return websocket.New() }},
+	} {
+		newAddon := addon.new
+		clusters.RegisterAddon(name, clusters.AddonFactory{
+			Description: addon.description,
+			New: func(clusters.AddonOptions) (clusters.Addon, error) {
+				return newAddon(), nil
+			},
+		})
+	}
+
+	clusters.RegisterAddon(istio.AddonName, clusters.AddonFactory{
+		Description: "Istio service mesh",
+		Options: []clusters.AddonOption{
+			{Name: "telemetry", Description: "deploy Prometheus, Grafana, Jaeger and Kiali with istio", Default: "true"},
+		},
+		New: func(options clusters.AddonOptions) (clusters.Addon, error) {
+			telemetry, err := parseBool(options, "telemetry")
+			if err != nil {
+				return nil, err
+			}
+			builder := istio.NewBuilder()
+			if telemetry {
+				builder.WithGrafana().WithJaeger().WithKiali().WithPrometheus()
+			}
+			return builder.Build(), nil
+		},
+	})
+
+	clusters.RegisterAddon(kubestatemetrics.AddonName, clusters.AddonFactory{
+		Description: "kube-state-metrics",
+		Options: []clusters.AddonOption{
+			{Name: "service-monitor", Description: "deploy a ServiceMonitor for Prometheus", Default: "true"},
+		},
+		New: func(options clusters.AddonOptions) (clusters.Addon, error) {
+			serviceMonitor, err := parseBool(options, "service-monitor")
+			if err != nil {
+				return nil, err
+			}
+			builder := kubestatemetrics.NewBuilder()
+			if serviceMonitor {
+				builder.WithServiceMonitor()
+			}
+			return builder.Build(), nil
+		},
+	})
+
+	clusters.RegisterAddon(loadimage.AddonName, clusters.AddonFactory{
+		Description: "load local docker images into the nodes (kind only)",
+		Options: []clusters.AddonOption{
+			{Name: "images", Description: "comma separated list of the images to load", Required: true},
+		},
+		New: func(options clusters.AddonOptions) (clusters.Addon, error) {
+			builder := loadimage.NewBuilder()
+			for _, image := range strings.Split(options["images"], ",") {
+				if _, err := builder.WithImage(strings.TrimSpace(image)); err != nil {
+					return nil, fmt.Errorf("option images must list images: %w", err)
+				}
+			}
+			return builder.Build(), nil
+		},
+	})
+
+	clusters.RegisterAddon(registry.AddonName, clusters.AddonFactory{
+		Description: "container image registry (requires cert-manager)",
+		Options: []clusters.AddonOption{
+			{Name: "loadbalancer", Description: "expose the registry with a LoadBalancer service", Default: "true"},
+		},
+		New: func(options clusters.AddonOptions) (clusters.Addon, error) {
+			loadBalancer, err := parseBool(options, "loadbalancer")
+			if err != nil {
+				return nil, err
+			}
+			builder := registry.NewBuilder()
+			if loadBalancer {
+				builder.WithServiceTypeLoadBalancer()
+			}
+			return builder.Build(), nil
+		},
+	})
+
+	clusters.RegisterAddon(kong.AddonName, clusters.AddonFactory{
+		Description: "Kong Gateway and Kong Ingress Controller",
+		Options: []clusters.AddonOption{
+			{Name: "disable-controller", Description: "deploy the gateway without the controller (proxy only)", Default: "false"},
+			{Name: "admin-service-loadbalancer", Description: "deploy the admin service as a LoadBalancer type", Default: "false"},
+			{Name: "gateway-image", Description: "container image (repository[:tag]) for the gateway (proxy)"},
+			{Name: "controller-image", Description: "container image (repository[:tag]) for the ingress controller"},
+			{Name: "dbmode", Description: `backend dbmode: "off" (DBLESS) or "postgres"`, Default: "off"},
+		},
+		New: newKong,
+	})
+}
+
+// newKong constructs the kong addon given its registered options.
+func newKong(options clusters.AddonOptions) (clusters.Addon, error) {
+	builder := kong.NewBuilder()
+
+	disableController, err := parseBool(options, "disable-controller")
+	if err != nil {
+		return nil, err
+	}
+	if disableController {
+		builder.WithControllerDisabled()
+	}
+
+	adminServiceLoadBalancer, err := parseBool(options, "admin-service-loadbalancer")
+	if err != nil {
+		return nil, err
+	}
+	if adminServiceLoadBalancer {
+		builder.WithProxyAdminServiceTypeLoadBalancer()
+	}
+
+	if image := options["gateway-image"]; image != "" {
+		repo, tag, err := parseImage(image)
+		if err != nil {
+			return nil, fmt.Errorf("malformed gateway-image: %w", err)
+		}
+		builder.WithProxyImage(repo, tag)
+	}
+
+	if image := options["controller-image"]; image != "" {
+		repo, tag, err := parseImage(image)
+		if err != nil {
+			return nil, fmt.Errorf("malformed controller-image: %w", err)
+		}
+		builder.WithControllerImage(repo, tag)
+	}
+
+	switch dbmode := options["dbmode"]; dbmode {
+	case "off":
+		builder.WithDBLess()
+	case "postgres":
+		builder.WithPostgreSQL()
+	default:
+		return nil, fmt.Errorf("%s is not a valid dbmode for kong, supported modes are \"off\" (DBLESS) or \"postgres\"", dbmode)
+	}
+
+	return builder.Build(), nil
+}
+
+// parseBool parses a boolean option.
+func parseBool(options clusters.AddonOptions, name string) (bool, error) {
+	value, err := strconv.ParseBool(options[name])
+	if err != nil {
+		return false, fmt.Errorf("option %s must be a boolean: %w", name, err)
+	}
+	return value, nil
+}
+
+// parseImage splits a container image into repository and tag, the tag
+// defaults to "latest".
+func parseImage(image string) (repo, tag string, err error) {
+	parts := strings.Split(image, ":")
+	switch len(parts) {
+	case 1:
+		return parts[0], "latest", nil
+	case 2: //nolint:gomnd
+		return parts[0], parts[1], nil
+	default:
+		return "", "", fmt.Errorf("%s is not a valid image", image)
+	}
+}
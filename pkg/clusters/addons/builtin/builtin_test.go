@@ -0,0 +1,82 @@
+package builtin
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+)
+
+func TestAddonsWithoutArgumentsAreRegistered(t *testing.T) {
+	// every addon package which can construct its addon without arguments
+	// has to be deployable by name, e.g. from the ktf CLI.
+	dirs, err := os.ReadDir("..")
+	require.NoError(t, err)
+
+	found := 0
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		name, ok := addonWithoutArguments(t, filepath.Join("..", dir.Name()))
+		if !ok {
+			continue
+		}
+		found++
+		_, registered := clusters.LookupAddon(name)
+		assert.True(t, registered, "addon %s (package %s) is not registered", name, dir.Name())
+	}
+	require.NotZero(t, found, "no addon packages found")
+}
+
+// addonWithoutArguments provides the AddonName of the addon package in the
+// given directory, if the package has a New function without arguments.
+func addonWithoutArguments(t *testing.T, dir string) (clusters.AddonName, bool) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	require.NoError(t, err)
+
+	var name clusters.AddonName
+	hasNew := false
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		require.NoError(t, err)
+
+		for _, decl := range f.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Recv == nil && decl.Name.Name == "New" && decl.Type.Params.NumFields() == 0 {
+					hasNew = true
+				}
+			case *ast.GenDecl:
+				if decl.Tok != token.CONST {
+					continue
+				}
+				for _, spec := range decl.Specs {
+					spec, ok := spec.(*ast.ValueSpec)
+					if !ok || len(spec.Names) != 1 || spec.Names[0].Name != "AddonName" || len(spec.Values) != 1 {
+						continue
+					}
+					if lit, ok := spec.Values[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+						value, err := strconv.Unquote(lit.Value)
+						require.NoError(t, err)
+						name = clusters.AddonName(value)
+					}
+				}
+			}
+		}
+	}
+	return name, hasNew && name != ""
+}
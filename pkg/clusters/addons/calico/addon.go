@@ -0,0 +1,110 @@
+package calico
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blang/semver/v4"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/manifests"
+)
+
+// -----------------------------------------------------------------------------
+// Calico Addon
+// -----------------------------------------------------------------------------
+
+const (
+	// AddonName indicates the unique name of this addon.
+	AddonName clusters.AddonName = "calico"
+
+	// DefaultNamespace indicates the namespace the Calico components are
+	// deployed to by the Calico manifests.
+	DefaultNamespace = "kube-system"
+
+	// manifestsURL is the URL of the Calico manifests for a given version.
+	manifestsURL = "https://raw.githubusercontent.com/projectcalico/calico/v%s/manifests/calico.yaml"
+
+	nodeDaemonSet         = "calico-node"
+	kubeControllersDeploy = "calico-kube-controllers"
+)
+
+// DefaultVersion is the version of Calico deployed by default.
+var DefaultVersion = semver.MustParse("3.25.0")
+
+// Addon is a Calico CNI addon, which provides NetworkPolicy enforcement. It's
+// meant to be deployed on a cluster created without a CNI, e.g. a kind
+// cluster built with WithDefaultCNIDisabled: until the addon is ready pods
+// other than host network pods can't be scheduled.
+type Addon struct {
+	version   semver.Version
+	manifests *manifests.Addon
+}
+
+// New produces a new clusters.Addon for Calico with the default settings.
+func New() *Addon {
+	return NewBuilder().Build()
+}
+
+// Version indicates the version of Calico which is deployed.
+func (a *Addon) Version() semver.Version {
+	return a.version
+}
+
+// -----------------------------------------------------------------------------
+// Calico Addon - Addon Implementation
+// -----------------------------------------------------------------------------
+
+func (a *Addon) Name() clusters.AddonName {
+	return AddonName
+}
+
+func (a *Addon) Dependencies(_ context.Context, _ clusters.Cluster) []clusters.AddonName {
+	return nil
+}
+
+func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
+	if err := a.manifests.Deploy(ctx, cluster); err != nil {
+		return fmt.Errorf("could not deploy calico: %w", err)
+	}
+	return nil
+}
+
+func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
+	return a.manifests.Delete(ctx, cluster)
+}
+
+func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) ([]runtime.Object, bool, error) {
+	daemonSet, err := cluster.Client().AppsV1().DaemonSets(DefaultNamespace).Get(ctx, nodeDaemonSet, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	if daemonSet.Status.DesiredNumberScheduled == 0 ||
+		daemonSet.Status.NumberReady != daemonSet.Status.DesiredNumberScheduled {
+		return []runtime.Object{daemonSet}, false, nil
+	}
+
+	deployment, err := cluster.Client().AppsV1().Deployments(DefaultNamespace).Get(ctx, kubeControllersDeploy, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	if deployment.Status.AvailableReplicas != *deployment.Spec.Replicas {
+		return []runtime.Object{deployment}, false, nil
+	}
+
+	return nil, true, nil
+}
+
+func (a *Addon) DumpDiagnostics(context.Context, clusters.Cluster) (map[string][]byte, error) {
+	diagnostics := make(map[string][]byte)
+	return diagnostics, nil
+}
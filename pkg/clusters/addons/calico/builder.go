@@ -0,0 +1,40 @@
+package calico
+
+import (
+	"fmt"
+
+	"github.com/blang/semver/v4"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/manifests"
+)
+
+// -----------------------------------------------------------------------------
+// Calico Addon - Builder
+// -----------------------------------------------------------------------------
+
+// Builder is a configuration tool to generate Calico cluster addons.
+type Builder struct {
+	version semver.Version
+}
+
+// NewBuilder provides a new Builder object for configuring Calico cluster addons.
+func NewBuilder() *Builder {
+	return &Builder{
+		version: DefaultVersion,
+	}
+}
+
+// WithVersion configures the version of Calico which should be deployed.
+func (b *Builder) WithVersion(version semver.Version) *Builder {
+	b.version = version
+	return b
+}
+
+// Build generates a new Calico cluster.Addon which can be loaded and deployed
+// into a test Environment's cluster.Cluster.
+func (b *Builder) Build() *Addon {
+	return &Addon{
+		version:   b.version,
+		manifests: manifests.NewBuilder(AddonName).WithURL(fmt.Sprintf(manifestsURL, b.version)).Build(),
+	}
+}
@@ -18,25 +18,32 @@ import (
 	"github.com/kong/kubernetes-testing-framework/internal/utils"
 	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
 	"github.com/kong/kubernetes-testing-framework/pkg/utils/github"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/images"
 )
 
 // -----------------------------------------------------------------------------
 // CertManager Addon - Builder
 // -----------------------------------------------------------------------------
 
+// Builder is a configuration tool to generate cert-manager cluster addons.
 type Builder struct {
 	version *semver.Version
 }
 
+// NewBuilder provides a new Builder object for configuring cert-manager cluster addons.
 func NewBuilder() *Builder {
 	return &Builder{}
 }
 
+// WithVersion pins the version of cert-manager which should be deployed,
+// otherwise the latest release is used.
 func (b *Builder) WithVersion(version semver.Version) *Builder {
 	b.version = &version
 	return b
 }
 
+// Build generates a new cert-manager cluster.Addon which can be loaded and
+// deployed into a test Environment's cluster.Cluster.
 func (b *Builder) Build() *Addon {
 	return &Addon{
 		version: b.version,
@@ -67,6 +74,32 @@ func New() clusters.Addon {
 	return &Addon{}
 }
 
+// Version indicates the cert-manager version for this addon, which is nil
+// until the addon is deployed if no version was pinned.
+func (a *Addon) Version() *semver.Version {
+	return a.version
+}
+
+// -----------------------------------------------------------------------------
+// CertManager Addon - Public Functions
+// -----------------------------------------------------------------------------
+
+// CreateSelfSignedClusterIssuer creates a self-signed ClusterIssuer with the
+// given name and waits for it to become ready. The addon deploys one named
+// DefaultIssuerName, this can be used to create additional ones.
+func CreateSelfSignedClusterIssuer(ctx context.Context, cluster clusters.Cluster, name string) error {
+	if err := clusters.ApplyManifestByYAML(ctx, cluster, selfSignedClusterIssuer(name)); err != nil {
+		return err
+	}
+	return clusters.WaitForCondition(ctx, cluster, DefaultNamespace, "clusterissuers.cert-manager.io", name, "Ready", defaultIssuerWaitSeconds)
+}
+
+// DeleteSelfSignedClusterIssuer deletes a ClusterIssuer created by
+// CreateSelfSignedClusterIssuer.
+func DeleteSelfSignedClusterIssuer(ctx context.Context, cluster clusters.Cluster, name string) error {
+	return clusters.DeleteManifestByYAML(ctx, cluster, selfSignedClusterIssuer(name))
+}
+
 // -----------------------------------------------------------------------------
 // CertManager Addon - Addon Implementation
 // -----------------------------------------------------------------------------
@@ -94,9 +127,15 @@ func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
 	}
 	defer os.Remove(kubeconfig.Name())
 
+	manifest, cleanup, err := utils.MirroredManifest(ctx, fmt.Sprintf(manifestFormatter, a.version))
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
 	deployArgs := []string{
 		"--kubeconfig", kubeconfig.Name(),
-		"apply", "-f", fmt.Sprintf(manifestFormatter, a.version),
+		"apply", "-f", manifest,
 	}
 
 	stderr := new(bytes.Buffer)
@@ -146,7 +185,7 @@ func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
 
 	deployArgs := []string{
 		"--kubeconfig", kubeconfig.Name(),
-		"delete", "-f", fmt.Sprintf(manifestFormatter, a.version),
+		"delete", "--ignore-not-found", "-f", fmt.Sprintf(manifestFormatter, a.version),
 	}
 
 	stderr := new(bytes.Buffer)
@@ -199,24 +238,23 @@ const (
 	defaultIssuerWaitSeconds = 60
 )
 
-var defaultIssuer = fmt.Sprintf(`---
+func selfSignedClusterIssuer(name string) string {
+	return fmt.Sprintf(`---
 apiVersion: cert-manager.io/v1
 kind: ClusterIssuer
 metadata:
   name: %s
 spec:
   selfSigned: {}
-`, DefaultIssuerName)
+`, name)
+}
 
 func (a *Addon) deployDefaultIssuer(ctx context.Context, cluster clusters.Cluster) error {
-	if err := clusters.ApplyManifestByYAML(ctx, cluster, defaultIssuer); err != nil {
-		return err
-	}
-	return clusters.WaitForCondition(ctx, cluster, DefaultNamespace, "clusterissuers.cert-manager.io", DefaultIssuerName, "Ready", defaultIssuerWaitSeconds)
+	return CreateSelfSignedClusterIssuer(ctx, cluster, DefaultIssuerName)
 }
 
 func (a *Addon) cleanupDefaultIssuer(ctx context.Context, cluster clusters.Cluster) error {
-	return clusters.DeleteManifestByYAML(ctx, cluster, defaultIssuer)
+	return DeleteSelfSignedClusterIssuer(ctx, cluster, DefaultIssuerName)
 }
 
 const webhookWaitJobName = "cert-manager-webhook-wait"
@@ -231,7 +269,7 @@ func (a *Addon) deployWebhookWaitJob(ctx context.Context, cluster clusters.Clust
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{{
 						Name:    "curl",
-						Image:   "curlimages/curl",
+						Image:   images.Mirror("curlimages/curl"),
 						Command: []string{"curl", "-k", fmt.Sprintf("https://cert-manager-webhook.%s.svc/mutate", DefaultNamespace)},
 					}},
 					RestartPolicy: corev1.RestartPolicyOnFailure,
@@ -0,0 +1,163 @@
+package cilium
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blang/semver/v4"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kong/kubernetes-testing-framework/internal/utils"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/types/kind"
+)
+
+// -----------------------------------------------------------------------------
+// Cilium Addon
+// -----------------------------------------------------------------------------
+
+const (
+	// AddonName indicates the unique name of this addon.
+	AddonName clusters.AddonName = "cilium"
+
+	// DefaultNamespace indicates the namespace this addon will be deployed to.
+	DefaultNamespace = "kube-system"
+
+	// HelmRepoURL is the URL of the Cilium Helm repository.
+	HelmRepoURL = "https://helm.cilium.io/"
+
+	// ReleaseName is the name of the Helm release.
+	ReleaseName = "cilium"
+
+	agentDaemonSet     = "cilium"
+	operatorDeployment = "cilium-operator"
+
+	// kindAPIServerPort is the port of the API server on kind control plane nodes.
+	kindAPIServerPort = 6443
+)
+
+// DefaultVersion is the version of Cilium deployed by default.
+var DefaultVersion = semver.MustParse("1.14.5")
+
+// Addon is a Cilium CNI addon. It's meant to be deployed on a cluster created
+// without a CNI (and without kube-proxy, for kube-proxy replacement), e.g. a
+// kind cluster built with WithDefaultCNIDisabled (and WithKubeProxyDisabled).
+type Addon struct {
+	version              semver.Version
+	kubeProxyReplacement bool
+	apiServerHost        string
+	apiServerPort        int
+}
+
+// New produces a new clusters.Addon for Cilium with the default settings.
+func New() *Addon {
+	return NewBuilder().Build()
+}
+
+// Version indicates the version of Cilium which is deployed.
+func (a *Addon) Version() semver.Version {
+	return a.version
+}
+
+// KubeProxyReplacement indicates whether Cilium's kube-proxy replacement is enabled.
+func (a *Addon) KubeProxyReplacement() bool {
+	return a.kubeProxyReplacement
+}
+
+// -----------------------------------------------------------------------------
+// Cilium Addon - Addon Implementation
+// -----------------------------------------------------------------------------
+
+func (a *Addon) Name() clusters.AddonName {
+	return AddonName
+}
+
+func (a *Addon) Dependencies(_ context.Context, _ clusters.Cluster) []clusters.AddonName {
+	return nil
+}
+
+func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
+	host, port := a.apiServerHost, a.apiServerPort
+	if a.kubeProxyReplacement && host == "" {
+		if cluster.Type() != kind.KindClusterType {
+			return fmt.Errorf("the API server address must be provided for kube-proxy replacement on %s clusters", cluster.Type())
+		}
+		host, port = fmt.Sprintf("%s-control-plane", cluster.Name()), kindAPIServerPort
+	}
+
+	release := utils.HelmRelease{
+		RepoName:  "cilium",
+		RepoURL:   HelmRepoURL,
+		Chart:     "cilium/cilium",
+		Name:      ReleaseName,
+		Namespace: DefaultNamespace,
+		Version:   a.version.String(),
+		Args:      a.helmArgs(host, port),
+	}
+
+	return utils.HelmInstall(ctx, cluster, release)
+}
+
+func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
+	return utils.HelmUninstall(ctx, cluster, ReleaseName, DefaultNamespace)
+}
+
+func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) ([]runtime.Object, bool, error) {
+	daemonSet, err := cluster.Client().AppsV1().DaemonSets(DefaultNamespace).Get(ctx, agentDaemonSet, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	if daemonSet.Status.DesiredNumberScheduled == 0 ||
+		daemonSet.Status.NumberReady != daemonSet.Status.DesiredNumberScheduled {
+		return []runtime.Object{daemonSet}, false, nil
+	}
+
+	deployment, err := cluster.Client().AppsV1().Deployments(DefaultNamespace).Get(ctx, operatorDeployment, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	if deployment.Status.AvailableReplicas != *deployment.Spec.Replicas {
+		return []runtime.Object{deployment}, false, nil
+	}
+
+	return nil, true, nil
+}
+
+func (a *Addon) Conditions(ctx context.Context, cluster clusters.Cluster) (bool, []clusters.Condition, error) {
+	return clusters.AddonConditions(ctx, cluster, a, DefaultNamespace, "app.kubernetes.io/part-of=cilium")
+}
+
+func (a *Addon) DumpDiagnostics(context.Context, clusters.Cluster) (map[string][]byte, error) {
+	diagnostics := make(map[string][]byte)
+	return diagnostics, nil
+}
+
+// -----------------------------------------------------------------------------
+// Cilium Addon - Private
+// -----------------------------------------------------------------------------
+
+// helmArgs provides the "--set" arguments for the chart, given the API server
+// address to use (if any).
+func (a *Addon) helmArgs(apiServerHost string, apiServerPort int) []string {
+	args := []string{
+		"--set", "ipam.mode=kubernetes",
+		"--set", "image.pullPolicy=IfNotPresent",
+		"--set", "operator.replicas=1",
+		"--set", fmt.Sprintf("kubeProxyReplacement=%t", a.kubeProxyReplacement),
+	}
+	if apiServerHost != "" {
+		args = append(args,
+			"--set", fmt.Sprintf("k8sServiceHost=%s", apiServerHost),
+			"--set", fmt.Sprintf("k8sServicePort=%d", apiServerPort),
+		)
+	}
+	return args
+}
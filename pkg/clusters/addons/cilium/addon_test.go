@@ -0,0 +1,25 @@
+package cilium
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHelmArgs(t *testing.T) {
+	assert.Equal(t, []string{
+		"--set", "ipam.mode=kubernetes",
+		"--set", "image.pullPolicy=IfNotPresent",
+		"--set", "operator.replicas=1",
+		"--set", "kubeProxyReplacement=false",
+	}, New().helmArgs("", 0))
+
+	assert.Equal(t, []string{
+		"--set", "ipam.mode=kubernetes",
+		"--set", "image.pullPolicy=IfNotPresent",
+		"--set", "operator.replicas=1",
+		"--set", "kubeProxyReplacement=true",
+		"--set", "k8sServiceHost=test-control-plane",
+		"--set", "k8sServicePort=6443",
+	}, NewBuilder().WithKubeProxyReplacement().Build().helmArgs("test-control-plane", 6443))
+}
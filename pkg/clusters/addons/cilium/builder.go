@@ -0,0 +1,59 @@
+package cilium
+
+import (
+	"github.com/blang/semver/v4"
+)
+
+// -----------------------------------------------------------------------------
+// Cilium Addon - Builder
+// -----------------------------------------------------------------------------
+
+// Builder is a configuration tool to generate Cilium cluster addons.
+type Builder struct {
+	version              semver.Version
+	kubeProxyReplacement bool
+	apiServerHost        string
+	apiServerPort        int
+}
+
+// NewBuilder provides a new Builder object for configuring Cilium cluster addons.
+func NewBuilder() *Builder {
+	return &Builder{
+		version: DefaultVersion,
+	}
+}
+
+// WithVersion configures the version of Cilium which should be deployed.
+func (b *Builder) WithVersion(version semver.Version) *Builder {
+	b.version = version
+	return b
+}
+
+// WithKubeProxyReplacement enables Cilium's kube-proxy replacement, in which
+// case Service load balancing is implemented by Cilium's eBPF datapath. The
+// cluster should be created without kube-proxy.
+func (b *Builder) WithKubeProxyReplacement() *Builder {
+	b.kubeProxyReplacement = true
+	return b
+}
+
+// WithAPIServer configures the address at which Cilium reaches the
+// Kubernetes API server, which is required with kube-proxy replacement as the
+// kubernetes Service can't be used. This is detected automatically for kind
+// clusters.
+func (b *Builder) WithAPIServer(host string, port int) *Builder {
+	b.apiServerHost = host
+	b.apiServerPort = port
+	return b
+}
+
+// Build generates a new Cilium cluster.Addon which can be loaded and deployed
+// into a test Environment's cluster.Cluster.
+func (b *Builder) Build() *Addon {
+	return &Addon{
+		version:              b.version,
+		kubeProxyReplacement: b.kubeProxyReplacement,
+		apiServerHost:        b.apiServerHost,
+		apiServerPort:        b.apiServerPort,
+	}
+}
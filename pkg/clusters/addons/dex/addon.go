@@ -0,0 +1,204 @@
+package dex
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/kong/kubernetes-testing-framework/internal/utils"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/kubernetes/generators"
+)
+
+// -----------------------------------------------------------------------------
+// Dex Addon
+// -----------------------------------------------------------------------------
+
+const (
+	// AddonName indicates the unique name of this addon.
+	AddonName clusters.AddonName = "dex"
+
+	// DefaultNamespace indicates the default namespace this addon will be deployed to.
+	DefaultNamespace = "dex"
+
+	// DefaultImage is the Dex container image deployed by default.
+	DefaultImage = "ghcr.io/dexidp/dex:v2.38.0"
+
+	// ServiceName is the name of the Dex service.
+	ServiceName = "dex"
+
+	// ServicePort is the HTTP port of the Dex service.
+	ServicePort = 5556
+
+	// DefaultIssuer is the issuer URL of Dex unless configured otherwise,
+	// which is its in-cluster URL.
+	DefaultIssuer = "http://dex.dex.svc:5556/dex"
+
+	configSecretName = "dex-config"
+)
+
+var (
+	// DefaultUser is the user configured when no static users are provided.
+	DefaultUser = StaticUser{
+		Email:    "admin@example.com",
+		Username: "admin",
+		Password: "password",
+	}
+
+	// DefaultClient is the OAuth2 client configured when no static clients
+	// are provided.
+	DefaultClient = StaticClient{
+		ID:     "ktf",
+		Secret: "ktf-secret",
+		Name:   "KTF",
+	}
+)
+
+// StaticUser is a user of Dex's password database.
+type StaticUser struct {
+	Email    string
+	Username string
+	Password string
+}
+
+// StaticClient is an OAuth2 client registered with Dex.
+type StaticClient struct {
+	ID           string
+	Secret       string
+	Name         string
+	RedirectURIs []string
+}
+
+// Addon is a Dex addon, which provides an OpenID Connect provider with an
+// in-memory storage, a static password database and static clients.
+type Addon struct {
+	image   string
+	issuer  string
+	users   []StaticUser
+	clients []StaticClient
+}
+
+// New produces a new clusters.Addon for Dex with the default settings.
+func New() *Addon {
+	return NewBuilder().Build()
+}
+
+// Namespace indicates the namespace where the Dex addon components are to be
+// deployed and managed.
+func (a *Addon) Namespace() string {
+	return DefaultNamespace
+}
+
+// Issuer provides the issuer URL of Dex, which is the base URL of its
+// OpenID Connect discovery document.
+func (a *Addon) Issuer() string {
+	return a.issuer
+}
+
+// Users provides the users of Dex's password database.
+func (a *Addon) Users() []StaticUser {
+	return a.users
+}
+
+// Clients provides the OAuth2 clients registered with Dex.
+func (a *Addon) Clients() []StaticClient {
+	return a.clients
+}
+
+// -----------------------------------------------------------------------------
+// Dex Addon - Addon Implementation
+// -----------------------------------------------------------------------------
+
+func (a *Addon) Name() clusters.AddonName {
+	return AddonName
+}
+
+func (a *Addon) Dependencies(_ context.Context, _ clusters.Cluster) []clusters.AddonName {
+	return nil
+}
+
+func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
+	// wait for dependency addons to be ready first
+	if err := clusters.WaitForAddonDependencies(ctx, cluster, a); err != nil {
+		return fmt.Errorf("failure waiting for addon dependencies: %w", err)
+	}
+
+	if err := clusters.CreateNamespace(ctx, cluster, DefaultNamespace); err != nil {
+		return err
+	}
+
+	config, err := a.config()
+	if err != nil {
+		return fmt.Errorf("could not generate dex configuration: %w", err)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: configSecretName,
+		},
+		Data: map[string][]byte{
+			"config.yaml": config,
+		},
+	}
+	if _, err := cluster.Client().CoreV1().Secrets(DefaultNamespace).Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	issuer, err := url.Parse(a.issuer)
+	if err != nil {
+		return fmt.Errorf("invalid dex issuer %s: %w", a.issuer, err)
+	}
+	container := generators.NewContainer(ServiceName, a.image, ServicePort)
+	container.Ports[0].Name = "http"
+	container.Command = []string{"dex", "serve", "/etc/dex/config.yaml"}
+	container.ReadinessProbe = &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path: issuer.Path + "/.well-known/openid-configuration",
+				Port: intstr.FromInt(ServicePort),
+			},
+		},
+	}
+	container.VolumeMounts = []corev1.VolumeMount{{Name: "config", MountPath: "/etc/dex", ReadOnly: true}}
+	deployment := generators.NewDeploymentForContainer(container)
+	deployment.Spec.Template.Spec.Volumes = []corev1.Volume{{
+		Name: "config",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: configSecretName},
+		},
+	}}
+	if _, err := cluster.Client().AppsV1().Deployments(DefaultNamespace).Create(ctx, deployment, metav1.CreateOptions{}); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	service := generators.NewServiceForDeployment(deployment, corev1.ServiceTypeClusterIP)
+	if _, err := cluster.Client().CoreV1().Services(DefaultNamespace).Create(ctx, service, metav1.CreateOptions{}); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
+	return clusters.DeleteNamespace(ctx, cluster, DefaultNamespace)
+}
+
+func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) ([]runtime.Object, bool, error) {
+	return utils.IsNamespaceAvailable(ctx, cluster, DefaultNamespace)
+}
+
+func (a *Addon) DumpDiagnostics(context.Context, clusters.Cluster) (map[string][]byte, error) {
+	diagnostics := make(map[string][]byte)
+	return diagnostics, nil
+}
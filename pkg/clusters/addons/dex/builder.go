@@ -0,0 +1,71 @@
+package dex
+
+// -----------------------------------------------------------------------------
+// Dex Addon - Builder
+// -----------------------------------------------------------------------------
+
+// Builder is a configuration tool to generate Dex cluster addons.
+type Builder struct {
+	image   string
+	issuer  string
+	users   []StaticUser
+	clients []StaticClient
+}
+
+// NewBuilder provides a new Builder object for configuring Dex cluster addons.
+func NewBuilder() *Builder {
+	return &Builder{
+		image: DefaultImage,
+	}
+}
+
+// WithImage configures the Dex container image which should be deployed.
+func (b *Builder) WithImage(image string) *Builder {
+	b.image = image
+	return b
+}
+
+// WithIssuer overrides the issuer URL of Dex, which defaults to the in-cluster
+// URL of its Service. The issuer needs to be reachable by whatever validates
+// the tokens, e.g. the API server when it's configured for OIDC authentication.
+func (b *Builder) WithIssuer(issuer string) *Builder {
+	b.issuer = issuer
+	return b
+}
+
+// WithStaticUser adds a user to Dex's password database.
+func (b *Builder) WithStaticUser(user StaticUser) *Builder {
+	b.users = append(b.users, user)
+	return b
+}
+
+// WithStaticClient adds an OAuth2 client to Dex.
+func (b *Builder) WithStaticClient(client StaticClient) *Builder {
+	b.clients = append(b.clients, client)
+	return b
+}
+
+// Build generates a new Dex cluster.Addon which can be loaded and deployed
+// into a test Environment's cluster.Cluster. If no users or no clients were
+// configured, DefaultUser and DefaultClient are used respectively.
+func (b *Builder) Build() *Addon {
+	issuer := b.issuer
+	if issuer == "" {
+		issuer = DefaultIssuer
+	}
+	users := b.users
+	if len(users) == 0 {
+		users = []StaticUser{DefaultUser}
+	}
+	clients := b.clients
+	if len(clients) == 0 {
+		clients = []StaticClient{DefaultClient}
+	}
+
+	return &Addon{
+		image:   b.image,
+		issuer:  issuer,
+		users:   users,
+		clients: clients,
+	}
+}
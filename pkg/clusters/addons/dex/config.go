@@ -0,0 +1,90 @@
+package dex
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+	"sigs.k8s.io/yaml"
+)
+
+// -----------------------------------------------------------------------------
+// Dex Addon - Configuration
+// -----------------------------------------------------------------------------
+
+type config struct {
+	Issuer           string           `json:"issuer"`
+	Storage          storageConfig    `json:"storage"`
+	Web              webConfig        `json:"web"`
+	OAuth2           oauth2Config     `json:"oauth2"`
+	EnablePasswordDB bool             `json:"enablePasswordDB"`
+	StaticPasswords  []staticPassword `json:"staticPasswords"`
+	StaticClients    []staticClient   `json:"staticClients"`
+}
+
+type storageConfig struct {
+	Type string `json:"type"`
+}
+
+type webConfig struct {
+	HTTP string `json:"http"`
+}
+
+type oauth2Config struct {
+	SkipApprovalScreen bool     `json:"skipApprovalScreen"`
+	PasswordConnector  string   `json:"passwordConnector"`
+	ResponseTypes      []string `json:"responseTypes"`
+}
+
+type staticPassword struct {
+	Email    string `json:"email"`
+	Hash     string `json:"hash"`
+	Username string `json:"username"`
+	UserID   string `json:"userID"`
+}
+
+type staticClient struct {
+	ID           string   `json:"id"`
+	Secret       string   `json:"secret"`
+	Name         string   `json:"name"`
+	RedirectURIs []string `json:"redirectURIs,omitempty"`
+}
+
+// config generates the Dex configuration file. The approval screen is skipped
+// and the password grant is enabled for the password database, so that tokens
+// can be obtained without a browser.
+func (a *Addon) config() ([]byte, error) {
+	cfg := config{
+		Issuer:           a.issuer,
+		Storage:          storageConfig{Type: "memory"},
+		Web:              webConfig{HTTP: fmt.Sprintf("0.0.0.0:%d", ServicePort)},
+		EnablePasswordDB: true,
+		OAuth2: oauth2Config{
+			SkipApprovalScreen: true,
+			PasswordConnector:  "local",
+			ResponseTypes:      []string{"code", "token", "id_token"},
+		},
+	}
+
+	for _, user := range a.users {
+		hash, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, err
+		}
+		// the user ID needs to be stable, as it's the subject of the tokens.
+		id := sha256.Sum256([]byte(user.Email))
+		cfg.StaticPasswords = append(cfg.StaticPasswords, staticPassword{
+			Email:    user.Email,
+			Hash:     string(hash),
+			Username: user.Username,
+			UserID:   hex.EncodeToString(id[:16]),
+		})
+	}
+
+	for _, client := range a.clients {
+		cfg.StaticClients = append(cfg.StaticClients, staticClient(client))
+	}
+
+	return yaml.Marshal(cfg)
+}
@@ -0,0 +1,53 @@
+package dex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"sigs.k8s.io/yaml"
+)
+
+func TestConfig(t *testing.T) {
+	addon := NewBuilder().
+		WithIssuer("http://127.0.0.1:5556/dex").
+		WithStaticUser(StaticUser{Email: "jane@example.com", Username: "jane", Password: "s3cr3t"}).
+		WithStaticClient(StaticClient{ID: "kong", Secret: "kong-secret", Name: "Kong", RedirectURIs: []string{"http://localhost/callback"}}).
+		Build()
+
+	raw, err := addon.config()
+	require.NoError(t, err)
+
+	cfg := config{}
+	require.NoError(t, yaml.Unmarshal(raw, &cfg))
+	assert.Equal(t, "http://127.0.0.1:5556/dex", cfg.Issuer)
+	assert.Equal(t, "memory", cfg.Storage.Type)
+	assert.Equal(t, "local", cfg.OAuth2.PasswordConnector)
+	assert.True(t, cfg.EnablePasswordDB)
+
+	require.Len(t, cfg.StaticPasswords, 1)
+	assert.Equal(t, "jane@example.com", cfg.StaticPasswords[0].Email)
+	assert.Equal(t, "jane", cfg.StaticPasswords[0].Username)
+	assert.NotEmpty(t, cfg.StaticPasswords[0].UserID)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(cfg.StaticPasswords[0].Hash), []byte("s3cr3t")))
+
+	require.Len(t, cfg.StaticClients, 1)
+	assert.Equal(t, staticClient{ID: "kong", Secret: "kong-secret", Name: "Kong", RedirectURIs: []string{"http://localhost/callback"}}, cfg.StaticClients[0])
+}
+
+func TestDefaults(t *testing.T) {
+	addon := New()
+	assert.Equal(t, DefaultIssuer, addon.Issuer())
+	assert.Equal(t, []StaticUser{DefaultUser}, addon.Users())
+	assert.Equal(t, []StaticClient{DefaultClient}, addon.Clients())
+}
+
+func TestParseTokenResponse(t *testing.T) {
+	token, err := parseTokenResponse([]byte(`{"access_token":"abc","token_type":"bearer","expires_in":86399,"id_token":"def"}`))
+	require.NoError(t, err)
+	assert.Equal(t, &Token{AccessToken: "abc", IDToken: "def", TokenType: "bearer", ExpiresIn: 86399}, token)
+
+	_, err = parseTokenResponse([]byte(`{"error":"invalid_client"}`))
+	assert.Error(t, err)
+}
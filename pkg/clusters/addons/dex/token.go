@@ -0,0 +1,68 @@
+package dex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+)
+
+// -----------------------------------------------------------------------------
+// Dex Addon - Tokens
+// -----------------------------------------------------------------------------
+
+// Token are the tokens issued by Dex to a client.
+type Token struct {
+	AccessToken string `json:"access_token"`
+	IDToken     string `json:"id_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// PasswordToken obtains tokens for the user on behalf of the client using the
+// OAuth2 resource owner password credentials grant, accessing Dex through the
+// Kubernetes API server service proxy. The ID token can be used as a bearer
+// token wherever the Dex issuer is trusted.
+func (a *Addon) PasswordToken(ctx context.Context, cluster clusters.Cluster, client StaticClient, user StaticUser) (*Token, error) {
+	issuer, err := url.Parse(a.issuer)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dex issuer %s: %w", a.issuer, err)
+	}
+
+	// client credentials are sent in the form, as the API server doesn't
+	// forward the Authorization header through the proxy.
+	form := url.Values{
+		"grant_type":    {"password"},
+		"scope":         {"openid email profile"},
+		"username":      {user.Email},
+		"password":      {user.Password},
+		"client_id":     {client.ID},
+		"client_secret": {client.Secret},
+	}
+	raw, err := cluster.Client().CoreV1().RESTClient().Post().
+		Namespace(DefaultNamespace).
+		Resource("services").
+		Name(fmt.Sprintf("http:%s:%d", ServiceName, ServicePort)).
+		SubResource("proxy").
+		Suffix(issuer.Path, "token").
+		SetHeader("Content-Type", "application/x-www-form-urlencoded").
+		Body([]byte(form.Encode())).
+		DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("dex token request for user %s failed: %w", user.Email, err)
+	}
+	return parseTokenResponse(raw)
+}
+
+func parseTokenResponse(raw []byte) (*Token, error) {
+	token := &Token{}
+	if err := json.Unmarshal(raw, token); err != nil {
+		return nil, fmt.Errorf("invalid dex token response: %w", err)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("dex token response contains no access token")
+	}
+	return token, nil
+}
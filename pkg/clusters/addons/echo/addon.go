@@ -0,0 +1,135 @@
+package echo
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/kong/kubernetes-testing-framework/internal/utils"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/kubernetes/generators"
+)
+
+// -----------------------------------------------------------------------------
+// Echo Addon
+// -----------------------------------------------------------------------------
+
+const (
+	// AddonName indicates the unique name of this addon.
+	AddonName clusters.AddonName = "echo"
+
+	// DefaultNamespace indicates the default namespace this addon will be deployed to.
+	DefaultNamespace = "echo"
+
+	// Image is the container image of the echo server.
+	Image = "ealen/echo-server:0.9.2"
+
+	// ServiceName is the name of the echo server Service.
+	ServiceName = "echo"
+
+	// ServicePort is the HTTP port of the echo server Service.
+	ServicePort = 80
+)
+
+// Addon is an echo server addon, whose HTTP responses describe the request it
+// received (see ParseResponse), to assert on what reaches the upstream.
+type Addon struct {
+	namespace string
+	replicas  int32
+}
+
+// New produces a new clusters.Addon for the echo server with the default settings.
+func New() *Addon {
+	return NewBuilder().Build()
+}
+
+// Namespace indicates the namespace where the echo server addon components
+// are to be deployed and managed.
+func (a *Addon) Namespace() string {
+	return a.namespace
+}
+
+// ServiceURL provides the in-cluster URL of the echo server Service, which
+// can be used as an upstream to route to.
+func (a *Addon) ServiceURL() string {
+	return fmt.Sprintf("http://%s.%s.svc:%d", ServiceName, a.namespace, ServicePort)
+}
+
+// Service retrieves the echo server Service from the cluster.
+func (a *Addon) Service(ctx context.Context, cluster clusters.Cluster) (*corev1.Service, error) {
+	return cluster.Client().CoreV1().Services(a.namespace).Get(ctx, ServiceName, metav1.GetOptions{})
+}
+
+// -----------------------------------------------------------------------------
+// Echo Addon - Addon Implementation
+// -----------------------------------------------------------------------------
+
+func (a *Addon) Name() clusters.AddonName {
+	return AddonName
+}
+
+func (a *Addon) Dependencies(_ context.Context, _ clusters.Cluster) []clusters.AddonName {
+	return nil
+}
+
+func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
+	// wait for dependency addons to be ready first
+	if err := clusters.WaitForAddonDependencies(ctx, cluster, a); err != nil {
+		return fmt.Errorf("failure waiting for addon dependencies: %w", err)
+	}
+
+	if err := clusters.CreateNamespace(ctx, cluster, a.namespace); err != nil {
+		return err
+	}
+
+	container := generators.NewContainer(ServiceName, Image, ServicePort)
+	container.Ports[0].Name = "http"
+	container.Env = []corev1.EnvVar{
+		{Name: "PORT", Value: fmt.Sprint(ServicePort)},
+		// the container environment is not relevant to tests.
+		{Name: "ENABLE__ENVIRONMENT", Value: "false"},
+	}
+	container.ReadinessProbe = &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path: "/",
+				Port: intstr.FromInt(ServicePort),
+			},
+		},
+	}
+	deployment := generators.NewDeploymentForContainer(container)
+	replicas := a.replicas
+	deployment.Spec.Replicas = &replicas
+	if _, err := cluster.Client().AppsV1().Deployments(a.namespace).Create(ctx, deployment, metav1.CreateOptions{}); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	service := generators.NewServiceForDeployment(deployment, corev1.ServiceTypeClusterIP)
+	if _, err := cluster.Client().CoreV1().Services(a.namespace).Create(ctx, service, metav1.CreateOptions{}); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
+	return clusters.DeleteNamespace(ctx, cluster, a.namespace)
+}
+
+func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) ([]runtime.Object, bool, error) {
+	return utils.IsNamespaceAvailable(ctx, cluster, a.namespace)
+}
+
+func (a *Addon) DumpDiagnostics(context.Context, clusters.Cluster) (map[string][]byte, error) {
+	diagnostics := make(map[string][]byte)
+	return diagnostics, nil
+}
@@ -0,0 +1,42 @@
+package echo
+
+// -----------------------------------------------------------------------------
+// Echo Addon - Builder
+// -----------------------------------------------------------------------------
+
+// Builder is a configuration tool to generate echo server cluster addons.
+type Builder struct {
+	namespace string
+	replicas  int32
+}
+
+// NewBuilder provides a new Builder object for configuring echo server
+// cluster addons.
+func NewBuilder() *Builder {
+	return &Builder{
+		namespace: DefaultNamespace,
+		replicas:  1,
+	}
+}
+
+// WithNamespace allows the namespace where the addon should be deployed to be
+// overridden from the default.
+func (b *Builder) WithNamespace(namespace string) *Builder {
+	b.namespace = namespace
+	return b
+}
+
+// WithReplicas configures the number of echo server replicas to deploy.
+func (b *Builder) WithReplicas(replicas int32) *Builder {
+	b.replicas = replicas
+	return b
+}
+
+// Build generates a new echo server cluster.Addon which can be loaded and
+// deployed into a test Environment's cluster.Cluster.
+func (b *Builder) Build() *Addon {
+	return &Addon{
+		namespace: b.namespace,
+		replicas:  b.replicas,
+	}
+}
@@ -0,0 +1,100 @@
+package echo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// -----------------------------------------------------------------------------
+// Echo Addon - Responses
+// -----------------------------------------------------------------------------
+
+// Response describes the request received by the echo server.
+type Response struct {
+	// Hostname is the hostname of the echo server Pod which served the request.
+	Hostname string
+
+	// Method is the HTTP method of the request.
+	Method string
+
+	// Path is the path of the request as received, including the query.
+	Path string
+
+	// Query are the query parameters of the request.
+	Query url.Values
+
+	// Headers are the headers of the request, with canonical keys.
+	Headers http.Header
+
+	// Body is the body of the request, decoded if it was JSON.
+	Body interface{}
+}
+
+// ParseResponse parses the body of an echo server response.
+func ParseResponse(raw []byte) (*Response, error) {
+	var resp struct {
+		Host struct {
+			Hostname string `json:"hostname"`
+		} `json:"host"`
+		HTTP struct {
+			Method      string `json:"method"`
+			OriginalURL string `json:"originalUrl"`
+		} `json:"http"`
+		Request struct {
+			Headers map[string]interface{} `json:"headers"`
+			Body    interface{}            `json:"body"`
+		} `json:"request"`
+	}
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("invalid echo server response: %w", err)
+	}
+	if resp.HTTP.Method == "" {
+		return nil, fmt.Errorf("invalid echo server response: no request method")
+	}
+
+	u, err := url.ParseRequestURI(resp.HTTP.OriginalURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid echo server request URL %q: %w", resp.HTTP.OriginalURL, err)
+	}
+
+	headers := make(http.Header, len(resp.Request.Headers))
+	for name, value := range resp.Request.Headers {
+		switch v := value.(type) {
+		case string:
+			headers.Add(name, v)
+		case []interface{}:
+			// repeated headers are provided as arrays
+			for _, item := range v {
+				headers.Add(name, fmt.Sprint(item))
+			}
+		default:
+			headers.Add(name, fmt.Sprint(v))
+		}
+	}
+
+	return &Response{
+		Hostname: resp.Host.Hostname,
+		Method:   resp.HTTP.Method,
+		Path:     resp.HTTP.OriginalURL,
+		Query:    u.Query(),
+		Headers:  headers,
+		Body:     resp.Request.Body,
+	}, nil
+}
+
+// ParseHTTPResponse reads and parses the body of an echo server response,
+// closing it. Responses with a status other than 200 are considered errors.
+func ParseHTTPResponse(resp *http.Response) (*Response, error) {
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected echo server response status %d: %s", resp.StatusCode, raw)
+	}
+	return ParseResponse(raw)
+}
@@ -0,0 +1,56 @@
+package echo
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testResponse = `{
+  "host": {"hostname": "echo-7d9c6f5b8-abcde", "ip": "::ffff:10.244.0.12", "ips": []},
+  "http": {"method": "POST", "baseUrl": "", "originalUrl": "/echo/test?a=1&b=2", "protocol": "http"},
+  "request": {
+    "params": {"0": "/echo/test"},
+    "query": {"a": "1", "b": "2"},
+    "cookies": {},
+    "body": {"hello": "world"},
+    "headers": {"host": "echo.echo.svc", "x-added": "true", "x-forwarded-for": ["10.0.0.1", "10.0.0.2"]}
+  }
+}`
+
+func TestParseResponse(t *testing.T) {
+	resp, err := ParseResponse([]byte(testResponse))
+	require.NoError(t, err)
+	assert.Equal(t, "echo-7d9c6f5b8-abcde", resp.Hostname)
+	assert.Equal(t, "POST", resp.Method)
+	assert.Equal(t, "/echo/test?a=1&b=2", resp.Path)
+	assert.Equal(t, url.Values{"a": {"1"}, "b": {"2"}}, resp.Query)
+	assert.Equal(t, "true", resp.Headers.Get("X-Added"))
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, resp.Headers.Values("X-Forwarded-For"))
+	assert.Equal(t, map[string]interface{}{"hello": "world"}, resp.Body)
+
+	_, err = ParseResponse([]byte(`{}`))
+	assert.Error(t, err)
+	_, err = ParseResponse([]byte(`not json`))
+	assert.Error(t, err)
+}
+
+func TestParseHTTPResponse(t *testing.T) {
+	resp, err := ParseHTTPResponse(&http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(testResponse)),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "POST", resp.Method)
+
+	_, err = ParseHTTPResponse(&http.Response{
+		StatusCode: http.StatusNotFound,
+		Body:       io.NopCloser(bytes.NewBufferString("no Route matched with those values")),
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,184 @@
+package envoygateway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blang/semver/v4"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayclient "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
+
+	"github.com/kong/kubernetes-testing-framework/internal/utils"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+)
+
+// -----------------------------------------------------------------------------
+// Envoy Gateway Addon
+// -----------------------------------------------------------------------------
+
+const (
+	// AddonName indicates the unique name of this addon.
+	AddonName clusters.AddonName = "envoygateway"
+
+	// DefaultNamespace indicates the default namespace this addon will be deployed to.
+	DefaultNamespace = "envoy-gateway-system"
+
+	// ControllerName is the controller name of Envoy Gateway, which
+	// GatewayClasses managed by Envoy Gateway need to reference.
+	ControllerName gatewayv1.GatewayController = "gateway.envoyproxy.io/gatewayclass-controller"
+
+	// ReleaseName is the name of the Helm release.
+	ReleaseName = "ktf-envoy-gateway"
+
+	// chart is the OCI reference of the Envoy Gateway Helm chart.
+	chart = "oci://docker.io/envoyproxy/gateway-helm"
+)
+
+// DefaultVersion is the version of Envoy Gateway deployed by default.
+var DefaultVersion = semver.MustParse("1.0.0")
+
+// Addon is an Envoy Gateway addon which can be deployed on a clusters.Cluster.
+// The Envoy Gateway chart includes the standard channel Gateway API CRDs,
+// which are only installed if they're not already present in the cluster.
+type Addon struct {
+	version          semver.Version
+	gatewayClassName string
+}
+
+// New produces a new clusters.Addon for Envoy Gateway with the default settings.
+func New() *Addon {
+	return NewBuilder().Build()
+}
+
+// Namespace indicates the namespace where the Envoy Gateway addon components
+// are to be deployed and managed.
+func (a *Addon) Namespace() string {
+	return DefaultNamespace
+}
+
+// Version indicates the version of Envoy Gateway which is deployed.
+func (a *Addon) Version() semver.Version {
+	return a.version
+}
+
+// GatewayClassName provides the name of the GatewayClass the addon creates,
+// if configured to create one.
+func (a *Addon) GatewayClassName() string {
+	return a.gatewayClassName
+}
+
+// -----------------------------------------------------------------------------
+// Envoy Gateway Addon - Addon Implementation
+// -----------------------------------------------------------------------------
+
+func (a *Addon) Name() clusters.AddonName {
+	return AddonName
+}
+
+func (a *Addon) Dependencies(_ context.Context, _ clusters.Cluster) []clusters.AddonName {
+	return nil
+}
+
+func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
+	// wait for dependency addons to be ready first
+	if err := clusters.WaitForAddonDependencies(ctx, cluster, a); err != nil {
+		return fmt.Errorf("failure waiting for addon dependencies: %w", err)
+	}
+
+	release := utils.HelmRelease{
+		Chart:     chart,
+		Name:      ReleaseName,
+		Namespace: DefaultNamespace,
+		// Envoy Gateway chart versions are prefixed with "v"
+		Version: "v" + a.version.String(),
+	}
+	if err := utils.HelmInstall(ctx, cluster, release); err != nil {
+		return err
+	}
+
+	if a.gatewayClassName != "" {
+		if _, err := CreateGatewayClass(ctx, cluster, a.gatewayClassName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
+	if a.gatewayClassName != "" {
+		if err := DeleteGatewayClass(ctx, cluster, a.gatewayClassName); err != nil {
+			return err
+		}
+	}
+	if err := utils.HelmUninstall(ctx, cluster, ReleaseName, DefaultNamespace); err != nil {
+		return err
+	}
+	return clusters.DeleteNamespace(ctx, cluster, DefaultNamespace)
+}
+
+func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) ([]runtime.Object, bool, error) {
+	return utils.IsNamespaceAvailable(ctx, cluster, DefaultNamespace)
+}
+
+func (a *Addon) Conditions(ctx context.Context, cluster clusters.Cluster) (bool, []clusters.Condition, error) {
+	return clusters.AddonConditions(ctx, cluster, a, DefaultNamespace, "")
+}
+
+func (a *Addon) DumpDiagnostics(context.Context, clusters.Cluster) (map[string][]byte, error) {
+	diagnostics := make(map[string][]byte)
+	return diagnostics, nil
+}
+
+// -----------------------------------------------------------------------------
+// Envoy Gateway Addon - GatewayClasses
+// -----------------------------------------------------------------------------
+
+// NewGatewayClass generates a GatewayClass with the given name which is
+// managed by Envoy Gateway.
+func NewGatewayClass(name string) *gatewayv1.GatewayClass {
+	return &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: gatewayv1.GatewayClassSpec{
+			ControllerName: ControllerName,
+		},
+	}
+}
+
+// CreateGatewayClass creates a GatewayClass with the given name which is
+// managed by Envoy Gateway, if it doesn't already exist.
+func CreateGatewayClass(ctx context.Context, cluster clusters.Cluster, name string) (*gatewayv1.GatewayClass, error) {
+	gc, err := gatewayclient.NewForConfig(cluster.Config())
+	if err != nil {
+		return nil, err
+	}
+
+	gatewayClass, err := gc.GatewayV1().GatewayClasses().Create(ctx, NewGatewayClass(name), metav1.CreateOptions{})
+	if err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("could not create GatewayClass %s: %w", name, err)
+		}
+		return gc.GatewayV1().GatewayClasses().Get(ctx, name, metav1.GetOptions{})
+	}
+	return gatewayClass, nil
+}
+
+// DeleteGatewayClass deletes the GatewayClass with the given name, if it exists.
+func DeleteGatewayClass(ctx context.Context, cluster clusters.Cluster, name string) error {
+	gc, err := gatewayclient.NewForConfig(cluster.Config())
+	if err != nil {
+		return err
+	}
+
+	if err := gc.GatewayV1().GatewayClasses().Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("could not delete GatewayClass %s: %w", name, err)
+		}
+	}
+	return nil
+}
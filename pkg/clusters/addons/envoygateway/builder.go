@@ -0,0 +1,44 @@
+package envoygateway
+
+import (
+	"github.com/blang/semver/v4"
+)
+
+// -----------------------------------------------------------------------------
+// Envoy Gateway Addon - Builder
+// -----------------------------------------------------------------------------
+
+// Builder is a configuration tool to generate Envoy Gateway cluster addons.
+type Builder struct {
+	version          semver.Version
+	gatewayClassName string
+}
+
+// NewBuilder provides a new Builder object for configuring Envoy Gateway cluster addons.
+func NewBuilder() *Builder {
+	return &Builder{
+		version: DefaultVersion,
+	}
+}
+
+// WithVersion configures the version of Envoy Gateway which should be deployed.
+func (b *Builder) WithVersion(version semver.Version) *Builder {
+	b.version = version
+	return b
+}
+
+// WithGatewayClass configures the addon to create a GatewayClass with the
+// given name, managed by Envoy Gateway, when it's deployed.
+func (b *Builder) WithGatewayClass(name string) *Builder {
+	b.gatewayClassName = name
+	return b
+}
+
+// Build generates a new Envoy Gateway cluster.Addon which can be loaded and
+// deployed into a test Environment's cluster.Cluster.
+func (b *Builder) Build() *Addon {
+	return &Addon{
+		version:          b.version,
+		gatewayClassName: b.gatewayClassName,
+	}
+}
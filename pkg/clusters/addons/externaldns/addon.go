@@ -0,0 +1,293 @@
+package externaldns
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kong/kubernetes-testing-framework/internal/utils"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/kubernetes/generators"
+)
+
+// -----------------------------------------------------------------------------
+// ExternalDNS Addon
+// -----------------------------------------------------------------------------
+
+const (
+	// AddonName indicates the unique name of this addon.
+	AddonName clusters.AddonName = "external-dns"
+
+	// DefaultNamespace indicates the default namespace this addon will be deployed to.
+	DefaultNamespace = "external-dns"
+
+	// DefaultVersion is the version of external-dns deployed by default.
+	DefaultVersion = "v0.14.0"
+
+	// DefaultDomain is the domain external-dns manages records for by default.
+	DefaultDomain = "ktf.test"
+
+	// EtcdServiceName is the name of the etcd service backing the stub DNS server.
+	EtcdServiceName = "external-dns-etcd"
+
+	// EtcdPort is the client port of the etcd service.
+	EtcdPort = 2379
+
+	// DNSServiceName is the name of the stub DNS (CoreDNS) service.
+	DNSServiceName = "external-dns-coredns"
+
+	// DNSPort is the port of the stub DNS service.
+	DNSPort = 53
+
+	etcdImage     = "quay.io/coreos/etcd:v3.5.11"
+	corednsImage  = "coredns/coredns:1.11.1"
+	externalDNS   = "external-dns"
+	externalImage = "registry.k8s.io/external-dns/external-dns"
+	corefile      = "external-dns-corefile"
+)
+
+// DefaultSources are the external-dns sources used by default.
+var DefaultSources = []string{"ingress", "service"}
+
+// Addon is an external-dns addon using the CoreDNS provider: records are
+// stored in an in-cluster etcd and served by an in-cluster CoreDNS, so that
+// record creation can be asserted without any external DNS provider.
+type Addon struct {
+	version string
+	domain  string
+	sources []string
+}
+
+// New produces a new clusters.Addon for external-dns with the default settings.
+func New() *Addon {
+	return NewBuilder().Build()
+}
+
+// Namespace indicates the namespace where the external-dns addon components
+// are to be deployed and managed.
+func (a *Addon) Namespace() string {
+	return DefaultNamespace
+}
+
+// Domain indicates the domain external-dns manages records for.
+func (a *Addon) Domain() string {
+	return a.domain
+}
+
+// NameserverAddress provides the in-cluster host:port of the stub DNS server,
+// which serves the records created by external-dns.
+func (a *Addon) NameserverAddress() string {
+	return fmt.Sprintf("%s.%s.svc:%d", DNSServiceName, DefaultNamespace, DNSPort)
+}
+
+// -----------------------------------------------------------------------------
+// ExternalDNS Addon - Addon Implementation
+// -----------------------------------------------------------------------------
+
+func (a *Addon) Name() clusters.AddonName {
+	return AddonName
+}
+
+func (a *Addon) Dependencies(_ context.Context, _ clusters.Cluster) []clusters.AddonName {
+	return nil
+}
+
+func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
+	// wait for dependency addons to be ready first
+	if err := clusters.WaitForAddonDependencies(ctx, cluster, a); err != nil {
+		return fmt.Errorf("failure waiting for addon dependencies: %w", err)
+	}
+
+	if err := clusters.CreateNamespace(ctx, cluster, DefaultNamespace); err != nil {
+		return err
+	}
+
+	if err := a.deployEtcd(ctx, cluster); err != nil {
+		return fmt.Errorf("could not deploy etcd for external-dns: %w", err)
+	}
+	if err := a.deployCoreDNS(ctx, cluster); err != nil {
+		return fmt.Errorf("could not deploy coredns for external-dns: %w", err)
+	}
+	if err := a.deployExternalDNS(ctx, cluster); err != nil {
+		return fmt.Errorf("could not deploy external-dns: %w", err)
+	}
+	return nil
+}
+
+func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
+	if err := cluster.Client().RbacV1().ClusterRoleBindings().Delete(ctx, externalDNS, metav1.DeleteOptions{}); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	if err := cluster.Client().RbacV1().ClusterRoles().Delete(ctx, externalDNS, metav1.DeleteOptions{}); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return clusters.DeleteNamespace(ctx, cluster, DefaultNamespace)
+}
+
+func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) ([]runtime.Object, bool, error) {
+	return utils.IsNamespaceAvailable(ctx, cluster, DefaultNamespace)
+}
+
+func (a *Addon) DumpDiagnostics(context.Context, clusters.Cluster) (map[string][]byte, error) {
+	diagnostics := make(map[string][]byte)
+	return diagnostics, nil
+}
+
+// -----------------------------------------------------------------------------
+// ExternalDNS Addon - Private
+// -----------------------------------------------------------------------------
+
+func (a *Addon) deployEtcd(ctx context.Context, cluster clusters.Cluster) error {
+	container := generators.NewContainer(EtcdServiceName, etcdImage, EtcdPort)
+	container.Command = []string{
+		"etcd",
+		"--data-dir=/tmp/etcd",
+		fmt.Sprintf("--listen-client-urls=http://0.0.0.0:%d", EtcdPort),
+		fmt.Sprintf("--advertise-client-urls=http://%s:%d", EtcdServiceName, EtcdPort),
+	}
+	return deployContainer(ctx, cluster, container, corev1.PodSpec{})
+}
+
+func (a *Addon) deployCoreDNS(ctx context.Context, cluster clusters.Cluster) error {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: corefile},
+		Data: map[string]string{
+			"Corefile": fmt.Sprintf(`%[1]s:%[2]d {
+    errors
+    log
+    etcd %[1]s {
+        path /skydns
+        endpoint http://%[3]s:%[4]d
+    }
+}
+`, a.domain, DNSPort, EtcdServiceName, EtcdPort),
+		},
+	}
+	if _, err := cluster.Client().CoreV1().ConfigMaps(DefaultNamespace).Create(ctx, configMap, metav1.CreateOptions{}); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	container := generators.NewContainer(DNSServiceName, corednsImage, DNSPort)
+	container.Ports = []corev1.ContainerPort{
+		{Name: "dns", ContainerPort: DNSPort, Protocol: corev1.ProtocolUDP},
+		{Name: "dns-tcp", ContainerPort: DNSPort, Protocol: corev1.ProtocolTCP},
+	}
+	container.Args = []string{"-conf", "/etc/coredns/Corefile"}
+	container.VolumeMounts = []corev1.VolumeMount{{Name: corefile, MountPath: "/etc/coredns"}}
+
+	return deployContainer(ctx, cluster, container, corev1.PodSpec{
+		Volumes: []corev1.Volume{{
+			Name: corefile,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: corefile},
+				},
+			},
+		}},
+	})
+}
+
+func (a *Addon) deployExternalDNS(ctx context.Context, cluster clusters.Cluster) error {
+	serviceAccount := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: externalDNS}}
+	if _, err := cluster.Client().CoreV1().ServiceAccounts(DefaultNamespace).Create(ctx, serviceAccount, metav1.CreateOptions{}); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	clusterRole := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: externalDNS},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"services", "endpoints", "pods", "nodes", "namespaces"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+			{
+				APIGroups: []string{"networking.k8s.io"},
+				Resources: []string{"ingresses"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+			{
+				APIGroups: []string{"gateway.networking.k8s.io"},
+				Resources: []string{"gateways", "httproutes", "grpcroutes", "tlsroutes", "tcproutes", "udproutes"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+		},
+	}
+	if _, err := cluster.Client().RbacV1().ClusterRoles().Create(ctx, clusterRole, metav1.CreateOptions{}); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	clusterRoleBinding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: externalDNS},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     externalDNS,
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      externalDNS,
+			Namespace: DefaultNamespace,
+		}},
+	}
+	if _, err := cluster.Client().RbacV1().ClusterRoleBindings().Create(ctx, clusterRoleBinding, metav1.CreateOptions{}); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	container := generators.NewContainer(externalDNS, fmt.Sprintf("%s:%s", externalImage, a.version), 7979) //nolint:gomnd
+	container.Ports[0].Name = "metrics"
+	container.Args = []string{
+		"--provider=coredns",
+		"--policy=sync",
+		"--interval=5s",
+		fmt.Sprintf("--domain-filter=%s", a.domain),
+		fmt.Sprintf("--txt-owner-id=%s", AddonName),
+	}
+	for _, source := range a.sources {
+		container.Args = append(container.Args, fmt.Sprintf("--source=%s", source))
+	}
+	container.Env = []corev1.EnvVar{{
+		Name:  "ETCD_URLS",
+		Value: fmt.Sprintf("http://%s:%d", EtcdServiceName, EtcdPort),
+	}}
+
+	return deployContainer(ctx, cluster, container, corev1.PodSpec{ServiceAccountName: externalDNS})
+}
+
+// deployContainer creates a Deployment and Service for the provided container,
+// using the volumes and service account of the provided pod spec.
+func deployContainer(ctx context.Context, cluster clusters.Cluster, container corev1.Container, podSpec corev1.PodSpec) error {
+	deployment := generators.NewDeploymentForContainer(container)
+	deployment.Spec.Template.Spec.Volumes = podSpec.Volumes
+	deployment.Spec.Template.Spec.ServiceAccountName = podSpec.ServiceAccountName
+	if _, err := cluster.Client().AppsV1().Deployments(DefaultNamespace).Create(ctx, deployment, metav1.CreateOptions{}); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	service := generators.NewServiceForDeployment(deployment, corev1.ServiceTypeClusterIP)
+	if _, err := cluster.Client().CoreV1().Services(DefaultNamespace).Create(ctx, service, metav1.CreateOptions{}); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package externaldns
+
+// -----------------------------------------------------------------------------
+// ExternalDNS Addon - Builder
+// -----------------------------------------------------------------------------
+
+// Builder is a configuration tool to generate external-dns cluster addons.
+type Builder struct {
+	version string
+	domain  string
+	sources []string
+}
+
+// NewBuilder provides a new Builder object for configuring external-dns cluster addons.
+func NewBuilder() *Builder {
+	return &Builder{
+		version: DefaultVersion,
+		domain:  DefaultDomain,
+		sources: DefaultSources,
+	}
+}
+
+// WithVersion configures the version (image tag) of external-dns which
+// should be deployed, e.g. "v0.14.0".
+func (b *Builder) WithVersion(version string) *Builder {
+	b.version = version
+	return b
+}
+
+// WithDomain configures the domain external-dns manages records for, and
+// which the stub DNS server is authoritative for.
+func (b *Builder) WithDomain(domain string) *Builder {
+	b.domain = domain
+	return b
+}
+
+// WithSources configures the external-dns sources (e.g. "ingress", "service",
+// "gateway-httproute") records are created from. Gateway API sources require
+// the Gateway API CRDs to be installed before the addon is deployed.
+func (b *Builder) WithSources(sources ...string) *Builder {
+	b.sources = sources
+	return b
+}
+
+// Build generates a new external-dns cluster.Addon which can be loaded and
+// deployed into a test Environment's cluster.Cluster.
+func (b *Builder) Build() *Addon {
+	return &Addon{
+		version: b.version,
+		domain:  b.domain,
+		sources: b.sources,
+	}
+}
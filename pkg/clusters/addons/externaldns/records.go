@@ -0,0 +1,103 @@
+package externaldns
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+)
+
+// -----------------------------------------------------------------------------
+// ExternalDNS Addon - Records
+// -----------------------------------------------------------------------------
+
+// Records provides the targets (IP addresses or hostnames) of the A, AAAA and
+// CNAME records external-dns has created for the given hostname, read
+// directly from the stub provider's etcd. No records (and no error) are
+// provided when external-dns hasn't created any records for the hostname yet.
+func (a *Addon) Records(ctx context.Context, cluster clusters.Cluster, hostname string) ([]string, error) {
+	prefix := skydnsKey(hostname) + "/"
+	request, err := json.Marshal(map[string]string{
+		"key":       base64.StdEncoding.EncodeToString([]byte(prefix)),
+		"range_end": base64.StdEncoding.EncodeToString(prefixRangeEnd([]byte(prefix))),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := cluster.Client().CoreV1().RESTClient().Post().
+		Namespace(DefaultNamespace).
+		Resource("services").
+		Name(fmt.Sprintf("http:%s:%d", EtcdServiceName, EtcdPort)).
+		SubResource("proxy").
+		Suffix("v3", "kv", "range").
+		SetHeader("Content-Type", "application/json").
+		Body(request).
+		DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not read records for %s from etcd: %w", hostname, err)
+	}
+	return parseRangeResponse(raw)
+}
+
+// skydnsKey provides the etcd key prefix under which the CoreDNS provider
+// stores the records of a hostname, e.g. "/skydns/test/ktf/foo" for
+// "foo.ktf.test".
+func skydnsKey(hostname string) string {
+	labels := strings.Split(strings.TrimSuffix(strings.ToLower(hostname), "."), ".")
+	for i, j := 0, len(labels)-1; i < j; i, j = i+1, j-1 {
+		labels[i], labels[j] = labels[j], labels[i]
+	}
+	return "/skydns/" + strings.Join(labels, "/")
+}
+
+// prefixRangeEnd provides the etcd range end matching all keys with the
+// provided prefix.
+func prefixRangeEnd(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff { //nolint:gomnd
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	// the prefix is all 0xff, range to the end of the keyspace
+	return []byte{0}
+}
+
+// parseRangeResponse parses the targets of the records found in an etcd v3
+// JSON gateway range response, ignoring the TXT ownership records.
+func parseRangeResponse(raw []byte) ([]string, error) {
+	resp := struct {
+		KVs []struct {
+			Value string `json:"value"`
+		} `json:"kvs"`
+	}{}
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("invalid etcd response: %w", err)
+	}
+
+	var targets []string
+	for _, kv := range resp.KVs {
+		value, err := base64.StdEncoding.DecodeString(kv.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid etcd value: %w", err)
+		}
+		record := struct {
+			Host string `json:"host"`
+		}{}
+		if err := json.Unmarshal(value, &record); err != nil {
+			return nil, fmt.Errorf("invalid skydns record %q: %w", value, err)
+		}
+		if record.Host != "" {
+			targets = append(targets, record.Host)
+		}
+	}
+	sort.Strings(targets)
+	return targets, nil
+}
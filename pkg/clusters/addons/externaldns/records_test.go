@@ -0,0 +1,37 @@
+package externaldns
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkydnsKey(t *testing.T) {
+	assert.Equal(t, "/skydns/test/ktf/foo", skydnsKey("foo.ktf.test"))
+	assert.Equal(t, "/skydns/test/ktf/foo", skydnsKey("Foo.ktf.test."))
+	assert.Equal(t, []byte("/skydns/test/ktf/foo0"), prefixRangeEnd([]byte("/skydns/test/ktf/foo/")))
+}
+
+func TestParseRangeResponse(t *testing.T) {
+	value := func(record string) string {
+		return base64.StdEncoding.EncodeToString([]byte(record))
+	}
+	targets, err := parseRangeResponse([]byte(fmt.Sprintf(`{"kvs": [
+		{"key": "a", "value": %q},
+		{"key": "b", "value": %q},
+		{"key": "c", "value": %q}
+	]}`,
+		value(`{"host": "172.18.0.3", "ttl": 300}`),
+		value(`{"text": "\"heritage=external-dns,external-dns/owner=external-dns\""}`),
+		value(`{"host": "172.18.0.2", "ttl": 300}`),
+	)))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"172.18.0.2", "172.18.0.3"}, targets)
+
+	targets, err = parseRangeResponse([]byte(`{"header": {}}`))
+	require.NoError(t, err)
+	assert.Empty(t, targets)
+}
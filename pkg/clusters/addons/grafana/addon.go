@@ -0,0 +1,256 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kong/kubernetes-testing-framework/internal/utils"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/prometheus"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/versions"
+)
+
+// -----------------------------------------------------------------------------
+// Grafana Addon
+// -----------------------------------------------------------------------------
+
+const (
+	// AddonName indicates the unique name of this addon.
+	AddonName clusters.AddonName = "grafana"
+
+	// DefaultNamespace indicates the default namespace this addon will be deployed to.
+	DefaultNamespace = "grafana"
+
+	// HelmRepoURL is the URL of the Grafana Helm repository.
+	HelmRepoURL = "https://grafana.github.io/helm-charts"
+
+	// ReleaseName is the name of the Helm release.
+	ReleaseName = "ktf-grafana"
+
+	// DefaultAdminPassword is the password of the Grafana "admin" user unless
+	// configured otherwise.
+	DefaultAdminPassword = "ktf-admin"
+
+	// DashboardLabel is the label which ConfigMaps containing dashboards
+	// need to have in order to be provisioned.
+	DashboardLabel = "grafana_dashboard"
+
+	// containerPort is the port the Grafana container listens on.
+	containerPort = 3000
+)
+
+// Addon is a Grafana addon which can be deployed on a clusters.Cluster.
+type Addon struct {
+	chartVersion      versions.Selector
+	adminPassword     string
+	prometheusEnabled bool
+	dashboards        map[string][]byte
+}
+
+// New produces a new clusters.Addon for Grafana with the default settings.
+func New() *Addon {
+	return NewBuilder().Build()
+}
+
+// Namespace indicates the namespace where the Grafana addon components are to
+// be deployed and managed.
+func (a *Addon) Namespace() string {
+	return DefaultNamespace
+}
+
+// AdminPassword provides the password of the Grafana "admin" user.
+func (a *Addon) AdminPassword() string {
+	return a.adminPassword
+}
+
+// -----------------------------------------------------------------------------
+// Grafana Addon - Public Methods
+// -----------------------------------------------------------------------------
+
+// AddDashboard provisions a dashboard (the dashboard JSON model) with the given
+// unique name on a deployed addon.
+func (a *Addon) AddDashboard(ctx context.Context, cluster clusters.Cluster, name string, dashboardJSON []byte) error {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "dashboard-" + dashboardName(name),
+			Labels: map[string]string{DashboardLabel: "1"},
+		},
+		Data: map[string]string{name + ".json": string(dashboardJSON)},
+	}
+
+	configMaps := cluster.Client().CoreV1().ConfigMaps(DefaultNamespace)
+	if _, err := configMaps.Create(ctx, configMap, metav1.CreateOptions{}); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("could not provision dashboard %s: %w", name, err)
+		}
+		if _, err := configMaps.Update(ctx, configMap, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("could not provision dashboard %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// PortForward forwards the given local port (a random one if 0) to the Grafana
+// UI until the provided context is done, and provides the URL of the UI.
+func (a *Addon) PortForward(ctx context.Context, cluster clusters.Cluster, localPort int) (string, error) {
+	pods, err := cluster.Client().CoreV1().Pods(DefaultNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: "app.kubernetes.io/name=grafana,app.kubernetes.io/instance=" + ReleaseName,
+		FieldSelector: "status.phase=Running",
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(pods.Items) == 0 {
+		return "", fmt.Errorf("no running grafana pods found")
+	}
+
+	// the forward is stopped once the context is done
+	address, _, err := clusters.PortForwardFromLocalPort(ctx, cluster, DefaultNamespace, "pod/"+pods.Items[0].Name, localPort, containerPort)
+	if err != nil {
+		return "", err
+	}
+	return "http://" + address, nil
+}
+
+// -----------------------------------------------------------------------------
+// Grafana Addon - Addon Implementation
+// -----------------------------------------------------------------------------
+
+func (a *Addon) Name() clusters.AddonName {
+	return AddonName
+}
+
+func (a *Addon) Dependencies(_ context.Context, _ clusters.Cluster) []clusters.AddonName {
+	if a.prometheusEnabled {
+		return []clusters.AddonName{prometheus.AddonName}
+	}
+	return nil
+}
+
+func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
+	// wait for dependency addons to be ready first
+	if err := clusters.WaitForAddonDependencies(ctx, cluster, a); err != nil {
+		return fmt.Errorf("failure waiting for addon dependencies: %w", err)
+	}
+
+	valuesFile, err := a.valuesFile()
+	if err != nil {
+		return err
+	}
+	defer os.Remove(valuesFile)
+
+	release := utils.HelmRelease{
+		RepoName:     "grafana",
+		RepoURL:      HelmRepoURL,
+		Chart:        "grafana/grafana",
+		Name:         ReleaseName,
+		Namespace:    DefaultNamespace,
+		ChartVersion: a.chartVersion,
+		Args:         []string{"--values", valuesFile},
+	}
+	if err := utils.HelmInstall(ctx, cluster, release); err != nil {
+		return err
+	}
+
+	for name, dashboardJSON := range a.dashboards {
+		if err := a.AddDashboard(ctx, cluster, name, dashboardJSON); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
+	if err := utils.HelmUninstall(ctx, cluster, ReleaseName, DefaultNamespace); err != nil {
+		return err
+	}
+
+	// the dashboards are removed along with the namespace
+	return clusters.DeleteNamespace(ctx, cluster, DefaultNamespace)
+}
+
+// Upgrade upgrades the chart of a deployed addon to the provided version,
+// retaining the rest of its configuration.
+func (a *Addon) Upgrade(ctx context.Context, cluster clusters.Cluster, version semver.Version) error {
+	a.chartVersion = versions.Exact(version)
+	return a.Deploy(ctx, cluster)
+}
+
+func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) ([]runtime.Object, bool, error) {
+	return utils.IsNamespaceAvailable(ctx, cluster, DefaultNamespace)
+}
+
+func (a *Addon) Conditions(ctx context.Context, cluster clusters.Cluster) (bool, []clusters.Condition, error) {
+	return clusters.AddonConditions(ctx, cluster, a, DefaultNamespace, "")
+}
+
+func (a *Addon) DumpDiagnostics(context.Context, clusters.Cluster) (map[string][]byte, error) {
+	diagnostics := make(map[string][]byte)
+	return diagnostics, nil
+}
+
+// -----------------------------------------------------------------------------
+// Grafana Addon - Private
+// -----------------------------------------------------------------------------
+
+// valuesFile writes the chart values to a temporary file, the caller is
+// responsible for removing it.
+func (a *Addon) valuesFile() (string, error) {
+	values := map[string]interface{}{
+		"adminPassword": a.adminPassword,
+		"sidecar": map[string]interface{}{
+			"dashboards": map[string]interface{}{
+				"enabled":         true,
+				"label":           DashboardLabel,
+				"searchNamespace": DefaultNamespace,
+			},
+		},
+	}
+	if a.prometheusEnabled {
+		values["datasources"] = map[string]interface{}{
+			"datasources.yaml": map[string]interface{}{
+				"apiVersion": 1,
+				"datasources": []map[string]interface{}{{
+					"name":      "Prometheus",
+					"type":      "prometheus",
+					"access":    "proxy",
+					"isDefault": true,
+					"url": fmt.Sprintf("http://%s.%s.svc:%d",
+						prometheus.ServiceName, prometheus.DefaultNamespace, prometheus.ServicePort),
+				}},
+			},
+		}
+	}
+
+	raw, err := yaml.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+
+	f, err := os.CreateTemp(os.TempDir(), "ktf-grafana-values-*.yaml")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := f.Write(raw); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// dashboardName normalizes a dashboard name for use in resource names.
+func dashboardName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", "-"))
+}
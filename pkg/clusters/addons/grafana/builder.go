@@ -0,0 +1,72 @@
+package grafana
+
+import (
+	"github.com/blang/semver/v4"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/versions"
+)
+
+// -----------------------------------------------------------------------------
+// Grafana Addon - Builder
+// -----------------------------------------------------------------------------
+
+// Builder is a configuration tool to generate Grafana cluster addons.
+type Builder struct {
+	chartVersion      versions.Selector
+	adminPassword     string
+	prometheusEnabled bool
+	dashboards        map[string][]byte
+}
+
+// NewBuilder provides a new Builder object for configuring Grafana cluster addons.
+func NewBuilder() *Builder {
+	return &Builder{
+		adminPassword: DefaultAdminPassword,
+		dashboards:    make(map[string][]byte),
+	}
+}
+
+// WithVersion pins the version of the Grafana chart which should be deployed,
+// otherwise the latest release is used.
+func (b *Builder) WithVersion(version semver.Version) *Builder {
+	b.chartVersion = versions.Exact(version)
+	return b
+}
+
+// WithMinorVersion selects the latest patch release of the given minor version
+// of the Grafana chart which should be deployed.
+func (b *Builder) WithMinorVersion(major, minor uint64) *Builder {
+	b.chartVersion = versions.LatestPatch(major, minor)
+	return b
+}
+
+// WithAdminPassword configures the password of the Grafana "admin" user.
+func (b *Builder) WithAdminPassword(password string) *Builder {
+	b.adminPassword = password
+	return b
+}
+
+// WithPrometheus configures the Prometheus addon as the default data source of
+// Grafana. The Prometheus addon then becomes a dependency of this addon.
+func (b *Builder) WithPrometheus() *Builder {
+	b.prometheusEnabled = true
+	return b
+}
+
+// WithDashboard provisions a dashboard (the dashboard JSON model, e.g. loaded
+// from a test fixture) with the given unique name when the addon is deployed.
+func (b *Builder) WithDashboard(name string, dashboardJSON []byte) *Builder {
+	b.dashboards[name] = dashboardJSON
+	return b
+}
+
+// Build generates a new Grafana cluster.Addon which can be loaded and deployed
+// into a test Environment's cluster.Cluster.
+func (b *Builder) Build() *Addon {
+	return &Addon{
+		chartVersion:      b.chartVersion,
+		adminPassword:     b.adminPassword,
+		prometheusEnabled: b.prometheusEnabled,
+		dashboards:        b.dashboards,
+	}
+}
@@ -0,0 +1,143 @@
+package grpcbin
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/kong/kubernetes-testing-framework/internal/utils"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/kubernetes/generators"
+)
+
+// -----------------------------------------------------------------------------
+// grpcbin Addon
+// -----------------------------------------------------------------------------
+
+const (
+	// AddonName indicates the unique name of this addon.
+	AddonName clusters.AddonName = "grpcbin"
+
+	// DefaultNamespace indicates the default namespace this addon will be deployed to.
+	DefaultNamespace = "grpcbin"
+
+	// Image is the container image of the grpcbin server.
+	Image = "moul/grpcbin:latest"
+
+	// MultiArchImage is the container image used instead of Image on clusters
+	// whose nodes aren't amd64, as Image is only published for amd64.
+	MultiArchImage = "kong/grpcbin:latest"
+
+	// ServiceName is the name of the grpcbin Service.
+	ServiceName = "grpcbin"
+
+	// PlaintextPort is the port of the grpcbin Service serving gRPC without TLS.
+	PlaintextPort = 9000
+
+	// TLSPort is the port of the grpcbin Service serving gRPC with TLS, using
+	// a self-signed certificate.
+	TLSPort = 9001
+)
+
+// Addon is a grpcbin addon, which provides a gRPC server implementing several
+// test services (e.g. "hello.HelloService") and server reflection, on both a
+// plaintext and a TLS port.
+type Addon struct {
+	namespace string
+}
+
+// New produces a new clusters.Addon for grpcbin with the default settings.
+func New() *Addon {
+	return NewBuilder().Build()
+}
+
+// Namespace indicates the namespace where the grpcbin addon components are to
+// be deployed and managed.
+func (a *Addon) Namespace() string {
+	return a.namespace
+}
+
+// Service retrieves the grpcbin Service from the cluster.
+func (a *Addon) Service(ctx context.Context, cluster clusters.Cluster) (*corev1.Service, error) {
+	return cluster.Client().CoreV1().Services(a.namespace).Get(ctx, ServiceName, metav1.GetOptions{})
+}
+
+// -----------------------------------------------------------------------------
+// grpcbin Addon - Addon Implementation
+// -----------------------------------------------------------------------------
+
+func (a *Addon) Name() clusters.AddonName {
+	return AddonName
+}
+
+func (a *Addon) Dependencies(_ context.Context, _ clusters.Cluster) []clusters.AddonName {
+	return nil
+}
+
+func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
+	// wait for dependency addons to be ready first
+	if err := clusters.WaitForAddonDependencies(ctx, cluster, a); err != nil {
+		return fmt.Errorf("failure waiting for addon dependencies: %w", err)
+	}
+
+	if err := clusters.CreateNamespace(ctx, cluster, a.namespace); err != nil {
+		return err
+	}
+
+	container := generators.NewContainer(ServiceName, image(ctx, cluster), PlaintextPort)
+	container.Ports[0].Name = "grpc"
+	container.Ports = append(container.Ports, corev1.ContainerPort{Name: "grpcs", ContainerPort: TLSPort})
+	container.ReadinessProbe = &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{
+				Port: intstr.FromInt(PlaintextPort),
+			},
+		},
+	}
+	deployment := generators.NewDeploymentForContainer(container)
+	if _, err := cluster.Client().AppsV1().Deployments(a.namespace).Create(ctx, deployment, metav1.CreateOptions{}); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	service := generators.NewServiceForDeployment(deployment, corev1.ServiceTypeClusterIP)
+	if _, err := cluster.Client().CoreV1().Services(a.namespace).Create(ctx, service, metav1.CreateOptions{}); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
+	return clusters.DeleteNamespace(ctx, cluster, a.namespace)
+}
+
+func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) ([]runtime.Object, bool, error) {
+	return utils.IsNamespaceAvailable(ctx, cluster, a.namespace)
+}
+
+func (a *Addon) DumpDiagnostics(context.Context, clusters.Cluster) (map[string][]byte, error) {
+	diagnostics := make(map[string][]byte)
+	return diagnostics, nil
+}
+
+// -----------------------------------------------------------------------------
+// grpcbin Addon - Private Functions
+// -----------------------------------------------------------------------------
+
+// image provides the grpcbin image matching the architecture of the cluster's
+// nodes, the multi-arch image is used if it can't be determined.
+func image(ctx context.Context, cluster clusters.Cluster) string {
+	if arch, err := clusters.Architecture(ctx, cluster); err == nil && arch == "amd64" {
+		return Image
+	}
+	return MultiArchImage
+}
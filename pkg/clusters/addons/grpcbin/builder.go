@@ -0,0 +1,32 @@
+package grpcbin
+
+// -----------------------------------------------------------------------------
+// grpcbin Addon - Builder
+// -----------------------------------------------------------------------------
+
+// Builder is a configuration tool to generate grpcbin cluster addons.
+type Builder struct {
+	namespace string
+}
+
+// NewBuilder provides a new Builder object for configuring grpcbin cluster addons.
+func NewBuilder() *Builder {
+	return &Builder{
+		namespace: DefaultNamespace,
+	}
+}
+
+// WithNamespace allows the namespace where the addon should be deployed to be
+// overridden from the default.
+func (b *Builder) WithNamespace(namespace string) *Builder {
+	b.namespace = namespace
+	return b
+}
+
+// Build generates a new grpcbin cluster.Addon which can be loaded and deployed
+// into a test Environment's cluster.Cluster.
+func (b *Builder) Build() *Addon {
+	return &Addon{
+		namespace: b.namespace,
+	}
+}
@@ -0,0 +1,82 @@
+package grpcbin
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+)
+
+// -----------------------------------------------------------------------------
+// grpcbin Addon - Client Helpers
+// -----------------------------------------------------------------------------
+
+// Dial builds a grpc.ClientConn to the given proxy URL, e.g. the URL of the
+// Kong proxy. An "https" URL is dialed with TLS (without verifying the proxy
+// certificate, but sending the host as SNI) and an "http" URL is dialed in
+// plaintext (h2c). The default port for the scheme is used if the URL has none.
+// Additional options can be provided, e.g. grpc.WithAuthority to match a route
+// by host.
+func Dial(ctx context.Context, proxyURL *url.URL, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	port := proxyURL.Port()
+	var creds credentials.TransportCredentials
+	switch proxyURL.Scheme {
+	case "https":
+		if port == "" {
+			port = "443"
+		}
+		creds = credentials.NewTLS(&tls.Config{
+			ServerName:         proxyURL.Hostname(),
+			InsecureSkipVerify: true, //nolint:gosec
+		})
+	case "http":
+		if port == "" {
+			port = "80"
+		}
+		creds = insecure.NewCredentials()
+	default:
+		return nil, fmt.Errorf("unsupported scheme %q for gRPC proxy URL %s", proxyURL.Scheme, proxyURL)
+	}
+
+	target := net.JoinHostPort(proxyURL.Hostname(), port)
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(creds)}, opts...)
+	return grpc.DialContext(ctx, target, opts...)
+}
+
+// ListServices lists the services served through the connection using gRPC
+// server reflection (which grpcbin supports), which is a convenient way of
+// asserting that gRPC requests are routed to grpcbin without generated stubs.
+func ListServices(ctx context.Context, conn *grpc.ClientConn) ([]string, error) {
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.CloseSend() //nolint:errcheck
+
+	if err := stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}); err != nil {
+		return nil, err
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		return nil, fmt.Errorf("gRPC server reflection failed: %s", errResp.GetErrorMessage())
+	}
+
+	services := make([]string, 0, len(resp.GetListServicesResponse().GetService()))
+	for _, service := range resp.GetListServicesResponse().GetService() {
+		services = append(services, service.GetName())
+	}
+	sort.Strings(services)
+	return services, nil
+}
@@ -0,0 +1,37 @@
+package grpcbin
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+func TestDialAndListServices(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	reflection.Register(server)
+	go server.Serve(listener) //nolint:errcheck
+	defer server.Stop()
+
+	ctx := context.Background()
+	conn, err := Dial(ctx, &url.URL{Scheme: "http", Host: listener.Addr().String()})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	services, err := ListServices(ctx, conn)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"grpc.health.v1.Health", "grpc.reflection.v1.ServerReflection", "grpc.reflection.v1alpha.ServerReflection"}, services)
+
+	_, err = Dial(ctx, &url.URL{Scheme: "ftp", Host: listener.Addr().String()})
+	assert.Error(t, err)
+}
@@ -29,6 +29,10 @@ const (
 	// Image is the container image that will be used by default.
 	Image = "kennethreitz/httpbin"
 
+	// MultiArchImage is the container image used instead of Image on clusters
+	// whose nodes aren't amd64, as Image is only published for amd64.
+	MultiArchImage = "kong/httpbin:0.1.0"
+
 	// DefaultPort is the port that will be used for the HttpBin endpoint
 	// on pods and services unless otherwise specified.
 	DefaultPort = 80
@@ -40,7 +44,9 @@ type Addon struct {
 	namespace          string
 	generateNamespace  bool
 	ingressAnnotations map[string]string
+	replicas           int32
 	path               string
+	serviceName        string
 }
 
 // New produces a new clusters.Addon for Kong but uses a very opionated set of
@@ -65,6 +71,31 @@ func (a *Addon) Path() string {
 	return a.path
 }
 
+// ServiceName provides the name of the Service HttpBin is exposed with in the
+// addon's namespace, once the addon is deployed.
+func (a *Addon) ServiceName() string {
+	return a.serviceName
+}
+
+// ServicePort provides the port of the HttpBin Service.
+func (a *Addon) ServicePort() int32 {
+	return DefaultPort
+}
+
+// ServiceURL provides the in-cluster URL of the HttpBin Service, which can be
+// used as an upstream to route to.
+func (a *Addon) ServiceURL() string {
+	return fmt.Sprintf("http://%s.%s.svc:%d", a.serviceName, a.namespace, DefaultPort)
+}
+
+// Service retrieves the HttpBin Service from the cluster.
+func (a *Addon) Service(ctx context.Context, cluster clusters.Cluster) (*corev1.Service, error) {
+	if a.serviceName == "" {
+		return nil, fmt.Errorf("httpbin addon has not been deployed")
+	}
+	return cluster.Client().CoreV1().Services(a.namespace).Get(ctx, a.serviceName, metav1.GetOptions{})
+}
+
 // -----------------------------------------------------------------------------
 // HttpBin Addon - Addon Implementation
 // -----------------------------------------------------------------------------
@@ -93,14 +124,14 @@ func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
 	}
 
 	// determine the kubernetes cluster version so we know if we need to use a legacy ingress API
-	kubernetesVersion, err := cluster.Version()
+	kubernetesVersion, err := cluster.Version(ctx)
 	if err != nil {
 		return err
 	}
 
 	// generate a container, deployment, service and ingress resource for the HttpBin addon
 	a.path = fmt.Sprintf("/%s", a.name)
-	container := generators.NewContainer(a.name, Image, DefaultPort)
+	container := generators.NewContainer(a.name, image(ctx, cluster), DefaultPort)
 	deployment, service, ingress := generators.NewIngressForContainerWithDeploymentAndService(
 		kubernetesVersion,
 		container,
@@ -108,6 +139,9 @@ func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
 		a.ingressAnnotations,
 		a.path,
 	)
+	replicas := a.replicas
+	deployment.Spec.Replicas = &replicas
+	a.serviceName = service.Name
 
 	// deploy the httpbin deployment
 	_, err = cluster.Client().AppsV1().Deployments(a.namespace).Create(ctx, deployment, metav1.CreateOptions{})
@@ -136,19 +170,7 @@ func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
 }
 
 func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
-	for {
-		select {
-		case <-ctx.Done():
-			return fmt.Errorf("context completed before addon could be deleted: %w", ctx.Err())
-		default:
-			if err := cluster.Client().CoreV1().Namespaces().Delete(ctx, a.namespace, metav1.DeleteOptions{}); err != nil {
-				if errors.IsNotFound(err) {
-					return nil
-				}
-				return err
-			}
-		}
-	}
+	return clusters.DeleteNamespace(ctx, cluster, a.namespace)
 }
 
 func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) (waitForObjects []runtime.Object, ready bool, err error) {
@@ -159,3 +181,16 @@ func (a *Addon) DumpDiagnostics(context.Context, clusters.Cluster) (map[string][
 	diagnostics := make(map[string][]byte)
 	return diagnostics, nil
 }
+
+// -----------------------------------------------------------------------------
+// HttpBin Addon - Private Functions
+// -----------------------------------------------------------------------------
+
+// image provides the HttpBin image matching the architecture of the cluster's
+// nodes, the multi-arch image is used if it can't be determined.
+func image(ctx context.Context, cluster clusters.Cluster) string {
+	if arch, err := clusters.Architecture(ctx, cluster); err == nil && arch == "amd64" {
+		return Image
+	}
+	return MultiArchImage
+}
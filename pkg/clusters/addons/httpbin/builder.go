@@ -10,6 +10,7 @@ type Builder struct {
 	namespace          string
 	generateNamespace  bool
 	ingressAnnotations map[string]string
+	replicas           int32
 }
 
 // NewBuilder provides a new Builder object for configuring HttpBin cluster addons.
@@ -18,6 +19,7 @@ func NewBuilder() *Builder {
 		name:               string(AddonName),
 		namespace:          DefaultNamespace,
 		ingressAnnotations: make(map[string]string),
+		replicas:           1,
 	}
 }
 
@@ -42,6 +44,12 @@ func (b *Builder) WithGeneratedNamespace() *Builder {
 	return b
 }
 
+// WithReplicas configures the number of HttpBin replicas to deploy.
+func (b *Builder) WithReplicas(replicas int32) *Builder {
+	b.replicas = replicas
+	return b
+}
+
 // WithIngressAnnotations allows injecting the annotations that will be placed
 // on the HttpBin Ingress resource for things like deciding the ingress.class.
 // This will override values for new keys provided, but will combine with any
@@ -73,5 +81,6 @@ func (b *Builder) Build() *Addon {
 		namespace:          b.namespace,
 		generateNamespace:  b.generateNamespace,
 		ingressAnnotations: b.ingressAnnotations,
+		replicas:           b.replicas,
 	}
 }
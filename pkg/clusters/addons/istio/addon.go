@@ -1,11 +1,10 @@
 package istio
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
+	"path"
 	"time"
 
 	"github.com/blang/semver/v4"
@@ -14,11 +13,12 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/watch"
 
+	"github.com/kong/kubernetes-testing-framework/internal/retry"
 	"github.com/kong/kubernetes-testing-framework/internal/utils"
 	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
 	"github.com/kong/kubernetes-testing-framework/pkg/utils/github"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/images"
 	"github.com/kong/kubernetes-testing-framework/pkg/utils/kubernetes/generators"
 )
 
@@ -47,6 +47,9 @@ type Addon struct {
 	grafanaEnabled    bool
 	jaegerEnabled     bool
 	kialiEnabled      bool
+
+	profile                   string
+	sidecarInjectedNamespaces []string
 }
 
 // New produces a new clusters.Addon for Kong but uses a very opionated set of
@@ -67,6 +70,12 @@ func (a *Addon) Version() semver.Version {
 	return a.istioVersion
 }
 
+// Profile indicates the Istio configuration profile for this addon, an empty
+// string indicates the default profile.
+func (a *Addon) Profile() string {
+	return a.profile
+}
+
 // -----------------------------------------------------------------------------
 // Istio Addon - Public Methods
 // -----------------------------------------------------------------------------
@@ -74,29 +83,24 @@ func (a *Addon) Version() semver.Version {
 // EnableMeshForNamespace will add the "istio-injection=enabled" label to the provided namespace
 // by name which will indicate to Istio to inject sidecard pods to add it to the mesh network.
 func (a *Addon) EnableMeshForNamespace(ctx context.Context, cluster clusters.Cluster, name string) error {
-	for {
-		select {
-		case <-ctx.Done():
-			return fmt.Errorf("context completed while trying to enable mesh for namespace %s: %w", name, ctx.Err())
-		default:
-			namespace, err := cluster.Client().CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
-			if err != nil {
-				return fmt.Errorf("could not enable mesh for namespace %s: %w", name, err)
-			}
-			namespace.ObjectMeta.Labels["istio-injection"] = "enabled"
-			_, err = cluster.Client().CoreV1().Namespaces().Update(ctx, namespace, metav1.UpdateOptions{})
-			if err != nil {
-				if errors.IsConflict(err) {
-					// if there's a conflict then an update happened since we pulled the namespace,
-					// simply pull and try again.
-					time.Sleep(time.Second)
-					continue
-				}
-				return fmt.Errorf("could not enable mesh for namespace %s: %w", name, err)
-			}
-			return nil
+	// conflicts mean an update happened since we pulled the namespace, so the
+	// whole get and update is retried.
+	err := retry.OnTransient(ctx, clusters.Logger(cluster), "enabling mesh for namespace "+name, func(ctx context.Context) error {
+		namespace, err := cluster.Client().CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if namespace.ObjectMeta.Labels == nil {
+			namespace.ObjectMeta.Labels = make(map[string]string)
 		}
+		namespace.ObjectMeta.Labels["istio-injection"] = "enabled"
+		_, err = cluster.Client().CoreV1().Namespaces().Update(ctx, namespace, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("could not enable mesh for namespace %s: %w", name, err)
 	}
+	return nil
 }
 
 // -----------------------------------------------------------------------------
@@ -119,12 +123,21 @@ func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
 		}
 	}
 
+	installCommand := "istioctl install -y"
+	if a.profile != "" {
+		installCommand = fmt.Sprintf("%s --set profile=%s", installCommand, a.profile)
+	}
+	// istioctl pulls all the images of Istio from the same hub
+	if hub := path.Dir(images.Mirror(istioHub + "/pilot")); hub != istioHub {
+		installCommand = fmt.Sprintf("%s --set hub=%s", installCommand, hub)
+	}
+
 	// generate a configMap deploy script and a job to run it to deploy Istio
 	a.istioDeployScript, a.istioDeployJob = generators.GenerateBashJob(
 		istioCTLImage,
 		a.istioVersion.String(),
 		"istioctl x precheck",
-		"istioctl install -y",
+		installCommand,
 	)
 
 	// create the configmap script in the admin namespace
@@ -142,26 +155,34 @@ func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
 	}
 
 	// wait for the job to complete
-	var deployJobCompleted bool
-	for !deployJobCompleted {
-		select {
-		case <-ctx.Done():
-			return fmt.Errorf("context completed while waiting for istio deployment job to finish: %w", ctx.Err())
-		default:
-			a.istioDeployJob, err = cluster.Client().BatchV1().Jobs(utils.AdminNamespace).Get(ctx, a.istioDeployJob.Name, metav1.GetOptions{})
-			if err != nil {
-				return err
-			}
-			if a.istioDeployJob.Status.Succeeded > 0 {
-				deployJobCompleted = true
-			} else {
-				time.Sleep(time.Second)
-			}
+	err = retry.Poll(ctx, clusters.Logger(cluster), time.Second, "waiting for istio deployment job to finish", func(ctx context.Context) (bool, error) {
+		job, err := cluster.Client().BatchV1().Jobs(utils.AdminNamespace).Get(ctx, a.istioDeployJob.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
 		}
+		a.istioDeployJob = job
+		return job.Status.Succeeded > 0, nil
+	})
+	if err != nil {
+		return err
 	}
 
 	// deploy any additional addons or extra components if the caller configured for them
-	return a.deployExtras(ctx, cluster)
+	if err := a.deployExtras(ctx, cluster); err != nil {
+		return err
+	}
+
+	// enable sidecar injection for any namespaces the caller configured
+	for _, namespace := range a.sidecarInjectedNamespaces {
+		if err := clusters.CreateNamespace(ctx, cluster, namespace); err != nil {
+			return err
+		}
+		if err := a.EnableMeshForNamespace(ctx, cluster, namespace); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
@@ -179,28 +200,7 @@ func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
 
 	// istio deploys everything it needs to the istio-system namespace, so deletion
 	// is simplified by simply deleting that namespace.
-	watcher, err := cluster.Client().CoreV1().Namespaces().Watch(ctx, metav1.ListOptions{})
-	if err != nil {
-		return err
-	}
-	if err := cluster.Client().CoreV1().Namespaces().Delete(ctx, Namespace, metav1.DeleteOptions{}); err != nil {
-		return err
-	}
-
-	// wait for the deletion to complete, within the extent of the provided context
-	var istioDeletionComplete bool
-	for !istioDeletionComplete {
-		select {
-		case <-ctx.Done():
-			return fmt.Errorf("context completed before istio deployment could complete: %w", ctx.Err())
-		case event := <-watcher.ResultChan():
-			if event.Type == watch.Deleted {
-				istioDeletionComplete = true
-			}
-		}
-	}
-
-	return nil
+	return clusters.DeleteNamespace(ctx, cluster, Namespace)
 }
 
 func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) (waitForObjects []runtime.Object, ready bool, err error) {
@@ -222,6 +222,9 @@ const (
 	//
 	// See: https://hub.docker.com/r/istio/istioctl
 	istioCTLImage = "istio/istioctl"
+
+	// istioHub is the registry path of the images deployed by istioctl.
+	istioHub = "docker.io/istio"
 )
 
 // -----------------------------------------------------------------------------
@@ -263,7 +266,7 @@ func (a *Addon) deployExtras(ctx context.Context, cluster clusters.Cluster) erro
 		manifestsURL := fmt.Sprintf(istioAddonTemplate, a.istioVersion.Major, a.istioVersion.Minor, "kiali")
 
 		// deploy the Kiali manifests (these will deploy to the istio-system namespace)
-		if err := retryKubectlApply(ctx, "--kubeconfig", kubeconfig.Name(), "apply", "-f", manifestsURL); err != nil {
+		if err := applyManifest(ctx, kubeconfig.Name(), manifestsURL); err != nil {
 			return err
 		}
 	}
@@ -273,7 +276,7 @@ func (a *Addon) deployExtras(ctx context.Context, cluster clusters.Cluster) erro
 		manifestsURL := fmt.Sprintf(istioAddonTemplate, a.istioVersion.Major, a.istioVersion.Minor, "jaeger")
 
 		// deploy the Jaeger manifests (these will deploy to the istio-system namespace)
-		if err := retryKubectlApply(ctx, "--kubeconfig", kubeconfig.Name(), "apply", "-f", manifestsURL); err != nil {
+		if err := applyManifest(ctx, kubeconfig.Name(), manifestsURL); err != nil {
 			return err
 		}
 	}
@@ -283,7 +286,7 @@ func (a *Addon) deployExtras(ctx context.Context, cluster clusters.Cluster) erro
 		manifestsURL := fmt.Sprintf(istioAddonTemplate, a.istioVersion.Major, a.istioVersion.Minor, "prometheus")
 
 		// deploy the Prometheus manifests (these will deploy to the istio-system namespace)
-		if err := retryKubectlApply(ctx, "--kubeconfig", kubeconfig.Name(), "apply", "-f", manifestsURL); err != nil {
+		if err := applyManifest(ctx, kubeconfig.Name(), manifestsURL); err != nil {
 			return err
 		}
 	}
@@ -293,7 +296,7 @@ func (a *Addon) deployExtras(ctx context.Context, cluster clusters.Cluster) erro
 		manifestsURL := fmt.Sprintf(istioAddonTemplate, a.istioVersion.Major, a.istioVersion.Minor, "grafana")
 
 		// deploy the Grafana manifests (these will deploy to the istio-system namespace)
-		if err := retryKubectlApply(ctx, "--kubeconfig", kubeconfig.Name(), "apply", "-f", manifestsURL); err != nil {
+		if err := applyManifest(ctx, kubeconfig.Name(), manifestsURL); err != nil {
 			return err
 		}
 	}
@@ -301,31 +304,20 @@ func (a *Addon) deployExtras(ctx context.Context, cluster clusters.Cluster) erro
 	return nil
 }
 
-const (
-	defaultRetries          = 3
-	defaultRetryWaitSeconds = 3
-)
-
-// retryKubectlApply retries a command multiple times with a limit, and is particularly
-// useful for kubectl commands with older Istio releases where manifests include
-// CRDs that have small timing issues that can crop up.
-func retryKubectlApply(ctx context.Context, args ...string) (err error) {
-	count := defaultRetries
-	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
-	for count > 0 {
-		cmd := exec.CommandContext(ctx, "kubectl", args...)
-		cmd.Stdout = stdout
-		cmd.Stderr = stderr
-		if err = cmd.Run(); err == nil {
-			break
-		}
-		time.Sleep(time.Second * defaultRetryWaitSeconds)
-		count--
-	}
-
+// applyManifest applies the manifest at the given URL, with its images pulled
+// from the configured registry mirrors.
+func applyManifest(ctx context.Context, kubeconfig, url string) error {
+	manifest, cleanup, err := utils.MirroredManifest(ctx, url)
 	if err != nil {
-		err = fmt.Errorf("kubectl failed: ARGS=(kubectl %v) STDOUT=(%s) STDERR=(%s): %w", args, stdout.String(), stderr.String(), err)
+		return err
 	}
+	defer cleanup()
+	return retryKubectlApply(ctx, "--kubeconfig", kubeconfig, "apply", "-f", manifest)
+}
 
-	return
+// retryKubectlApply retries a kubectl command until it succeeds, and is particularly
+// useful for kubectl commands with older Istio releases where manifests include
+// CRDs that have small timing issues that can crop up.
+func retryKubectlApply(ctx context.Context, args ...string) error {
+	return retry.Command("kubectl", args...).Do(ctx)
 }
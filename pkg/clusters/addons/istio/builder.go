@@ -16,6 +16,9 @@ type Builder struct {
 	grafanaEnabled    bool
 	jaegerEnabled     bool
 	kialiEnabled      bool
+
+	profile                   string
+	sidecarInjectedNamespaces []string
 }
 
 // NewBuilder provides a new Builder object for configuring Istio cluster addons.
@@ -31,6 +34,22 @@ func (b *Builder) WithVersion(version semver.Version) *Builder {
 	return b
 }
 
+// WithProfile configures the Istio configuration profile (e.g. "default",
+// "demo" or "minimal") which should be installed.
+//
+// See: https://istio.io/latest/docs/setup/additional-setup/config-profiles/
+func (b *Builder) WithProfile(profile string) *Builder {
+	b.profile = profile
+	return b
+}
+
+// WithSidecarInjection enables automatic sidecar injection for the provided
+// namespaces once Istio is deployed, creating the namespaces if needed.
+func (b *Builder) WithSidecarInjection(namespaces ...string) *Builder {
+	b.sidecarInjectedNamespaces = append(b.sidecarInjectedNamespaces, namespaces...)
+	return b
+}
+
 // WithPrometheus triggers a deployment of Prometheus configured specifically for Istio.
 //
 // See: https://istio.io/latest/docs/ops/integrations/prometheus/
@@ -74,5 +93,8 @@ func (b *Builder) Build() *Addon {
 		grafanaEnabled:    b.grafanaEnabled,
 		jaegerEnabled:     b.jaegerEnabled,
 		kialiEnabled:      b.kialiEnabled,
+
+		profile:                   b.profile,
+		sidecarInjectedNamespaces: b.sidecarInjectedNamespaces,
 	}
 }
@@ -0,0 +1,243 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/blang/semver/v4"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kong/kubernetes-testing-framework/internal/utils"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/versions"
+)
+
+// -----------------------------------------------------------------------------
+// Kafka Addon
+// -----------------------------------------------------------------------------
+
+const (
+	// AddonName indicates the unique name of this addon.
+	AddonName clusters.AddonName = "kafka"
+
+	// DefaultNamespace indicates the default namespace this addon will be deployed to.
+	DefaultNamespace = "kafka"
+
+	// HelmRepoURL is the URL of the Strimzi Helm repository.
+	HelmRepoURL = "https://strimzi.io/charts/"
+
+	// ReleaseName is the name of the Strimzi operator Helm release.
+	ReleaseName = "ktf-strimzi"
+
+	// ClusterName is the name of the Kafka cluster (the Kafka resource).
+	ClusterName = "ktf"
+
+	// BootstrapPort is the port of the plain text listener of the Kafka cluster.
+	BootstrapPort = 9092
+)
+
+// KafkaGVR is the GroupVersionResource of Strimzi Kafka clusters.
+var KafkaGVR = schema.GroupVersionResource{
+	Group:    "kafka.strimzi.io",
+	Version:  "v1beta2",
+	Resource: "kafkas",
+}
+
+// Addon is a Kafka addon, which deploys the Strimzi operator and a Kafka
+// cluster with a single broker and ephemeral storage managed by it.
+type Addon struct {
+	chartVersion versions.Selector
+}
+
+// New produces a new clusters.Addon for Kafka with the default settings.
+func New() *Addon {
+	return NewBuilder().Build()
+}
+
+// Namespace indicates the namespace where the Kafka addon components are to
+// be deployed and managed.
+func (a *Addon) Namespace() string {
+	return DefaultNamespace
+}
+
+// BootstrapServers provides the in-cluster bootstrap server address (host:port)
+// of the plain text listener of the Kafka cluster.
+func (a *Addon) BootstrapServers() string {
+	return fmt.Sprintf("%s-kafka-bootstrap.%s.svc:%d", ClusterName, DefaultNamespace, BootstrapPort)
+}
+
+// -----------------------------------------------------------------------------
+// Kafka Addon - Addon Implementation
+// -----------------------------------------------------------------------------
+
+func (a *Addon) Name() clusters.AddonName {
+	return AddonName
+}
+
+func (a *Addon) Dependencies(_ context.Context, _ clusters.Cluster) []clusters.AddonName {
+	return nil
+}
+
+func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
+	// wait for dependency addons to be ready first
+	if err := clusters.WaitForAddonDependencies(ctx, cluster, a); err != nil {
+		return fmt.Errorf("failure waiting for addon dependencies: %w", err)
+	}
+
+	release := utils.HelmRelease{
+		RepoName:     "strimzi",
+		RepoURL:      HelmRepoURL,
+		Chart:        "strimzi/strimzi-kafka-operator",
+		Name:         ReleaseName,
+		Namespace:    DefaultNamespace,
+		ChartVersion: a.chartVersion,
+	}
+	if err := utils.HelmInstall(ctx, cluster, release); err != nil {
+		return err
+	}
+
+	dynamicClient, err := cluster.DynamicClient()
+	if err != nil {
+		return err
+	}
+
+	// the Kafka CRD may not be served right after the chart is installed
+	for {
+		_, err := dynamicClient.Resource(KafkaGVR).Namespace(DefaultNamespace).Create(ctx, newKafkaCluster(), metav1.CreateOptions{})
+		if err == nil || errors.IsAlreadyExists(err) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("could not create kafka cluster: %w", err)
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
+	dynamicClient, err := cluster.DynamicClient()
+	if err != nil {
+		return err
+	}
+
+	// the Kafka cluster needs to be deleted while the operator still runs,
+	// a missing CRD is reported as not found as well.
+	if err := dynamicClient.Resource(KafkaGVR).Namespace(DefaultNamespace).Delete(ctx, ClusterName, metav1.DeleteOptions{}); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	if err := utils.HelmUninstall(ctx, cluster, ReleaseName, DefaultNamespace); err != nil {
+		return err
+	}
+	return clusters.DeleteNamespace(ctx, cluster, DefaultNamespace)
+}
+
+// Upgrade upgrades the chart of a deployed addon to the provided version,
+// retaining the rest of its configuration.
+func (a *Addon) Upgrade(ctx context.Context, cluster clusters.Cluster, version semver.Version) error {
+	a.chartVersion = versions.Exact(version)
+	return a.Deploy(ctx, cluster)
+}
+
+func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) ([]runtime.Object, bool, error) {
+	waitingForObjects, ready, err := utils.IsNamespaceAvailable(ctx, cluster, DefaultNamespace)
+	if err != nil || !ready {
+		return waitingForObjects, ready, err
+	}
+
+	dynamicClient, err := cluster.DynamicClient()
+	if err != nil {
+		return nil, false, err
+	}
+	kafka, err := dynamicClient.Resource(KafkaGVR).Namespace(DefaultNamespace).Get(ctx, ClusterName, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	if !kafkaReady(kafka) {
+		return []runtime.Object{kafka}, false, nil
+	}
+
+	return nil, true, nil
+}
+
+func (a *Addon) Conditions(ctx context.Context, cluster clusters.Cluster) (bool, []clusters.Condition, error) {
+	return clusters.AddonConditions(ctx, cluster, a, DefaultNamespace, "")
+}
+
+func (a *Addon) DumpDiagnostics(context.Context, clusters.Cluster) (map[string][]byte, error) {
+	diagnostics := make(map[string][]byte)
+	return diagnostics, nil
+}
+
+// -----------------------------------------------------------------------------
+// Kafka Addon - Private
+// -----------------------------------------------------------------------------
+
+// newKafkaCluster generates a single broker Kafka cluster with ephemeral
+// storage and a plain text listener.
+func newKafkaCluster() *unstructured.Unstructured {
+	ephemeral := map[string]interface{}{"type": "ephemeral"}
+	kafka := &unstructured.Unstructured{}
+	kafka.SetUnstructuredContent(map[string]interface{}{
+		"apiVersion": KafkaGVR.GroupVersion().String(),
+		"kind":       "Kafka",
+		"metadata": map[string]interface{}{
+			"name":      ClusterName,
+			"namespace": DefaultNamespace,
+		},
+		"spec": map[string]interface{}{
+			"kafka": map[string]interface{}{
+				"replicas": int64(1),
+				"listeners": []interface{}{
+					map[string]interface{}{
+						"name": "plain",
+						"port": int64(BootstrapPort),
+						"type": "internal",
+						"tls":  false,
+					},
+				},
+				"config": map[string]interface{}{
+					"offsets.topic.replication.factor":         int64(1),
+					"transaction.state.log.replication.factor": int64(1),
+					"transaction.state.log.min.isr":            int64(1),
+					"default.replication.factor":               int64(1),
+					"min.insync.replicas":                      int64(1),
+				},
+				"storage": ephemeral,
+			},
+			"zookeeper": map[string]interface{}{
+				"replicas": int64(1),
+				"storage":  ephemeral,
+			},
+			"entityOperator": map[string]interface{}{
+				"topicOperator": map[string]interface{}{},
+				"userOperator":  map[string]interface{}{},
+			},
+		},
+	})
+	return kafka
+}
+
+// kafkaReady indicates whether the Ready condition of the provided Kafka
+// cluster is true.
+func kafkaReady(kafka *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(kafka.Object, "status", "conditions")
+	for _, c := range conditions {
+		if condition, ok := c.(map[string]interface{}); ok && condition["type"] == "Ready" {
+			return condition["status"] == string(metav1.ConditionTrue)
+		}
+	}
+	return false
+}
@@ -0,0 +1,27 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestKafkaReady(t *testing.T) {
+	kafka := newKafkaCluster()
+	assert.Equal(t, ClusterName, kafka.GetName())
+	assert.False(t, kafkaReady(kafka))
+
+	require.NoError(t, unstructured.SetNestedSlice(kafka.Object, []interface{}{
+		map[string]interface{}{"type": "NotReady", "status": "True"},
+	}, "status", "conditions"))
+	assert.False(t, kafkaReady(kafka))
+
+	require.NoError(t, unstructured.SetNestedSlice(kafka.Object, []interface{}{
+		map[string]interface{}{"type": "Ready", "status": "True"},
+	}, "status", "conditions"))
+	assert.True(t, kafkaReady(kafka))
+
+	assert.Equal(t, "ktf-kafka-bootstrap.kafka.svc:9092", New().BootstrapServers())
+}
@@ -0,0 +1,43 @@
+package kafka
+
+import (
+	"github.com/blang/semver/v4"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/versions"
+)
+
+// -----------------------------------------------------------------------------
+// Kafka Addon - Builder
+// -----------------------------------------------------------------------------
+
+// Builder is a configuration tool to generate Kafka cluster addons.
+type Builder struct {
+	chartVersion versions.Selector
+}
+
+// NewBuilder provides a new Builder object for configuring Kafka cluster addons.
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// WithVersion pins the version of the Strimzi operator chart which should be
+// deployed, otherwise the latest release is used.
+func (b *Builder) WithVersion(version semver.Version) *Builder {
+	b.chartVersion = versions.Exact(version)
+	return b
+}
+
+// WithMinorVersion selects the latest patch release of the given minor version
+// of the Strimzi operator chart which should be deployed.
+func (b *Builder) WithMinorVersion(major, minor uint64) *Builder {
+	b.chartVersion = versions.LatestPatch(major, minor)
+	return b
+}
+
+// Build generates a new Kafka cluster.Addon which can be loaded and deployed
+// into a test Environment's cluster.Cluster.
+func (b *Builder) Build() *Addon {
+	return &Addon{
+		chartVersion: b.chartVersion,
+	}
+}
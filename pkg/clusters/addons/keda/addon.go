@@ -0,0 +1,105 @@
+package keda
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blang/semver/v4"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kong/kubernetes-testing-framework/internal/utils"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/versions"
+)
+
+// -----------------------------------------------------------------------------
+// KEDA Addon
+// -----------------------------------------------------------------------------
+
+const (
+	// AddonName indicates the unique name of this addon.
+	AddonName clusters.AddonName = "keda"
+
+	// DefaultNamespace indicates the default namespace this addon will be deployed to.
+	DefaultNamespace = "keda"
+
+	// HelmRepoURL is the URL of the KEDA Helm repository.
+	HelmRepoURL = "https://kedacore.github.io/charts"
+
+	// ReleaseName is the name of the Helm release.
+	ReleaseName = "ktf-keda"
+)
+
+// Addon is a KEDA (Kubernetes Event-driven Autoscaling) addon which can be
+// deployed on a clusters.Cluster.
+type Addon struct {
+	chartVersion versions.Selector
+}
+
+// New produces a new clusters.Addon for KEDA with the default settings.
+func New() *Addon {
+	return NewBuilder().Build()
+}
+
+// Namespace indicates the namespace where the KEDA addon components are to be
+// deployed and managed.
+func (a *Addon) Namespace() string {
+	return DefaultNamespace
+}
+
+// -----------------------------------------------------------------------------
+// KEDA Addon - Addon Implementation
+// -----------------------------------------------------------------------------
+
+func (a *Addon) Name() clusters.AddonName {
+	return AddonName
+}
+
+func (a *Addon) Dependencies(_ context.Context, _ clusters.Cluster) []clusters.AddonName {
+	return nil
+}
+
+func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
+	// wait for dependency addons to be ready first
+	if err := clusters.WaitForAddonDependencies(ctx, cluster, a); err != nil {
+		return fmt.Errorf("failure waiting for addon dependencies: %w", err)
+	}
+
+	release := utils.HelmRelease{
+		RepoName:     "kedacore",
+		RepoURL:      HelmRepoURL,
+		Chart:        "kedacore/keda",
+		Name:         ReleaseName,
+		Namespace:    DefaultNamespace,
+		ChartVersion: a.chartVersion,
+	}
+
+	return utils.HelmInstall(ctx, cluster, release)
+}
+
+func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
+	if err := utils.HelmUninstall(ctx, cluster, ReleaseName, DefaultNamespace); err != nil {
+		return err
+	}
+	return clusters.DeleteNamespace(ctx, cluster, DefaultNamespace)
+}
+
+// Upgrade upgrades the chart of a deployed addon to the provided version,
+// retaining the rest of its configuration.
+func (a *Addon) Upgrade(ctx context.Context, cluster clusters.Cluster, version semver.Version) error {
+	a.chartVersion = versions.Exact(version)
+	return a.Deploy(ctx, cluster)
+}
+
+func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) ([]runtime.Object, bool, error) {
+	return utils.IsNamespaceAvailable(ctx, cluster, DefaultNamespace)
+}
+
+func (a *Addon) Conditions(ctx context.Context, cluster clusters.Cluster) (bool, []clusters.Condition, error) {
+	return clusters.AddonConditions(ctx, cluster, a, DefaultNamespace, "")
+}
+
+func (a *Addon) DumpDiagnostics(context.Context, clusters.Cluster) (map[string][]byte, error) {
+	diagnostics := make(map[string][]byte)
+	return diagnostics, nil
+}
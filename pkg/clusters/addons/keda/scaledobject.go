@@ -0,0 +1,80 @@
+package keda
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+)
+
+// -----------------------------------------------------------------------------
+// KEDA Addon - ScaledObjects
+// -----------------------------------------------------------------------------
+
+// ScaledObjectGVR is the GroupVersionResource of KEDA ScaledObjects.
+var ScaledObjectGVR = schema.GroupVersionResource{
+	Group:    "keda.sh",
+	Version:  "v1alpha1",
+	Resource: "scaledobjects",
+}
+
+// PrometheusTrigger configures a ScaledObject to scale on the result of a
+// PromQL query.
+type PrometheusTrigger struct {
+	// ServerAddress is the URL of the Prometheus server, e.g.
+	// "http://prometheus-stack-prometheus.monitoring.svc:9090" for the
+	// Prometheus addon.
+	ServerAddress string
+
+	// Query is the PromQL query, which needs to produce a single value.
+	Query string
+
+	// Threshold is the target value of the query per replica.
+	Threshold string
+}
+
+// NewPrometheusScaledObject generates an (unstructured) ScaledObject which
+// scales the named Deployment between minReplicas and maxReplicas based on the
+// provided Prometheus trigger.
+func NewPrometheusScaledObject(namespace, name, deployment string, minReplicas, maxReplicas int64, trigger PrometheusTrigger) *unstructured.Unstructured {
+	scaledObject := &unstructured.Unstructured{}
+	scaledObject.SetUnstructuredContent(map[string]interface{}{
+		"apiVersion": ScaledObjectGVR.GroupVersion().String(),
+		"kind":       "ScaledObject",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+		},
+		"spec": map[string]interface{}{
+			"scaleTargetRef": map[string]interface{}{
+				"name": deployment,
+			},
+			"minReplicaCount": minReplicas,
+			"maxReplicaCount": maxReplicas,
+			"triggers": []interface{}{
+				map[string]interface{}{
+					"type": "prometheus",
+					"metadata": map[string]interface{}{
+						"serverAddress": trigger.ServerAddress,
+						"query":         trigger.Query,
+						"threshold":     trigger.Threshold,
+					},
+				},
+			},
+		},
+	})
+	return scaledObject
+}
+
+// CreateScaledObject creates the provided (unstructured) ScaledObject, see
+// NewPrometheusScaledObject.
+func CreateScaledObject(ctx context.Context, cluster clusters.Cluster, scaledObject *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	dynamicClient, err := cluster.DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	return dynamicClient.Resource(ScaledObjectGVR).Namespace(scaledObject.GetNamespace()).Create(ctx, scaledObject, metav1.CreateOptions{})
+}
@@ -0,0 +1,211 @@
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/kong/kubernetes-testing-framework/internal/utils"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/kubernetes/generators"
+)
+
+// -----------------------------------------------------------------------------
+// Keycloak Addon
+// -----------------------------------------------------------------------------
+
+const (
+	// AddonName indicates the unique name of this addon.
+	AddonName clusters.AddonName = "keycloak"
+
+	// DefaultNamespace indicates the default namespace this addon will be deployed to.
+	DefaultNamespace = "keycloak"
+
+	// DefaultImage is the Keycloak container image deployed by default.
+	DefaultImage = "quay.io/keycloak/keycloak:24.0.1"
+
+	// DefaultAdminUsername is the username of the Keycloak admin user unless
+	// configured otherwise.
+	DefaultAdminUsername = "admin"
+
+	// DefaultAdminPassword is the password of the Keycloak admin user unless
+	// configured otherwise.
+	DefaultAdminPassword = "ktf-admin"
+
+	// ServiceName is the name of the Keycloak service.
+	ServiceName = "keycloak"
+
+	// ServicePort is the HTTP port of the Keycloak service.
+	ServicePort = 8080
+
+	realmsConfigMapName = "keycloak-realms"
+	realmsImportPath    = "/opt/keycloak/data/import"
+)
+
+// Addon is a Keycloak addon, which provides an OAuth2 and OpenID Connect
+// provider running in development mode, bootstrapped with realm fixtures.
+type Addon struct {
+	image         string
+	adminUsername string
+	adminPassword string
+	realms        [][]byte
+	realmFiles    []string
+}
+
+// New produces a new clusters.Addon for Keycloak with the default settings.
+func New() *Addon {
+	return NewBuilder().Build()
+}
+
+// Namespace indicates the namespace where the Keycloak addon components are
+// to be deployed and managed.
+func (a *Addon) Namespace() string {
+	return DefaultNamespace
+}
+
+// URL provides the in-cluster base URL of Keycloak, which is also the base of
+// the issuer URLs of tokens, regardless of how Keycloak is accessed.
+func (a *Addon) URL() string {
+	return fmt.Sprintf("http://%s.%s.svc:%d", ServiceName, DefaultNamespace, ServicePort)
+}
+
+// IssuerURL provides the OpenID Connect issuer URL of the given realm.
+func (a *Addon) IssuerURL(realm string) string {
+	return fmt.Sprintf("%s/realms/%s", a.URL(), realm)
+}
+
+// -----------------------------------------------------------------------------
+// Keycloak Addon - Addon Implementation
+// -----------------------------------------------------------------------------
+
+func (a *Addon) Name() clusters.AddonName {
+	return AddonName
+}
+
+func (a *Addon) Dependencies(_ context.Context, _ clusters.Cluster) []clusters.AddonName {
+	return nil
+}
+
+func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
+	// wait for dependency addons to be ready first
+	if err := clusters.WaitForAddonDependencies(ctx, cluster, a); err != nil {
+		return fmt.Errorf("failure waiting for addon dependencies: %w", err)
+	}
+
+	realms, err := a.realmsConfigMap()
+	if err != nil {
+		return err
+	}
+
+	if err := clusters.CreateNamespace(ctx, cluster, DefaultNamespace); err != nil {
+		return err
+	}
+
+	if _, err := cluster.Client().CoreV1().ConfigMaps(DefaultNamespace).Create(ctx, realms, metav1.CreateOptions{}); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	container := generators.NewContainer(ServiceName, a.image, ServicePort)
+	container.Ports[0].Name = "http"
+	container.Args = []string{"start-dev", "--import-realm"}
+	container.Env = []corev1.EnvVar{
+		{Name: "KEYCLOAK_ADMIN", Value: a.adminUsername},
+		{Name: "KEYCLOAK_ADMIN_PASSWORD", Value: a.adminPassword},
+		// use the in-cluster URL in issued tokens, also when accessed
+		// through a port forward.
+		{Name: "KC_HOSTNAME_URL", Value: a.URL()},
+		{Name: "KC_HOSTNAME_STRICT_BACKCHANNEL", Value: "false"},
+	}
+	container.VolumeMounts = []corev1.VolumeMount{{Name: "realms", MountPath: realmsImportPath, ReadOnly: true}}
+	container.ReadinessProbe = &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path: "/realms/master",
+				Port: intstr.FromInt(ServicePort),
+			},
+		},
+	}
+	deployment := generators.NewDeploymentForContainer(container)
+	deployment.Spec.Template.Spec.Volumes = []corev1.Volume{{
+		Name: "realms",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: realmsConfigMapName},
+			},
+		},
+	}}
+	if _, err := cluster.Client().AppsV1().Deployments(DefaultNamespace).Create(ctx, deployment, metav1.CreateOptions{}); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	service := generators.NewServiceForDeployment(deployment, corev1.ServiceTypeClusterIP)
+	if _, err := cluster.Client().CoreV1().Services(DefaultNamespace).Create(ctx, service, metav1.CreateOptions{}); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
+	return clusters.DeleteNamespace(ctx, cluster, DefaultNamespace)
+}
+
+func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) ([]runtime.Object, bool, error) {
+	return utils.IsNamespaceAvailable(ctx, cluster, DefaultNamespace)
+}
+
+func (a *Addon) DumpDiagnostics(context.Context, clusters.Cluster) (map[string][]byte, error) {
+	diagnostics := make(map[string][]byte)
+	return diagnostics, nil
+}
+
+// -----------------------------------------------------------------------------
+// Keycloak Addon - Private
+// -----------------------------------------------------------------------------
+
+// realmsConfigMap generates the ConfigMap containing the realm fixtures, which
+// is mounted in Keycloak's import directory.
+func (a *Addon) realmsConfigMap() (*corev1.ConfigMap, error) {
+	realms := make([][]byte, 0, len(a.realms)+len(a.realmFiles))
+	realms = append(realms, a.realms...)
+	for _, path := range a.realmFiles {
+		realm, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not read keycloak realm fixture: %w", err)
+		}
+		realms = append(realms, realm)
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: realmsConfigMapName,
+		},
+		Data: make(map[string]string, len(realms)),
+	}
+	for _, realm := range realms {
+		var meta struct {
+			Realm string `json:"realm"`
+		}
+		if err := json.Unmarshal(realm, &meta); err != nil {
+			return nil, fmt.Errorf("invalid keycloak realm fixture: %w", err)
+		}
+		if meta.Realm == "" {
+			return nil, fmt.Errorf("invalid keycloak realm fixture: missing realm name")
+		}
+		configMap.Data[meta.Realm+"-realm.json"] = string(realm)
+	}
+	return configMap, nil
+}
@@ -0,0 +1,32 @@
+package keycloak
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRealmsConfigMap(t *testing.T) {
+	fixture := filepath.Join(t.TempDir(), "kong.json")
+	require.NoError(t, os.WriteFile(fixture, []byte(`{"realm":"kong","enabled":true}`), 0o600))
+
+	configMap, err := NewBuilder().
+		WithRealm([]byte(`{"realm":"test","enabled":true}`)).
+		WithRealmFile(fixture).
+		Build().
+		realmsConfigMap()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"test-realm.json": `{"realm":"test","enabled":true}`,
+		"kong-realm.json": `{"realm":"kong","enabled":true}`,
+	}, configMap.Data)
+
+	_, err = NewBuilder().WithRealm([]byte(`{"enabled":true}`)).Build().realmsConfigMap()
+	assert.Error(t, err)
+
+	_, err = NewBuilder().WithRealmFile(filepath.Join(t.TempDir(), "missing.json")).Build().realmsConfigMap()
+	assert.Error(t, err)
+}
@@ -0,0 +1,175 @@
+package keycloak
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+)
+
+// -----------------------------------------------------------------------------
+// Keycloak Addon - Admin API
+// -----------------------------------------------------------------------------
+
+// AdminClient is a client for the Keycloak admin REST API, authenticated as
+// the admin user.
+type AdminClient struct {
+	baseURL  string
+	username string
+	password string
+	client   *http.Client
+}
+
+// AdminClient provides a client for the Keycloak admin REST API. As the API
+// requires bearer tokens, which the Kubernetes API server service proxy
+// doesn't forward, Keycloak is accessed through a port forward which lasts
+// until the provided context is done.
+func (a *Addon) AdminClient(ctx context.Context, cluster clusters.Cluster) (*AdminClient, error) {
+	baseURL, err := a.PortForward(ctx, cluster, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &AdminClient{
+		baseURL:  baseURL,
+		username: a.adminUsername,
+		password: a.adminPassword,
+		client:   &http.Client{},
+	}, nil
+}
+
+// PortForward forwards the given local port (a random one if 0) to Keycloak
+// until the provided context is done, and provides the local URL of Keycloak.
+func (a *Addon) PortForward(ctx context.Context, cluster clusters.Cluster, localPort int) (string, error) {
+	pods, err := cluster.Client().CoreV1().Pods(DefaultNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: "app=" + ServiceName,
+		FieldSelector: "status.phase=Running",
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(pods.Items) == 0 {
+		return "", fmt.Errorf("no running keycloak pods found")
+	}
+
+	// the forward is stopped once the context is done
+	address, _, err := clusters.PortForwardFromLocalPort(ctx, cluster, DefaultNamespace, "pod/"+pods.Items[0].Name, localPort, ServicePort)
+	if err != nil {
+		return "", err
+	}
+	return "http://" + address, nil
+}
+
+// Do performs a request against the admin REST API, e.g. "GET" of
+// "/realms/kong/clients". The body, if not nil, is encoded as JSON.
+// The response body is returned for successful responses.
+func (c *AdminClient) Do(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
+	// admin tokens are short lived, so a new one is obtained for each request.
+	token, err := c.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewReader(raw)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+"/admin"+path, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return c.do(req)
+}
+
+// CreateRealm creates a realm from its JSON representation.
+func (c *AdminClient) CreateRealm(ctx context.Context, realmJSON []byte) error {
+	_, err := c.Do(ctx, http.MethodPost, "/realms", json.RawMessage(realmJSON))
+	return err
+}
+
+// CreateClient creates a client in the realm from its JSON representation,
+// e.g. {"clientId": "kong", "secret": "kong-secret", "directAccessGrantsEnabled": true}.
+func (c *AdminClient) CreateClient(ctx context.Context, realm string, clientJSON []byte) error {
+	_, err := c.Do(ctx, http.MethodPost, fmt.Sprintf("/realms/%s/clients", url.PathEscape(realm)), json.RawMessage(clientJSON))
+	return err
+}
+
+// CreateUser creates an enabled user with the given password in the realm.
+func (c *AdminClient) CreateUser(ctx context.Context, realm, username, password string) error {
+	user := map[string]interface{}{
+		"username": username,
+		"enabled":  true,
+		"credentials": []map[string]interface{}{{
+			"type":      "password",
+			"value":     password,
+			"temporary": false,
+		}},
+	}
+	_, err := c.Do(ctx, http.MethodPost, fmt.Sprintf("/realms/%s/users", url.PathEscape(realm)), user)
+	return err
+}
+
+// -----------------------------------------------------------------------------
+// Keycloak Addon - Admin API - Private
+// -----------------------------------------------------------------------------
+
+// token obtains an access token for the admin user from the master realm.
+func (c *AdminClient) token(ctx context.Context) (string, error) {
+	form := url.Values{
+		"grant_type": {"password"},
+		"client_id":  {"admin-cli"},
+		"username":   {c.username},
+		"password":   {c.password},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		c.baseURL+"/realms/master/protocol/openid-connect/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	raw, err := c.do(req)
+	if err != nil {
+		return "", fmt.Errorf("could not obtain keycloak admin token: %w", err)
+	}
+	var resp struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return "", fmt.Errorf("invalid keycloak token response: %w", err)
+	}
+	return resp.AccessToken, nil
+}
+
+func (c *AdminClient) do(req *http.Request) ([]byte, error) {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("keycloak request %s %s failed with status %d: %s", req.Method, req.URL.Path, resp.StatusCode, raw)
+	}
+	return raw, nil
+}
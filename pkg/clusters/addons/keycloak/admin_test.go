@@ -0,0 +1,45 @@
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/realms/master/protocol/openid-connect/token":
+			require.NoError(t, r.ParseForm())
+			if r.Form.Get("username") != "admin" || r.Form.Get("password") != "ktf-admin" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"access_token":"token"}`))
+		case "/admin/realms/kong/users":
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			raw, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			user := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal(raw, &user))
+			assert.Equal(t, "jane", user["username"])
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &AdminClient{baseURL: server.URL, username: "admin", password: "ktf-admin", client: server.Client()}
+	require.NoError(t, client.CreateUser(context.Background(), "kong", "jane", "s3cr3t"))
+	assert.Error(t, client.CreateUser(context.Background(), "missing", "jane", "s3cr3t"))
+
+	client.password = "wrong"
+	assert.Error(t, client.CreateUser(context.Background(), "kong", "jane", "s3cr3t"))
+}
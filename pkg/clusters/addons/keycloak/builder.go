@@ -0,0 +1,63 @@
+package keycloak
+
+// -----------------------------------------------------------------------------
+// Keycloak Addon - Builder
+// -----------------------------------------------------------------------------
+
+// Builder is a configuration tool to generate Keycloak cluster addons.
+type Builder struct {
+	image         string
+	adminUsername string
+	adminPassword string
+	realms        [][]byte
+	realmFiles    []string
+}
+
+// NewBuilder provides a new Builder object for configuring Keycloak cluster addons.
+func NewBuilder() *Builder {
+	return &Builder{
+		image:         DefaultImage,
+		adminUsername: DefaultAdminUsername,
+		adminPassword: DefaultAdminPassword,
+	}
+}
+
+// WithImage configures the Keycloak container image which should be deployed.
+func (b *Builder) WithImage(image string) *Builder {
+	b.image = image
+	return b
+}
+
+// WithAdminCredentials configures the credentials of the Keycloak admin user.
+func (b *Builder) WithAdminCredentials(username, password string) *Builder {
+	b.adminUsername = username
+	b.adminPassword = password
+	return b
+}
+
+// WithRealm adds a realm, in the JSON representation produced by Keycloak's
+// realm export, which is imported when Keycloak starts. Its clients, users and
+// roles are bootstrapped along with it.
+func (b *Builder) WithRealm(realmJSON []byte) *Builder {
+	b.realms = append(b.realms, realmJSON)
+	return b
+}
+
+// WithRealmFile adds a realm fixture file to import when Keycloak starts,
+// see WithRealm. The file is read when the addon is deployed.
+func (b *Builder) WithRealmFile(path string) *Builder {
+	b.realmFiles = append(b.realmFiles, path)
+	return b
+}
+
+// Build generates a new Keycloak cluster.Addon which can be loaded and
+// deployed into a test Environment's cluster.Cluster.
+func (b *Builder) Build() *Addon {
+	return &Addon{
+		image:         b.image,
+		adminUsername: b.adminUsername,
+		adminPassword: b.adminPassword,
+		realms:        b.realms,
+		realmFiles:    b.realmFiles,
+	}
+}
@@ -6,12 +6,13 @@ import (
 
 // Builder constructs a knative addon
 type Builder struct {
-	version string
+	version      string
+	ingressClass string
 }
 
 // NewBuilder returns a new Builder
 func NewBuilder() *Builder {
-	return &Builder{version: DefaultVersion}
+	return &Builder{version: DefaultVersion, ingressClass: DefaultIngressClass}
 }
 
 // WithVersion sets the Knative version to deploy. The version must be a valid Knative git tag (e.g. `knative-v1.10.0`).
@@ -23,9 +24,17 @@ func (b *Builder) WithVersion(version string) (*Builder, error) {
 	return b, nil
 }
 
+// WithIngressClass sets the ingress class which Knative Serving uses to expose
+// KServices. By default Kong is used.
+func (b *Builder) WithIngressClass(ingressClass string) *Builder {
+	b.ingressClass = ingressClass
+	return b
+}
+
 // Build creates a knative addon using the builder parameters
 func (b *Builder) Build() *Addon {
 	return &Addon{
-		version: b.version,
+		version:      b.version,
+		ingressClass: b.ingressClass,
 	}
 }
@@ -13,8 +13,10 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/kong/kubernetes-testing-framework/internal/retry"
+	"github.com/kong/kubernetes-testing-framework/internal/utils"
 	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
 	"github.com/kong/kubernetes-testing-framework/pkg/utils/github"
 )
@@ -32,14 +34,29 @@ const (
 
 	// DefaultVersion is the Knative version deployed when the user requests no specific version
 	DefaultVersion = "0.0.0"
+
+	// DefaultIngressClass is the ingress class Knative Serving is configured
+	// to use by default, which is Kong's Knative ingress class. No networking
+	// layer (e.g. Kourier) is deployed by the addon.
+	DefaultIngressClass = "kong"
+
+	// webhookService is the name of the Knative Serving admission webhook service.
+	webhookService = "webhook"
 )
 
+// Addon is a Knative Serving addon which can be deployed on a clusters.Cluster.
 type Addon struct {
-	version string
+	version      string
+	ingressClass string
 }
 
 func New() clusters.Addon {
-	return &Addon{version: DefaultVersion}
+	return &Addon{version: DefaultVersion, ingressClass: DefaultIngressClass}
+}
+
+// IngressClass indicates the ingress class Knative Serving is configured with.
+func (a *Addon) IngressClass() string {
+	return a.ingressClass
 }
 
 // -----------------------------------------------------------------------------
@@ -60,7 +77,10 @@ func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
 			return err
 		}
 	}
-	return deployKnative(ctx, cluster, a.version)
+	if err := deployKnative(ctx, cluster, a.version); err != nil {
+		return err
+	}
+	return configureIngressClass(ctx, cluster, a.ingressClass)
 }
 
 func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
@@ -85,7 +105,22 @@ func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) ([]runtime.
 		return waitingForObjects, false, nil
 	}
 
-	return nil, true, nil
+	// the webhook deployment may be available before its service has any
+	// endpoints, in which case KService creation is rejected.
+	endpoints, err := cluster.Client().CoreV1().Endpoints(DefaultNamespace).Get(ctx, webhookService, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	for _, subset := range endpoints.Subsets {
+		if len(subset.Addresses) > 0 {
+			return nil, true, nil
+		}
+	}
+
+	return []runtime.Object{endpoints}, false, nil
 }
 
 func (a *Addon) DumpDiagnostics(context.Context, clusters.Cluster) (map[string][]byte, error) {
@@ -117,8 +152,14 @@ func deployKnative(ctx context.Context, cluster clusters.Cluster, version string
 		return fmt.Errorf("knative CRD deployment failed: %w", err)
 	}
 
+	core, cleanup, err := utils.MirroredManifest(ctx, fmt.Sprintf(knativeCore, version))
+	if err != nil {
+		return fmt.Errorf("knative core deployment failed: %w", err)
+	}
+	defer cleanup()
+
 	err = retry.
-		Command("kubectl", "--kubeconfig", kubeconfig.Name(), "apply", "-f", fmt.Sprintf(knativeCore, version)).
+		Command("kubectl", "--kubeconfig", kubeconfig.Name(), "apply", "-f", core).
 		Do(ctx)
 	if err != nil {
 		return fmt.Errorf("knative core deployment failed: %w", err)
@@ -184,6 +225,17 @@ func deployKnative(ctx context.Context, cluster clusters.Cluster, version string
 	}
 }
 
+// configureIngressClass configures the ingress class used by Knative Serving.
+func configureIngressClass(ctx context.Context, cluster clusters.Cluster, ingressClass string) error {
+	patch := []byte(fmt.Sprintf(`{"data":{"ingress-class":%q}}`, ingressClass))
+	_, err := cluster.Client().CoreV1().ConfigMaps(DefaultNamespace).
+		Patch(ctx, "config-network", types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("could not configure knative ingress class %s: %w", ingressClass, err)
+	}
+	return nil
+}
+
 func deleteKnative(ctx context.Context, cluster clusters.Cluster, version string) error {
 	// generate a temporary kubeconfig since we use kubectl to cleanup this addon
 	kubeconfig, err := clusters.TempKubeconfig(cluster)
@@ -215,23 +267,13 @@ func deleteKnative(ctx context.Context, cluster clusters.Cluster, version string
 	}
 
 	// wait for the namespace to tear down
-	for {
-		select {
-		case <-ctx.Done():
-			if err := ctx.Err(); err != nil {
-				return fmt.Errorf("context completed with error while waiting for knative namespace %s to cleanup: %w", DefaultNamespace, err)
-			}
-			return fmt.Errorf("context completed while waiting for knative namespace %s to cleanup", DefaultNamespace)
-		default:
-			if err := cluster.Client().CoreV1().Namespaces().Delete(ctx, DefaultNamespace, metav1.DeleteOptions{}); err != nil {
-				if errors.IsNotFound(err) {
-					return nil
-				}
-				return err
-			}
-			time.Sleep(time.Second)
+	return retry.Poll(ctx, clusters.Logger(cluster), time.Second, "waiting for knative namespace "+DefaultNamespace+" to cleanup", func(ctx context.Context) (bool, error) {
+		err := cluster.Client().CoreV1().Namespaces().Delete(ctx, DefaultNamespace, metav1.DeleteOptions{})
+		if errors.IsNotFound(err) {
+			return true, nil
 		}
-	}
+		return false, err
+	})
 }
 
 // useLatestKnativeVersion locates and sets the knative version to deploy to the latest
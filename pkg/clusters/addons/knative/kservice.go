@@ -0,0 +1,128 @@
+package knative
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/images"
+)
+
+// -----------------------------------------------------------------------------
+// Knative Addon - KServices
+// -----------------------------------------------------------------------------
+
+// kservicePollInterval is how often the status of a KService is checked.
+const kservicePollInterval = time.Second
+
+// KServiceGVR is the GroupVersionResource of Knative Serving Services.
+var KServiceGVR = schema.GroupVersionResource{
+	Group:    "serving.knative.dev",
+	Version:  "v1",
+	Resource: "services",
+}
+
+// NewKService generates an (unstructured) Knative Service running a single
+// container with the given image and environment variables.
+func NewKService(namespace, name, image string, env map[string]string) *unstructured.Unstructured {
+	container := map[string]interface{}{
+		"image": images.Mirror(image),
+	}
+	if len(env) > 0 {
+		names := make([]string, 0, len(env))
+		for name := range env {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		envVars := make([]interface{}, 0, len(env))
+		for _, name := range names {
+			envVars = append(envVars, map[string]interface{}{"name": name, "value": env[name]})
+		}
+		container["env"] = envVars
+	}
+
+	kservice := &unstructured.Unstructured{}
+	kservice.SetUnstructuredContent(map[string]interface{}{
+		"apiVersion": KServiceGVR.GroupVersion().String(),
+		"kind":       "Service",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+		},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{container},
+				},
+			},
+		},
+	})
+	return kservice
+}
+
+// CreateKService creates the provided (unstructured) Knative Service, see NewKService.
+func CreateKService(ctx context.Context, cluster clusters.Cluster, kservice *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	dynamicClient, err := cluster.DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	return dynamicClient.Resource(KServiceGVR).Namespace(kservice.GetNamespace()).Create(ctx, kservice, metav1.CreateOptions{})
+}
+
+// WaitForKServiceReady waits for the Knative Service with the given name to
+// become ready and provides the URL it's served at.
+func WaitForKServiceReady(ctx context.Context, cluster clusters.Cluster, namespace, name string) (string, error) {
+	dynamicClient, err := cluster.DynamicClient()
+	if err != nil {
+		return "", err
+	}
+
+	ticker := time.NewTicker(kservicePollInterval)
+	defer ticker.Stop()
+
+	var reason string
+	for {
+		kservice, err := dynamicClient.Resource(KServiceGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return "", err
+		}
+		if err == nil {
+			var ready bool
+			ready, reason = kserviceReady(kservice)
+			if ready {
+				url, _, _ := unstructured.NestedString(kservice.Object, "status", "url")
+				return url, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("knative service %s/%s did not become ready (%s): %w", namespace, name, reason, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// kserviceReady indicates whether the Ready condition of the provided Knative
+// Service is true and otherwise why it is not.
+func kserviceReady(kservice *unstructured.Unstructured) (bool, string) {
+	conditions, _, _ := unstructured.NestedSlice(kservice.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != "Ready" {
+			continue
+		}
+		if condition["status"] == "True" {
+			return true, ""
+		}
+		return false, fmt.Sprintf("%v: %v", condition["reason"], condition["message"])
+	}
+	return false, "no Ready condition reported"
+}
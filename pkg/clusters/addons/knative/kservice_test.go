@@ -0,0 +1,34 @@
+package knative
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestKServiceReady(t *testing.T) {
+	kservice := NewKService("default", "hello", "ghcr.io/knative/helloworld-go:latest", map[string]string{"TARGET": "KTF"})
+	containers, _, err := unstructured.NestedSlice(kservice.Object, "spec", "template", "spec", "containers")
+	require.NoError(t, err)
+	require.Len(t, containers, 1)
+
+	ready, reason := kserviceReady(kservice)
+	assert.False(t, ready)
+	assert.Equal(t, "no Ready condition reported", reason)
+
+	require.NoError(t, unstructured.SetNestedSlice(kservice.Object, []interface{}{
+		map[string]interface{}{"type": "Ready", "status": "False", "reason": "RevisionMissing", "message": "not yet"},
+	}, "status", "conditions"))
+	ready, reason = kserviceReady(kservice)
+	assert.False(t, ready)
+	assert.Equal(t, "RevisionMissing: not yet", reason)
+
+	require.NoError(t, unstructured.SetNestedSlice(kservice.Object, []interface{}{
+		map[string]interface{}{"type": "ConfigurationsReady", "status": "True"},
+		map[string]interface{}{"type": "Ready", "status": "True"},
+	}, "status", "conditions"))
+	ready, _ = kserviceReady(kservice)
+	assert.True(t, ready)
+}
@@ -3,37 +3,37 @@ package kong
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
-	"os/exec"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/kong/go-database-reconciler/pkg/dump"
 	"github.com/kong/go-database-reconciler/pkg/file"
 	"github.com/kong/go-database-reconciler/pkg/state"
 	deckutils "github.com/kong/go-database-reconciler/pkg/utils"
 	"github.com/kong/go-kong/kong"
 	pwgen "github.com/sethvargo/go-password/password"
-	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/cli-runtime/pkg/genericclioptions"
-	"k8s.io/kubectl/pkg/cmd/create"
-	"k8s.io/kubectl/pkg/scheme"
+	"sigs.k8s.io/yaml"
 
-	"github.com/kong/kubernetes-testing-framework/internal/retry"
 	"github.com/kong/kubernetes-testing-framework/internal/utils"
 	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
 	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/metallb"
 	"github.com/kong/kubernetes-testing-framework/pkg/clusters/types/kind"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/images"
 )
 
 // -----------------------------------------------------------------------------
@@ -71,7 +71,7 @@ const (
 
 // Addon is a Kong Proxy addon which can be deployed on a clusters.Cluster.
 type Addon struct {
-	logger *logrus.Logger
+	logger logr.Logger
 
 	name string
 
@@ -80,6 +80,8 @@ type Addon struct {
 	helmReleaseName string
 	deployArgs      []string
 	chartVersion    string
+	replicas        int
+	ingressClass    string
 
 	// ingress controller configuration options
 	ingressControllerDisabled bool
@@ -89,14 +91,25 @@ type Addon struct {
 	// proxy server general configuration options
 	proxyAdminServiceTypeLoadBalancer bool
 	proxyDBMode                       DBMode
+	proxyPostgreSQL                   *PostgreSQLConfig
 	proxyImage                        string
 	proxyImageTag                     string
 	proxyPullSecret                   pullSecret
+	imagePullSecrets                  []string
+	imagePullPolicy                   corev1.PullPolicy
 	proxyLogLevel                     string
 	proxyServiceType                  corev1.ServiceType
 	proxyEnvVars                      map[string]string
 	proxyReadinessProbePath           string
 
+	// hybrid mode configuration options
+	role         Role
+	controlPlane *Addon
+	konnect      *KonnectConfig
+
+	// additional stream listeners of the proxy
+	streamListeners []StreamListener
+
 	// Node ports
 	httpNodePort  int
 	adminNodePort int
@@ -108,6 +121,9 @@ type Addon struct {
 	// additionalValues stores values that are set during installing by helm.
 	// for each key-value pair, an argument `--set <key>=<value>` is added.
 	additionalValues map[string]string
+	// helmValues and valuesFiles are passed to helm as values files.
+	helmValues  map[string]interface{}
+	valuesFiles []string
 }
 
 type pullSecret struct {
@@ -130,6 +146,41 @@ func (a *Addon) Namespace() string {
 	return a.namespace
 }
 
+// IngressClass provides the ingress class reconciled by the ingress
+// controller, "kong" unless configured otherwise.
+func (a *Addon) IngressClass() string {
+	if a.ingressClass == "" {
+		return DefaultIngressClass
+	}
+	return a.ingressClass
+}
+
+// ProxyServiceName provides the name of the Service serving the proxy, which
+// is DefaultProxyServiceName for the default helm release name.
+func (a *Addon) ProxyServiceName() string {
+	return a.fullname() + "-proxy"
+}
+
+// AdminServiceName provides the name of the Service serving the Admin API,
+// which is DefaultAdminServiceName for the default helm release name.
+func (a *Addon) AdminServiceName() string {
+	return a.fullname() + "-admin"
+}
+
+// UDPServiceName provides the name of the Service serving UDP traffic, which
+// is DefaultUDPServiceName for the default helm release name.
+func (a *Addon) UDPServiceName() string {
+	return a.fullname() + "-udp-proxy"
+}
+
+// EnterpriseSuperAdminPassword provides the password of the super admin in
+// enterprise mode with a database, which is generated during the deployment
+// unless configured with WithProxyEnterpriseSuperAdminPassword. Requests to the
+// Admin API need to provide it in the "Kong-Admin-Token" header.
+func (a *Addon) EnterpriseSuperAdminPassword() string {
+	return a.proxyEnterpriseSuperAdminPassword
+}
+
 // -----------------------------------------------------------------------------
 // Kong Addon - Proxy Endpoint Methods
 // -----------------------------------------------------------------------------
@@ -145,7 +196,7 @@ func (a *Addon) ProxyURL(ctx context.Context, cluster clusters.Cluster) (*url.UR
 		return nil, fmt.Errorf("the addon is not ready on cluster %s: non-empty unresolved objects list: %+v", cluster.Name(), waitForObjects)
 	}
 
-	return urlForService(ctx, cluster, types.NamespacedName{Namespace: a.namespace, Name: DefaultProxyServiceName}, DefaultProxyHTTPPort)
+	return urlForService(ctx, cluster, types.NamespacedName{Namespace: a.namespace, Name: a.ProxyServiceName()}, DefaultProxyHTTPPort)
 }
 
 // ProxyAdminURL provides a routable *url.URL for accessing the Kong Admin API.
@@ -159,7 +210,43 @@ func (a *Addon) ProxyAdminURL(ctx context.Context, cluster clusters.Cluster) (*u
 		return nil, fmt.Errorf("the addon is not ready on cluster %s, see: %+v", cluster.Name(), waitForObjects)
 	}
 
-	return urlForService(ctx, cluster, types.NamespacedName{Namespace: a.namespace, Name: DefaultAdminServiceName}, DefaultAdminServicePort)
+	return urlForService(ctx, cluster, types.NamespacedName{Namespace: a.namespace, Name: a.AdminServiceName()}, DefaultAdminServicePort)
+}
+
+// ProxyStreamAddress provides the routable host:port of the stream listener
+// with the given port, which is served by the proxy Service for TCP and TLS
+// listeners or the UDP Service for UDP listeners. This includes the default
+// listeners (see DefaultTCPServicePort, DefaultTLSServicePort and
+// DefaultUDPServicePort).
+func (a *Addon) ProxyStreamAddress(ctx context.Context, cluster clusters.Cluster, protocol corev1.Protocol, port int) (string, error) {
+	found := false
+	for _, listener := range a.StreamListeners() {
+		if listener.Protocol == protocol && listener.Port == port {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return "", fmt.Errorf("no %s stream listener with port %d is configured", protocol, port)
+	}
+
+	waitForObjects, ready, err := a.Ready(ctx, cluster)
+	if err != nil {
+		return "", err
+	}
+	if !ready {
+		return "", fmt.Errorf("the addon is not ready on cluster %s, see: %+v", cluster.Name(), waitForObjects)
+	}
+
+	serviceName := a.ProxyServiceName()
+	if protocol == corev1.ProtocolUDP {
+		serviceName = a.UDPServiceName()
+	}
+	host, err := hostForService(ctx, cluster, types.NamespacedName{Namespace: a.namespace, Name: serviceName})
+	if err != nil {
+		return "", err
+	}
+	return net.JoinHostPort(host, strconv.Itoa(port)), nil
 }
 
 // ProxyUDPURL provides a routable *url.URL for accessing the default UDP service for the Kong Proxy.
@@ -173,7 +260,27 @@ func (a *Addon) ProxyUDPURL(ctx context.Context, cluster clusters.Cluster) (*url
 		return nil, fmt.Errorf("the addon is not ready on cluster %s, see: %+v", cluster.Name(), waitForObjects)
 	}
 
-	return urlForService(ctx, cluster, types.NamespacedName{Namespace: a.namespace, Name: DefaultUDPServiceName}, DefaultUDPServicePort)
+	return urlForService(ctx, cluster, types.NamespacedName{Namespace: a.namespace, Name: a.UDPServiceName()}, DefaultUDPServicePort)
+}
+
+// IngressURL provides a *url.URL for accessing the Kong proxy from the host
+// running the tests, through its load balancer, a node port or a forwarded
+// port (see clusters.ServiceAddress).
+func (a *Addon) IngressURL(ctx context.Context, cluster clusters.Cluster) (*url.URL, error) {
+	waitForObjects, ready, err := a.Ready(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ready {
+		return nil, fmt.Errorf("the addon is not ready on cluster %s, see: %+v", cluster.Name(), waitForObjects)
+	}
+
+	address, err := clusters.ServiceAddress(ctx, cluster, a.namespace, a.ProxyServiceName(), DefaultProxyHTTPPort)
+	if err != nil {
+		return nil, err
+	}
+	return &url.URL{Scheme: "http", Host: address}, nil
 }
 
 // -----------------------------------------------------------------------------
@@ -185,14 +292,16 @@ func (a *Addon) Name() clusters.AddonName {
 }
 
 func (a *Addon) Dependencies(_ context.Context, cluster clusters.Cluster) []clusters.AddonName {
+	var dependencies []clusters.AddonName
 	if _, ok := cluster.(*kind.Cluster); ok {
 		if a.proxyAdminServiceTypeLoadBalancer {
-			return []clusters.AddonName{
-				metallb.AddonName,
-			}
+			dependencies = append(dependencies, metallb.AddonName)
 		}
 	}
-	return nil
+	if a.controlPlane != nil {
+		dependencies = append(dependencies, a.controlPlane.Name())
+	}
+	return dependencies
 }
 
 func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
@@ -201,75 +310,38 @@ func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
 		return fmt.Errorf("failure waiting for addon dependencies: %w", err)
 	}
 
-	// generate a temporary kubeconfig since we're going to be using the helm CLI
-	kubeconfig, err := clusters.TempKubeconfig(cluster)
-	if err != nil {
-		return err
-	}
-	defer os.Remove(kubeconfig.Name())
-
-	// ensure the repo exists
-	err = retry.Command("helm", "--kubeconfig", kubeconfig.Name(), "repo", "add", "--force-update", "kong", KongHelmRepoURL).Do(ctx)
-	if err != nil {
-		return err
-	}
-
-	// ensure all repos are up to date
-	err = retry.Command("helm", "--kubeconfig", kubeconfig.Name(), "repo", "update").Do(ctx)
-	if err != nil {
-		return err
-	}
-
 	// create a namespace ahead of deployment so things like license secrets and other configurations
 	// can be preloaded.
 	if err := clusters.CreateNamespace(ctx, cluster, a.namespace); err != nil {
 		return err
 	}
 
-	// Pin the chart version if specified.
-	if a.chartVersion != "" {
-		a.deployArgs = append(a.deployArgs, "--version", a.chartVersion)
+	// provide the certificate the control plane and data planes authenticate
+	// each other with in hybrid mode.
+	hybridArgs, err := a.hybridArgs()
+	if err != nil {
+		return err
+	}
+	if a.role != "" {
+		if err := a.deployClusterCertificate(ctx, cluster); err != nil {
+			return err
+		}
 	}
 
 	if a.proxyPullSecret != (pullSecret{}) {
 		// create the pull Secret
-		opts := create.CreateSecretDockerRegistryOptions{
-			Name:       ProxyPullSecretName,
-			Namespace:  a.namespace,
-			Client:     cluster.Client().CoreV1(),
-			PrintFlags: genericclioptions.NewPrintFlags("created").WithTypeSetter(scheme.Scheme),
-			Username:   a.proxyPullSecret.Username,
-			Email:      a.proxyPullSecret.Email,
-			Password:   a.proxyPullSecret.Password,
-			Server:     a.proxyPullSecret.Server,
-		}
-		if opts.Server == "" {
-			opts.Server = "https://index.docker.io/v1/"
-		}
-		opts.PrintObj = func(obj runtime.Object) error {
-			return nil
-		}
-
-		if err := opts.Run(); err != nil {
+		secret, err := newPullSecret(a.namespace, a.proxyPullSecret)
+		if err != nil {
 			return err
 		}
-
-		// use the pull Secret
-		a.deployArgs = append(a.deployArgs,
-			"--set", "image.pullSecrets={proxy-pull}",
-		)
+		if err := applySecret(ctx, cluster, secret); err != nil {
+			return fmt.Errorf("failed to create the proxy image pull secret: %w", err)
+		}
 	}
 
 	// if the dbmode is postgres, set several related values
-	args := []string{"--kubeconfig", kubeconfig.Name(), "upgrade", "--install", a.helmReleaseName, "kong/kong"}
 	if a.proxyDBMode == PostgreSQL {
-		a.deployArgs = append(a.deployArgs,
-			"--set", "env.database=postgres",
-			"--set", "postgresql.enabled=true",
-			"--set", "postgresql.auth.username=kong",
-			"--set", "postgresql.auth.database=kong",
-			"--set", "postgresql.service.port=5432",
-		)
+		a.deployArgs = append(a.deployArgs, postgresArgs(a.proxyPostgreSQL)...)
 	}
 
 	if cluster.IPFamily() == clusters.IPv6 {
@@ -287,25 +359,14 @@ func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
 		a.deployArgs = append(a.deployArgs,
 			"--set", "ingressController.enabled=false",
 			"--set", "ingressController.installCRDs=false",
-			"--skip-crds",
 		)
 	}
 
-	// set the ingress controller container image values if provided by the caller
-	if a.ingressControllerImage != "" {
-		a.deployArgs = append(a.deployArgs, "--set", fmt.Sprintf("ingressController.image.repository=%s", a.ingressControllerImage))
-	}
-	if a.ingressControllerImageTag != "" {
-		a.deployArgs = append(a.deployArgs, "--set", fmt.Sprintf("ingressController.image.tag=%s", a.ingressControllerImageTag))
-	}
-
 	// set the container image values if provided by the caller
-	if a.proxyImage != "" {
-		a.deployArgs = append(a.deployArgs, "--set", fmt.Sprintf("image.repository=%s", a.proxyImage))
-	}
-	if a.proxyImageTag != "" {
-		a.deployArgs = append(a.deployArgs, "--set", fmt.Sprintf("image.tag=%s", a.proxyImageTag))
-	}
+	a.deployArgs = append(a.deployArgs, a.imageArgs()...)
+
+	// set the replicas and the ingress class of this release
+	a.deployArgs = append(a.deployArgs, a.releaseArgs()...)
 
 	// set the service type of the proxy admin's Kubernetes service
 	if a.proxyAdminServiceTypeLoadBalancer {
@@ -374,10 +435,18 @@ func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
 		a.deployArgs = append(a.deployArgs, "--set", fmt.Sprintf("%s=%s", name, value))
 	}
 
+	// values files have the lowest precedence, regardless of their position
+	valuesArgs, cleanup, err := a.valuesArgs()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	a.deployArgs = append(a.deployArgs, valuesArgs...)
+
 	// compile the helm installation values
-	args = append(args, "--namespace", a.namespace)
-	args = append(args, a.deployArgs...)
+	args := append([]string{}, a.deployArgs...)
 	args = append(args, defaults()...)
+	args = append(args, hybridArgs...)
 
 	if a.httpNodePort > 0 {
 		args = append(args, "--set", fmt.Sprintf("proxy.http.nodePort=%d", a.httpNodePort))
@@ -386,45 +455,47 @@ func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
 		args = append(args, "--set", fmt.Sprintf("admin.http.nodePort=%d", a.adminNodePort))
 	}
 
-	args = append(args, exposePortsDefault()...)
-	a.logger.Debugf("helm install arguments: %+v", args)
+	args = append(args, a.streamArgs()...)
 
-	// Sometimes running helm install fails. Just in case this happens, retry.
-	return retry.
-		Command("helm", args...).
-		DoWithErrorHandling(ctx, func(err error, _, stderr *bytes.Buffer) error {
-			// ignore if addon is already deployed
-			if strings.Contains(stderr.String(), "cannot re-use") {
-				return nil
-			}
-			return fmt.Errorf("%s: %w", stderr, err)
-		})
+	release := utils.HelmRelease{
+		RepoName:  "kong",
+		RepoURL:   KongHelmRepoURL,
+		Chart:     "kong/kong",
+		Name:      a.helmReleaseName,
+		Namespace: a.namespace,
+		Version:   a.chartVersion,
+		Args:      args,
+		SkipCRDs:  a.ingressControllerDisabled,
+	}
+	a.loggerFor(cluster).V(1).Info("installing helm release", "release", release)
+	return utils.HelmInstall(ctx, cluster, release)
 }
 
 func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
-	// generate a temporary kubeconfig since we're going to be using the helm CLI
-	kubeconfig, err := clusters.TempKubeconfig(cluster)
-	if err != nil {
+	// delete the chart release from the cluster
+	if err := utils.HelmUninstall(ctx, cluster, a.helmReleaseName, a.namespace); err != nil {
 		return err
 	}
-	defer os.Remove(kubeconfig.Name())
 
-	// delete the chart release from the cluster
-	stderr := new(bytes.Buffer)
-	cmd := exec.CommandContext(ctx, "helm", "--kubeconfig", kubeconfig.Name(), "uninstall", a.helmReleaseName, "--namespace", a.namespace) //nolint:gosec
-	cmd.Stdout = io.Discard
-	cmd.Stderr = stderr
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("%s: %w", stderr.String(), err)
-	}
-
-	if a.proxyEnterpriseLicenseJSON != "" {
-		stderr := new(bytes.Buffer)
-		cmd = exec.Command("kubectl", "delete", "secret", DefaultEnterpriseLicenseSecretName, "--namespace", a.namespace, "--kubeconfig", kubeconfig.Name()) //nolint:gosec
-		cmd.Stdout = io.Discard
-		cmd.Stderr = stderr
-		if err := cmd.Run(); err != nil {
-			return fmt.Errorf("delete secret err msg %s: %w", stderr.String(), err)
+	// clean up the cluster certificate deployed for hybrid mode
+	if a.role != "" {
+		err := cluster.Client().CoreV1().Secrets(a.namespace).Delete(ctx, ClusterCertSecretName, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete cluster certificate secret: %w", err)
+		}
+	}
+
+	// clean up the secrets deployed for enterprise mode
+	if a.proxyEnterpriseEnabled {
+		for _, name := range []string{
+			DefaultEnterpriseLicenseSecretName,
+			DefaultEnterpriseAdminPasswordSecretName,
+			DefaultAdminGUISessionConfSecretName,
+		} {
+			err := cluster.Client().CoreV1().Secrets(a.namespace).Delete(ctx, name, metav1.DeleteOptions{})
+			if err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete enterprise secret %s: %w", name, err)
+			}
 		}
 	}
 
@@ -432,7 +503,29 @@ func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
 }
 
 func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) (waitForObjects []runtime.Object, ready bool, err error) {
-	return utils.IsNamespaceAvailable(ctx, cluster, a.namespace)
+	waitForObjects, ready, err = utils.IsNamespaceAvailable(ctx, cluster, a.namespace)
+	if err != nil || !ready || a.proxyDBMode != PostgreSQL {
+		return waitForObjects, ready, err
+	}
+
+	// in PostgreSQL mode the proxy can only serve its configuration once the
+	// database migrations have been run by the chart's migrations job.
+	job, err := cluster.Client().BatchV1().Jobs(a.namespace).Get(ctx, a.fullname()+"-init-migrations", metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	if ready, err := migrationsJobReady(job); !ready || err != nil {
+		return []runtime.Object{job}, false, err
+	}
+
+	return nil, true, nil
+}
+
+func (a *Addon) Conditions(ctx context.Context, cluster clusters.Cluster) (bool, []clusters.Condition, error) {
+	return clusters.AddonConditions(ctx, cluster, a, a.namespace, "")
 }
 
 func (a *Addon) DumpDiagnostics(ctx context.Context, cluster clusters.Cluster) (map[string][]byte, error) {
@@ -579,6 +672,127 @@ const (
 // Kong Addon - Private Functions
 // -----------------------------------------------------------------------------
 
+// loggerFor provides the logger of the addon, or the logger of the cluster if
+// the addon wasn't configured with one.
+func (a *Addon) loggerFor(cluster clusters.Cluster) logr.Logger {
+	if a.logger.GetSink() == nil {
+		return clusters.Logger(cluster)
+	}
+	return a.logger
+}
+
+// releaseArgs provides the helm installation values for the number of replicas
+// and the ingress class of the release.
+func (a *Addon) releaseArgs() []string {
+	var args []string
+	if a.replicas > 0 {
+		args = append(args, "--set", fmt.Sprintf("replicaCount=%d", a.replicas))
+	}
+	if a.ingressClass != "" {
+		args = append(args,
+			"--set", fmt.Sprintf("ingressController.ingressClass=%s", a.ingressClass),
+			"--set", fmt.Sprintf("ingressController.env.election_id=kong-ingress-controller-leader-%s", a.ingressClass),
+		)
+	}
+	return args
+}
+
+// imageArgs provides the helm installation values for the configured proxy and
+// ingress controller images, their pull policy and pull secrets.
+func (a *Addon) imageArgs() []string {
+	// images which must not be pulled are expected to be available on the
+	// nodes under their original name.
+	mirror := images.Mirror
+	if a.imagePullPolicy == corev1.PullNever {
+		mirror = func(image string) string { return image }
+	}
+
+	var args []string
+	if a.ingressControllerImage != "" {
+		args = append(args, "--set", fmt.Sprintf("ingressController.image.repository=%s", mirror(a.ingressControllerImage)))
+	}
+	if a.ingressControllerImageTag != "" {
+		args = append(args, "--set", fmt.Sprintf("ingressController.image.tag=%s", a.ingressControllerImageTag))
+	}
+	if a.proxyImage != "" {
+		args = append(args, "--set", fmt.Sprintf("image.repository=%s", mirror(a.proxyImage)))
+	}
+	if a.proxyImageTag != "" {
+		args = append(args, "--set", fmt.Sprintf("image.tag=%s", a.proxyImageTag))
+	}
+	if a.imagePullPolicy != "" {
+		args = append(args, "--set", fmt.Sprintf("image.pullPolicy=%s", a.imagePullPolicy))
+	}
+
+	pullSecrets := a.imagePullSecrets
+	if a.proxyPullSecret != (pullSecret{}) {
+		pullSecrets = append([]string{ProxyPullSecretName}, pullSecrets...)
+	}
+	if len(pullSecrets) > 0 {
+		args = append(args, "--set", fmt.Sprintf("image.pullSecrets={%s}", strings.Join(pullSecrets, ",")))
+	}
+	return args
+}
+
+// valuesArgs provides the "--values" arguments for the configured helm values
+// and values files. The values are written to a temporary file, which the
+// returned function removes.
+func (a *Addon) valuesArgs() ([]string, func(), error) {
+	args := make([]string, 0, 2*(len(a.valuesFiles)+1)) //nolint:gomnd
+	cleanup := func() {}
+
+	if len(a.helmValues) > 0 {
+		raw, err := yaml.Marshal(a.helmValues)
+		if err != nil {
+			return nil, cleanup, fmt.Errorf("invalid helm values: %w", err)
+		}
+		f, err := os.CreateTemp(os.TempDir(), "ktf-kong-values-*.yaml")
+		if err != nil {
+			return nil, cleanup, err
+		}
+		defer f.Close()
+		cleanup = func() { os.Remove(f.Name()) }
+		if _, err := f.Write(raw); err != nil {
+			cleanup()
+			return nil, func() {}, err
+		}
+		args = append(args, "--values", f.Name())
+	}
+
+	for _, path := range a.valuesFiles {
+		args = append(args, "--values", path)
+	}
+	return args, cleanup, nil
+}
+
+// mergeValues deeply merges the src helm values into dst.
+func mergeValues(dst, src map[string]interface{}) {
+	for k, v := range src {
+		srcMap, srcIsMap := v.(map[string]interface{})
+		dstMap, dstIsMap := dst[k].(map[string]interface{})
+		switch {
+		case srcIsMap && dstIsMap:
+			mergeValues(dstMap, srcMap)
+		case srcIsMap:
+			// copy nested maps so later merges don't modify the caller's values
+			dstMap = make(map[string]interface{}, len(srcMap))
+			mergeValues(dstMap, srcMap)
+			dst[k] = dstMap
+		default:
+			dst[k] = v
+		}
+	}
+}
+
+// fullname provides the prefix the chart uses for the names of the resources
+// of the release, which follows the chart's "kong.fullname" template.
+func (a *Addon) fullname() string {
+	if strings.Contains(a.helmReleaseName, "kong") {
+		return a.helmReleaseName
+	}
+	return a.helmReleaseName + "-kong"
+}
+
 // defaults provides a list of opinionated default deployment options for the Kong
 // proxy intended to cover the "general use case" and intentionally omitting the
 // Kong Kubernetes Ingress Controller (KIC) component with the expectation that the
@@ -604,42 +818,34 @@ func enterpriseDefaults() []string {
 	}
 }
 
-// we set up a few default ports for TCP and UDP proxy stream, it's up to
-// test cases to use these how they see fit AND clean up after themselves.
-func exposePortsDefault() []string {
-	return []string{
-		"--set", fmt.Sprintf("proxy.stream[0].containerPort=%d", DefaultTCPServicePort),
-		"--set", fmt.Sprintf("proxy.stream[0].servicePort=%d", DefaultTCPServicePort),
-		"--set", fmt.Sprintf("proxy.stream[1].containerPort=%d", DefaultTLSServicePort),
-		"--set", fmt.Sprintf("proxy.stream[1].servicePort=%d", DefaultTLSServicePort),
-		"--set", "proxy.stream[1].parameters[0]=ssl",
-		"--set", "proxy.stream[1].parameters[1]=reuseport",
-		"--set", fmt.Sprintf("udpProxy.stream[0].containerPort=%d", DefaultUDPServicePort),
-		"--set", fmt.Sprintf("udpProxy.stream[0].servicePort=%d", DefaultUDPServicePort),
-		"--set", "udpProxy.stream[0].protocol=UDP",
-		"--set", "udpProxy.stream[0].parameters[0]=udp",
-		"--set", "udpProxy.stream[0].parameters[1]=reuseport",
+func urlForService(ctx context.Context, cluster clusters.Cluster, nsn types.NamespacedName, port int) (*url.URL, error) {
+	host, err := hostForService(ctx, cluster, nsn)
+	if err != nil {
+		return nil, err
 	}
+	return url.Parse(fmt.Sprintf("http://%s:%d", host, port))
 }
 
-func urlForService(ctx context.Context, cluster clusters.Cluster, nsn types.NamespacedName, port int) (*url.URL, error) {
+// hostForService provides the LoadBalancer IP of a LoadBalancer Service or the
+// cluster IP of any other Service.
+func hostForService(ctx context.Context, cluster clusters.Cluster, nsn types.NamespacedName) (string, error) {
 	service, err := cluster.Client().CoreV1().Services(nsn.Namespace).Get(ctx, nsn.Name, metav1.GetOptions{})
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	switch service.Spec.Type {
 	case corev1.ServiceTypeLoadBalancer:
 		if len(service.Status.LoadBalancer.Ingress) == 1 {
-			return url.Parse(fmt.Sprintf("http://%s:%d", service.Status.LoadBalancer.Ingress[0].IP, port))
+			return service.Status.LoadBalancer.Ingress[0].IP, nil
 		}
 	default:
 		if service.Spec.ClusterIP != "" {
-			return url.Parse(fmt.Sprintf("http://%s:%d", service.Spec.ClusterIP, port))
+			return service.Spec.ClusterIP, nil
 		}
 	}
 
-	return nil, fmt.Errorf("service %s has not yet been provisoned", service.Name)
+	return "", fmt.Errorf("service %s has not yet been provisoned", service.Name)
 }
 
 // deployKongEnterpriseLicenseSecret deploys a Kubernetes secret containing the enterprise license data
@@ -648,6 +854,9 @@ func deployKongEnterpriseLicenseSecret(ctx context.Context, cluster clusters.Clu
 	if licenseJSON == "" {
 		return fmt.Errorf("enterprise mode was enabled but no license was provided")
 	}
+	if _, err := ParseLicense(licenseJSON); err != nil {
+		return fmt.Errorf("enterprise mode was enabled with an invalid license: %w", err)
+	}
 
 	kongLicenseSecret := &corev1.Secret{
 		Type: corev1.SecretTypeOpaque,
@@ -660,8 +869,7 @@ func deployKongEnterpriseLicenseSecret(ctx context.Context, cluster clusters.Clu
 		},
 	}
 
-	_, err := cluster.Client().CoreV1().Secrets(namespace).Create(ctx, kongLicenseSecret, metav1.CreateOptions{})
-	if err != nil {
+	if err := applySecret(ctx, cluster, kongLicenseSecret); err != nil {
 		return fmt.Errorf("failed to create the enterprise license secret: %w", err)
 	}
 
@@ -690,8 +898,7 @@ func deployEnterpriseSuperAdminPasswordSecret(ctx context.Context, cluster clust
 		},
 	}
 
-	_, err = cluster.Client().CoreV1().Secrets(namespace).Create(ctx, kongSuperAdminPasswordSecret, metav1.CreateOptions{})
-	if err != nil {
+	if err := applySecret(ctx, cluster, kongSuperAdminPasswordSecret); err != nil {
 		return "", fmt.Errorf("failed to create the superuser admin password secret: %w", err)
 	}
 
@@ -719,10 +926,65 @@ func deployKongEnterpriseAdminGUISessionConf(ctx context.Context, cluster cluste
 		},
 	}
 
-	_, err = cluster.Client().CoreV1().Secrets(namespace).Create(ctx, newSecret, metav1.CreateOptions{})
-	if err != nil {
+	if err := applySecret(ctx, cluster, newSecret); err != nil {
 		return fmt.Errorf("failed to create secret for admin gui session config: %w", err)
 	}
 
 	return nil
 }
+
+// newPullSecret generates the docker registry secret used to pull the proxy
+// image, using Docker Hub if no server is configured.
+func newPullSecret(namespace string, ps pullSecret) (*corev1.Secret, error) {
+	server := ps.Server
+	if server == "" {
+		server = "https://index.docker.io/v1/"
+	}
+
+	type dockerConfigEntry struct {
+		Username string `json:"username,omitempty"`
+		Password string `json:"password,omitempty"`
+		Email    string `json:"email,omitempty"`
+		Auth     string `json:"auth,omitempty"`
+	}
+	dockerConfig := map[string]map[string]dockerConfigEntry{
+		"auths": {
+			server: {
+				Username: ps.Username,
+				Password: ps.Password,
+				Email:    ps.Email,
+				Auth:     base64.StdEncoding.EncodeToString([]byte(ps.Username + ":" + ps.Password)),
+			},
+		},
+	}
+	data, err := json.Marshal(dockerConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ProxyPullSecretName,
+			Namespace: namespace,
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: data,
+		},
+	}, nil
+}
+
+// applySecret creates the provided secret, or updates it if it already exists
+// (e.g. when the addon is deployed again).
+func applySecret(ctx context.Context, cluster clusters.Cluster, secret *corev1.Secret) error {
+	secrets := cluster.Client().CoreV1().Secrets(secret.Namespace)
+	if _, err := secrets.Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+		if _, err := secrets.Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+)
+
+// -----------------------------------------------------------------------------
+// Kong Addon - Admin API
+// -----------------------------------------------------------------------------
+
+const (
+	// AdminTokenHeader is the header which authenticates requests to the
+	// Admin API when RBAC is enforced in enterprise mode.
+	AdminTokenHeader = "Kong-Admin-Token"
+
+	// adminContainerPort is the port the Admin API listens on in the proxy pods.
+	adminContainerPort = 8001
+)
+
+// AdminAPI provides the URL of the Kong Admin API and a client authenticated
+// for it (with the super admin password in enterprise mode with a database).
+// If the admin Service is a LoadBalancer (see
+// WithProxyAdminServiceTypeLoadBalancer) its address is used, otherwise the
+// Admin API is port forwarded until the provided context is done, so that it
+// can be reached from outside the cluster.
+func (a *Addon) AdminAPI(ctx context.Context, cluster clusters.Cluster) (*url.URL, *http.Client, error) {
+	var adminURL *url.URL
+	var err error
+	if a.proxyAdminServiceTypeLoadBalancer {
+		adminURL, err = a.ProxyAdminURL(ctx, cluster)
+	} else {
+		adminURL, err = a.AdminPortForward(ctx, cluster, 0)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return adminURL, newAdminClient(a.proxyEnterpriseSuperAdminPassword), nil
+}
+
+// AdminPortForward forwards the given local port (a random one if 0) to the
+// Admin API of a running proxy pod until the provided context is done, and
+// provides the local URL of the Admin API.
+func (a *Addon) AdminPortForward(ctx context.Context, cluster clusters.Cluster, localPort int) (*url.URL, error) {
+	pods, err := cluster.Client().CoreV1().Pods(a.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: "app.kubernetes.io/name=kong,app.kubernetes.io/instance=" + a.helmReleaseName,
+		FieldSelector: "status.phase=Running",
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pods.Items) == 0 {
+		return nil, fmt.Errorf("no running kong pods found for release %s", a.helmReleaseName)
+	}
+
+	// the forward is stopped once the context is done
+	address, _, err := clusters.PortForwardFromLocalPort(ctx, cluster, a.namespace, "pod/"+pods.Items[0].Name, localPort, adminContainerPort)
+	if err != nil {
+		return nil, fmt.Errorf("port forwarding to the admin api of release %s failed: %w", a.helmReleaseName, err)
+	}
+	return url.Parse("http://" + address)
+}
+
+// newAdminClient provides a client which authenticates its requests with the
+// given admin token, if any.
+func newAdminClient(token string) *http.Client {
+	if token == "" {
+		return &http.Client{}
+	}
+	return &http.Client{Transport: &adminTokenTransport{token: token, base: http.DefaultTransport}}
+}
+
+// adminTokenTransport adds the admin token header to requests.
+type adminTokenTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+func (t *adminTokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(AdminTokenHeader, t.token)
+	return t.base.RoundTrip(req)
+}
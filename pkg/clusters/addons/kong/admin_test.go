@@ -0,0 +1,33 @@
+package kong
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAdminClient(t *testing.T) {
+	var token string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get(AdminTokenHeader)
+	}))
+	defer server.Close()
+
+	resp, err := newAdminClient("").Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Empty(t, token)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err = newAdminClient("s3cr3t").Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, "s3cr3t", token)
+
+	// the caller's request is not modified
+	assert.Empty(t, req.Header.Get(AdminTokenHeader))
+}
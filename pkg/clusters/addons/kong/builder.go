@@ -1,9 +1,7 @@
 package kong
 
 import (
-	"io"
-
-	"github.com/sirupsen/logrus"
+	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -17,7 +15,7 @@ const (
 
 // Builder is a configuration tool for Kong cluster.Addons
 type Builder struct {
-	logger *logrus.Logger
+	logger logr.Logger
 
 	name string
 
@@ -26,6 +24,8 @@ type Builder struct {
 	helmReleaseName string
 	deployArgs      []string
 	chartVersion    string
+	replicas        int
+	ingressClass    string
 
 	// ingress controller configuration options
 	ingressControllerDisabled bool
@@ -35,14 +35,25 @@ type Builder struct {
 	// proxy server general configuration options
 	proxyAdminServiceTypeLoadBalancer bool
 	proxyDBMode                       DBMode
+	proxyPostgreSQL                   *PostgreSQLConfig
 	proxyImage                        string
 	proxyImageTag                     string
 	proxyPullSecret                   pullSecret
+	imagePullSecrets                  []string
+	imagePullPolicy                   corev1.PullPolicy
 	proxyLogLevel                     string
 	proxyServiceType                  corev1.ServiceType
 	proxyEnvVars                      map[string]string
 	proxyReadinessProbePath           string
 
+	// hybrid mode configuration options
+	role         Role
+	controlPlane *Addon
+	konnect      *KonnectConfig
+
+	// additional stream listeners of the proxy
+	streamListeners []StreamListener
+
 	// ports
 	httpNodePort  int
 	adminNodePort int
@@ -54,6 +65,9 @@ type Builder struct {
 	// additionalValues stores values that are set during installing by helm.
 	// for each key-value pair, an argument `--set <key>=<value>` is added.
 	additionalValues map[string]string
+	// helmValues and valuesFiles are passed to helm as values files.
+	helmValues  map[string]interface{}
+	valuesFiles []string
 }
 
 // NewBuilder provides a new Builder object for configuring and generating
@@ -70,9 +84,10 @@ func NewBuilder() *Builder {
 	return builder.WithDBLess()
 }
 
-// WithLogger adds a logger that will provide extra information about the build step
-// of the addon at various configured log levels.
-func (b *Builder) WithLogger(logger *logrus.Logger) *Builder {
+// WithLogger adds a logger that will provide extra information about the deployment
+// of the addon, verbose information being logged at V(1). The logger of the cluster
+// is used by default (see clusters.Logger).
+func (b *Builder) WithLogger(logger logr.Logger) *Builder {
 	b.logger = logger
 	return b
 }
@@ -80,11 +95,6 @@ func (b *Builder) WithLogger(logger *logrus.Logger) *Builder {
 // Build generates a new kong cluster.Addon which can be loaded and deployed
 // into a test Environment's cluster.Cluster.
 func (b *Builder) Build() *Addon {
-	// if no logger was provided provide a nil logger as a default
-	if b.logger == nil {
-		b.logger = &logrus.Logger{Out: io.Discard}
-	}
-
 	// LoadBalancer is used by default for historical and convenience reasons.
 	switch b.proxyServiceType {
 	case "":
@@ -105,6 +115,8 @@ func (b *Builder) Build() *Addon {
 		helmReleaseName: b.helmReleaseName,
 		deployArgs:      b.deployArgs,
 		chartVersion:    b.chartVersion,
+		replicas:        b.replicas,
+		ingressClass:    b.ingressClass,
 
 		ingressControllerDisabled: b.ingressControllerDisabled,
 		ingressControllerImage:    b.ingressControllerImage,
@@ -112,25 +124,37 @@ func (b *Builder) Build() *Addon {
 
 		proxyAdminServiceTypeLoadBalancer: b.proxyAdminServiceTypeLoadBalancer,
 		proxyDBMode:                       b.proxyDBMode,
+		proxyPostgreSQL:                   b.proxyPostgreSQL,
 		proxyImage:                        b.proxyImage,
 		proxyImageTag:                     b.proxyImageTag,
 		proxyPullSecret:                   b.proxyPullSecret,
+		imagePullSecrets:                  b.imagePullSecrets,
+		imagePullPolicy:                   b.imagePullPolicy,
 		proxyLogLevel:                     b.proxyLogLevel,
 		proxyServiceType:                  b.proxyServiceType,
 		proxyEnvVars:                      b.proxyEnvVars,
 		proxyReadinessProbePath:           b.proxyReadinessProbePath,
 
+		role:         b.role,
+		controlPlane: b.controlPlane,
+		konnect:      b.konnect,
+
 		proxyEnterpriseEnabled:            b.proxyEnterpriseEnabled,
 		proxyEnterpriseLicenseJSON:        b.proxyEnterpriseLicenseJSON,
 		proxyEnterpriseSuperAdminPassword: b.proxyEnterpriseSuperAdminPassword,
 
+		streamListeners: b.streamListeners,
+
 		httpNodePort:  b.httpNodePort,
 		adminNodePort: b.adminNodePort,
 
 		additionalValues: b.additionalValues,
+		helmValues:       b.helmValues,
+		valuesFiles:      b.valuesFiles,
 	}
 }
 
+// WithNamespace configures the namespace the Addon is deployed to.
 func (b *Builder) WithNamespace(namespace string) *Builder {
 	b.namespace = namespace
 	return b
@@ -170,6 +194,16 @@ func (b *Builder) WithDBLess() *Builder {
 // WithPostgreSQL configures the resulting Addon to deploy a PostgreSQL proxy backend.
 func (b *Builder) WithPostgreSQL() *Builder {
 	b.proxyDBMode = PostgreSQL
+	b.proxyPostgreSQL = nil
+	return b
+}
+
+// WithExternalPostgreSQL configures the resulting Addon to deploy a proxy backed
+// by an existing PostgreSQL server instead of the one bundled with the chart.
+// The database migrations are run against it during the deployment.
+func (b *Builder) WithExternalPostgreSQL(config PostgreSQLConfig) *Builder {
+	b.proxyDBMode = PostgreSQL
+	b.proxyPostgreSQL = &config
 	return b
 }
 
@@ -187,6 +221,23 @@ func (b *Builder) WithControllerImage(repo, tag string) *Builder {
 	return b
 }
 
+// WithImagePullPolicy configures the pull policy of the proxy and ingress
+// controller images. With corev1.PullNever the images are not rewritten to
+// use registry mirrors, so images loaded into the cluster (e.g. by
+// Cluster.LoadImages) are used as they are.
+func (b *Builder) WithImagePullPolicy(policy corev1.PullPolicy) *Builder {
+	b.imagePullPolicy = policy
+	return b
+}
+
+// WithImagePullSecrets configures existing secrets in the addon's namespace
+// to be used to pull the proxy and ingress controller images, in addition to
+// the secret configured with WithProxyImagePullSecret.
+func (b *Builder) WithImagePullSecrets(names ...string) *Builder {
+	b.imagePullSecrets = append(b.imagePullSecrets, names...)
+	return b
+}
+
 // WithLogLevel sets the proxy log level
 func (b *Builder) WithLogLevel(level string) *Builder {
 	b.proxyLogLevel = level
@@ -201,6 +252,28 @@ func (b *Builder) WithProxyServiceType(serviceType corev1.ServiceType) *Builder
 	return b
 }
 
+// WithTCPListener opens an additional TCP stream listener with the given port
+// on the proxy and its Service, e.g. for TCPRoutes.
+func (b *Builder) WithTCPListener(port int) *Builder {
+	b.streamListeners = append(b.streamListeners, StreamListener{Port: port, Protocol: corev1.ProtocolTCP})
+	return b
+}
+
+// WithTLSListener opens an additional TLS stream listener with the given port
+// on the proxy and its Service, e.g. for TLSRoutes terminated or passed
+// through by the proxy.
+func (b *Builder) WithTLSListener(port int) *Builder {
+	b.streamListeners = append(b.streamListeners, StreamListener{Port: port, Protocol: corev1.ProtocolTCP, TLS: true})
+	return b
+}
+
+// WithUDPListener opens an additional UDP stream listener with the given port
+// on the proxy and its UDP Service, e.g. for UDPRoutes.
+func (b *Builder) WithUDPListener(port int) *Builder {
+	b.streamListeners = append(b.streamListeners, StreamListener{Port: port, Protocol: corev1.ProtocolUDP})
+	return b
+}
+
 // WithProxyEnvVar sets an arbitrary proxy/Kong container environment variable to a string value. The name must be
 // the lowercase kong.conf style with no KONG_ prefix.
 func (b *Builder) WithProxyEnvVar(name, value string) *Builder {
@@ -208,6 +281,49 @@ func (b *Builder) WithProxyEnvVar(name, value string) *Builder {
 	return b
 }
 
+// -----------------------------------------------------------------------------
+// Kong Proxy Hybrid Mode Configuration Options
+// -----------------------------------------------------------------------------
+
+// WithControlPlaneRole configures the Addon as the control plane of a hybrid
+// mode deployment, which doesn't proxy traffic but serves its configuration to
+// data planes (see WithDataPlaneRole). As control planes require a database
+// a DBLESS Addon is switched to the bundled PostgreSQL backend.
+func (b *Builder) WithControlPlaneRole() *Builder {
+	b.role = RoleControlPlane
+	b.controlPlane = nil
+	b.konnect = nil
+	if b.proxyDBMode == DBLESS {
+		b.proxyDBMode = PostgreSQL
+	}
+	return b
+}
+
+// WithDataPlaneRole configures the Addon as a DBLESS data plane without an
+// ingress controller, which receives its configuration from the given control
+// plane Addon deployed to the same cluster. The control plane needs to be
+// deployed with a distinct name, namespace or release name.
+func (b *Builder) WithDataPlaneRole(controlPlane *Addon) *Builder {
+	b.role = RoleDataPlane
+	b.controlPlane = controlPlane
+	b.konnect = nil
+	b.proxyDBMode = DBLESS
+	b.ingressControllerDisabled = true
+	return b
+}
+
+// WithKonnectDataPlane configures the Addon as a DBLESS data plane without an
+// ingress controller, which receives its configuration from a control plane
+// hosted by Konnect.
+func (b *Builder) WithKonnectDataPlane(config KonnectConfig) *Builder {
+	b.role = RoleDataPlane
+	b.controlPlane = nil
+	b.konnect = &config
+	b.proxyDBMode = DBLESS
+	b.ingressControllerDisabled = true
+	return b
+}
+
 // -----------------------------------------------------------------------------
 // Kong Proxy Enterprise Configuration Options
 // -----------------------------------------------------------------------------
@@ -262,6 +378,26 @@ func (b *Builder) WithAdditionalValue(name, value string) *Builder {
 	return b
 }
 
+// WithHelmValues merges the given values (in the structure of the chart's
+// values.yaml) into the values the chart is installed with. Values configured
+// with the other builder options and WithAdditionalValue take precedence.
+func (b *Builder) WithHelmValues(values map[string]interface{}) *Builder {
+	if b.helmValues == nil {
+		b.helmValues = make(map[string]interface{})
+	}
+	mergeValues(b.helmValues, values)
+	return b
+}
+
+// WithValuesFile adds a values file the chart is installed with. Values files
+// are applied in the order they were added, after the values configured with
+// WithHelmValues. Values configured with the other builder options and
+// WithAdditionalValue take precedence.
+func (b *Builder) WithValuesFile(path string) *Builder {
+	b.valuesFiles = append(b.valuesFiles, path)
+	return b
+}
+
 // WithHTTPNodePort sets the HTTP Nodeport.
 func (b *Builder) WithHTTPNodePort(port int) *Builder {
 	b.httpNodePort = port
@@ -274,6 +410,22 @@ func (b *Builder) WithAdminNodePort(port int) *Builder {
 	return b
 }
 
+// WithReplicas sets the number of proxy replicas. The ingress controller runs
+// in the proxy pods, so it is scaled along with the proxy, with only the
+// elected leader configuring the proxies.
+func (b *Builder) WithReplicas(replicas int) *Builder {
+	b.replicas = replicas
+	return b
+}
+
+// WithIngressClass sets the ingress class the ingress controller reconciles,
+// which needs to be distinct for every Kong addon in a cluster. The leader
+// election of the ingress controller is scoped to the ingress class as well.
+func (b *Builder) WithIngressClass(ingressClass string) *Builder {
+	b.ingressClass = ingressClass
+	return b
+}
+
 // WithHelmReleaseName sets the helm release name.
 func (b *Builder) WithHelmReleaseName(name string) *Builder {
 	b.helmReleaseName = name
@@ -292,3 +444,13 @@ func (b *Builder) WithName(name string) *Builder {
 	b.name = name
 	return b
 }
+
+// WithRelease configures the Addon as one of multiple Kong releases in the
+// same cluster: the name is used as the addon name, the helm release name and
+// the ingress class, and the Addon is deployed to a namespace of the same name.
+func (b *Builder) WithRelease(name string) *Builder {
+	return b.WithName(name).
+		WithHelmReleaseName(name).
+		WithIngressClass(name).
+		WithNamespace(name)
+}
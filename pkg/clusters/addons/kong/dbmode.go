@@ -1,5 +1,12 @@
 package kong
 
+import (
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
 // -----------------------------------------------------------------------------
 // DBMode
 // -----------------------------------------------------------------------------
@@ -14,3 +21,71 @@ const (
 	// PostgreSQL indicates that the Kong Proxy should be deployed with a PostgreSQL storage backend.
 	PostgreSQL DBMode = "postgres"
 )
+
+// -----------------------------------------------------------------------------
+// DBMode - PostgreSQL
+// -----------------------------------------------------------------------------
+
+// PostgreSQLConfig configures the connection to an existing PostgreSQL server
+// the Kong proxy should use instead of the PostgreSQL server bundled with the
+// chart.
+type PostgreSQLConfig struct {
+	// Host is the address of the PostgreSQL server, e.g. the DNS name of a
+	// Service in the cluster.
+	Host string
+
+	// Port is the port of the PostgreSQL server, 5432 if not set.
+	Port int
+
+	// User is the user Kong authenticates as.
+	User string
+
+	// Password is the password of the user.
+	Password string
+
+	// Database is the name of the database Kong uses, which needs to exist.
+	Database string
+}
+
+// DefaultPostgreSQLPort is the port used for PostgreSQL servers unless
+// configured otherwise.
+const DefaultPostgreSQLPort = 5432
+
+// postgresArgs provides the helm installation values to deploy the proxy with
+// a PostgreSQL backend, which is the bundled PostgreSQL server unless an
+// external server is configured.
+func postgresArgs(config *PostgreSQLConfig) []string {
+	args := []string{"--set", "env.database=postgres"}
+	if config == nil {
+		return append(args,
+			"--set", "postgresql.enabled=true",
+			"--set", "postgresql.auth.username=kong",
+			"--set", "postgresql.auth.database=kong",
+			"--set", fmt.Sprintf("postgresql.service.port=%d", DefaultPostgreSQLPort),
+		)
+	}
+
+	port := config.Port
+	if port == 0 {
+		port = DefaultPostgreSQLPort
+	}
+	return append(args,
+		"--set", "postgresql.enabled=false",
+		"--set", fmt.Sprintf("env.pg_host=%s", config.Host),
+		"--set", fmt.Sprintf("env.pg_port=%d", port),
+		"--set", fmt.Sprintf("env.pg_user=%s", config.User),
+		"--set", fmt.Sprintf("env.pg_password=%s", config.Password),
+		"--set", fmt.Sprintf("env.pg_database=%s", config.Database),
+	)
+}
+
+// migrationsJobReady indicates whether the database migrations job created by
+// the chart has completed and fails if the job failed.
+func migrationsJobReady(job *batchv1.Job) (bool, error) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return false, fmt.Errorf("kong database migrations job %s failed: %s", job.Name, condition.Message)
+		}
+	}
+	return job.Status.Succeeded > 0, nil
+}
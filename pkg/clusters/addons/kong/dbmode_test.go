@@ -0,0 +1,62 @@
+package kong
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPostgresArgs(t *testing.T) {
+	assert.Equal(t, []string{
+		"--set", "env.database=postgres",
+		"--set", "postgresql.enabled=true",
+		"--set", "postgresql.auth.username=kong",
+		"--set", "postgresql.auth.database=kong",
+		"--set", "postgresql.service.port=5432",
+	}, postgresArgs(nil))
+
+	assert.Equal(t, []string{
+		"--set", "env.database=postgres",
+		"--set", "postgresql.enabled=false",
+		"--set", "env.pg_host=postgres.databases.svc",
+		"--set", "env.pg_port=5432",
+		"--set", "env.pg_user=kong",
+		"--set", "env.pg_password=secret",
+		"--set", "env.pg_database=kong-tests",
+	}, postgresArgs(&PostgreSQLConfig{
+		Host:     "postgres.databases.svc",
+		User:     "kong",
+		Password: "secret",
+		Database: "kong-tests",
+	}))
+}
+
+func TestMigrationsJobReady(t *testing.T) {
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "ingress-controller-kong-init-migrations"}}
+	ready, err := migrationsJobReady(job)
+	require.NoError(t, err)
+	assert.False(t, ready)
+
+	job.Status.Succeeded = 1
+	ready, err = migrationsJobReady(job)
+	require.NoError(t, err)
+	assert.True(t, ready)
+
+	job.Status.Succeeded = 0
+	job.Status.Conditions = []batchv1.JobCondition{{
+		Type:    batchv1.JobFailed,
+		Status:  corev1.ConditionTrue,
+		Message: "Job has reached the specified backoff limit",
+	}}
+	_, err = migrationsJobReady(job)
+	assert.ErrorContains(t, err, "backoff limit")
+}
+
+func TestFullname(t *testing.T) {
+	assert.Equal(t, DefaultReleaseName, NewBuilder().Build().fullname())
+	assert.Equal(t, "my-kong", NewBuilder().WithHelmReleaseName("my-kong").Build().fullname())
+}
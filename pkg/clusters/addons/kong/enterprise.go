@@ -2,6 +2,7 @@ package kong
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"regexp"
@@ -24,6 +25,10 @@ const LicenseDataEnvVar = "KONG_LICENSE_DATA"
 // portion of an RFC3339 timestamp, this is commonly used in Kong license timestamps.
 var partialRFC3339Regex = regexp.MustCompile("^[0-9]+-[0-9]+-[0-9]+$")
 
+// ErrLicenseExpired is returned when validating a Kong enterprise license
+// which is past its expiration date.
+var ErrLicenseExpired = errors.New("the license is expired")
+
 // -----------------------------------------------------------------------------
 // Kong License - Public Types
 // -----------------------------------------------------------------------------
@@ -73,6 +78,16 @@ func GetLicenseFromEnv() (*License, error) {
 		return nil, fmt.Errorf("no license could be found because %s was not set", LicenseDataEnvVar)
 	}
 
+	licenseObj, err := ParseLicense(licenseJSON)
+	if errors.Is(err, ErrLicenseExpired) {
+		return nil, fmt.Errorf("the provided %s is expired", LicenseDataEnvVar)
+	}
+	return licenseObj, err
+}
+
+// ParseLicense parses and validates the provided license JSON, returning an
+// error if it is malformed or expired.
+func ParseLicense(licenseJSON string) (*License, error) {
 	// validate overall structure
 	licenseObj := &License{}
 	if err := json.Unmarshal([]byte(licenseJSON), licenseObj); err != nil {
@@ -93,7 +108,7 @@ func GetLicenseFromEnv() (*License, error) {
 
 	// validate expiration
 	if time.Now().UTC().After(t) {
-		return nil, fmt.Errorf("the provided %s is expired", LicenseDataEnvVar)
+		return nil, fmt.Errorf("%w: %s", ErrLicenseExpired, expirationDateStr)
 	}
 
 	return licenseObj, nil
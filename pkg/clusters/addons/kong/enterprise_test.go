@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func TestGetLicenseJSON(t *testing.T) {
@@ -56,3 +57,38 @@ func TestGetLicenseJSON(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, validLicenseJSON, licenseSecret.Data["license"])
 }
+
+func TestParseLicense(t *testing.T) {
+	tomorrow := time.Now().UTC().Add(time.Hour * 24).Format(time.RFC3339)
+	license, err := ParseLicense(fmt.Sprintf(`{"license":{"payload":{"customer":"ktf","license_expiration_date":%q},"version":"1"}}`, tomorrow))
+	assert.NoError(t, err)
+	assert.Equal(t, "ktf", license.Data.Payload.Customer)
+
+	_, err = ParseLicense(`{"license":{"payload":{"license_expiration_date":"2021-10-20"}}}`)
+	assert.ErrorIs(t, err, ErrLicenseExpired)
+
+	_, err = ParseLicense(`{"license":{"payload":{"license_expiration_date":"soon"}}}`)
+	assert.ErrorContains(t, err, "invalid license date")
+
+	_, err = ParseLicense(`not json`)
+	assert.ErrorContains(t, err, "invalid license JSON")
+}
+
+func TestNewPullSecret(t *testing.T) {
+	secret, err := newPullSecret("kong-system", pullSecret{Username: "user", Password: "pass"})
+	assert.NoError(t, err)
+	assert.Equal(t, ProxyPullSecretName, secret.Name)
+	assert.Equal(t, "kong-system", secret.Namespace)
+	assert.Equal(t, corev1.SecretTypeDockerConfigJson, secret.Type)
+	assert.JSONEq(t,
+		`{"auths":{"https://index.docker.io/v1/":{"username":"user","password":"pass","auth":"dXNlcjpwYXNz"}}}`,
+		string(secret.Data[corev1.DockerConfigJsonKey]),
+	)
+
+	secret, err = newPullSecret("kong-system", pullSecret{Server: "registry.example.com", Username: "user", Password: "pass", Email: "user@example.com"})
+	assert.NoError(t, err)
+	assert.JSONEq(t,
+		`{"auths":{"registry.example.com":{"username":"user","password":"pass","email":"user@example.com","auth":"dXNlcjpwYXNz"}}}`,
+		string(secret.Data[corev1.DockerConfigJsonKey]),
+	)
+}
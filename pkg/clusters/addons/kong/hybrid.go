@@ -0,0 +1,225 @@
+package kong
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+)
+
+// -----------------------------------------------------------------------------
+// Kong Addon - Hybrid Mode
+// -----------------------------------------------------------------------------
+
+// Role indicates the role of the Kong proxy in a hybrid mode deployment.
+type Role string
+
+const (
+	// RoleTraditional indicates that the proxy is not part of a hybrid mode
+	// deployment, which is the default.
+	RoleTraditional Role = "traditional"
+
+	// RoleControlPlane indicates that the proxy is the control plane of a
+	// hybrid mode deployment, which manages the configuration and doesn't
+	// proxy traffic.
+	RoleControlPlane Role = "control_plane"
+
+	// RoleDataPlane indicates that the proxy is a data plane of a hybrid mode
+	// deployment, which receives its configuration from a control plane.
+	RoleDataPlane Role = "data_plane"
+)
+
+const (
+	// ClusterCertSecretName is the name of the TLS Secret containing the
+	// certificate the control plane and data planes use to authenticate each
+	// other in hybrid mode.
+	ClusterCertSecretName = "kong-cluster-cert"
+
+	// DefaultClusterServicePort is the port of the control plane's cluster
+	// Service which data planes connect to.
+	DefaultClusterServicePort = 8005
+
+	// clusterCertPath is the path the chart mounts the cluster certificate to.
+	clusterCertPath = "/etc/secrets/" + ClusterCertSecretName
+
+	// clusterCertValidity is the validity of the generated cluster
+	// certificate, which only needs to outlive a test run.
+	clusterCertValidity = 24 * time.Hour * 365
+)
+
+// KonnectConfig configures a data plane connected to a control plane hosted
+// by Konnect.
+type KonnectConfig struct {
+	// ControlPlaneEndpoint is the host:port of the Konnect control plane
+	// endpoint, e.g. "1234abcd.us.cp0.konghq.com:443".
+	ControlPlaneEndpoint string
+
+	// TelemetryEndpoint is the host:port of the Konnect telemetry endpoint,
+	// e.g. "1234abcd.us.tp0.konghq.com:443".
+	TelemetryEndpoint string
+
+	// Cert and Key are the PEM encoded data plane certificate and private key
+	// registered with the Konnect control plane.
+	Cert []byte
+	Key  []byte
+}
+
+// Role provides the role of the Kong proxy in a hybrid mode deployment.
+func (a *Addon) Role() Role {
+	if a.role == "" {
+		return RoleTraditional
+	}
+	return a.role
+}
+
+// ClusterEndpoint provides the in-cluster host:port data planes connect to
+// when the addon is deployed as a control plane.
+func (a *Addon) ClusterEndpoint() string {
+	return fmt.Sprintf("%s-cluster.%s.svc:%d", a.fullname(), a.namespace, DefaultClusterServicePort)
+}
+
+// -----------------------------------------------------------------------------
+// Kong Addon - Hybrid Mode - Private
+// -----------------------------------------------------------------------------
+
+// deployClusterCertificate provides the cluster certificate secret in the
+// addon's namespace: a generated one for control planes (keeping an existing
+// one so connected data planes remain valid), a copy of the control plane's
+// one for in-cluster data planes or the provided one for Konnect.
+func (a *Addon) deployClusterCertificate(ctx context.Context, cluster clusters.Cluster) error {
+	secrets := cluster.Client().CoreV1().Secrets(a.namespace)
+
+	var certPEM, keyPEM []byte
+	switch {
+	case a.konnect != nil:
+		certPEM, keyPEM = a.konnect.Cert, a.konnect.Key
+	case a.role == RoleDataPlane:
+		secret, err := cluster.Client().CoreV1().Secrets(a.controlPlane.namespace).Get(ctx, ClusterCertSecretName, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to retrieve the cluster certificate of the control plane: %w", err)
+		}
+		certPEM, keyPEM = secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]
+	default:
+		if _, err := secrets.Get(ctx, ClusterCertSecretName, metav1.GetOptions{}); err == nil {
+			return nil
+		} else if !apierrors.IsNotFound(err) {
+			return err
+		}
+		var err error
+		certPEM, keyPEM, err = generateClusterCertificate()
+		if err != nil {
+			return fmt.Errorf("failed to generate the cluster certificate: %w", err)
+		}
+	}
+
+	return applySecret(ctx, cluster, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ClusterCertSecretName,
+			Namespace: a.namespace,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+		},
+	})
+}
+
+// hybridArgs provides the helm installation values for the configured hybrid
+// mode role, which need to take precedence over the defaults.
+func (a *Addon) hybridArgs() ([]string, error) {
+	if a.role == "" {
+		return nil, nil
+	}
+
+	args := []string{
+		"--set", fmt.Sprintf("env.role=%s", a.role),
+		"--set", fmt.Sprintf("env.cluster_cert=%s/%s", clusterCertPath, corev1.TLSCertKey),
+		"--set", fmt.Sprintf("env.cluster_cert_key=%s/%s", clusterCertPath, corev1.TLSPrivateKeyKey),
+		"--set", fmt.Sprintf("secretVolumes={%s}", ClusterCertSecretName),
+	}
+
+	switch {
+	case a.role == RoleControlPlane:
+		return append(args,
+			"--set", "cluster.enabled=true",
+			"--set", "cluster.tls.enabled=true",
+			"--set", fmt.Sprintf("cluster.tls.servicePort=%d", DefaultClusterServicePort),
+			"--set", fmt.Sprintf("cluster.tls.containerPort=%d", DefaultClusterServicePort),
+			"--set", "proxy.enabled=false",
+			"--set", "udpProxy.enabled=false",
+		), nil
+	case a.konnect != nil:
+		cpHost, _, err := net.SplitHostPort(a.konnect.ControlPlaneEndpoint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid konnect control plane endpoint: %w", err)
+		}
+		tpHost, _, err := net.SplitHostPort(a.konnect.TelemetryEndpoint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid konnect telemetry endpoint: %w", err)
+		}
+		return append(args,
+			"--set", "env.database=off",
+			"--set", "env.cluster_mtls=pki",
+			"--set", fmt.Sprintf("env.cluster_control_plane=%s", a.konnect.ControlPlaneEndpoint),
+			"--set", fmt.Sprintf("env.cluster_server_name=%s", cpHost),
+			"--set", fmt.Sprintf("env.cluster_telemetry_endpoint=%s", a.konnect.TelemetryEndpoint),
+			"--set", fmt.Sprintf("env.cluster_telemetry_server_name=%s", tpHost),
+			"--set", "env.lua_ssl_trusted_certificate=system",
+			"--set", "env.konnect_mode=on",
+			"--set", "env.vitals=off",
+			"--set", "admin.enabled=false",
+		), nil
+	default:
+		return append(args,
+			"--set", "env.database=off",
+			"--set", fmt.Sprintf("env.cluster_control_plane=%s", a.controlPlane.ClusterEndpoint()),
+			"--set", fmt.Sprintf("env.lua_ssl_trusted_certificate=%s/%s", clusterCertPath, corev1.TLSCertKey),
+			"--set", "admin.enabled=false",
+		), nil
+	}
+}
+
+// generateClusterCertificate generates the self-signed certificate shared by
+// the control plane and its data planes.
+func generateClusterCertificate() (certPEM, keyPEM []byte, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "kong_clustering"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(clusterCertValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		nil
+}
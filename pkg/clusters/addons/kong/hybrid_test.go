@@ -0,0 +1,80 @@
+package kong
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+)
+
+func TestHybridArgs(t *testing.T) {
+	args, err := NewBuilder().Build().hybridArgs()
+	require.NoError(t, err)
+	assert.Empty(t, args)
+
+	controlPlane := NewBuilder().WithName("kong-cp").WithNamespace("kong-cp").WithControlPlaneRole().Build()
+	assert.Equal(t, RoleControlPlane, controlPlane.Role())
+	assert.Equal(t, PostgreSQL, controlPlane.proxyDBMode)
+	assert.Equal(t, "ingress-controller-kong-cluster.kong-cp.svc:8005", controlPlane.ClusterEndpoint())
+	args, err = controlPlane.hybridArgs()
+	require.NoError(t, err)
+	assert.Subset(t, args, []string{
+		"env.role=control_plane",
+		"env.cluster_cert=/etc/secrets/kong-cluster-cert/tls.crt",
+		"env.cluster_cert_key=/etc/secrets/kong-cluster-cert/tls.key",
+		"secretVolumes={kong-cluster-cert}",
+		"cluster.enabled=true",
+		"cluster.tls.servicePort=8005",
+		"proxy.enabled=false",
+	})
+
+	dataPlane := NewBuilder().WithDataPlaneRole(controlPlane).Build()
+	assert.Equal(t, RoleDataPlane, dataPlane.Role())
+	assert.Equal(t, DBLESS, dataPlane.proxyDBMode)
+	assert.True(t, dataPlane.ingressControllerDisabled)
+	assert.Equal(t, []clusters.AddonName{"kong-cp"}, dataPlane.Dependencies(context.Background(), nil))
+	args, err = dataPlane.hybridArgs()
+	require.NoError(t, err)
+	assert.Subset(t, args, []string{
+		"env.role=data_plane",
+		"env.cluster_control_plane=ingress-controller-kong-cluster.kong-cp.svc:8005",
+		"env.lua_ssl_trusted_certificate=/etc/secrets/kong-cluster-cert/tls.crt",
+		"admin.enabled=false",
+	})
+
+	konnect := NewBuilder().WithKonnectDataPlane(KonnectConfig{
+		ControlPlaneEndpoint: "1234abcd.us.cp0.konghq.com:443",
+		TelemetryEndpoint:    "1234abcd.us.tp0.konghq.com:443",
+	}).Build()
+	args, err = konnect.hybridArgs()
+	require.NoError(t, err)
+	assert.Subset(t, args, []string{
+		"env.role=data_plane",
+		"env.cluster_mtls=pki",
+		"env.cluster_control_plane=1234abcd.us.cp0.konghq.com:443",
+		"env.cluster_server_name=1234abcd.us.cp0.konghq.com",
+		"env.cluster_telemetry_endpoint=1234abcd.us.tp0.konghq.com:443",
+		"env.cluster_telemetry_server_name=1234abcd.us.tp0.konghq.com",
+		"env.konnect_mode=on",
+	})
+
+	_, err = NewBuilder().WithKonnectDataPlane(KonnectConfig{ControlPlaneEndpoint: "missing-port"}).Build().hybridArgs()
+	assert.ErrorContains(t, err, "invalid konnect control plane endpoint")
+}
+
+func TestGenerateClusterCertificate(t *testing.T) {
+	certPEM, keyPEM, err := generateClusterCertificate()
+	require.NoError(t, err)
+
+	pair, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	require.NoError(t, err)
+	assert.Equal(t, "kong_clustering", cert.Subject.CommonName)
+	assert.ElementsMatch(t, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}, cert.ExtKeyUsage)
+}
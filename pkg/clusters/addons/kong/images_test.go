@@ -0,0 +1,42 @@
+package kong
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/images"
+)
+
+func TestImageArgs(t *testing.T) {
+	assert.Empty(t, NewBuilder().Build().imageArgs())
+
+	images.SetRegistryMirror(images.DefaultRegistry, "mirror.example.com")
+	defer images.SetRegistryMirror(images.DefaultRegistry, "")
+
+	addon := NewBuilder().
+		WithProxyImage("kong/kong", "3.5").
+		WithControllerImage("kong/kubernetes-ingress-controller", "3.0").
+		WithProxyImagePullSecret("", "user", "pass", "").
+		WithImagePullSecrets("extra-pull").
+		Build()
+	assert.Equal(t, []string{
+		"--set", "ingressController.image.repository=" + images.Mirror("kong/kubernetes-ingress-controller"),
+		"--set", "ingressController.image.tag=3.0",
+		"--set", "image.repository=" + images.Mirror("kong/kong"),
+		"--set", "image.tag=3.5",
+		"--set", "image.pullSecrets={proxy-pull,extra-pull}",
+	}, addon.imageArgs())
+
+	// images which are never pulled are not mirrored
+	addon = NewBuilder().
+		WithProxyImage("kong/kong", "pr-123").
+		WithImagePullPolicy(corev1.PullNever).
+		Build()
+	assert.Equal(t, []string{
+		"--set", "image.repository=kong/kong",
+		"--set", "image.tag=pr-123",
+		"--set", "image.pullPolicy=Never",
+	}, addon.imageArgs())
+}
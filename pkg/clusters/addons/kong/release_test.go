@@ -0,0 +1,33 @@
+package kong
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReleaseArgs(t *testing.T) {
+	addon := NewBuilder().Build()
+	assert.Empty(t, addon.releaseArgs())
+	assert.Equal(t, DefaultIngressClass, addon.IngressClass())
+	assert.Equal(t, DefaultProxyServiceName, addon.ProxyServiceName())
+	assert.Equal(t, DefaultAdminServiceName, addon.AdminServiceName())
+	assert.Equal(t, DefaultUDPServiceName, addon.UDPServiceName())
+
+	addon = NewBuilder().WithRelease("tenant-a").WithReplicas(3).Build()
+	assert.Equal(t, "tenant-a", string(addon.Name()))
+	assert.Equal(t, "tenant-a", addon.Namespace())
+	assert.Equal(t, "tenant-a", addon.IngressClass())
+	assert.Equal(t, "tenant-a-kong-proxy", addon.ProxyServiceName())
+	assert.Equal(t, "tenant-a-kong-admin", addon.AdminServiceName())
+	assert.Equal(t, "tenant-a-kong-udp-proxy", addon.UDPServiceName())
+	assert.Equal(t, []string{
+		"--set", "replicaCount=3",
+		"--set", "ingressController.ingressClass=tenant-a",
+		"--set", "ingressController.env.election_id=kong-ingress-controller-leader-tenant-a",
+	}, addon.releaseArgs())
+
+	// release names containing "kong" are used as they are by the chart
+	addon = NewBuilder().WithRelease("kong-b").Build()
+	assert.Equal(t, "kong-b-proxy", addon.ProxyServiceName())
+}
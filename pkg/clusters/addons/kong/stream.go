@@ -0,0 +1,88 @@
+package kong
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// -----------------------------------------------------------------------------
+// Kong Addon - Stream Listeners
+// -----------------------------------------------------------------------------
+
+// StreamListener is a stream (L4) listener of the proxy.
+type StreamListener struct {
+	// Port is both the container port of the listener and the port of the
+	// Service exposing it.
+	Port int
+
+	// Protocol is either TCP or UDP.
+	Protocol corev1.Protocol
+
+	// TLS indicates that the TCP listener accepts TLS connections.
+	TLS bool
+}
+
+// defaultStreamListeners are the stream listeners the proxy is always deployed
+// with, it's up to test cases to use these how they see fit AND clean up after
+// themselves.
+var defaultStreamListeners = []StreamListener{
+	{Port: DefaultTCPServicePort, Protocol: corev1.ProtocolTCP},
+	{Port: DefaultTLSServicePort, Protocol: corev1.ProtocolTCP, TLS: true},
+	{Port: DefaultUDPServicePort, Protocol: corev1.ProtocolUDP},
+}
+
+// StreamListeners provides the stream listeners of the proxy, the default
+// ones followed by the ones configured with the builder.
+func (a *Addon) StreamListeners() []StreamListener {
+	listeners := make([]StreamListener, 0, len(defaultStreamListeners)+len(a.streamListeners))
+	listeners = append(listeners, defaultStreamListeners...)
+	for _, listener := range a.streamListeners {
+		duplicate := false
+		for _, existing := range listeners {
+			if existing.Protocol == listener.Protocol && existing.Port == listener.Port {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			listeners = append(listeners, listener)
+		}
+	}
+	return listeners
+}
+
+// streamArgs provides the helm installation values for the stream listeners,
+// TCP ones are served by the proxy Service and UDP ones by the UDP Service.
+func (a *Addon) streamArgs() []string {
+	var args []string
+	tcp, udp := 0, 0
+	for _, listener := range a.StreamListeners() {
+		if listener.Protocol == corev1.ProtocolUDP {
+			prefix := fmt.Sprintf("udpProxy.stream[%d]", udp)
+			args = append(args,
+				"--set", fmt.Sprintf("%s.containerPort=%d", prefix, listener.Port),
+				"--set", fmt.Sprintf("%s.servicePort=%d", prefix, listener.Port),
+				"--set", fmt.Sprintf("%s.protocol=UDP", prefix),
+				"--set", fmt.Sprintf("%s.parameters[0]=udp", prefix),
+				"--set", fmt.Sprintf("%s.parameters[1]=reuseport", prefix),
+			)
+			udp++
+			continue
+		}
+
+		prefix := fmt.Sprintf("proxy.stream[%d]", tcp)
+		args = append(args,
+			"--set", fmt.Sprintf("%s.containerPort=%d", prefix, listener.Port),
+			"--set", fmt.Sprintf("%s.servicePort=%d", prefix, listener.Port),
+		)
+		if listener.TLS {
+			args = append(args,
+				"--set", fmt.Sprintf("%s.parameters[0]=ssl", prefix),
+				"--set", fmt.Sprintf("%s.parameters[1]=reuseport", prefix),
+			)
+		}
+		tcp++
+	}
+	return args
+}
@@ -0,0 +1,47 @@
+package kong
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestStreamArgs(t *testing.T) {
+	defaultArgs := []string{
+		"--set", "proxy.stream[0].containerPort=8888",
+		"--set", "proxy.stream[0].servicePort=8888",
+		"--set", "proxy.stream[1].containerPort=8899",
+		"--set", "proxy.stream[1].servicePort=8899",
+		"--set", "proxy.stream[1].parameters[0]=ssl",
+		"--set", "proxy.stream[1].parameters[1]=reuseport",
+		"--set", "udpProxy.stream[0].containerPort=9999",
+		"--set", "udpProxy.stream[0].servicePort=9999",
+		"--set", "udpProxy.stream[0].protocol=UDP",
+		"--set", "udpProxy.stream[0].parameters[0]=udp",
+		"--set", "udpProxy.stream[0].parameters[1]=reuseport",
+	}
+	assert.Equal(t, defaultArgs, NewBuilder().Build().streamArgs())
+
+	addon := NewBuilder().
+		WithTCPListener(7000).
+		WithTLSListener(7443).
+		WithUDPListener(5353).
+		WithUDPListener(DefaultUDPServicePort).
+		Build()
+	assert.Equal(t, append(defaultArgs,
+		"--set", "proxy.stream[2].containerPort=7000",
+		"--set", "proxy.stream[2].servicePort=7000",
+		"--set", "proxy.stream[3].containerPort=7443",
+		"--set", "proxy.stream[3].servicePort=7443",
+		"--set", "proxy.stream[3].parameters[0]=ssl",
+		"--set", "proxy.stream[3].parameters[1]=reuseport",
+		"--set", "udpProxy.stream[1].containerPort=5353",
+		"--set", "udpProxy.stream[1].servicePort=5353",
+		"--set", "udpProxy.stream[1].protocol=UDP",
+		"--set", "udpProxy.stream[1].parameters[0]=udp",
+		"--set", "udpProxy.stream[1].parameters[1]=reuseport",
+	), addon.streamArgs())
+	assert.Len(t, addon.StreamListeners(), 6)
+	assert.Contains(t, addon.StreamListeners(), StreamListener{Port: 7443, Protocol: corev1.ProtocolTCP, TLS: true})
+}
@@ -0,0 +1,60 @@
+package kong
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestHelmValues(t *testing.T) {
+	original := map[string]interface{}{
+		"proxy": map[string]interface{}{
+			"annotations": map[string]interface{}{"a": "1"},
+		},
+	}
+	addon := NewBuilder().
+		WithHelmValues(original).
+		WithHelmValues(map[string]interface{}{
+			"proxy": map[string]interface{}{
+				"annotations": map[string]interface{}{"b": "2"},
+			},
+			"replicaCount": 2,
+		}).
+		WithValuesFile("custom-values.yaml").
+		Build()
+
+	// the values provided by the caller are not modified
+	assert.Equal(t, map[string]interface{}{"a": "1"}, original["proxy"].(map[string]interface{})["annotations"])
+
+	args, cleanup, err := addon.valuesArgs()
+	require.NoError(t, err)
+	require.Len(t, args, 4)
+	assert.Equal(t, "--values", args[0])
+	assert.Equal(t, []string{"--values", "custom-values.yaml"}, args[2:])
+
+	raw, err := os.ReadFile(args[1])
+	require.NoError(t, err)
+	values := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal(raw, &values))
+	assert.Equal(t, map[string]interface{}{
+		"proxy": map[string]interface{}{
+			"annotations": map[string]interface{}{"a": "1", "b": "2"},
+		},
+		"replicaCount": float64(2),
+	}, values)
+
+	cleanup()
+	_, err = os.Stat(args[1])
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestHelmValuesNone(t *testing.T) {
+	args, cleanup, err := NewBuilder().WithValuesFile(filepath.Join("testdata", "values.yaml")).Build().valuesArgs()
+	require.NoError(t, err)
+	defer cleanup()
+	assert.Equal(t, []string{"--values", filepath.Join("testdata", "values.yaml")}, args)
+}
@@ -38,6 +38,10 @@ const (
 	// DefaultProxyTLSServicePort is the port on the service at which the Kong proxy can be reached by default.
 	DefaultProxyTLSServicePort = 443
 
+	// DefaultIngressClass is the ingress class reconciled by the ingress
+	// controller unless configured otherwise.
+	DefaultIngressClass = "kong"
+
 	// DefaultUDPServiceName provides the name of the LoadBalancer service the proxy uses for UDP traffic.
 	DefaultUDPServiceName = DefaultReleaseName + "-udp-proxy"
 
@@ -0,0 +1,263 @@
+package kong
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blang/semver/v4"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+)
+
+// -----------------------------------------------------------------------------
+// Kong Addon - Versions
+// -----------------------------------------------------------------------------
+
+const (
+	// proxyContainerName is the name of the proxy container in the chart's
+	// deployment.
+	proxyContainerName = "proxy"
+
+	// controllerContainerName is the name of the ingress controller container
+	// in the chart's deployment.
+	controllerContainerName = "ingress-controller"
+)
+
+// Versions are the versions of the components of a deployed Kong addon.
+type Versions struct {
+	// Proxy is the version reported by the Admin API (e.g. "3.5.0" or
+	// "3.4.1.0-enterprise-edition"), or the tag of the proxy image for data
+	// planes which don't serve the Admin API.
+	Proxy string
+
+	// ProxyImage is the image of the proxy container.
+	ProxyImage string
+
+	// Controller is the tag of the ingress controller image, which is empty if
+	// the ingress controller is disabled.
+	Controller string
+
+	// ControllerImage is the image of the ingress controller container.
+	ControllerImage string
+}
+
+// Versions provides the versions of the deployed proxy and ingress controller.
+func (a *Addon) Versions(ctx context.Context, cluster clusters.Cluster) (Versions, error) {
+	deployment, err := cluster.Client().AppsV1().Deployments(a.namespace).Get(ctx, a.fullname(), metav1.GetOptions{})
+	if err != nil {
+		return Versions{}, err
+	}
+	versions := deploymentVersions(deployment)
+
+	// data planes don't serve the Admin API
+	if a.role == RoleDataPlane {
+		versions.Proxy = imageTag(versions.ProxyImage)
+		return versions, nil
+	}
+
+	// the port forward to the Admin API is only needed for this request
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	adminURL, client, err := a.AdminAPI(ctx, cluster)
+	if err != nil {
+		return Versions{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, adminURL.String()+"/", nil)
+	if err != nil {
+		return Versions{}, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return Versions{}, fmt.Errorf("could not retrieve Kong root: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Versions{}, fmt.Errorf("could not retrieve Kong root: unexpected status %s", resp.Status)
+	}
+	var root struct {
+		Version string `json:"version"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&root); err != nil {
+		return Versions{}, fmt.Errorf("could not parse Kong root: %w", err)
+	}
+	versions.Proxy = root.Version
+
+	return versions, nil
+}
+
+// Upgrade upgrades the Kong Gateway of a deployed addon to the provided
+// version (the tag of the proxy image), retaining the rest of its
+// configuration, e.g. the database of the proxy. Use WaitForVersion to wait for
+// the upgrade to be rolled out.
+func (a *Addon) Upgrade(ctx context.Context, cluster clusters.Cluster, version semver.Version) error {
+	a.proxyImageTag = version.String()
+	// the deployment arguments are compiled again from the configuration.
+	a.deployArgs = nil
+	return a.Deploy(ctx, cluster)
+}
+
+// WaitForVersion waits until all proxy replicas are rolled out and the proxy
+// reports the given version, which can be a prefix of the reported version
+// (e.g. "3.5" for "3.5.1") or an enterprise version with or without its suffix
+// (e.g. "3.4.1.0"), e.g. after upgrading the addon.
+func (a *Addon) WaitForVersion(ctx context.Context, cluster clusters.Cluster, version string) error {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	var lastErr error
+	for {
+		deployment, err := cluster.Client().AppsV1().Deployments(a.namespace).Get(ctx, a.fullname(), metav1.GetOptions{})
+		switch {
+		case err != nil:
+			lastErr = err
+		case !deploymentRolledOut(deployment):
+			lastErr = fmt.Errorf("deployment %s is not rolled out yet", deployment.Name)
+		default:
+			versions, err := a.Versions(ctx, cluster)
+			switch {
+			case err != nil:
+				lastErr = err
+			case !versionMatches(versions.Proxy, version):
+				lastErr = fmt.Errorf("kong is serving version %s", versions.Proxy)
+			default:
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("kong did not serve version %s: %w, last error: %v", version, ctx.Err(), lastErr)
+		case <-ticker.C:
+		}
+	}
+}
+
+// deploymentVersions provides the images and the controller version of the
+// chart's deployment.
+func deploymentVersions(deployment *appsv1.Deployment) Versions {
+	versions := Versions{}
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		switch container.Name {
+		case proxyContainerName:
+			versions.ProxyImage = container.Image
+		case controllerContainerName:
+			versions.ControllerImage = container.Image
+			versions.Controller = imageTag(container.Image)
+		}
+	}
+	return versions
+}
+
+// deploymentRolledOut indicates whether all replicas of the deployment run its
+// current template and are available.
+func deploymentRolledOut(deployment *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	status := deployment.Status
+	return status.ObservedGeneration >= deployment.Generation &&
+		status.UpdatedReplicas == replicas &&
+		status.Replicas == replicas &&
+		status.AvailableReplicas == replicas
+}
+
+// imageTag provides the tag of the given image, without a digest.
+func imageTag(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	name := image[strings.LastIndex(image, "/")+1:]
+	if _, tag, ok := strings.Cut(name, ":"); ok {
+		return tag
+	}
+	return "latest"
+}
+
+// versionMatches indicates whether the reported version matches the wanted
+// version, which can be a prefix of it at a version component boundary (e.g.
+// "3.5" for "3.5.1"). Enterprise versions have a fourth component and a suffix,
+// e.g. "3.4.1.0-enterprise-edition" matches "3.4.1", "3.4.1.0" and
+// "3.4.1.0-enterprise", while pre-releases (e.g. "3.5.0-rc.1") only match
+// versions with the same suffix.
+func versionMatches(reported, want string) bool {
+	reportedVersion, err := parseKongVersion(reported)
+	if err != nil {
+		return reported == want
+	}
+	wantVersion, err := parseKongVersion(want)
+	if err != nil {
+		return reported == want
+	}
+
+	if wantVersion.components > reportedVersion.components ||
+		reportedVersion.Major != wantVersion.Major ||
+		(wantVersion.components > 1 && reportedVersion.Minor != wantVersion.Minor) ||
+		(wantVersion.components > 2 && reportedVersion.Patch != wantVersion.Patch) ||
+		(wantVersion.components > 3 && reportedVersion.revision != wantVersion.revision) {
+		return false
+	}
+
+	if wantVersion.suffix == "" {
+		return !reportedVersion.isPrerelease()
+	}
+	return reportedVersion.suffix == wantVersion.suffix || strings.HasPrefix(reportedVersion.suffix, wantVersion.suffix+"-")
+}
+
+// kongVersion is a Kong Gateway version or image tag, e.g. "3.5", "3.5.0-rc.1"
+// or "3.4.1.0-enterprise-edition".
+type kongVersion struct {
+	semver.Version
+
+	// revision is the fourth component of enterprise versions.
+	revision uint64
+
+	// components is the number of numeric components of the version.
+	components int
+
+	// suffix is what follows the numeric components, e.g. "rc.1" or
+	// "enterprise-edition".
+	suffix string
+}
+
+// parseKongVersion parses a Kong Gateway version, normalising its first three
+// components with semver.
+func parseKongVersion(version string) (kongVersion, error) {
+	core, suffix, _ := strings.Cut(strings.TrimPrefix(version, "v"), "-")
+	components := strings.Split(core, ".")
+	if len(components) > 4 { //nolint:gomnd
+		return kongVersion{}, fmt.Errorf("invalid version %s", version)
+	}
+
+	v := kongVersion{components: len(components), suffix: suffix}
+	if len(components) == 4 { //nolint:gomnd
+		revision, err := strconv.ParseUint(components[3], 10, 64)
+		if err != nil {
+			return kongVersion{}, fmt.Errorf("invalid version %s: %w", version, err)
+		}
+		v.revision = revision
+		components = components[:3]
+	}
+	parsed, err := semver.ParseTolerant(strings.Join(components, "."))
+	if err != nil {
+		return kongVersion{}, fmt.Errorf("invalid version %s: %w", version, err)
+	}
+	v.Version = parsed
+	return v, nil
+}
+
+// isPrerelease indicates whether the suffix of the version denotes a
+// pre-release, as opposed to an edition or a flavour of the image.
+func (v kongVersion) isPrerelease() bool {
+	for _, prefix := range []string{"alpha", "beta", "rc", "pre"} {
+		if strings.HasPrefix(v.suffix, prefix) {
+			return true
+		}
+	}
+	return false
+}
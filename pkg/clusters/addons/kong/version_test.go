@@ -0,0 +1,65 @@
+package kong
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestImageTag(t *testing.T) {
+	assert.Equal(t, "3.5", imageTag("kong:3.5"))
+	assert.Equal(t, "3.0.1", imageTag("docker.io/kong/kubernetes-ingress-controller:3.0.1"))
+	assert.Equal(t, "latest", imageTag("localhost:5000/kong"))
+	assert.Equal(t, "3.5", imageTag("localhost:5000/kong:3.5@sha256:abcd"))
+}
+
+func TestVersionMatches(t *testing.T) {
+	assert.True(t, versionMatches("3.5.0", "3.5.0"))
+	assert.True(t, versionMatches("3.5.1", "3.5"))
+	assert.True(t, versionMatches("3.4.1.0-enterprise-edition", "3.4.1"))
+	assert.False(t, versionMatches("3.50.0", "3.5"))
+	assert.False(t, versionMatches("3.5.0-rc.1", "3.5.0"))
+	assert.False(t, versionMatches("3.4.2", "3.5"))
+
+	// enterprise versions and suffixed image tags
+	assert.True(t, versionMatches("3.4.1.0-enterprise-edition", "3.4.1.0"))
+	assert.True(t, versionMatches("3.4.1.0-enterprise-edition", "3.4.1.0-enterprise"))
+	assert.True(t, versionMatches("3.4.1.0-enterprise-edition", "3.4"))
+	assert.False(t, versionMatches("3.4.1.1-enterprise-edition", "3.4.1.0"))
+	assert.False(t, versionMatches("3.4.1.0-enterprise-edition", "3.4.10"))
+	assert.True(t, versionMatches("3.5.0-ubuntu", "3.5.0"))
+	assert.True(t, versionMatches("3.5.0-rc.1", "3.5.0-rc.1"))
+	assert.True(t, versionMatches("3.5.0", "v3.5"))
+	assert.False(t, versionMatches("3.5.0", "3.5.0.0"))
+	assert.True(t, versionMatches("latest", "latest"))
+}
+
+func TestDeploymentVersions(t *testing.T) {
+	deployment := &appsv1.Deployment{}
+	deployment.Spec.Template.Spec.Containers = []corev1.Container{
+		{Name: controllerContainerName, Image: "kong/kubernetes-ingress-controller:3.0"},
+		{Name: proxyContainerName, Image: "kong:3.5"},
+	}
+	assert.Equal(t, Versions{
+		ProxyImage:      "kong:3.5",
+		Controller:      "3.0",
+		ControllerImage: "kong/kubernetes-ingress-controller:3.0",
+	}, deploymentVersions(deployment))
+}
+
+func TestDeploymentRolledOut(t *testing.T) {
+	deployment := &appsv1.Deployment{}
+	deployment.Generation = 2
+	deployment.Spec.Replicas = ptr.To(int32(2))
+	deployment.Status = appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 1, AvailableReplicas: 2}
+	assert.False(t, deploymentRolledOut(deployment))
+
+	deployment.Status = appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2}
+	assert.False(t, deploymentRolledOut(deployment))
+
+	deployment.Status.ObservedGeneration = 2
+	assert.True(t, deploymentRolledOut(deployment))
+}
@@ -117,11 +117,11 @@ func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
 		return fmt.Errorf("could not get ArgoCD instance: %w", err)
 	}
 	err = argo.DeleteApplication(ctx, a.appName)
-	if err != nil {
+	if err != nil && !errors.IsNotFound(err) {
 		return fmt.Errorf("could not delete Application: %w", err)
 	}
 	err = argo.DeleteAppProject(ctx, a.project)
-	if err != nil {
+	if err != nil && !errors.IsNotFound(err) {
 		return fmt.Errorf("could not delete AppProject: %w", err)
 	}
 	return nil
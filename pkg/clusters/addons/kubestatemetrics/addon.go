@@ -0,0 +1,148 @@
+package kubestatemetrics
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/blang/semver/v4"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kong/kubernetes-testing-framework/internal/utils"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/prometheus"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/versions"
+)
+
+// -----------------------------------------------------------------------------
+// kube-state-metrics Addon
+// -----------------------------------------------------------------------------
+
+const (
+	// AddonName indicates the unique name of this addon.
+	AddonName clusters.AddonName = "kube-state-metrics"
+
+	// DefaultNamespace indicates the default namespace this addon will be
+	// deployed to, which is shared with the Prometheus addon.
+	DefaultNamespace = prometheus.DefaultNamespace
+
+	// HelmRepoURL is the URL of the prometheus-community Helm repository.
+	HelmRepoURL = prometheus.HelmRepoURL
+
+	// ReleaseName is the name of the Helm release.
+	ReleaseName = "ktf-kube-state-metrics"
+
+	// ServiceName is the name of the kube-state-metrics service.
+	ServiceName = "kube-state-metrics"
+
+	// ServicePort is the port of the kube-state-metrics service.
+	ServicePort = 8080
+)
+
+// Addon is a kube-state-metrics addon which can be deployed on a
+// clusters.Cluster to expose metrics about the state of Kubernetes objects
+// (e.g. kube_ingress_info), optionally scraped by the Prometheus addon.
+type Addon struct {
+	chartVersion   versions.Selector
+	serviceMonitor bool
+}
+
+// New produces a new clusters.Addon for kube-state-metrics with the default settings.
+func New() *Addon {
+	return NewBuilder().Build()
+}
+
+// Namespace indicates the namespace where the kube-state-metrics addon
+// components are to be deployed and managed.
+func (a *Addon) Namespace() string {
+	return DefaultNamespace
+}
+
+// Metrics retrieves the current metrics exposed by kube-state-metrics in the
+// Prometheus text format, accessed through the Kubernetes API server service
+// proxy. This doesn't require the Prometheus addon to be deployed.
+func (a *Addon) Metrics(ctx context.Context, cluster clusters.Cluster) ([]byte, error) {
+	raw, err := cluster.Client().CoreV1().Services(DefaultNamespace).
+		ProxyGet("http", ServiceName, strconv.Itoa(ServicePort), "/metrics", nil).
+		DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve kube-state-metrics metrics: %w", err)
+	}
+	return raw, nil
+}
+
+// -----------------------------------------------------------------------------
+// kube-state-metrics Addon - Addon Implementation
+// -----------------------------------------------------------------------------
+
+func (a *Addon) Name() clusters.AddonName {
+	return AddonName
+}
+
+func (a *Addon) Dependencies(_ context.Context, _ clusters.Cluster) []clusters.AddonName {
+	// the ServiceMonitor CRD is provided by the Prometheus addon.
+	if a.serviceMonitor {
+		return []clusters.AddonName{prometheus.AddonName}
+	}
+	return nil
+}
+
+func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
+	// wait for dependency addons to be ready first
+	if err := clusters.WaitForAddonDependencies(ctx, cluster, a); err != nil {
+		return fmt.Errorf("failure waiting for addon dependencies: %w", err)
+	}
+
+	release := utils.HelmRelease{
+		RepoName:     "prometheus-community",
+		RepoURL:      HelmRepoURL,
+		Chart:        "prometheus-community/kube-state-metrics",
+		Name:         ReleaseName,
+		Namespace:    DefaultNamespace,
+		ChartVersion: a.chartVersion,
+		Args:         a.helmArgs(),
+	}
+
+	return utils.HelmInstall(ctx, cluster, release)
+}
+
+func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
+	if err := utils.HelmUninstall(ctx, cluster, ReleaseName, DefaultNamespace); err != nil {
+		return err
+	}
+	// the namespace is shared with the Prometheus addon.
+	return clusters.DeleteAddonNamespace(ctx, cluster, a, DefaultNamespace)
+}
+
+// Upgrade upgrades the chart of a deployed addon to the provided version,
+// retaining the rest of its configuration.
+func (a *Addon) Upgrade(ctx context.Context, cluster clusters.Cluster, version semver.Version) error {
+	a.chartVersion = versions.Exact(version)
+	return a.Deploy(ctx, cluster)
+}
+
+func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) ([]runtime.Object, bool, error) {
+	return utils.IsNamespaceAvailable(ctx, cluster, DefaultNamespace)
+}
+
+func (a *Addon) Conditions(ctx context.Context, cluster clusters.Cluster) (bool, []clusters.Condition, error) {
+	return clusters.AddonConditions(ctx, cluster, a, DefaultNamespace, "")
+}
+
+func (a *Addon) DumpDiagnostics(context.Context, clusters.Cluster) (map[string][]byte, error) {
+	diagnostics := make(map[string][]byte)
+	return diagnostics, nil
+}
+
+// -----------------------------------------------------------------------------
+// kube-state-metrics Addon - Private Functions
+// -----------------------------------------------------------------------------
+
+func (a *Addon) helmArgs() []string {
+	return []string{
+		"--set", fmt.Sprintf("fullnameOverride=%s", ServiceName),
+		"--set", fmt.Sprintf("prometheus.monitor.enabled=%t", a.serviceMonitor),
+		// expose all labels of the objects, so tests can select on them.
+		"--set", "metricLabelsAllowlist[0]=*=[*]",
+	}
+}
@@ -0,0 +1,23 @@
+package kubestatemetrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/prometheus"
+)
+
+func TestServiceMonitor(t *testing.T) {
+	ctx := context.Background()
+
+	addon := New()
+	assert.Contains(t, addon.helmArgs(), "prometheus.monitor.enabled=false")
+	assert.Empty(t, addon.Dependencies(ctx, nil))
+
+	addon = NewBuilder().WithServiceMonitor().Build()
+	assert.Contains(t, addon.helmArgs(), "prometheus.monitor.enabled=true")
+	assert.Equal(t, []clusters.AddonName{prometheus.AddonName}, addon.Dependencies(ctx, nil))
+}
@@ -0,0 +1,54 @@
+package kubestatemetrics
+
+import (
+	"github.com/blang/semver/v4"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/versions"
+)
+
+// -----------------------------------------------------------------------------
+// kube-state-metrics Addon - Builder
+// -----------------------------------------------------------------------------
+
+// Builder is a configuration tool to generate kube-state-metrics cluster addons.
+type Builder struct {
+	chartVersion   versions.Selector
+	serviceMonitor bool
+}
+
+// NewBuilder provides a new Builder object for configuring kube-state-metrics
+// cluster addons.
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// WithVersion pins the version of the kube-state-metrics chart which should
+// be deployed, otherwise the latest release is used.
+func (b *Builder) WithVersion(version semver.Version) *Builder {
+	b.chartVersion = versions.Exact(version)
+	return b
+}
+
+// WithMinorVersion selects the latest patch release of the given minor version
+// of the kube-state-metrics chart which should be deployed.
+func (b *Builder) WithMinorVersion(major, minor uint64) *Builder {
+	b.chartVersion = versions.LatestPatch(major, minor)
+	return b
+}
+
+// WithServiceMonitor makes the addon depend on the Prometheus addon and
+// creates a ServiceMonitor for kube-state-metrics, so that its metrics can be
+// queried through the Prometheus addon.
+func (b *Builder) WithServiceMonitor() *Builder {
+	b.serviceMonitor = true
+	return b
+}
+
+// Build generates a new kube-state-metrics cluster.Addon which can be loaded
+// and deployed into a test Environment's cluster.Cluster.
+func (b *Builder) Build() *Addon {
+	return &Addon{
+		chartVersion:   b.chartVersion,
+		serviceMonitor: b.serviceMonitor,
+	}
+}
@@ -1,22 +1,14 @@
 package kuma
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"io"
-	"os"
-	"os/exec"
-	"strings"
 	"time"
 
 	"github.com/blang/semver/v4"
-	"github.com/sirupsen/logrus"
-	"k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
 
-	"github.com/kong/kubernetes-testing-framework/internal/retry"
 	"github.com/kong/kubernetes-testing-framework/internal/utils"
 	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
 )
@@ -43,11 +35,12 @@ const (
 // Addon is a Kuma addon which can be deployed on a clusters.Cluster.
 type Addon struct {
 	name   string
-	logger *logrus.Logger
+	logger logr.Logger
 
 	version semver.Version
 
 	mtlsEnabled bool
+	meshes      []string
 }
 
 // New produces a new clusters.Addon for Kuma with MTLS enabled
@@ -73,29 +66,12 @@ func (a *Addon) Version() semver.Version {
 // EnableMeshForNamespace will add the "kuma.io/sidecar-injection: enabled" label to the provided namespace,
 // enabling sidecar injections fo all Pods in the namespace
 func EnableMeshForNamespace(ctx context.Context, cluster clusters.Cluster, name string) error {
-	for {
-		select {
-		case <-ctx.Done():
-			return fmt.Errorf("context completed while trying to enable mesh for namespace %s: %w", name, ctx.Err())
-		default:
-			namespace, err := cluster.Client().CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
-			if err != nil {
-				return fmt.Errorf("could not enable mesh for namespace %s: %w", name, err)
-			}
-			namespace.ObjectMeta.Labels["kuma.io/sidecar-injection"] = "enabled"
-			_, err = cluster.Client().CoreV1().Namespaces().Update(ctx, namespace, metav1.UpdateOptions{})
-			if err != nil {
-				if errors.IsConflict(err) {
-					// if there's a conflict then an update happened since we pulled the namespace,
-					// simply pull and try again.
-					time.Sleep(time.Second)
-					continue
-				}
-				return fmt.Errorf("could not enable mesh for namespace %s: %w", name, err)
-			}
-			return nil
-		}
-	}
+	return labelNamespace(ctx, cluster, name, map[string]string{SidecarInjectionLabel: "enabled"})
+}
+
+// Meshes indicates the names of the additional Meshes which the addon creates.
+func (a *Addon) Meshes() []string {
+	return a.meshes
 }
 
 // -----------------------------------------------------------------------------
@@ -116,49 +92,15 @@ func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
 		return fmt.Errorf("failure waiting for addon dependencies: %w", err)
 	}
 
-	// generate a temporary kubeconfig since we're going to be using the helm CLI
-	kubeconfig, err := clusters.TempKubeconfig(cluster)
-	if err != nil {
-		return err
-	}
-	defer os.Remove(kubeconfig.Name())
-
-	// ensure the repo exists
-	stderr := new(bytes.Buffer)
-	cmd := exec.CommandContext(ctx, "helm", "--kubeconfig", kubeconfig.Name(), "repo", "add", "--force-update", "kuma", KumaHelmRepo) //nolint:gosec
-	cmd.Stdout = io.Discard
-	cmd.Stderr = stderr
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("%s: %w", stderr.String(), err)
+	release := utils.HelmRelease{
+		RepoName:  "kuma",
+		RepoURL:   KumaHelmRepo,
+		Chart:     "kuma/kuma",
+		Name:      DefaultReleaseName,
+		Namespace: Namespace,
 	}
-
-	// ensure all repos are up to date
-	stderr = new(bytes.Buffer)
-	cmd = exec.CommandContext(ctx, "helm", "--kubeconfig", kubeconfig.Name(), "repo", "update") //nolint:gosec
-	cmd.Stdout = io.Discard
-	cmd.Stderr = stderr
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("%s: %w", stderr.String(), err)
-	}
-
-	// if the dbmode is postgres, set several related values
-	args := []string{"--kubeconfig", kubeconfig.Name(), "install", DefaultReleaseName, "kuma/kuma"}
-
-	// compile the helm installation values
-	args = append(args, "--create-namespace", "--namespace", Namespace)
-	a.logger.Debugf("helm install arguments: %+v", args)
-
-	// Sometimes running helm install fails. Just in case this happens, retry.
-	err = retry.
-		Command("helm", args...).
-		DoWithErrorHandling(ctx, func(err error, _, stderr *bytes.Buffer) error {
-			// ignore if addon is already deployed
-			if strings.Contains(stderr.String(), "cannot re-use") {
-				return nil
-			}
-			return fmt.Errorf("%s: %w", stderr, err)
-		})
-	if err != nil {
+	a.loggerFor(cluster).V(1).Info("installing helm release", "release", release)
+	if err := utils.HelmInstall(ctx, cluster, release); err != nil {
 		return err
 	}
 
@@ -168,33 +110,30 @@ func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
 		}
 	}
 
+	for _, mesh := range a.meshes {
+		if err := ApplyMesh(ctx, cluster, NewMesh(mesh, a.mtlsEnabled)); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
-	// generate a temporary kubeconfig since we're going to be using the helm CLI
-	kubeconfig, err := clusters.TempKubeconfig(cluster)
-	if err != nil {
+	if err := utils.HelmUninstall(ctx, cluster, DefaultReleaseName, Namespace); err != nil {
 		return err
 	}
-	defer os.Remove(kubeconfig.Name())
-
-	// delete the chart release from the cluster
-	stderr := new(bytes.Buffer)
-	cmd := exec.CommandContext(ctx, "helm", "--kubeconfig", kubeconfig.Name(), "uninstall", DefaultReleaseName, "--namespace", Namespace) //nolint:gosec
-	cmd.Stdout = io.Discard
-	cmd.Stderr = stderr
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("%s: %w", stderr.String(), err)
-	}
-
-	return nil
+	return clusters.DeleteNamespace(ctx, cluster, Namespace)
 }
 
 func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) (waitForObjects []runtime.Object, ready bool, err error) {
 	return utils.IsNamespaceAvailable(ctx, cluster, Namespace)
 }
 
+func (a *Addon) Conditions(ctx context.Context, cluster clusters.Cluster) (bool, []clusters.Condition, error) {
+	return clusters.AddonConditions(ctx, cluster, a, Namespace, "")
+}
+
 func (a *Addon) DumpDiagnostics(context.Context, clusters.Cluster) (map[string][]byte, error) {
 	diagnostics := make(map[string][]byte)
 	return diagnostics, nil
@@ -204,47 +143,18 @@ func (a *Addon) DumpDiagnostics(context.Context, clusters.Cluster) (map[string][
 // Kuma Addon - Private Methods
 // -----------------------------------------------------------------------------
 
-// TODO this actually just clobbers the default mesh, which ideally we don't want to do
-// however, Kuma apparently doesn't have a clientset, so vov. could do JSON patches, but eh
-
-const (
-	mtlsEnabledDefaultMesh = `apiVersion: kuma.io/v1alpha1
-kind: Mesh
-metadata:
-  name: default
-spec:
-  mtls:
-    backends:
-    - conf:
-        caCert:
-          RSAbits: 2048
-          expiration: 10y
-      dpCert:
-        rotation:
-          expiration: 1d
-      name: ca-1
-      type: builtin
-    enabledBackend: ca-1`
-)
-
-// enableMTLS attempts to apply a Mesh resource with a basic retry mechanism to deal with delays in the Kuma webhook
-// startup
-func (a *Addon) enableMTLS(ctx context.Context, cluster clusters.Cluster) (err error) {
-	ticker := time.NewTicker(5 * time.Second) //nolint:gomnd
-	timeoutTimer := time.NewTimer(time.Minute)
-
-	for {
-		select {
-		case <-ctx.Done():
-			return fmt.Errorf("context completed while retrying to apply Mesh")
-		case <-ticker.C:
-			err = clusters.ApplyManifestByYAML(ctx, cluster, mtlsEnabledDefaultMesh)
-			if err == nil {
-				return nil
-			}
-		case <-timeoutTimer.C:
-			// return the error of last retry.
-			return err
-		}
+// loggerFor provides the logger of the addon, or the logger of the cluster if
+// the addon wasn't configured with one.
+func (a *Addon) loggerFor(cluster clusters.Cluster) logr.Logger {
+	if a.logger.GetSink() == nil {
+		return clusters.Logger(cluster)
 	}
+	return a.logger
+}
+
+// enableMTLS enables MTLS on the default Mesh, giving up after a minute.
+func (a *Addon) enableMTLS(ctx context.Context, cluster clusters.Cluster) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	return ApplyMesh(ctx, cluster, NewMesh(DefaultMeshName, true))
 }
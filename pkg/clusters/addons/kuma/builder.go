@@ -1,10 +1,8 @@
 package kuma
 
 import (
-	"io"
-
 	"github.com/blang/semver/v4"
-	"github.com/sirupsen/logrus"
+	"github.com/go-logr/logr"
 )
 
 // -----------------------------------------------------------------------------
@@ -15,9 +13,10 @@ import (
 type Builder struct {
 	name    string
 	version semver.Version
-	logger  *logrus.Logger
+	logger  logr.Logger
 
 	mtlsEnabled bool
+	meshes      []string
 }
 
 // NewBuilder provides a new Builder object for configuring Kuma cluster addons.
@@ -33,9 +32,10 @@ func (b *Builder) WithVersion(version semver.Version) *Builder {
 	return b
 }
 
-// WithLogger adds a logger that will provide extra information about the build step
-// of the addon at various configured log levels.
-func (b *Builder) WithLogger(logger *logrus.Logger) *Builder {
+// WithLogger adds a logger that will provide extra information about the deployment
+// of the addon, verbose information being logged at V(1). The logger of the cluster
+// is used by default (see clusters.Logger).
+func (b *Builder) WithLogger(logger logr.Logger) *Builder {
 	b.logger = logger
 	return b
 }
@@ -48,17 +48,23 @@ func (b *Builder) WithMTLS() *Builder {
 	return b
 }
 
+// WithMeshes configures additional Meshes which are created once the control
+// plane is deployed, with MTLS enabled if configured with WithMTLS. Namespaces
+// can join them with AddNamespaceToMesh.
+func (b *Builder) WithMeshes(names ...string) *Builder {
+	b.meshes = append(b.meshes, names...)
+	return b
+}
+
 // Build generates a new kong cluster.Addon which can be loaded and deployed
 // into a test Environment's cluster.Cluster.
 func (b *Builder) Build() *Addon {
-	if b.logger == nil {
-		b.logger = &logrus.Logger{Out: io.Discard}
-	}
 	return &Addon{
 		name:    b.name,
 		version: b.version,
 		logger:  b.logger,
 
 		mtlsEnabled: b.mtlsEnabled,
+		meshes:      b.meshes,
 	}
 }
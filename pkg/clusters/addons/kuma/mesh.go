@@ -0,0 +1,158 @@
+package kuma
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kong/kubernetes-testing-framework/internal/retry"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+)
+
+// -----------------------------------------------------------------------------
+// Kuma Addon - Meshes
+// -----------------------------------------------------------------------------
+
+const (
+	// DefaultMeshName is the name of the Mesh which Kuma creates on startup
+	// and which workloads join unless they select a different one.
+	DefaultMeshName = "default"
+
+	// SidecarInjectionLabel is the namespace label which enables the
+	// injection of Kuma sidecars in all Pods of the namespace.
+	SidecarInjectionLabel = "kuma.io/sidecar-injection"
+
+	// MeshLabel is the namespace label which selects the Mesh the Pods of the
+	// namespace join.
+	MeshLabel = "kuma.io/mesh"
+)
+
+// MeshGVR is the GroupVersionResource of Kuma Meshes.
+var MeshGVR = schema.GroupVersionResource{
+	Group:    "kuma.io",
+	Version:  "v1alpha1",
+	Resource: "meshes",
+}
+
+// NewMesh generates a Kuma Mesh with the given name, optionally with mutual
+// TLS enabled using Kuma's builtin CA.
+//
+// See: https://kuma.io/docs/latest/policies/mutual-tls/
+func NewMesh(name string, mtls bool) *unstructured.Unstructured {
+	spec := map[string]interface{}{}
+	if mtls {
+		spec["mtls"] = map[string]interface{}{
+			"enabledBackend": "ca-1",
+			"backends": []interface{}{
+				map[string]interface{}{
+					"name": "ca-1",
+					"type": "builtin",
+					"conf": map[string]interface{}{
+						"caCert": map[string]interface{}{
+							"RSAbits":    int64(2048), //nolint:gomnd
+							"expiration": "10y",
+						},
+					},
+					"dpCert": map[string]interface{}{
+						"rotation": map[string]interface{}{
+							"expiration": "1d",
+						},
+					},
+				},
+			},
+		}
+	}
+
+	mesh := &unstructured.Unstructured{}
+	mesh.SetUnstructuredContent(map[string]interface{}{
+		"apiVersion": MeshGVR.GroupVersion().String(),
+		"kind":       "Mesh",
+		"metadata": map[string]interface{}{
+			"name": name,
+		},
+		"spec": spec,
+	})
+	return mesh
+}
+
+// ApplyMesh creates the provided Mesh, or replaces the spec of an existing
+// Mesh with the same name (e.g. the default Mesh). Kuma's admission webhook
+// may not be serving yet right after the control plane is deployed, so
+// failures are retried until the context is done.
+func ApplyMesh(ctx context.Context, cluster clusters.Cluster, mesh *unstructured.Unstructured) error {
+	dynamicClient, err := cluster.DynamicClient()
+	if err != nil {
+		return err
+	}
+	meshes := dynamicClient.Resource(MeshGVR)
+
+	for {
+		err = applyMesh(ctx, meshes, mesh)
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("could not apply mesh %s: %w", mesh.GetName(), err)
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// AddNamespaceToMesh labels the provided namespace so that Kuma sidecars are
+// injected in all its Pods, which join the given Mesh.
+func AddNamespaceToMesh(ctx context.Context, cluster clusters.Cluster, namespace, mesh string) error {
+	return labelNamespace(ctx, cluster, namespace, map[string]string{
+		SidecarInjectionLabel: "enabled",
+		MeshLabel:             mesh,
+	})
+}
+
+// -----------------------------------------------------------------------------
+// Kuma Addon - Meshes - Private Functions
+// -----------------------------------------------------------------------------
+
+func applyMesh(ctx context.Context, meshes dynamic.NamespaceableResourceInterface, mesh *unstructured.Unstructured) error {
+	existing, err := meshes.Get(ctx, mesh.GetName(), metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		_, err = meshes.Create(ctx, mesh, metav1.CreateOptions{})
+		return err
+	}
+
+	existing.Object["spec"] = mesh.Object["spec"]
+	_, err = meshes.Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
+
+// labelNamespace adds the provided labels to a namespace, retrying on conflicts
+// and other transient errors.
+func labelNamespace(ctx context.Context, cluster clusters.Cluster, name string, labels map[string]string) error {
+	err := retry.OnTransient(ctx, clusters.Logger(cluster), "labeling namespace "+name, func(ctx context.Context) error {
+		namespace, err := cluster.Client().CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if namespace.Labels == nil {
+			namespace.Labels = make(map[string]string, len(labels))
+		}
+		for k, v := range labels {
+			namespace.Labels[k] = v
+		}
+		_, err = cluster.Client().CoreV1().Namespaces().Update(ctx, namespace, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("could not label namespace %s: %w", name, err)
+	}
+	return nil
+}
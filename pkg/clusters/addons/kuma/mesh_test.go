@@ -0,0 +1,28 @@
+package kuma
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestNewMesh(t *testing.T) {
+	mesh := NewMesh("test", false)
+	assert.Equal(t, "kuma.io/v1alpha1", mesh.GetAPIVersion())
+	assert.Equal(t, "Mesh", mesh.GetKind())
+	assert.Equal(t, "test", mesh.GetName())
+	_, found, err := unstructured.NestedMap(mesh.Object, "spec", "mtls")
+	assert.NoError(t, err)
+	assert.False(t, found)
+
+	mesh = NewMesh(DefaultMeshName, true)
+	backend, found, err := unstructured.NestedString(mesh.Object, "spec", "mtls", "enabledBackend")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "ca-1", backend)
+	backends, found, err := unstructured.NestedSlice(mesh.Object, "spec", "mtls", "backends")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Len(t, backends, 1)
+}
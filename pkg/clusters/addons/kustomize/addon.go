@@ -0,0 +1,125 @@
+package kustomize
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/kustomize/api/types"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/manifests"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/kubernetes/kubectl"
+)
+
+// -----------------------------------------------------------------------------
+// Kustomize Addon
+// -----------------------------------------------------------------------------
+
+// Addon is a generic addon which renders a kustomize directory or remote
+// reference, optionally with patches, and applies the result to the cluster.
+// The resources it creates are deleted again when the addon is deleted.
+type Addon struct {
+	name         clusters.AddonName
+	source       string
+	namespace    string
+	patches      []types.Patch
+	dependencies []clusters.AddonName
+
+	// applied are the rendered manifests which were applied on deploy.
+	applied *manifests.Addon
+}
+
+// Source provides the kustomize directory or remote reference of the addon.
+func (a *Addon) Source() string {
+	return a.source
+}
+
+// Render renders the source with the configured patches without applying it.
+func (a *Addon) Render() (string, error) {
+	source := a.source
+	// local directories are resolved relative to the working directory, while
+	// kustomize resolves resources relative to the kustomization.
+	if _, err := os.Stat(source); err == nil {
+		if source, err = filepath.Abs(source); err != nil {
+			return "", err
+		}
+	}
+
+	rendered, err := kubectl.GetKustomizedManifest(types.Kustomization{
+		Resources: []string{source},
+		Namespace: a.namespace,
+		Patches:   a.patches,
+	})
+	if err != nil {
+		return "", fmt.Errorf("could not render kustomization %s: %w", a.source, err)
+	}
+	manifest, err := io.ReadAll(rendered)
+	if err != nil {
+		return "", err
+	}
+	return string(manifest), nil
+}
+
+// -----------------------------------------------------------------------------
+// Kustomize Addon - Addon Implementation
+// -----------------------------------------------------------------------------
+
+func (a *Addon) Name() clusters.AddonName {
+	return a.name
+}
+
+func (a *Addon) Dependencies(_ context.Context, _ clusters.Cluster) []clusters.AddonName {
+	return a.dependencies
+}
+
+func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
+	// wait for dependency addons to be ready first
+	if err := clusters.WaitForAddonDependencies(ctx, cluster, a); err != nil {
+		return fmt.Errorf("failure waiting for addon dependencies: %w", err)
+	}
+
+	manifest, err := a.Render()
+	if err != nil {
+		return err
+	}
+	// keep track of partially applied manifests for deletion as well
+	a.applied = manifests.NewBuilder(a.name).WithYAML(manifest).Build()
+	if err := a.applied.Deploy(ctx, cluster); err != nil {
+		return fmt.Errorf("could not apply kustomization %s: %w", a.source, err)
+	}
+
+	return nil
+}
+
+func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
+	applied := a.applied
+	if applied == nil {
+		manifest, err := a.Render()
+		if err != nil {
+			return err
+		}
+		applied = manifests.NewBuilder(a.name).WithYAML(manifest).Build()
+	}
+	if err := applied.Delete(ctx, cluster); err != nil {
+		return fmt.Errorf("could not delete kustomization %s: %w", a.source, err)
+	}
+	a.applied = nil
+
+	return nil
+}
+
+func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) ([]runtime.Object, bool, error) {
+	if a.applied == nil {
+		return nil, false, nil
+	}
+	return a.applied.Ready(ctx, cluster)
+}
+
+func (a *Addon) DumpDiagnostics(context.Context, clusters.Cluster) (map[string][]byte, error) {
+	diagnostics := make(map[string][]byte)
+	return diagnostics, nil
+}
@@ -0,0 +1,49 @@
+package kustomize
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/resid"
+)
+
+const testKustomization = `resources:
+- configmap.yaml
+`
+
+const testConfigMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+  namespace: original
+data:
+  key: value
+`
+
+func TestRender(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "kustomization.yaml"), []byte(testKustomization), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "configmap.yaml"), []byte(testConfigMap), 0o600))
+
+	addon := NewBuilder("test", dir).
+		WithNamespace("overridden").
+		WithPatches(types.Patch{
+			Patch: `[{"op": "replace", "path": "/data/key", "value": "patched"}]`,
+			Target: &types.Selector{
+				ResId: resid.ResId{Gvk: resid.Gvk{Kind: "ConfigMap"}, Name: "test"},
+			},
+		}).
+		Build()
+
+	manifest, err := addon.Render()
+	require.NoError(t, err)
+	assert.Contains(t, manifest, "namespace: overridden")
+	assert.Contains(t, manifest, "key: patched")
+
+	_, err = NewBuilder("test", filepath.Join(dir, "missing")).Build().Render()
+	require.Error(t, err)
+}
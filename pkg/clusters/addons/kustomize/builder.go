@@ -0,0 +1,64 @@
+package kustomize
+
+import (
+	"sigs.k8s.io/kustomize/api/types"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+)
+
+// -----------------------------------------------------------------------------
+// Kustomize Addon - Builder
+// -----------------------------------------------------------------------------
+
+// Builder is a configuration tool to generate Kustomize cluster addons.
+type Builder struct {
+	name         clusters.AddonName
+	source       string
+	namespace    string
+	patches      []types.Patch
+	dependencies []clusters.AddonName
+}
+
+// NewBuilder provides a new Builder object for configuring a Kustomize cluster
+// addon with the given unique name, which renders and applies the provided
+// source. The source can be a local kustomize directory or a remote reference
+// such as "github.com/org/repo/config/default?ref=v1.0.0".
+func NewBuilder(name clusters.AddonName, source string) *Builder {
+	return &Builder{
+		name:   name,
+		source: source,
+	}
+}
+
+// WithNamespace overrides the namespace of all namespaced resources rendered
+// from the source.
+func (b *Builder) WithNamespace(namespace string) *Builder {
+	b.namespace = namespace
+	return b
+}
+
+// WithPatches adds kustomize patches (strategic merge or JSON 6902 patches)
+// which are applied on top of the source when rendering.
+func (b *Builder) WithPatches(patches ...types.Patch) *Builder {
+	b.patches = append(b.patches, patches...)
+	return b
+}
+
+// WithDependencies configures addons which need to be ready before the source
+// is applied.
+func (b *Builder) WithDependencies(dependencies ...clusters.AddonName) *Builder {
+	b.dependencies = append(b.dependencies, dependencies...)
+	return b
+}
+
+// Build generates a new Kustomize cluster.Addon which can be loaded and
+// deployed into a test Environment's cluster.Cluster.
+func (b *Builder) Build() *Addon {
+	return &Addon{
+		name:         b.name,
+		source:       b.source,
+		namespace:    b.namespace,
+		patches:      b.patches,
+		dependencies: b.dependencies,
+	}
+}
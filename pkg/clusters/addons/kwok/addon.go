@@ -0,0 +1,141 @@
+package kwok
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blang/semver/v4"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/manifests"
+)
+
+// -----------------------------------------------------------------------------
+// kwok Addon
+// -----------------------------------------------------------------------------
+
+const (
+	// AddonName indicates the unique name of this addon.
+	AddonName clusters.AddonName = "kwok"
+
+	// DefaultNamespace indicates the namespace the kwok controller is
+	// deployed to by the kwok manifests.
+	DefaultNamespace = "kube-system"
+
+	// DefaultNodes is the number of simulated nodes registered by default.
+	DefaultNodes = 10
+
+	// manifestsURL is the URL of the kwok release manifests for a given
+	// version and manifest name.
+	manifestsURL = "https://github.com/kubernetes-sigs/kwok/releases/download/v%s/%s"
+
+	controllerDeployment = "kwok-controller"
+)
+
+// DefaultVersion is the version of kwok deployed by default.
+var DefaultVersion = semver.MustParse("0.5.1")
+
+// Addon is a kwok addon, which simulates nodes (and the pods scheduled to
+// them) in an existing cluster, so that controllers can be scale tested
+// against many nodes and endpoints while the rest of the cluster stays real.
+// See ConfigurePodSpec for scheduling pods to the simulated nodes.
+type Addon struct {
+	version   semver.Version
+	nodes     int
+	manifests *manifests.Addon
+}
+
+// New produces a new clusters.Addon for kwok with the default settings.
+func New() *Addon {
+	return NewBuilder().Build()
+}
+
+// Namespace indicates the namespace where the kwok controller is deployed.
+func (a *Addon) Namespace() string {
+	return DefaultNamespace
+}
+
+// Version indicates the version of kwok which is deployed.
+func (a *Addon) Version() semver.Version {
+	return a.version
+}
+
+// Nodes provides the names of the simulated nodes registered by the addon.
+func (a *Addon) Nodes() []string {
+	names := make([]string, 0, a.nodes)
+	for i := 0; i < a.nodes; i++ {
+		names = append(names, NodeName(i))
+	}
+	return names
+}
+
+// -----------------------------------------------------------------------------
+// kwok Addon - Addon Implementation
+// -----------------------------------------------------------------------------
+
+func (a *Addon) Name() clusters.AddonName {
+	return AddonName
+}
+
+func (a *Addon) Dependencies(_ context.Context, _ clusters.Cluster) []clusters.AddonName {
+	return nil
+}
+
+func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
+	// wait for dependency addons to be ready first
+	if err := clusters.WaitForAddonDependencies(ctx, cluster, a); err != nil {
+		return fmt.Errorf("failure waiting for addon dependencies: %w", err)
+	}
+
+	if err := a.manifests.Deploy(ctx, cluster); err != nil {
+		return fmt.Errorf("could not deploy kwok: %w", err)
+	}
+
+	return CreateNodes(ctx, cluster, a.Nodes()...)
+}
+
+func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
+	for _, name := range a.Nodes() {
+		if err := cluster.Client().CoreV1().Nodes().Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+			if !errors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+
+	return a.manifests.Delete(ctx, cluster)
+}
+
+func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) ([]runtime.Object, bool, error) {
+	deployment, err := cluster.Client().AppsV1().Deployments(DefaultNamespace).Get(ctx, controllerDeployment, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	if deployment.Status.AvailableReplicas < 1 {
+		return []runtime.Object{deployment}, false, nil
+	}
+
+	// the simulated nodes become ready once kwok takes over their status.
+	for _, name := range a.Nodes() {
+		node, err := cluster.Client().CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, false, err
+		}
+		if !nodeReady(node) {
+			return []runtime.Object{node}, false, nil
+		}
+	}
+
+	return nil, true, nil
+}
+
+func (a *Addon) DumpDiagnostics(context.Context, clusters.Cluster) (map[string][]byte, error) {
+	diagnostics := make(map[string][]byte)
+	return diagnostics, nil
+}
@@ -0,0 +1,54 @@
+package kwok
+
+import (
+	"fmt"
+
+	"github.com/blang/semver/v4"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/manifests"
+)
+
+// -----------------------------------------------------------------------------
+// kwok Addon - Builder
+// -----------------------------------------------------------------------------
+
+// Builder is a configuration tool to generate kwok cluster addons.
+type Builder struct {
+	version semver.Version
+	nodes   int
+}
+
+// NewBuilder provides a new Builder object for configuring kwok cluster addons.
+func NewBuilder() *Builder {
+	return &Builder{
+		version: DefaultVersion,
+		nodes:   DefaultNodes,
+	}
+}
+
+// WithVersion configures the version of kwok which should be deployed.
+func (b *Builder) WithVersion(version semver.Version) *Builder {
+	b.version = version
+	return b
+}
+
+// WithNodes configures the number of simulated nodes which are registered
+// once kwok is deployed.
+func (b *Builder) WithNodes(count int) *Builder {
+	b.nodes = count
+	return b
+}
+
+// Build generates a new kwok cluster.Addon which can be loaded and deployed
+// into a test Environment's cluster.Cluster.
+func (b *Builder) Build() *Addon {
+	// the stages make simulated nodes and pods become ready immediately.
+	return &Addon{
+		version: b.version,
+		nodes:   b.nodes,
+		manifests: manifests.NewBuilder(AddonName).
+			WithURL(fmt.Sprintf(manifestsURL, b.version, "kwok.yaml")).
+			WithURL(fmt.Sprintf(manifestsURL, b.version, "stage-fast.yaml")).
+			Build(),
+	}
+}
@@ -0,0 +1,108 @@
+package kwok
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+)
+
+// -----------------------------------------------------------------------------
+// kwok Addon - Simulated Nodes
+// -----------------------------------------------------------------------------
+
+const (
+	// NodeAnnotation is the annotation which makes kwok manage a node.
+	NodeAnnotation = "kwok.x-k8s.io/node"
+
+	// NodeLabel is the label simulated nodes created by the addon have,
+	// with the value "kwok".
+	NodeLabel = "type"
+
+	// NodeTaintKey is the key of the taint simulated nodes have, so that
+	// only pods tolerating it (see ConfigurePodSpec) are scheduled to them.
+	NodeTaintKey = "kwok.x-k8s.io/node"
+)
+
+// NodeName provides the name of the simulated node with the given index.
+func NodeName(index int) string {
+	return fmt.Sprintf("kwok-node-%d", index)
+}
+
+// NewNode generates a simulated node with the given name, which kwok manages
+// once created.
+func NewNode(name string) *corev1.Node {
+	capacity := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("32"),
+		corev1.ResourceMemory: resource.MustParse("256Gi"),
+		corev1.ResourcePods:   resource.MustParse("110"),
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Annotations: map[string]string{
+				NodeAnnotation: "fake",
+			},
+			Labels: map[string]string{
+				NodeLabel:                       "kwok",
+				corev1.LabelHostname:            name,
+				corev1.LabelOSStable:            "linux",
+				corev1.LabelArchStable:          "amd64",
+				"node-role.kubernetes.io/agent": "",
+			},
+		},
+		Spec: corev1.NodeSpec{
+			Taints: []corev1.Taint{{
+				Key:    NodeTaintKey,
+				Value:  "fake",
+				Effect: corev1.TaintEffectNoSchedule,
+			}},
+		},
+		Status: corev1.NodeStatus{
+			Capacity:    capacity,
+			Allocatable: capacity,
+		},
+	}
+}
+
+// CreateNodes registers simulated nodes with the given names.
+func CreateNodes(ctx context.Context, cluster clusters.Cluster, names ...string) error {
+	for _, name := range names {
+		if _, err := cluster.Client().CoreV1().Nodes().Create(ctx, NewNode(name), metav1.CreateOptions{}); err != nil {
+			if !errors.IsAlreadyExists(err) {
+				return fmt.Errorf("could not create simulated node %s: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ConfigurePodSpec configures a pod spec (e.g. of a Deployment's template) so
+// that its pods are only scheduled to the simulated nodes. kwok marks these
+// pods as running and assigns them IPs, so they populate Endpoints without
+// running any containers.
+func ConfigurePodSpec(spec *corev1.PodSpec) {
+	if spec.NodeSelector == nil {
+		spec.NodeSelector = make(map[string]string, 1)
+	}
+	spec.NodeSelector[NodeLabel] = "kwok"
+	spec.Tolerations = append(spec.Tolerations, corev1.Toleration{
+		Key:      NodeTaintKey,
+		Operator: corev1.TolerationOpExists,
+		Effect:   corev1.TaintEffectNoSchedule,
+	})
+}
+
+func nodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
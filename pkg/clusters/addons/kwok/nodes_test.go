@@ -0,0 +1,31 @@
+package kwok
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestNodes(t *testing.T) {
+	assert.Equal(t, []string{"kwok-node-0", "kwok-node-1"}, NewBuilder().WithNodes(2).Build().Nodes())
+
+	node := NewNode("kwok-node-0")
+	assert.Equal(t, "fake", node.Annotations[NodeAnnotation])
+	assert.Equal(t, "kwok", node.Labels[NodeLabel])
+	assert.False(t, nodeReady(node))
+
+	node.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}}
+	assert.True(t, nodeReady(node))
+}
+
+func TestConfigurePodSpec(t *testing.T) {
+	spec := corev1.PodSpec{}
+	ConfigurePodSpec(&spec)
+	assert.Equal(t, map[string]string{NodeLabel: "kwok"}, spec.NodeSelector)
+
+	// pods configured this way tolerate the simulated nodes' taint
+	node := NewNode("kwok-node-0")
+	assert.Len(t, spec.Tolerations, 1)
+	assert.True(t, spec.Tolerations[0].ToleratesTaint(&node.Spec.Taints[0]))
+}
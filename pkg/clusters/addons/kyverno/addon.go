@@ -0,0 +1,111 @@
+package kyverno
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blang/semver/v4"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kong/kubernetes-testing-framework/internal/utils"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/versions"
+)
+
+// -----------------------------------------------------------------------------
+// Kyverno Addon
+// -----------------------------------------------------------------------------
+
+const (
+	// AddonName indicates the unique name of this addon.
+	AddonName clusters.AddonName = "kyverno"
+
+	// DefaultNamespace indicates the default namespace this addon will be deployed to.
+	DefaultNamespace = "kyverno"
+
+	// HelmRepoURL is the URL of the Kyverno Helm repository.
+	HelmRepoURL = "https://kyverno.github.io/kyverno/"
+
+	// ReleaseName is the name of the Helm release.
+	ReleaseName = "ktf-kyverno"
+)
+
+// Addon is a Kyverno policy engine addon which can be deployed on a
+// clusters.Cluster. A single replica of each Kyverno controller is deployed.
+type Addon struct {
+	chartVersion versions.Selector
+}
+
+// New produces a new clusters.Addon for Kyverno with the default settings.
+func New() *Addon {
+	return NewBuilder().Build()
+}
+
+// Namespace indicates the namespace where the Kyverno addon components are to
+// be deployed and managed.
+func (a *Addon) Namespace() string {
+	return DefaultNamespace
+}
+
+// -----------------------------------------------------------------------------
+// Kyverno Addon - Addon Implementation
+// -----------------------------------------------------------------------------
+
+func (a *Addon) Name() clusters.AddonName {
+	return AddonName
+}
+
+func (a *Addon) Dependencies(_ context.Context, _ clusters.Cluster) []clusters.AddonName {
+	return nil
+}
+
+func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
+	// wait for dependency addons to be ready first
+	if err := clusters.WaitForAddonDependencies(ctx, cluster, a); err != nil {
+		return fmt.Errorf("failure waiting for addon dependencies: %w", err)
+	}
+
+	release := utils.HelmRelease{
+		RepoName:     "kyverno",
+		RepoURL:      HelmRepoURL,
+		Chart:        "kyverno/kyverno",
+		Name:         ReleaseName,
+		Namespace:    DefaultNamespace,
+		ChartVersion: a.chartVersion,
+		Args: []string{
+			"--set", "admissionController.replicas=1",
+			"--set", "backgroundController.replicas=1",
+			"--set", "cleanupController.replicas=1",
+			"--set", "reportsController.replicas=1",
+		},
+	}
+
+	return utils.HelmInstall(ctx, cluster, release)
+}
+
+func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
+	if err := utils.HelmUninstall(ctx, cluster, ReleaseName, DefaultNamespace); err != nil {
+		return err
+	}
+	return clusters.DeleteNamespace(ctx, cluster, DefaultNamespace)
+}
+
+// Upgrade upgrades the chart of a deployed addon to the provided version,
+// retaining the rest of its configuration.
+func (a *Addon) Upgrade(ctx context.Context, cluster clusters.Cluster, version semver.Version) error {
+	a.chartVersion = versions.Exact(version)
+	return a.Deploy(ctx, cluster)
+}
+
+func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) ([]runtime.Object, bool, error) {
+	return utils.IsNamespaceAvailable(ctx, cluster, DefaultNamespace)
+}
+
+func (a *Addon) Conditions(ctx context.Context, cluster clusters.Cluster) (bool, []clusters.Condition, error) {
+	return clusters.AddonConditions(ctx, cluster, a, DefaultNamespace, "")
+}
+
+func (a *Addon) DumpDiagnostics(context.Context, clusters.Cluster) (map[string][]byte, error) {
+	diagnostics := make(map[string][]byte)
+	return diagnostics, nil
+}
@@ -0,0 +1,174 @@
+package kyverno
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+)
+
+// -----------------------------------------------------------------------------
+// Kyverno Addon - Policies
+// -----------------------------------------------------------------------------
+
+// policyPollInterval is how often the status of a policy is checked.
+const policyPollInterval = time.Second
+
+var (
+	// ClusterPolicyGVR is the GroupVersionResource of Kyverno ClusterPolicies.
+	ClusterPolicyGVR = schema.GroupVersionResource{Group: "kyverno.io", Version: "v1", Resource: "clusterpolicies"}
+
+	// PolicyGVR is the GroupVersionResource of (namespaced) Kyverno Policies.
+	PolicyGVR = schema.GroupVersionResource{Group: "kyverno.io", Version: "v1", Resource: "policies"}
+)
+
+// LoadPolicies creates (or updates) all the Kyverno Policies and
+// ClusterPolicies found in the provided (multi-document) YAML and waits for
+// them to become ready, so that they're enforced once this returns.
+func LoadPolicies(ctx context.Context, cluster clusters.Cluster, manifests []byte) ([]*unstructured.Unstructured, error) {
+	policies, err := decodePolicies(manifests)
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicClient, err := cluster.DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, policy := range policies {
+		client := policyClient(dynamicClient, policy)
+		created, err := client.Create(ctx, policy, metav1.CreateOptions{})
+		if apierrors.IsAlreadyExists(err) {
+			var existing *unstructured.Unstructured
+			existing, err = client.Get(ctx, policy.GetName(), metav1.GetOptions{})
+			if err == nil {
+				policy.SetResourceVersion(existing.GetResourceVersion())
+				created, err = client.Update(ctx, policy, metav1.UpdateOptions{})
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not load kyverno policy %s: %w", policy.GetName(), err)
+		}
+		policies[i] = created
+	}
+
+	for _, policy := range policies {
+		if err := waitForPolicyReady(ctx, policyClient(dynamicClient, policy), policy.GetName()); err != nil {
+			return nil, err
+		}
+	}
+
+	return policies, nil
+}
+
+// LoadPoliciesFromFile loads the Kyverno policies found in the YAML file at the
+// given path, see LoadPolicies.
+func LoadPoliciesFromFile(ctx context.Context, cluster clusters.Cluster, path string) ([]*unstructured.Unstructured, error) {
+	manifests, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return LoadPolicies(ctx, cluster, manifests)
+}
+
+// DeletePolicies deletes the provided Kyverno policies, tolerating policies
+// which have already been deleted.
+func DeletePolicies(ctx context.Context, cluster clusters.Cluster, policies ...*unstructured.Unstructured) error {
+	dynamicClient, err := cluster.DynamicClient()
+	if err != nil {
+		return err
+	}
+
+	for _, policy := range policies {
+		if err := policyClient(dynamicClient, policy).Delete(ctx, policy.GetName(), metav1.DeleteOptions{}); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return fmt.Errorf("could not delete kyverno policy %s: %w", policy.GetName(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// decodePolicies decodes the Kyverno policies found in (multi-document) YAML.
+func decodePolicies(manifests []byte) ([]*unstructured.Unstructured, error) {
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(manifests), 4096) //nolint:gomnd
+
+	var policies []*unstructured.Unstructured
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("invalid kyverno policy manifests: %w", err)
+		}
+		if len(obj.Object) == 0 {
+			continue // empty document
+		}
+
+		if obj.GroupVersionKind().Group != ClusterPolicyGVR.Group ||
+			(obj.GetKind() != "ClusterPolicy" && obj.GetKind() != "Policy") {
+			return nil, fmt.Errorf("%s %s is not a kyverno policy", obj.GetKind(), obj.GetName())
+		}
+		policies = append(policies, obj)
+	}
+	return policies, nil
+}
+
+// policyClient provides the dynamic client for the kind of the provided policy.
+func policyClient(dynamicClient dynamic.Interface, policy *unstructured.Unstructured) dynamic.ResourceInterface {
+	if policy.GetKind() == "Policy" {
+		namespace := policy.GetNamespace()
+		if namespace == "" {
+			namespace = metav1.NamespaceDefault
+		}
+		return dynamicClient.Resource(PolicyGVR).Namespace(namespace)
+	}
+	return dynamicClient.Resource(ClusterPolicyGVR)
+}
+
+// waitForPolicyReady waits for the Ready condition of the named policy to be true.
+func waitForPolicyReady(ctx context.Context, client dynamic.ResourceInterface, name string) error {
+	ticker := time.NewTicker(policyPollInterval)
+	defer ticker.Stop()
+
+	for {
+		policy, err := client.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if policyReady(policy) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("kyverno policy %s did not become ready: %w", name, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// policyReady indicates whether the Ready condition of the provided policy is true.
+func policyReady(policy *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(policy.Object, "status", "conditions")
+	for _, c := range conditions {
+		if condition, ok := c.(map[string]interface{}); ok && condition["type"] == "Ready" {
+			return condition["status"] == string(metav1.ConditionTrue)
+		}
+	}
+	return false
+}
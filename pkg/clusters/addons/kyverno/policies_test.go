@@ -0,0 +1,48 @@
+package kyverno
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestDecodePolicies(t *testing.T) {
+	policies, err := decodePolicies([]byte(`---
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-labels
+spec:
+  validationFailureAction: Enforce
+---
+apiVersion: kyverno.io/v1
+kind: Policy
+metadata:
+  name: disallow-latest
+  namespace: kong
+`))
+	require.NoError(t, err)
+	require.Len(t, policies, 2)
+	assert.Equal(t, "require-labels", policies[0].GetName())
+	assert.Equal(t, "Policy", policies[1].GetKind())
+	assert.Equal(t, "kong", policies[1].GetNamespace())
+
+	_, err = decodePolicies([]byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: not-a-policy
+`))
+	require.Error(t, err)
+}
+
+func TestPolicyReady(t *testing.T) {
+	policy := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	assert.False(t, policyReady(policy))
+
+	require.NoError(t, unstructured.SetNestedSlice(policy.Object, []interface{}{
+		map[string]interface{}{"type": "Ready", "status": "True"},
+	}, "status", "conditions"))
+	assert.True(t, policyReady(policy))
+}
@@ -0,0 +1,211 @@
+package linkerd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/blang/semver/v4"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kong/kubernetes-testing-framework/internal/retry"
+	"github.com/kong/kubernetes-testing-framework/internal/utils"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/versions"
+)
+
+// -----------------------------------------------------------------------------
+// Linkerd Addon
+// -----------------------------------------------------------------------------
+
+const (
+	// AddonName indicates the unique name of this addon.
+	AddonName clusters.AddonName = "linkerd"
+
+	// DefaultNamespace indicates the default namespace this addon will be deployed to.
+	DefaultNamespace = "linkerd"
+
+	// HelmRepoURL is the URL of the Linkerd stable Helm repository.
+	HelmRepoURL = "https://helm.linkerd.io/stable"
+
+	// InjectAnnotation is the annotation which enables (with the value
+	// "enabled") or disables (with the value "disabled") Linkerd proxy
+	// injection for the annotated namespace or pod template.
+	InjectAnnotation = "linkerd.io/inject"
+
+	crdsReleaseName         = "linkerd-crds"
+	controlPlaneReleaseName = "linkerd-control-plane"
+)
+
+// Addon is a Linkerd service mesh addon which can be deployed on a
+// clusters.Cluster. The identity certificates the control plane requires are
+// generated on deployment.
+type Addon struct {
+	chartVersion        versions.Selector
+	injectionNamespaces []string
+}
+
+// New produces a new clusters.Addon for Linkerd with the default settings.
+func New() *Addon {
+	return NewBuilder().Build()
+}
+
+// Namespace indicates the namespace where the Linkerd addon components are to
+// be deployed and managed.
+func (a *Addon) Namespace() string {
+	return DefaultNamespace
+}
+
+// EnableMeshForNamespace annotates the named namespace so that Linkerd
+// proxies are injected into all pods subsequently created in it.
+func (a *Addon) EnableMeshForNamespace(ctx context.Context, cluster clusters.Cluster, name string) error {
+	// conflicts mean an update happened since we pulled the namespace, so the
+	// whole get and update is retried.
+	err := retry.OnTransient(ctx, clusters.Logger(cluster), "enabling mesh for namespace "+name, func(ctx context.Context) error {
+		namespace, err := cluster.Client().CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		metav1.SetMetaDataAnnotation(&namespace.ObjectMeta, InjectAnnotation, "enabled")
+		_, err = cluster.Client().CoreV1().Namespaces().Update(ctx, namespace, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("could not enable mesh for namespace %s: %w", name, err)
+	}
+	return nil
+}
+
+// InjectPodTemplate annotates the provided pod template (e.g. of a Deployment)
+// so that the Linkerd proxy is injected into its pods.
+func InjectPodTemplate(template *corev1.PodTemplateSpec) {
+	metav1.SetMetaDataAnnotation(&template.ObjectMeta, InjectAnnotation, "enabled")
+}
+
+// -----------------------------------------------------------------------------
+// Linkerd Addon - Addon Implementation
+// -----------------------------------------------------------------------------
+
+func (a *Addon) Name() clusters.AddonName {
+	return AddonName
+}
+
+func (a *Addon) Dependencies(_ context.Context, _ clusters.Cluster) []clusters.AddonName {
+	return nil
+}
+
+func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
+	// wait for dependency addons to be ready first
+	if err := clusters.WaitForAddonDependencies(ctx, cluster, a); err != nil {
+		return fmt.Errorf("failure waiting for addon dependencies: %w", err)
+	}
+
+	err := utils.HelmInstall(ctx, cluster, utils.HelmRelease{
+		RepoName:     "linkerd",
+		RepoURL:      HelmRepoURL,
+		Chart:        "linkerd/linkerd-crds",
+		Name:         crdsReleaseName,
+		Namespace:    DefaultNamespace,
+		ChartVersion: a.chartVersion,
+	})
+	if err != nil {
+		return fmt.Errorf("could not deploy linkerd CRDs: %w", err)
+	}
+
+	certsDir, err := writeIdentityCertificates()
+	if err != nil {
+		return fmt.Errorf("could not generate linkerd identity certificates: %w", err)
+	}
+	defer os.RemoveAll(certsDir)
+
+	err = utils.HelmInstall(ctx, cluster, utils.HelmRelease{
+		RepoName:     "linkerd",
+		RepoURL:      HelmRepoURL,
+		Chart:        "linkerd/linkerd-control-plane",
+		Name:         controlPlaneReleaseName,
+		Namespace:    DefaultNamespace,
+		ChartVersion: a.chartVersion,
+		Args: []string{
+			"--set-file", "identityTrustAnchorsPEM=" + filepath.Join(certsDir, "ca.crt"),
+			"--set-file", "identity.issuer.tls.crtPEM=" + filepath.Join(certsDir, "issuer.crt"),
+			"--set-file", "identity.issuer.tls.keyPEM=" + filepath.Join(certsDir, "issuer.key"),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("could not deploy linkerd control plane: %w", err)
+	}
+
+	for _, namespace := range a.injectionNamespaces {
+		if err := clusters.CreateNamespace(ctx, cluster, namespace); err != nil {
+			return err
+		}
+		if err := a.EnableMeshForNamespace(ctx, cluster, namespace); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
+	if err := utils.HelmUninstall(ctx, cluster, controlPlaneReleaseName, DefaultNamespace); err != nil {
+		return err
+	}
+	if err := utils.HelmUninstall(ctx, cluster, crdsReleaseName, DefaultNamespace); err != nil {
+		return err
+	}
+	return clusters.DeleteNamespace(ctx, cluster, DefaultNamespace)
+}
+
+// Upgrade upgrades the charts of a deployed addon to the provided version,
+// retaining the rest of its configuration.
+func (a *Addon) Upgrade(ctx context.Context, cluster clusters.Cluster, version semver.Version) error {
+	a.chartVersion = versions.Exact(version)
+	return a.Deploy(ctx, cluster)
+}
+
+func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) ([]runtime.Object, bool, error) {
+	return utils.IsNamespaceAvailable(ctx, cluster, DefaultNamespace)
+}
+
+func (a *Addon) Conditions(ctx context.Context, cluster clusters.Cluster) (bool, []clusters.Condition, error) {
+	return clusters.AddonConditions(ctx, cluster, a, DefaultNamespace, "")
+}
+
+func (a *Addon) DumpDiagnostics(context.Context, clusters.Cluster) (map[string][]byte, error) {
+	diagnostics := make(map[string][]byte)
+	return diagnostics, nil
+}
+
+// -----------------------------------------------------------------------------
+// Linkerd Addon - Private
+// -----------------------------------------------------------------------------
+
+// writeIdentityCertificates generates the identity certificates and writes
+// them to a temporary directory for the "--set-file" values of the release,
+// which the caller is responsible for removing.
+func writeIdentityCertificates() (string, error) {
+	certs, err := generateIdentityCertificates()
+	if err != nil {
+		return "", err
+	}
+
+	dir, err := os.MkdirTemp("", "ktf-linkerd-")
+	if err != nil {
+		return "", err
+	}
+	for name, data := range map[string][]byte{
+		"ca.crt":     certs.trustAnchorPEM,
+		"issuer.crt": certs.issuerCertPEM,
+		"issuer.key": certs.issuerKeyPEM,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil { //nolint:gomnd
+			os.RemoveAll(dir)
+			return "", err
+		}
+	}
+	return dir, nil
+}
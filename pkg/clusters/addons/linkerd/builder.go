@@ -0,0 +1,52 @@
+package linkerd
+
+import (
+	"github.com/blang/semver/v4"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/versions"
+)
+
+// -----------------------------------------------------------------------------
+// Linkerd Addon - Builder
+// -----------------------------------------------------------------------------
+
+// Builder is a configuration tool to generate Linkerd cluster addons.
+type Builder struct {
+	chartVersion        versions.Selector
+	injectionNamespaces []string
+}
+
+// NewBuilder provides a new Builder object for configuring Linkerd cluster addons.
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// WithVersion pins the version of the Linkerd charts which should be
+// deployed, otherwise the latest stable release is used.
+func (b *Builder) WithVersion(version semver.Version) *Builder {
+	b.chartVersion = versions.Exact(version)
+	return b
+}
+
+// WithMinorVersion selects the latest patch release of the given minor version
+// of the Linkerd charts which should be deployed.
+func (b *Builder) WithMinorVersion(major, minor uint64) *Builder {
+	b.chartVersion = versions.LatestPatch(major, minor)
+	return b
+}
+
+// WithProxyInjection configures the addon to create (if needed) the given
+// namespaces and enable Linkerd proxy injection for them once deployed.
+func (b *Builder) WithProxyInjection(namespaces ...string) *Builder {
+	b.injectionNamespaces = append(b.injectionNamespaces, namespaces...)
+	return b
+}
+
+// Build generates a new Linkerd cluster.Addon which can be loaded and deployed
+// into a test Environment's cluster.Cluster.
+func (b *Builder) Build() *Addon {
+	return &Addon{
+		chartVersion:        b.chartVersion,
+		injectionNamespaces: b.injectionNamespaces,
+	}
+}
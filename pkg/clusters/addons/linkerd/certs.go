@@ -0,0 +1,83 @@
+package linkerd
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"time"
+)
+
+// identityCertificates are the certificates the Linkerd identity service
+// needs to issue certificates to the proxies.
+type identityCertificates struct {
+	trustAnchorPEM []byte
+	issuerCertPEM  []byte
+	issuerKeyPEM   []byte
+}
+
+// identityValidity is the validity of the generated identity certificates,
+// which only need to outlive a test run.
+const identityValidity = 24 * time.Hour * 365
+
+// generateIdentityCertificates generates a self-signed trust anchor and an
+// issuer certificate signed by it, as the Linkerd charts require them to be
+// provided.
+func generateIdentityCertificates() (identityCertificates, error) {
+	now := time.Now()
+
+	anchorKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return identityCertificates{}, err
+	}
+	anchorTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "root.linkerd.cluster.local"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(identityValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLen:            1,
+	}
+	anchorDER, err := x509.CreateCertificate(rand.Reader, anchorTemplate, anchorTemplate, &anchorKey.PublicKey, anchorKey)
+	if err != nil {
+		return identityCertificates{}, err
+	}
+	anchor, err := x509.ParseCertificate(anchorDER)
+	if err != nil {
+		return identityCertificates{}, err
+	}
+
+	issuerKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return identityCertificates{}, err
+	}
+	issuerTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(2), //nolint:gomnd
+		Subject:               pkix.Name{CommonName: "identity.linkerd.cluster.local"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(identityValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	issuerDER, err := x509.CreateCertificate(rand.Reader, issuerTemplate, anchor, &issuerKey.PublicKey, anchorKey)
+	if err != nil {
+		return identityCertificates{}, err
+	}
+	issuerKeyDER, err := x509.MarshalECPrivateKey(issuerKey)
+	if err != nil {
+		return identityCertificates{}, err
+	}
+
+	return identityCertificates{
+		trustAnchorPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: anchorDER}),
+		issuerCertPEM:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: issuerDER}),
+		issuerKeyPEM:   pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: issuerKeyDER}),
+	}, nil
+}
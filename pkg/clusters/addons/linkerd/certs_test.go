@@ -0,0 +1,30 @@
+package linkerd
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateIdentityCertificates(t *testing.T) {
+	certs, err := generateIdentityCertificates()
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	require.True(t, roots.AppendCertsFromPEM(certs.trustAnchorPEM))
+
+	block, _ := pem.Decode(certs.issuerCertPEM)
+	require.NotNil(t, block)
+	issuer, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	require.True(t, issuer.IsCA)
+	_, err = issuer.Verify(x509.VerifyOptions{Roots: roots})
+	require.NoError(t, err)
+
+	block, _ = pem.Decode(certs.issuerKeyPEM)
+	require.NotNil(t, block)
+	_, err = x509.ParseECPrivateKey(block.Bytes)
+	require.NoError(t, err)
+}
@@ -0,0 +1,246 @@
+package loki
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/blang/semver/v4"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kong/kubernetes-testing-framework/internal/utils"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/versions"
+)
+
+// -----------------------------------------------------------------------------
+// Loki Addon
+// -----------------------------------------------------------------------------
+
+const (
+	// AddonName indicates the unique name of this addon.
+	AddonName clusters.AddonName = "loki"
+
+	// DefaultNamespace indicates the default namespace this addon will be deployed to.
+	DefaultNamespace = "loki"
+
+	// HelmRepoURL is the URL of the Grafana Helm repository.
+	HelmRepoURL = "https://grafana.github.io/helm-charts"
+
+	// ReleaseName is the name of the Helm release.
+	ReleaseName = "ktf-loki"
+
+	// ServiceName is the name of the Loki service.
+	ServiceName = ReleaseName
+
+	// ServicePort is the port of the Loki service.
+	ServicePort = 3100
+
+	// defaultQueryLimit is the maximum number of log lines a query provides.
+	defaultQueryLimit = 5000
+)
+
+// Addon is a Loki addon (using the loki-stack chart) which deploys a single
+// Loki instance and promtail on every node, collecting the logs of all the
+// containers in the cluster.
+type Addon struct {
+	chartVersion versions.Selector
+	values       map[string]string
+}
+
+// New produces a new clusters.Addon for Loki with the default settings.
+func New() *Addon {
+	return NewBuilder().Build()
+}
+
+// Namespace indicates the namespace where the Loki addon components are to be
+// deployed and managed.
+func (a *Addon) Namespace() string {
+	return DefaultNamespace
+}
+
+// -----------------------------------------------------------------------------
+// Loki Addon - Addon Implementation
+// -----------------------------------------------------------------------------
+
+func (a *Addon) Name() clusters.AddonName {
+	return AddonName
+}
+
+func (a *Addon) Dependencies(_ context.Context, _ clusters.Cluster) []clusters.AddonName {
+	return nil
+}
+
+func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
+	// wait for dependency addons to be ready first
+	if err := clusters.WaitForAddonDependencies(ctx, cluster, a); err != nil {
+		return fmt.Errorf("failure waiting for addon dependencies: %w", err)
+	}
+
+	release := utils.HelmRelease{
+		RepoName:     "grafana",
+		RepoURL:      HelmRepoURL,
+		Chart:        "grafana/loki-stack",
+		Name:         ReleaseName,
+		Namespace:    DefaultNamespace,
+		ChartVersion: a.chartVersion,
+		Args:         a.helmValues(),
+	}
+
+	return utils.HelmInstall(ctx, cluster, release)
+}
+
+func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
+	if err := utils.HelmUninstall(ctx, cluster, ReleaseName, DefaultNamespace); err != nil {
+		return err
+	}
+	return clusters.DeleteNamespace(ctx, cluster, DefaultNamespace)
+}
+
+// Upgrade upgrades the chart of a deployed addon to the provided version,
+// retaining the rest of its configuration.
+func (a *Addon) Upgrade(ctx context.Context, cluster clusters.Cluster, version semver.Version) error {
+	a.chartVersion = versions.Exact(version)
+	return a.Deploy(ctx, cluster)
+}
+
+func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) ([]runtime.Object, bool, error) {
+	return utils.IsNamespaceAvailable(ctx, cluster, DefaultNamespace)
+}
+
+func (a *Addon) Conditions(ctx context.Context, cluster clusters.Cluster) (bool, []clusters.Condition, error) {
+	return clusters.AddonConditions(ctx, cluster, a, DefaultNamespace, "")
+}
+
+func (a *Addon) DumpDiagnostics(context.Context, clusters.Cluster) (map[string][]byte, error) {
+	diagnostics := make(map[string][]byte)
+	return diagnostics, nil
+}
+
+// -----------------------------------------------------------------------------
+// Loki Addon - Queries
+// -----------------------------------------------------------------------------
+
+// LogLine is a single log line found by a LogQL query.
+type LogLine struct {
+	// Labels are the labels of the stream the line belongs to (e.g.
+	// namespace, pod and container).
+	Labels map[string]string
+
+	// Timestamp is the time the line was emitted at.
+	Timestamp time.Time
+
+	// Line is the log line.
+	Line string
+}
+
+// Query runs a LogQL log query (e.g. `{namespace="kong"} |= "error"`) against
+// the Loki instance deployed by the addon, accessed through the Kubernetes API
+// server service proxy, and provides the matching lines emitted within the
+// given duration ordered from oldest to newest.
+//
+// Note that promtail ships logs asynchronously, so tests should retry the
+// query until the expected lines are found.
+func (a *Addon) Query(ctx context.Context, cluster clusters.Cluster, query string, since time.Duration) ([]LogLine, error) {
+	now := time.Now()
+	params := map[string]string{
+		"query":     query,
+		"start":     strconv.FormatInt(now.Add(-since).UnixNano(), 10),
+		"end":       strconv.FormatInt(now.UnixNano(), 10),
+		"limit":     strconv.Itoa(defaultQueryLimit),
+		"direction": "forward",
+	}
+
+	raw, err := cluster.Client().CoreV1().Services(DefaultNamespace).
+		ProxyGet("http", ServiceName, strconv.Itoa(ServicePort), "/loki/api/v1/query_range", params).
+		DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loki query %q failed: %w", query, err)
+	}
+	return parseQueryResponse(raw)
+}
+
+type queryResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Stream map[string]string `json:"stream"`
+			Values [][2]string       `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// parseQueryResponse parses a Loki HTTP API query_range response for a log query.
+func parseQueryResponse(raw []byte) ([]LogLine, error) {
+	resp := queryResponse{}
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("invalid loki response: %w", err)
+	}
+	if resp.Status != "success" {
+		return nil, fmt.Errorf("loki query failed with status %q", resp.Status)
+	}
+	if resp.Data.ResultType != "streams" {
+		return nil, fmt.Errorf("unsupported loki result type %q, only log queries are supported", resp.Data.ResultType)
+	}
+
+	var lines []LogLine
+	for _, stream := range resp.Data.Result {
+		for _, value := range stream.Values {
+			ns, err := strconv.ParseInt(value[0], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid loki timestamp %q: %w", value[0], err)
+			}
+			lines = append(lines, LogLine{
+				Labels:    stream.Stream,
+				Timestamp: time.Unix(0, ns),
+				Line:      value[1],
+			})
+		}
+	}
+
+	// lines are ordered within streams, but not across them
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].Timestamp.Before(lines[j].Timestamp)
+	})
+	return lines, nil
+}
+
+// -----------------------------------------------------------------------------
+// Loki Addon - Private
+// -----------------------------------------------------------------------------
+
+// minimalValues only deploy Loki and promtail from the loki-stack chart.
+var minimalValues = map[string]string{
+	"loki.enabled":       "true",
+	"promtail.enabled":   "true",
+	"grafana.enabled":    "false",
+	"prometheus.enabled": "false",
+	"fluent-bit.enabled": "false",
+}
+
+// helmValues provides the "--set" arguments for the chart.
+func (a *Addon) helmValues() []string {
+	values := make(map[string]string, len(minimalValues)+len(a.values))
+	for k, v := range minimalValues {
+		values[k] = v
+	}
+	for k, v := range a.values {
+		values[k] = v
+	}
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	args := make([]string, 0, len(keys)*2) //nolint:gomnd
+	for _, k := range keys {
+		args = append(args, "--set", fmt.Sprintf("%s=%s", k, values[k]))
+	}
+	return args
+}
@@ -0,0 +1,31 @@
+package loki
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQueryResponse(t *testing.T) {
+	lines, err := parseQueryResponse([]byte(`{
+		"status": "success",
+		"data": {
+			"resultType": "streams",
+			"result": [
+				{"stream": {"container": "proxy"}, "values": [["1700000000000000002", "GET /foo 200"]]},
+				{"stream": {"container": "ingress-controller"}, "values": [["1700000000000000001", "sync ok"], ["1700000000000000003", "sync failed"]]}
+			]
+		}
+	}`))
+	require.NoError(t, err)
+	require.Len(t, lines, 3)
+	assert.Equal(t, "sync ok", lines[0].Line)
+	assert.Equal(t, "GET /foo 200", lines[1].Line)
+	assert.Equal(t, map[string]string{"container": "proxy"}, lines[1].Labels)
+	assert.Equal(t, time.Unix(0, 1700000000000000003), lines[2].Timestamp)
+
+	_, err = parseQueryResponse([]byte(`{"status": "success", "data": {"resultType": "matrix", "result": []}}`))
+	require.Error(t, err)
+}
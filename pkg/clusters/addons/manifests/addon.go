@@ -0,0 +1,131 @@
+package manifests
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kong/kubernetes-testing-framework/internal/utils"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/images"
+	kubemanifests "github.com/kong/kubernetes-testing-framework/pkg/utils/kubernetes/manifests"
+)
+
+// -----------------------------------------------------------------------------
+// Manifests Addon
+// -----------------------------------------------------------------------------
+
+// FieldManager is the field manager used when applying manifests.
+const FieldManager = kubemanifests.FieldManager
+
+// Addon is a generic addon which applies raw manifests from URLs, local paths,
+// filesystems (e.g. an embed.FS) or strings using the Kubernetes API. The
+// objects it creates are tracked and deleted again when the addon is deleted,
+// objects which already existed are updated but left in place. The images of
+// containers are pulled from the configured registry mirrors (see images.Mirror).
+type Addon struct {
+	name         clusters.AddonName
+	sources      []kubemanifests.Source
+	dependencies []clusters.AddonName
+
+	// created are the objects which were created on deploy.
+	created []kubemanifests.ObjectReference
+	// namespaces are the namespaces of the objects which were applied on deploy.
+	namespaces []string
+}
+
+// -----------------------------------------------------------------------------
+// Manifests Addon - Addon Implementation
+// -----------------------------------------------------------------------------
+
+func (a *Addon) Name() clusters.AddonName {
+	return a.name
+}
+
+func (a *Addon) Dependencies(_ context.Context, _ clusters.Cluster) []clusters.AddonName {
+	return a.dependencies
+}
+
+func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
+	// wait for dependency addons to be ready first
+	if err := clusters.WaitForAddonDependencies(ctx, cluster, a); err != nil {
+		return fmt.Errorf("failure waiting for addon dependencies: %w", err)
+	}
+
+	objects, err := kubemanifests.Load(ctx, a.sources...)
+	if err != nil {
+		return err
+	}
+	c, err := kubemanifests.NewClient(cluster.Config())
+	if err != nil {
+		return err
+	}
+
+	namespaces := make(map[string]struct{})
+	for _, obj := range objects {
+		images.MirrorObject(obj.Object)
+		ref, created, err := c.Apply(ctx, obj)
+		if err != nil {
+			return fmt.Errorf("could not apply %s %s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		if created {
+			a.created = append(a.created, ref)
+		}
+		if obj.GetKind() == "Namespace" {
+			namespaces[obj.GetName()] = struct{}{}
+		} else if ref.Namespace != "" {
+			namespaces[ref.Namespace] = struct{}{}
+		}
+	}
+
+	a.namespaces = make([]string, 0, len(namespaces))
+	for namespace := range namespaces {
+		a.namespaces = append(a.namespaces, namespace)
+	}
+	sort.Strings(a.namespaces)
+
+	return nil
+}
+
+// Delete deletes the objects created on deploy in reverse order. If the addon
+// wasn't deployed by this process all the objects of the manifests are deleted.
+func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
+	c, err := kubemanifests.NewClient(cluster.Config())
+	if err != nil {
+		return err
+	}
+
+	if len(a.created) == 0 {
+		objects, err := kubemanifests.Load(ctx, a.sources...)
+		if err != nil {
+			return err
+		}
+		return c.DeleteObjects(ctx, objects)
+	}
+
+	for i := len(a.created) - 1; i >= 0; i-- {
+		if err := c.Delete(ctx, a.created[i]); err != nil {
+			return fmt.Errorf("could not delete %s: %w", a.created[i], err)
+		}
+	}
+	a.created = nil
+
+	return nil
+}
+
+func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) ([]runtime.Object, bool, error) {
+	for _, namespace := range a.namespaces {
+		waitingForObjects, ready, err := utils.IsNamespaceAvailable(ctx, cluster, namespace)
+		if err != nil || !ready {
+			return waitingForObjects, ready, err
+		}
+	}
+	return nil, true, nil
+}
+
+func (a *Addon) DumpDiagnostics(context.Context, clusters.Cluster) (map[string][]byte, error) {
+	diagnostics := make(map[string][]byte)
+	return diagnostics, nil
+}
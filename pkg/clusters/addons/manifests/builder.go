@@ -0,0 +1,73 @@
+package manifests
+
+import (
+	"io/fs"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	kubemanifests "github.com/kong/kubernetes-testing-framework/pkg/utils/kubernetes/manifests"
+)
+
+// -----------------------------------------------------------------------------
+// Manifests Addon - Builder
+// -----------------------------------------------------------------------------
+
+// Builder is a configuration tool to generate Manifests cluster addons.
+type Builder struct {
+	name         clusters.AddonName
+	sources      []kubemanifests.Source
+	dependencies []clusters.AddonName
+}
+
+// NewBuilder provides a new Builder object for configuring a Manifests cluster
+// addon with the given unique name. Sources are applied in the order they are
+// configured in.
+func NewBuilder(name clusters.AddonName) *Builder {
+	return &Builder{
+		name: name,
+	}
+}
+
+// WithURL adds a manifest which is downloaded from the given URL.
+func (b *Builder) WithURL(url string) *Builder {
+	b.sources = append(b.sources, kubemanifests.FromURL(url))
+	return b
+}
+
+// WithPath adds a manifest file, or all the manifest files (.yaml, .yml and
+// .json) of a directory in lexical order.
+func (b *Builder) WithPath(path string) *Builder {
+	b.sources = append(b.sources, kubemanifests.FromPath(path))
+	return b
+}
+
+// WithFS adds the manifest files of a filesystem, e.g. an embed.FS, matching
+// the given glob patterns in the order of the patterns. Without patterns all
+// the manifest files (.yaml, .yml and .json) of the filesystem are added in
+// lexical order.
+func (b *Builder) WithFS(fsys fs.FS, patterns ...string) *Builder {
+	b.sources = append(b.sources, kubemanifests.FromFS(fsys, patterns...))
+	return b
+}
+
+// WithYAML adds a raw YAML manifest, which may contain multiple documents.
+func (b *Builder) WithYAML(manifest string) *Builder {
+	b.sources = append(b.sources, kubemanifests.FromYAML(manifest))
+	return b
+}
+
+// WithDependencies configures addons which need to be ready before the
+// manifests are applied.
+func (b *Builder) WithDependencies(dependencies ...clusters.AddonName) *Builder {
+	b.dependencies = append(b.dependencies, dependencies...)
+	return b
+}
+
+// Build generates a new Manifests cluster.Addon which can be loaded and
+// deployed into a test Environment's cluster.Cluster.
+func (b *Builder) Build() *Addon {
+	return &Addon{
+		name:         b.name,
+		sources:      b.sources,
+		dependencies: b.dependencies,
+	}
+}
@@ -0,0 +1,236 @@
+package metallb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/docker"
+)
+
+// -----------------------------------------------------------------------------
+// Metallb Addon - BGP Mode
+// -----------------------------------------------------------------------------
+
+const (
+	// BGPRouterImage is the FRR image of the BGP router container MetalLB
+	// peers with in BGP mode.
+	BGPRouterImage = "quay.io/frrouting/frr:9.1.0"
+
+	// BGPRouterASN is the autonomous system number of the BGP router container.
+	BGPRouterASN = 64512
+
+	// BGPMetallbASN is the autonomous system number of the MetalLB speakers.
+	BGPMetallbASN = 64513
+
+	bgpPeerName          = "ktf-router"
+	bgpAdvertisementName = "ktf-empty"
+)
+
+var (
+	bgpPeerResource = schema.GroupVersionResource{
+		Group:    "metallb.io",
+		Version:  "v1beta2",
+		Resource: "bgppeers",
+	}
+	bgpaResource = schema.GroupVersionResource{
+		Group:    "metallb.io",
+		Version:  "v1beta1",
+		Resource: "bgpadvertisements",
+	}
+)
+
+// BGPRouterContainerName provides the name of the docker container running the
+// BGP router for the cluster with the given name.
+func BGPRouterContainerName(clusterName string) string {
+	return clusterName + "-bgp-router"
+}
+
+// BGPRoute is a route the BGP router container learned from MetalLB.
+type BGPRoute struct {
+	// Prefix is the announced prefix, e.g. "172.18.255.200/32".
+	Prefix string
+
+	// NextHops are the addresses of the nodes the prefix is routed to.
+	NextHops []string
+}
+
+// BGPRoutes provides the routes the BGP router container of the given cluster
+// learned, which can be used to verify that LoadBalancer addresses are
+// announced when the addon is deployed in BGP mode.
+func BGPRoutes(ctx context.Context, cluster clusters.Cluster) ([]BGPRoute, error) {
+	out, err := docker.RunPrivilegedCommandWithOutput(ctx, BGPRouterContainerName(cluster.Name()),
+		"vtysh", "-c", "show ip route bgp json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve bgp routes: %w", err)
+	}
+	return parseBGPRoutes(out)
+}
+
+// -----------------------------------------------------------------------------
+// Metallb Addon - BGP Mode - Private
+// -----------------------------------------------------------------------------
+
+// deployBGPPeer starts an FRR router container on the docker network of the
+// cluster, which accepts BGP sessions from any address of the network, and
+// configures MetalLB to peer with it and announce all pools.
+func deployBGPPeer(ctx context.Context, cluster clusters.Cluster, dockerNetwork string) error {
+	network, _, err := docker.GetDockerContainerIPNetwork(docker.GetKindContainerID(cluster.Name()), dockerNetwork)
+	if err != nil {
+		return err
+	}
+	if network == nil {
+		return fmt.Errorf("bgp mode requires an IPv4 subnet on docker network %s", dockerNetwork)
+	}
+
+	name := BGPRouterContainerName(cluster.Name())
+	if err := docker.RemoveContainer(ctx, name); err != nil {
+		return err
+	}
+	files := map[string][]byte{
+		"/etc/frr/daemons":  []byte(frrDaemons),
+		"/etc/frr/frr.conf": []byte(frrConfig(network.String())),
+	}
+	if err := docker.StartPrivilegedContainer(ctx, name, BGPRouterImage, dockerNetwork, files); err != nil {
+		return fmt.Errorf("failed to start bgp router: %w", err)
+	}
+	routerIP, err := docker.GetContainerIP(name, dockerNetwork)
+	if err != nil {
+		return err
+	}
+
+	dynamicClient, err := cluster.DynamicClient()
+	if err != nil {
+		return fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	if err := createResource(ctx, dynamicClient.Resource(bgpPeerResource).Namespace(DefaultNamespace), newBGPPeer(routerIP)); err != nil {
+		return err
+	}
+	return createResource(ctx, dynamicClient.Resource(bgpaResource).Namespace(DefaultNamespace), &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": bgpaResource.GroupVersion().String(),
+			"kind":       "BGPAdvertisement",
+			"metadata": map[string]interface{}{
+				"name": bgpAdvertisementName,
+			},
+		},
+	})
+}
+
+// deleteBGPResources deletes the MetalLB BGP configuration and the BGP router
+// container of the cluster.
+func deleteBGPResources(ctx context.Context, cluster clusters.Cluster, dynamicClient dynamic.Interface) error {
+	err := dynamicClient.Resource(bgpaResource).Namespace(DefaultNamespace).Delete(ctx, bgpAdvertisementName, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	err = dynamicClient.Resource(bgpPeerResource).Namespace(DefaultNamespace).Delete(ctx, bgpPeerName, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return docker.RemoveContainer(ctx, BGPRouterContainerName(cluster.Name()))
+}
+
+// newBGPPeer generates a MetalLB BGPPeer for the router with the given address.
+func newBGPPeer(routerIP string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": bgpPeerResource.GroupVersion().String(),
+			"kind":       "BGPPeer",
+			"metadata": map[string]interface{}{
+				"name": bgpPeerName,
+			},
+			"spec": map[string]interface{}{
+				"myASN":       int64(BGPMetallbASN),
+				"peerASN":     int64(BGPRouterASN),
+				"peerAddress": routerIP,
+			},
+		},
+	}
+}
+
+// frrDaemons enables bgpd in addition to the daemons FRR always starts.
+const frrDaemons = `bgpd=yes
+vtysh_enable=yes
+zebra_options="  -A 127.0.0.1 -s 90000000"
+bgpd_options="   -A 127.0.0.1"
+`
+
+// frrConfig generates an FRR configuration accepting BGP sessions from MetalLB
+// speakers with an address in the given subnet.
+func frrConfig(subnet string) string {
+	return fmt.Sprintf(`router bgp %d
+ no bgp ebgp-requires-policy
+ neighbor metallb peer-group
+ neighbor metallb remote-as %d
+ bgp listen range %s peer-group metallb
+ address-family ipv4 unicast
+  neighbor metallb activate
+ exit-address-family
+`, BGPRouterASN, BGPMetallbASN, subnet)
+}
+
+// parseBGPRoutes parses the output of "show ip route bgp json".
+func parseBGPRoutes(raw []byte) ([]BGPRoute, error) {
+	var table map[string][]struct {
+		Prefix   string `json:"prefix"`
+		NextHops []struct {
+			IP string `json:"ip"`
+		} `json:"nexthops"`
+	}
+	if err := json.Unmarshal(raw, &table); err != nil {
+		return nil, fmt.Errorf("invalid bgp routes: %w", err)
+	}
+
+	routes := make([]BGPRoute, 0, len(table))
+	for prefix, entries := range table {
+		route := BGPRoute{Prefix: prefix}
+		for _, entry := range entries {
+			for _, nextHop := range entry.NextHops {
+				if nextHop.IP != "" {
+					route.NextHops = append(route.NextHops, nextHop.IP)
+				}
+			}
+		}
+		sort.Strings(route.NextHops)
+		routes = append(routes, route)
+	}
+	sort.Slice(routes, func(i, j int) bool { return routes[i].Prefix < routes[j].Prefix })
+	return routes, nil
+}
+
+// createResource creates the given object, replacing an existing one, and
+// retries while the CRD or the MetalLB webhook are not available yet.
+func createResource(ctx context.Context, res dynamic.ResourceInterface, obj *unstructured.Unstructured) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	var lastErr error
+	for {
+		_, err := res.Create(ctx, obj, metav1.CreateOptions{})
+		if err == nil {
+			return nil
+		}
+		if apierrors.IsAlreadyExists(err) {
+			// delete the existing resource and recreate it in another round of loop.
+			err = res.Delete(ctx, obj.GetName(), metav1.DeleteOptions{})
+		}
+
+		lastErr = err
+		select {
+		case <-time.After(time.Second):
+		case <-ctx.Done():
+			return fmt.Errorf("failed to create %s %s: %w, last error %v", obj.GetAPIVersion(), obj.GetKind(), ctx.Err(), lastErr)
+		}
+	}
+}
@@ -7,6 +7,9 @@ package metallb
 // Builder is a configuration tool for metallb cluster.Addons.
 type Builder struct {
 	disablePoolCreation bool
+	addresses           []string
+	addressCount        int
+	bgpMode             bool
 }
 
 // NewBuilder provides a new Builder object with default addon settings.
@@ -23,9 +26,37 @@ func (b *Builder) WithIPAddressPoolDisabled() *Builder {
 	return b
 }
 
+// WithAddresses configures explicit addresses for the IP address pool, as
+// ranges ("192.168.1.10-192.168.1.20") or CIDRs ("192.168.1.0/28"), instead
+// of deriving a pool from the upper half of the cluster's docker network.
+// Clusters sharing a docker network need distinct addresses.
+func (b *Builder) WithAddresses(addresses ...string) *Builder {
+	b.addresses = append(b.addresses, addresses...)
+	return b
+}
+
+// WithAddressCount limits a pool derived from the docker network to its first
+// n addresses per IP family. It has no effect on addresses configured with
+// WithAddresses.
+func (b *Builder) WithAddressCount(n int) *Builder {
+	b.addressCount = n
+	return b
+}
+
+// WithBGPMode makes MetalLB announce LoadBalancer addresses via BGP to an FRR
+// router container attached to the cluster's docker network, instead of
+// answering ARP/NDP requests in L2 mode (the default).
+func (b *Builder) WithBGPMode() *Builder {
+	b.bgpMode = true
+	return b
+}
+
 // Build generates an addon with the builder's configuration.
 func (b *Builder) Build() *Addon {
 	return &Addon{
 		disablePoolCreation: b.disablePoolCreation,
+		addresses:           b.addresses,
+		addressCount:        b.addressCount,
+		bgpMode:             b.bgpMode,
 	}
 }
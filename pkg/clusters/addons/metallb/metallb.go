@@ -1,6 +1,7 @@
 package metallb
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"encoding/base64"
@@ -18,7 +19,6 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/client-go/dynamic"
 	"sigs.k8s.io/kustomize/api/types"
 	kustomize "sigs.k8s.io/kustomize/api/types"
 	"sigs.k8s.io/kustomize/kyaml/resid"
@@ -27,6 +27,7 @@ import (
 	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
 	"github.com/kong/kubernetes-testing-framework/pkg/clusters/types/kind"
 	"github.com/kong/kubernetes-testing-framework/pkg/utils/docker"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/images"
 	"github.com/kong/kubernetes-testing-framework/pkg/utils/kubernetes/kubectl"
 )
 
@@ -60,6 +61,9 @@ var (
 
 type Addon struct {
 	disablePoolCreation bool
+	addresses           []string
+	addressCount        int
+	bgpMode             bool
 }
 
 func New() clusters.Addon {
@@ -83,7 +87,12 @@ func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
 		return fmt.Errorf("the metallb addon is currently only supported on %s clusters", kind.KindClusterType)
 	}
 
-	return a.deployMetallbForKindCluster(ctx, cluster, kind.DefaultKindDockerNetwork)
+	dockerNetwork := kind.DefaultKindDockerNetwork
+	if kindCluster, ok := cluster.(*kind.Cluster); ok {
+		dockerNetwork = kindCluster.DockerNetwork()
+	}
+
+	return a.deployMetallbForKindCluster(ctx, cluster, dockerNetwork)
 }
 
 func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
@@ -91,19 +100,25 @@ func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
 		return fmt.Errorf("the metallb addon is currently only supported on %s clusters", kind.KindClusterType)
 	}
 
-	dynamicClient, err := dynamic.NewForConfig(cluster.Config())
+	dynamicClient, err := cluster.DynamicClient()
 	if err != nil {
 		return fmt.Errorf("failed to create dynamic client: %w", err)
 	}
 
-	res := dynamicClient.Resource(l2aResource).Namespace(DefaultNamespace)
-	err = res.Delete(ctx, l2AdvertisementName, metav1.DeleteOptions{})
-	if err != nil {
-		return err
+	if a.bgpMode {
+		if err := deleteBGPResources(ctx, cluster, dynamicClient); err != nil {
+			return err
+		}
+	} else {
+		res := dynamicClient.Resource(l2aResource).Namespace(DefaultNamespace)
+		err = res.Delete(ctx, l2AdvertisementName, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
 	}
-	res = dynamicClient.Resource(ipapResource).Namespace(DefaultNamespace)
+	res := dynamicClient.Resource(ipapResource).Namespace(DefaultNamespace)
 	err = res.Delete(ctx, addressPoolName, metav1.DeleteOptions{})
-	if err != nil {
+	if err != nil && !apierrors.IsNotFound(err) {
 		return err
 	}
 
@@ -134,6 +149,10 @@ func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) ([]runtime.
 	return nil, true, nil
 }
 
+func (a *Addon) Conditions(ctx context.Context, cluster clusters.Cluster) (bool, []clusters.Condition, error) {
+	return clusters.AddonConditions(ctx, cluster, a, DefaultNamespace, "")
+}
+
 func (a *Addon) DumpDiagnostics(context.Context, clusters.Cluster) (map[string][]byte, error) {
 	diagnostics := make(map[string][]byte)
 	return diagnostics, nil
@@ -170,12 +189,21 @@ func (a *Addon) deployMetallbForKindCluster(ctx context.Context, cluster cluster
 
 	// create an ip address pool
 	if !a.disablePoolCreation {
-		if err := createIPAddressPool(ctx, cluster, dockerNetwork); err != nil {
+		addresses, err := a.poolAddresses(cluster, dockerNetwork)
+		if err != nil {
+			return err
+		}
+		if err := createIPAddressPool(ctx, cluster, addresses); err != nil {
 			return err
 		}
 	}
 
-	if err := createL2Advertisement(ctx, cluster); err != nil {
+	// announce the pool's addresses either via BGP or in L2 mode
+	if a.bgpMode {
+		if err := deployBGPPeer(ctx, cluster, dockerNetwork); err != nil {
+			return err
+		}
+	} else if err := createL2Advertisement(ctx, cluster); err != nil {
 		return err
 	}
 
@@ -202,19 +230,39 @@ func (a *Addon) deployMetallbForKindCluster(ctx context.Context, cluster cluster
 	return nil
 }
 
-func createIPAddressPool(ctx context.Context, cluster clusters.Cluster, dockerNetwork string) error {
+// poolAddresses provides the addresses of the IP address pool, which are either
+// configured explicitly or derived from the docker network of the cluster.
+func (a *Addon) poolAddresses(cluster clusters.Cluster, dockerNetwork string) ([]string, error) {
+	if len(a.addresses) > 0 {
+		return a.addresses, nil
+	}
+
 	// get an IP range for the docker container network to use for MetalLB
 	// this returns addresses based on the _Docker network_ the cluster runs on, not the cluster itself. this may,
 	// for example, return IPv4 addresses even for an IPv6-only cluster. although unsupported addresses will be listed
 	// in the IPAddressPool, speaker will not actually assign them if they are not compatible with the cluster network.
 	network, network6, err := docker.GetDockerContainerIPNetwork(docker.GetKindContainerID(cluster.Name()), dockerNetwork)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	// the docker network may only have a subnet for one of the IP families,
+	// e.g. when it was created with a custom subnet.
+	addresses := make([]string, 0, 2) //nolint:gomnd
+	if network != nil {
+		ipStart, ipEnd := getIPRangeForMetallb(*network)
+		ipEnd = limitRange(ipStart, ipEnd, a.addressCount)
+		addresses = append(addresses, fmt.Sprintf("%s-%s", ipStart, ipEnd))
 	}
-	ipStart, ipEnd := getIPRangeForMetallb(*network)
-	ip6Start, ip6End := getIPRangeForMetallb(*network6)
+	if network6 != nil {
+		ip6Start, ip6End := getIPRangeForMetallb(*network6)
+		ip6End = limitRange(ip6Start, ip6End, a.addressCount)
+		addresses = append(addresses, fmt.Sprintf("%s-%s", ip6Start, ip6End))
+	}
+	return addresses, nil
+}
 
-	dynamicClient, err := dynamic.NewForConfig(cluster.Config())
+func createIPAddressPool(ctx context.Context, cluster clusters.Cluster, addresses []string) error {
+	dynamicClient, err := cluster.DynamicClient()
 	if err != nil {
 		return fmt.Errorf("failed to create dynamic client: %w", err)
 	}
@@ -234,10 +282,7 @@ func createIPAddressPool(ctx context.Context, cluster clusters.Cluster, dockerNe
 					"name": addressPoolName,
 				},
 				"spec": map[string]interface{}{
-					"addresses": []string{
-						fmt.Sprintf("%s-%s", ipStart, ipEnd),
-						fmt.Sprintf("%s-%s", ip6Start, ip6End),
-					},
+					"addresses": addresses,
 				},
 			},
 		}, metav1.CreateOptions{})
@@ -263,7 +308,7 @@ func createIPAddressPool(ctx context.Context, cluster clusters.Cluster, dockerNe
 }
 
 func createL2Advertisement(ctx context.Context, cluster clusters.Cluster) error {
-	dynamicClient, err := dynamic.NewForConfig(cluster.Config())
+	dynamicClient, err := cluster.DynamicClient()
 	if err != nil {
 		return fmt.Errorf("failed to create dynamic client: %w", err)
 	}
@@ -325,6 +370,19 @@ func getIPRangeForMetallb(network net.IPNet) (startIP, endIP netip.Addr) {
 	return halfRange.From().Next(), halfRange.To().Prev()
 }
 
+// limitRange provides the end of the range starting at startIP which holds at
+// most n addresses and does not exceed endIP. A non-positive n keeps endIP.
+func limitRange(startIP, endIP netip.Addr, n int) netip.Addr {
+	if n <= 0 {
+		return endIP
+	}
+	last := startIP
+	for i := 1; i < n && last.Less(endIP); i++ {
+		last = last.Next()
+	}
+	return last
+}
+
 // TODO: needs to be replaced with non-kubectl, just used this originally for speed.
 //
 // See: https://github.com/Kong/kubernetes-testing-framework/issues/25
@@ -362,6 +420,20 @@ func getManifest() (io.Reader, error) {
 	})
 }
 
+// mirrorManifest rewrites the images of the manifest to be pulled from the
+// configured registry mirrors.
+func mirrorManifest(manifest io.Reader) (io.Reader, error) {
+	b, err := io.ReadAll(manifest)
+	if err != nil {
+		return nil, err
+	}
+	b, err = images.MirrorManifest(b)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(b), nil
+}
+
 func metallbDeployHack(ctx context.Context, cluster clusters.Cluster) error {
 	// generate a temporary kubeconfig since we're going to be using kubectl
 	kubeconfig, err := clusters.TempKubeconfig(cluster)
@@ -380,6 +452,10 @@ func metallbDeployHack(ctx context.Context, cluster clusters.Cluster) error {
 	if err != nil {
 		return fmt.Errorf("could not deploy metallb: %w", err)
 	}
+	manifest, err = mirrorManifest(manifest)
+	if err != nil {
+		return fmt.Errorf("could not deploy metallb: %w", err)
+	}
 
 	// ensure the repo exists
 	return retry.Command("kubectl", deployArgs...).
@@ -391,7 +467,7 @@ func metallbDeployHack(ctx context.Context, cluster clusters.Cluster) error {
 func metallbDeleteHack(ctx context.Context, kubeconfig *os.File) error {
 	deployArgs := []string{
 		"--kubeconfig", kubeconfig.Name(),
-		"delete", "-f", "-",
+		"delete", "--ignore-not-found", "-f", "-",
 	}
 
 	manifest, err := getManifest()
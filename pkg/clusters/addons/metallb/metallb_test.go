@@ -2,9 +2,11 @@ package metallb
 
 import (
 	"net"
+	"net/netip"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestHelperFunctions(t *testing.T) {
@@ -19,3 +21,44 @@ func TestHelperFunctions(t *testing.T) {
 	assert.Equal(t, net.IPv4(192, 168, 1, 129).String(), ip1.String())
 	assert.Equal(t, net.IPv4(192, 168, 1, 254).String(), ip2.String())
 }
+
+func TestLimitRange(t *testing.T) {
+	start, end := netip.MustParseAddr("172.18.128.1"), netip.MustParseAddr("172.18.255.254")
+	assert.Equal(t, "172.18.255.254", limitRange(start, end, 0).String())
+	assert.Equal(t, "172.18.128.1", limitRange(start, end, 1).String())
+	assert.Equal(t, "172.18.128.16", limitRange(start, end, 16).String())
+
+	// the range is never exceeded
+	end = netip.MustParseAddr("172.18.128.4")
+	assert.Equal(t, "172.18.128.4", limitRange(start, end, 16).String())
+
+	start, end = netip.MustParseAddr("fc00:f853:ccd:e793:8000::1"), netip.MustParseAddr("fc00:f853:ccd:e793:ffff:ffff:ffff:fffe")
+	assert.Equal(t, "fc00:f853:ccd:e793:8000::a", limitRange(start, end, 10).String())
+}
+
+func TestParseBGPRoutes(t *testing.T) {
+	raw := []byte(`{
+  "172.18.255.201/32": [{"prefix": "172.18.255.201/32", "protocol": "bgp", "nexthops": [{"ip": "172.18.0.3", "afi": "ipv4"}, {"ip": "172.18.0.2", "afi": "ipv4"}]}],
+  "172.18.255.200/32": [{"prefix": "172.18.255.200/32", "protocol": "bgp", "nexthops": [{"ip": "172.18.0.2", "afi": "ipv4"}]}]
+}`)
+	routes, err := parseBGPRoutes(raw)
+	require.NoError(t, err)
+	assert.Equal(t, []BGPRoute{
+		{Prefix: "172.18.255.200/32", NextHops: []string{"172.18.0.2"}},
+		{Prefix: "172.18.255.201/32", NextHops: []string{"172.18.0.2", "172.18.0.3"}},
+	}, routes)
+
+	routes, err = parseBGPRoutes([]byte(`{}`))
+	require.NoError(t, err)
+	assert.Empty(t, routes)
+
+	_, err = parseBGPRoutes([]byte(`not json`))
+	assert.Error(t, err)
+}
+
+func TestFRRConfig(t *testing.T) {
+	config := frrConfig("172.18.0.0/16")
+	assert.Contains(t, config, "router bgp 64512\n")
+	assert.Contains(t, config, "neighbor metallb remote-as 64513\n")
+	assert.Contains(t, config, "bgp listen range 172.18.0.0/16 peer-group metallb\n")
+}
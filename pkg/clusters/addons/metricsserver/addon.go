@@ -0,0 +1,142 @@
+package metricsserver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blang/semver/v4"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kong/kubernetes-testing-framework/internal/utils"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/types/kind"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/versions"
+)
+
+// -----------------------------------------------------------------------------
+// Metrics Server Addon
+// -----------------------------------------------------------------------------
+
+const (
+	// AddonName indicates the unique name of this addon.
+	AddonName clusters.AddonName = "metrics-server"
+
+	// DefaultNamespace indicates the default namespace this addon will be deployed to.
+	DefaultNamespace = "metrics-server"
+
+	// HelmRepoURL is the URL of the metrics-server Helm repository.
+	HelmRepoURL = "https://kubernetes-sigs.github.io/metrics-server/"
+
+	// ReleaseName is the name of the Helm release.
+	ReleaseName = "ktf-metrics-server"
+
+	// MetricsGroupVersion is the aggregated API served by metrics-server which
+	// the HorizontalPodAutoscaler controller consumes resource metrics from.
+	MetricsGroupVersion = "metrics.k8s.io/v1beta1"
+)
+
+// Addon is a metrics-server addon which can be deployed on a clusters.Cluster
+// to provide the resource metrics API, as needed by HorizontalPodAutoscalers
+// and "kubectl top".
+type Addon struct {
+	chartVersion       versions.Selector
+	kubeletInsecureTLS bool
+}
+
+// New produces a new clusters.Addon for metrics-server with the default settings.
+func New() *Addon {
+	return NewBuilder().Build()
+}
+
+// Namespace indicates the namespace where the metrics-server addon components
+// are to be deployed and managed.
+func (a *Addon) Namespace() string {
+	return DefaultNamespace
+}
+
+// -----------------------------------------------------------------------------
+// Metrics Server Addon - Addon Implementation
+// -----------------------------------------------------------------------------
+
+func (a *Addon) Name() clusters.AddonName {
+	return AddonName
+}
+
+func (a *Addon) Dependencies(_ context.Context, _ clusters.Cluster) []clusters.AddonName {
+	return nil
+}
+
+func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
+	// wait for dependency addons to be ready first
+	if err := clusters.WaitForAddonDependencies(ctx, cluster, a); err != nil {
+		return fmt.Errorf("failure waiting for addon dependencies: %w", err)
+	}
+
+	// kind kubelets serve with self-signed certificates which metrics-server
+	// can't verify.
+	insecureTLS := a.kubeletInsecureTLS || cluster.Type() == kind.KindClusterType
+
+	release := utils.HelmRelease{
+		RepoName:     "metrics-server",
+		RepoURL:      HelmRepoURL,
+		Chart:        "metrics-server/metrics-server",
+		Name:         ReleaseName,
+		Namespace:    DefaultNamespace,
+		ChartVersion: a.chartVersion,
+		Args:         helmArgs(insecureTLS),
+	}
+
+	return utils.HelmInstall(ctx, cluster, release)
+}
+
+func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
+	if err := utils.HelmUninstall(ctx, cluster, ReleaseName, DefaultNamespace); err != nil {
+		return err
+	}
+	return clusters.DeleteNamespace(ctx, cluster, DefaultNamespace)
+}
+
+// Upgrade upgrades the chart of a deployed addon to the provided version,
+// retaining the rest of its configuration.
+func (a *Addon) Upgrade(ctx context.Context, cluster clusters.Cluster, version semver.Version) error {
+	a.chartVersion = versions.Exact(version)
+	return a.Deploy(ctx, cluster)
+}
+
+func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) ([]runtime.Object, bool, error) {
+	waitingForObjects, ready, err := utils.IsNamespaceAvailable(ctx, cluster, DefaultNamespace)
+	if err != nil || !ready {
+		return waitingForObjects, ready, err
+	}
+
+	// the deployment being available doesn't mean the aggregated API is, and
+	// HPAs silently fail to scale until it is, so check it is being served.
+	if _, err := cluster.Client().Discovery().ServerResourcesForGroupVersion(MetricsGroupVersion); err != nil {
+		return nil, false, nil //nolint:nilerr
+	}
+
+	return nil, true, nil
+}
+
+func (a *Addon) Conditions(ctx context.Context, cluster clusters.Cluster) (bool, []clusters.Condition, error) {
+	return clusters.AddonConditions(ctx, cluster, a, DefaultNamespace, "")
+}
+
+func (a *Addon) DumpDiagnostics(context.Context, clusters.Cluster) (map[string][]byte, error) {
+	diagnostics := make(map[string][]byte)
+	return diagnostics, nil
+}
+
+// -----------------------------------------------------------------------------
+// Metrics Server Addon - Private Functions
+// -----------------------------------------------------------------------------
+
+func helmArgs(kubeletInsecureTLS bool) []string {
+	args := []string{
+		"--set", "args[0]=--kubelet-preferred-address-types=InternalIP\\,Hostname\\,ExternalIP",
+	}
+	if kubeletInsecureTLS {
+		args = append(args, "--set", "args[1]=--kubelet-insecure-tls")
+	}
+	return args
+}
@@ -0,0 +1,18 @@
+package metricsserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHelmArgs(t *testing.T) {
+	assert.Equal(t, []string{
+		"--set", "args[0]=--kubelet-preferred-address-types=InternalIP\\,Hostname\\,ExternalIP",
+	}, helmArgs(false))
+
+	assert.Equal(t, []string{
+		"--set", "args[0]=--kubelet-preferred-address-types=InternalIP\\,Hostname\\,ExternalIP",
+		"--set", "args[1]=--kubelet-insecure-tls",
+	}, helmArgs(true))
+}
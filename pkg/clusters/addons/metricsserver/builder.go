@@ -0,0 +1,54 @@
+package metricsserver
+
+import (
+	"github.com/blang/semver/v4"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/versions"
+)
+
+// -----------------------------------------------------------------------------
+// Metrics Server Addon - Builder
+// -----------------------------------------------------------------------------
+
+// Builder is a configuration tool to generate metrics-server cluster addons.
+type Builder struct {
+	chartVersion       versions.Selector
+	kubeletInsecureTLS bool
+}
+
+// NewBuilder provides a new Builder object for configuring metrics-server
+// cluster addons.
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// WithVersion pins the version of the metrics-server chart which should be
+// deployed, otherwise the latest release is used.
+func (b *Builder) WithVersion(version semver.Version) *Builder {
+	b.chartVersion = versions.Exact(version)
+	return b
+}
+
+// WithMinorVersion selects the latest patch release of the given minor version
+// of the metrics-server chart which should be deployed.
+func (b *Builder) WithMinorVersion(major, minor uint64) *Builder {
+	b.chartVersion = versions.LatestPatch(major, minor)
+	return b
+}
+
+// WithKubeletInsecureTLS configures metrics-server to skip verification of the
+// kubelets' serving certificates, which are self-signed on many test clusters.
+// This is always enabled on kind clusters.
+func (b *Builder) WithKubeletInsecureTLS() *Builder {
+	b.kubeletInsecureTLS = true
+	return b
+}
+
+// Build generates a new metrics-server cluster.Addon which can be loaded and
+// deployed into a test Environment's cluster.Cluster.
+func (b *Builder) Build() *Addon {
+	return &Addon{
+		chartVersion:       b.chartVersion,
+		kubeletInsecureTLS: b.kubeletInsecureTLS,
+	}
+}
@@ -0,0 +1,228 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/kong/kubernetes-testing-framework/internal/utils"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/images"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/kubernetes/generators"
+)
+
+// -----------------------------------------------------------------------------
+// MinIO Addon
+// -----------------------------------------------------------------------------
+
+const (
+	// AddonName indicates the unique name of this addon.
+	AddonName clusters.AddonName = "minio"
+
+	// DefaultNamespace indicates the default namespace this addon will be deployed to.
+	DefaultNamespace = "minio"
+
+	// DefaultAccessKeyID is the default root access key ID of the MinIO server.
+	DefaultAccessKeyID = "ktf-minio"
+
+	// DefaultSecretAccessKey is the default root secret access key of the MinIO server.
+	DefaultSecretAccessKey = "ktf-minio-secret"
+
+	// ServiceName is the name of the MinIO service.
+	ServiceName = "minio"
+
+	// APIPort is the port of the MinIO S3 API.
+	APIPort = 9000
+
+	// ConsolePort is the port of the MinIO console.
+	ConsolePort = 9001
+
+	// Region is the region reported by the MinIO server.
+	Region = "us-east-1"
+
+	minioImage       = "quay.io/minio/minio:latest"
+	mcImage          = "quay.io/minio/mc:latest"
+	createBucketsJob = "minio-create-buckets"
+)
+
+// Credentials are credentials to access the MinIO S3 API.
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// Addon is a MinIO addon, which provides an S3-compatible object storage
+// server with ephemeral storage.
+type Addon struct {
+	credentials Credentials
+	buckets     []string
+}
+
+// New produces a new clusters.Addon for MinIO with the default settings.
+func New() *Addon {
+	return NewBuilder().Build()
+}
+
+// Namespace indicates the namespace where the MinIO addon components are to
+// be deployed and managed.
+func (a *Addon) Namespace() string {
+	return DefaultNamespace
+}
+
+// Endpoint provides the in-cluster URL of the MinIO S3 API.
+func (a *Addon) Endpoint() string {
+	return fmt.Sprintf("http://%s.%s.svc:%d", ServiceName, DefaultNamespace, APIPort)
+}
+
+// Credentials provides the root credentials of the MinIO server.
+func (a *Addon) Credentials() Credentials {
+	return a.credentials
+}
+
+// Buckets provides the buckets created by the addon.
+func (a *Addon) Buckets() []string {
+	return a.buckets
+}
+
+// -----------------------------------------------------------------------------
+// MinIO Addon - Addon Implementation
+// -----------------------------------------------------------------------------
+
+func (a *Addon) Name() clusters.AddonName {
+	return AddonName
+}
+
+func (a *Addon) Dependencies(_ context.Context, _ clusters.Cluster) []clusters.AddonName {
+	return nil
+}
+
+func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
+	// wait for dependency addons to be ready first
+	if err := clusters.WaitForAddonDependencies(ctx, cluster, a); err != nil {
+		return fmt.Errorf("failure waiting for addon dependencies: %w", err)
+	}
+
+	if err := clusters.CreateNamespace(ctx, cluster, DefaultNamespace); err != nil {
+		return err
+	}
+
+	container := generators.NewContainer(ServiceName, minioImage, APIPort)
+	container.Ports[0].Name = "api"
+	container.Ports = append(container.Ports, corev1.ContainerPort{Name: "console", ContainerPort: ConsolePort})
+	container.Args = []string{"server", "/data", fmt.Sprintf("--console-address=:%d", ConsolePort)}
+	container.Env = a.credentialsEnv()
+	container.ReadinessProbe = &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path: "/minio/health/ready",
+				Port: intstr.FromInt(APIPort),
+			},
+		},
+	}
+	deployment := generators.NewDeploymentForContainer(container)
+	deployment.Spec.Template.Spec.Volumes = []corev1.Volume{{
+		Name:         "data",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}}
+	deployment.Spec.Template.Spec.Containers[0].VolumeMounts = []corev1.VolumeMount{{Name: "data", MountPath: "/data"}}
+	if _, err := cluster.Client().AppsV1().Deployments(DefaultNamespace).Create(ctx, deployment, metav1.CreateOptions{}); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	service := generators.NewServiceForDeployment(deployment, corev1.ServiceTypeClusterIP)
+	if _, err := cluster.Client().CoreV1().Services(DefaultNamespace).Create(ctx, service, metav1.CreateOptions{}); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	if len(a.buckets) > 0 {
+		if _, err := cluster.Client().BatchV1().Jobs(DefaultNamespace).Create(ctx, a.createBucketsJob(), metav1.CreateOptions{}); err != nil {
+			if !errors.IsAlreadyExists(err) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
+	return clusters.DeleteNamespace(ctx, cluster, DefaultNamespace)
+}
+
+func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) ([]runtime.Object, bool, error) {
+	waitingForObjects, ready, err := utils.IsNamespaceAvailable(ctx, cluster, DefaultNamespace)
+	if err != nil || !ready || len(a.buckets) == 0 {
+		return waitingForObjects, ready, err
+	}
+
+	job, err := cluster.Client().BatchV1().Jobs(DefaultNamespace).Get(ctx, createBucketsJob, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	if job.Status.Succeeded < 1 {
+		return []runtime.Object{job}, false, nil
+	}
+
+	return nil, true, nil
+}
+
+func (a *Addon) DumpDiagnostics(context.Context, clusters.Cluster) (map[string][]byte, error) {
+	diagnostics := make(map[string][]byte)
+	return diagnostics, nil
+}
+
+// -----------------------------------------------------------------------------
+// MinIO Addon - Private
+// -----------------------------------------------------------------------------
+
+func (a *Addon) credentialsEnv() []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{Name: "MINIO_ROOT_USER", Value: a.credentials.AccessKeyID},
+		{Name: "MINIO_ROOT_PASSWORD", Value: a.credentials.SecretAccessKey},
+	}
+}
+
+// createBucketsJob generates a Job which creates the configured buckets using
+// the MinIO client once the server is reachable.
+func (a *Addon) createBucketsJob() *batchv1.Job {
+	targets := make([]string, 0, len(a.buckets))
+	for _, bucket := range a.buckets {
+		targets = append(targets, "ktf/"+bucket)
+	}
+	script := fmt.Sprintf(`until mc alias set ktf %s "$MINIO_ROOT_USER" "$MINIO_ROOT_PASSWORD"; do sleep 1; done
+mc mb --ignore-existing %s
+`, a.Endpoint(), strings.Join(targets, " "))
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: createBucketsJob,
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:    createBucketsJob,
+						Image:   images.Mirror(mcImage),
+						Command: []string{"/bin/sh", "-c", script},
+						Env:     a.credentialsEnv(),
+					}},
+					RestartPolicy: corev1.RestartPolicyOnFailure,
+				},
+			},
+		},
+	}
+}
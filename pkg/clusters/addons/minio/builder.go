@@ -0,0 +1,46 @@
+package minio
+
+// -----------------------------------------------------------------------------
+// MinIO Addon - Builder
+// -----------------------------------------------------------------------------
+
+// Builder is a configuration tool to generate MinIO cluster addons.
+type Builder struct {
+	credentials Credentials
+	buckets     []string
+}
+
+// NewBuilder provides a new Builder object for configuring MinIO cluster addons.
+func NewBuilder() *Builder {
+	return &Builder{
+		credentials: Credentials{
+			AccessKeyID:     DefaultAccessKeyID,
+			SecretAccessKey: DefaultSecretAccessKey,
+		},
+	}
+}
+
+// WithCredentials configures the root credentials of the MinIO server.
+// The secret access key must be at least 8 characters long.
+func (b *Builder) WithCredentials(accessKeyID, secretAccessKey string) *Builder {
+	b.credentials = Credentials{
+		AccessKeyID:     accessKeyID,
+		SecretAccessKey: secretAccessKey,
+	}
+	return b
+}
+
+// WithBuckets configures buckets which are created once MinIO is deployed.
+func (b *Builder) WithBuckets(buckets ...string) *Builder {
+	b.buckets = append(b.buckets, buckets...)
+	return b
+}
+
+// Build generates a new MinIO cluster.Addon which can be loaded and deployed
+// into a test Environment's cluster.Cluster.
+func (b *Builder) Build() *Addon {
+	return &Addon{
+		credentials: b.credentials,
+		buckets:     b.buckets,
+	}
+}
@@ -0,0 +1,280 @@
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/blang/semver/v4"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kong/kubernetes-testing-framework/internal/utils"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/versions"
+)
+
+// -----------------------------------------------------------------------------
+// Prometheus Addon
+// -----------------------------------------------------------------------------
+
+const (
+	// AddonName indicates the unique name of this addon.
+	AddonName clusters.AddonName = "prometheus"
+
+	// DefaultNamespace indicates the default namespace this addon will be deployed to.
+	DefaultNamespace = "monitoring"
+
+	// HelmRepoURL is the URL of the prometheus-community Helm repository.
+	HelmRepoURL = "https://prometheus-community.github.io/helm-charts"
+
+	// ReleaseName is the name of the Helm release.
+	ReleaseName = "ktf-prometheus"
+
+	// ServiceName is the name of the Prometheus service.
+	ServiceName = "prometheus-stack-prometheus"
+
+	// ServicePort is the port of the Prometheus service.
+	ServicePort = 9090
+)
+
+// Addon is a Prometheus Operator addon (using the kube-prometheus-stack chart)
+// which can be deployed on a clusters.Cluster. Only the operator and a single
+// Prometheus instance selecting all ServiceMonitors and PodMonitors in the
+// cluster are deployed.
+type Addon struct {
+	chartVersion versions.Selector
+	values       map[string]string
+}
+
+// New produces a new clusters.Addon for Prometheus with the default settings.
+func New() *Addon {
+	return NewBuilder().Build()
+}
+
+// Namespace indicates the namespace where the Prometheus addon components are
+// to be deployed and managed.
+func (a *Addon) Namespace() string {
+	return DefaultNamespace
+}
+
+// -----------------------------------------------------------------------------
+// Prometheus Addon - Addon Implementation
+// -----------------------------------------------------------------------------
+
+func (a *Addon) Name() clusters.AddonName {
+	return AddonName
+}
+
+func (a *Addon) Dependencies(_ context.Context, _ clusters.Cluster) []clusters.AddonName {
+	return nil
+}
+
+func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
+	// wait for dependency addons to be ready first
+	if err := clusters.WaitForAddonDependencies(ctx, cluster, a); err != nil {
+		return fmt.Errorf("failure waiting for addon dependencies: %w", err)
+	}
+
+	release := utils.HelmRelease{
+		RepoName:     "prometheus-community",
+		RepoURL:      HelmRepoURL,
+		Chart:        "prometheus-community/kube-prometheus-stack",
+		Name:         ReleaseName,
+		Namespace:    DefaultNamespace,
+		ChartVersion: a.chartVersion,
+		Args:         a.helmValues(),
+	}
+
+	return utils.HelmInstall(ctx, cluster, release)
+}
+
+func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
+	if err := utils.HelmUninstall(ctx, cluster, ReleaseName, DefaultNamespace); err != nil {
+		return err
+	}
+	// the namespace is shared with the kube-state-metrics addon.
+	return clusters.DeleteAddonNamespace(ctx, cluster, a, DefaultNamespace)
+}
+
+// Upgrade upgrades the chart of a deployed addon to the provided version,
+// retaining the rest of its configuration.
+func (a *Addon) Upgrade(ctx context.Context, cluster clusters.Cluster, version semver.Version) error {
+	a.chartVersion = versions.Exact(version)
+	return a.Deploy(ctx, cluster)
+}
+
+func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) ([]runtime.Object, bool, error) {
+	return utils.IsNamespaceAvailable(ctx, cluster, DefaultNamespace)
+}
+
+func (a *Addon) Conditions(ctx context.Context, cluster clusters.Cluster) (bool, []clusters.Condition, error) {
+	return clusters.AddonConditions(ctx, cluster, a, DefaultNamespace, "")
+}
+
+func (a *Addon) DumpDiagnostics(context.Context, clusters.Cluster) (map[string][]byte, error) {
+	diagnostics := make(map[string][]byte)
+	return diagnostics, nil
+}
+
+// -----------------------------------------------------------------------------
+// Prometheus Addon - Queries
+// -----------------------------------------------------------------------------
+
+// Sample is a single sample of an instant PromQL query result.
+type Sample struct {
+	// Metric are the labels of the sample's series.
+	Metric map[string]string
+
+	// Value is the value of the sample.
+	Value float64
+
+	// Timestamp is the time the sample was evaluated at.
+	Timestamp time.Time
+}
+
+// Query runs an instant PromQL query against the Prometheus instance deployed
+// by the addon, accessed through the Kubernetes API server service proxy.
+// Vector and scalar results are supported, a scalar result is provided as
+// a single Sample without labels.
+func (a *Addon) Query(ctx context.Context, cluster clusters.Cluster, query string) ([]Sample, error) {
+	return a.QueryAt(ctx, cluster, query, time.Time{})
+}
+
+// QueryAt runs an instant PromQL query evaluated at the given time, or at the
+// current time if it is zero. See Query.
+func (a *Addon) QueryAt(ctx context.Context, cluster clusters.Cluster, query string, at time.Time) ([]Sample, error) {
+	params := map[string]string{"query": query}
+	if !at.IsZero() {
+		params["time"] = strconv.FormatFloat(float64(at.UnixMilli())/1000, 'f', 3, 64) //nolint:gomnd
+	}
+	raw, err := cluster.Client().CoreV1().Services(DefaultNamespace).
+		ProxyGet("http", ServiceName, strconv.Itoa(ServicePort), "/api/v1/query", params).
+		DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("prometheus query %q failed: %w", query, err)
+	}
+	return parseQueryResponse(raw)
+}
+
+type queryResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// parseQueryResponse parses a Prometheus HTTP API instant query response.
+func parseQueryResponse(raw []byte) ([]Sample, error) {
+	resp := queryResponse{}
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("invalid prometheus response: %w", err)
+	}
+	if resp.Status != "success" {
+		return nil, fmt.Errorf("prometheus query failed: %s", resp.Error)
+	}
+
+	switch resp.Data.ResultType {
+	case "vector":
+		var vector []struct {
+			Metric map[string]string `json:"metric"`
+			Value  []interface{}     `json:"value"`
+		}
+		if err := json.Unmarshal(resp.Data.Result, &vector); err != nil {
+			return nil, fmt.Errorf("invalid prometheus vector result: %w", err)
+		}
+		samples := make([]Sample, 0, len(vector))
+		for _, v := range vector {
+			sample, err := parseSampleValue(v.Value)
+			if err != nil {
+				return nil, err
+			}
+			sample.Metric = v.Metric
+			samples = append(samples, sample)
+		}
+		sort.SliceStable(samples, func(i, j int) bool {
+			return fmt.Sprint(samples[i].Metric) < fmt.Sprint(samples[j].Metric)
+		})
+		return samples, nil
+	case "scalar":
+		var scalar []interface{}
+		if err := json.Unmarshal(resp.Data.Result, &scalar); err != nil {
+			return nil, fmt.Errorf("invalid prometheus scalar result: %w", err)
+		}
+		sample, err := parseSampleValue(scalar)
+		if err != nil {
+			return nil, err
+		}
+		return []Sample{sample}, nil
+	default:
+		return nil, fmt.Errorf("unsupported prometheus result type %q", resp.Data.ResultType)
+	}
+}
+
+// parseSampleValue parses a [<unix timestamp>, "<value>"] pair.
+func parseSampleValue(pair []interface{}) (Sample, error) {
+	if len(pair) != 2 { //nolint:gomnd
+		return Sample{}, fmt.Errorf("invalid prometheus sample %v", pair)
+	}
+	ts, ok := pair[0].(float64)
+	if !ok {
+		return Sample{}, fmt.Errorf("invalid prometheus sample timestamp %v", pair[0])
+	}
+	raw, ok := pair[1].(string)
+	if !ok {
+		return Sample{}, fmt.Errorf("invalid prometheus sample value %v", pair[1])
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return Sample{}, fmt.Errorf("invalid prometheus sample value %q: %w", raw, err)
+	}
+	return Sample{
+		Value:     value,
+		Timestamp: time.UnixMilli(int64(ts * 1000)), //nolint:gomnd
+	}, nil
+}
+
+// -----------------------------------------------------------------------------
+// Prometheus Addon - Private
+// -----------------------------------------------------------------------------
+
+// minimalValues keep the footprint of the kube-prometheus-stack chart minimal
+// and make Prometheus select all monitors in the cluster.
+var minimalValues = map[string]string{
+	"fullnameOverride":         "prometheus-stack",
+	"grafana.enabled":          "false",
+	"alertmanager.enabled":     "false",
+	"nodeExporter.enabled":     "false",
+	"kubeStateMetrics.enabled": "false",
+	"defaultRules.create":      "false",
+	"prometheus.prometheusSpec.serviceMonitorSelectorNilUsesHelmValues": "false",
+	"prometheus.prometheusSpec.podMonitorSelectorNilUsesHelmValues":     "false",
+	"prometheus.prometheusSpec.ruleSelectorNilUsesHelmValues":           "false",
+}
+
+// helmValues provides the "--set" arguments for the chart.
+func (a *Addon) helmValues() []string {
+	values := make(map[string]string, len(minimalValues)+len(a.values))
+	for k, v := range minimalValues {
+		values[k] = v
+	}
+	for k, v := range a.values {
+		values[k] = v
+	}
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	args := make([]string, 0, len(keys)*2) //nolint:gomnd
+	for _, k := range keys {
+		args = append(args, "--set", fmt.Sprintf("%s=%s", k, values[k]))
+	}
+	return args
+}
@@ -0,0 +1,39 @@
+package prometheus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQueryResponse(t *testing.T) {
+	samples, err := parseQueryResponse([]byte(`{
+		"status": "success",
+		"data": {
+			"resultType": "vector",
+			"result": [
+				{"metric": {"job": "b"}, "value": [1700000000.5, "2"]},
+				{"metric": {"job": "a"}, "value": [1700000000.5, "1.5"]}
+			]
+		}
+	}`))
+	require.NoError(t, err)
+	require.Len(t, samples, 2)
+	assert.Equal(t, map[string]string{"job": "a"}, samples[0].Metric)
+	assert.Equal(t, 1.5, samples[0].Value)
+	assert.Equal(t, time.UnixMilli(1700000000500), samples[0].Timestamp)
+	assert.Equal(t, 2.0, samples[1].Value)
+
+	samples, err = parseQueryResponse([]byte(`{"status": "success", "data": {"resultType": "scalar", "result": [1700000000, "42"]}}`))
+	require.NoError(t, err)
+	require.Len(t, samples, 1)
+	assert.Equal(t, 42.0, samples[0].Value)
+
+	_, err = parseQueryResponse([]byte(`{"status": "error", "error": "parse error"}`))
+	require.ErrorContains(t, err, "parse error")
+
+	_, err = parseQueryResponse([]byte(`{"status": "success", "data": {"resultType": "matrix", "result": []}}`))
+	require.Error(t, err)
+}
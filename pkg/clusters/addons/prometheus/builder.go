@@ -0,0 +1,54 @@
+package prometheus
+
+import (
+	"github.com/blang/semver/v4"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/versions"
+)
+
+// -----------------------------------------------------------------------------
+// Prometheus Addon - Builder
+// -----------------------------------------------------------------------------
+
+// Builder is a configuration tool to generate Prometheus cluster addons.
+type Builder struct {
+	chartVersion versions.Selector
+	values       map[string]string
+}
+
+// NewBuilder provides a new Builder object for configuring Prometheus cluster addons.
+func NewBuilder() *Builder {
+	return &Builder{
+		values: make(map[string]string),
+	}
+}
+
+// WithVersion pins the version of the kube-prometheus-stack chart which
+// should be deployed, otherwise the latest release is used.
+func (b *Builder) WithVersion(version semver.Version) *Builder {
+	b.chartVersion = versions.Exact(version)
+	return b
+}
+
+// WithMinorVersion selects the latest patch release of the given minor version
+// of the kube-prometheus-stack chart which should be deployed.
+func (b *Builder) WithMinorVersion(major, minor uint64) *Builder {
+	b.chartVersion = versions.LatestPatch(major, minor)
+	return b
+}
+
+// WithAdditionalValue sets an additional value for the kube-prometheus-stack
+// chart, which can be used to re-enable any of the disabled components.
+func (b *Builder) WithAdditionalValue(name, value string) *Builder {
+	b.values[name] = value
+	return b
+}
+
+// Build generates a new Prometheus cluster.Addon which can be loaded and
+// deployed into a test Environment's cluster.Cluster.
+func (b *Builder) Build() *Addon {
+	return &Addon{
+		chartVersion: b.chartVersion,
+		values:       b.values,
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/kong/kubernetes-testing-framework/pkg/clusters/types/kind"
 	cmutils "github.com/kong/kubernetes-testing-framework/pkg/utils/certmanager"
 	dockerutils "github.com/kong/kubernetes-testing-framework/pkg/utils/docker"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/images"
 	"github.com/kong/kubernetes-testing-framework/pkg/utils/kubernetes/generators"
 	"github.com/kong/kubernetes-testing-framework/pkg/utils/kubernetes/networking"
 )
@@ -44,6 +45,8 @@ type Addon struct {
 	name                    string
 	registryVersion         *semver.Version
 	serviceTypeLoadBalancer bool
+	tlsDisabled             bool
+	basicAuth               *BasicAuth
 
 	certificatePEM      []byte
 	clusterIP           string
@@ -54,6 +57,14 @@ type Addon struct {
 	certificateName string
 	certSecretName  string
 	pvcName         string
+	authSecretName  string
+}
+
+// BasicAuth are the credentials required by a Registry configured with
+// basic authentication.
+type BasicAuth struct {
+	Username string
+	Password string
 }
 
 // New produces a new clusters.Addon for Kong but uses a very opionated set of
@@ -86,6 +97,31 @@ func (a *Addon) LoadBalancerAddress() string {
 	return a.loadBalancerAddress
 }
 
+// Address indicates the address (without a port, as the default port for the
+// scheme is used) images in the registry are referenced with: the
+// LoadBalancer address if the addon was configured to use a LoadBalancer type
+// Service, otherwise the cluster IP.
+func (a *Addon) Address() string {
+	if a.loadBalancerAddress != "" {
+		return a.loadBalancerAddress
+	}
+	return a.clusterIP
+}
+
+// Scheme indicates whether the registry server serves "https" or "http".
+func (a *Addon) Scheme() string {
+	if a.tlsDisabled {
+		return "http"
+	}
+	return "https"
+}
+
+// BasicAuth provides the credentials required by the registry server, or nil
+// if it doesn't require authentication.
+func (a *Addon) BasicAuth() *BasicAuth {
+	return a.basicAuth
+}
+
 // Certificate returns the PEM encoded x509 certificate used for TLS
 // communications with the registry server.
 func (a *Addon) CertificatePEM() []byte {
@@ -101,9 +137,12 @@ func (a *Addon) Name() clusters.AddonName {
 }
 
 func (a *Addon) Dependencies(_ context.Context, cluster clusters.Cluster) []clusters.AddonName {
-	// in all cases we depend on cert-manager in order to create the SSL
-	// certificate for HTTPS communications to the registry.
-	dependencies := []clusters.AddonName{certmanager.AddonName}
+	// unless TLS is disabled we depend on cert-manager in order to create
+	// the SSL certificate for HTTPS communications to the registry.
+	var dependencies []clusters.AddonName
+	if !a.tlsDisabled {
+		dependencies = append(dependencies, certmanager.AddonName)
+	}
 
 	// if we're running on a kind cluster and a loadbalancer service was requested,
 	// the metallb is a required dependency. Other cluster implementations are
@@ -142,11 +181,6 @@ const (
 )
 
 func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
-	// currently this addon can _only_ work on a kind cluster
-	if _, ok := cluster.(*kind.Cluster); !ok {
-		return fmt.Errorf("the registry addon is currently only supported on kind clusters")
-	}
-
 	// wait for dependency addons to be ready first
 	if err := clusters.WaitForAddonDependencies(ctx, cluster, a); err != nil {
 		return fmt.Errorf("failure waiting for addon dependencies: %w", err)
@@ -164,18 +198,18 @@ func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
 	}
 
 	// create a registry container and deployment
-	httpbinContainer := corev1.Container{
+	registryContainer := corev1.Container{
 		Name:  string(AddonName),
-		Image: fmt.Sprintf("%s:%s", AddonName, registryTag),
+		Image: images.Mirror(fmt.Sprintf("%s:%s", AddonName, registryTag)),
 		Ports: []corev1.ContainerPort{
 			{
-				Name:          "https",
+				Name:          a.Scheme(),
 				ContainerPort: registryListenPort,
 				Protocol:      corev1.ProtocolTCP,
 			},
 		},
 	}
-	deployment := generators.NewDeploymentForContainer(httpbinContainer)
+	deployment := generators.NewDeploymentForContainer(registryContainer)
 	var err error
 	deployment, err = cluster.Client().AppsV1().Deployments(Namespace).Create(ctx, deployment, metav1.CreateOptions{})
 	if err != nil {
@@ -183,8 +217,12 @@ func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
 	}
 	a.deploymentName = deployment.Name
 
-	// expose the deployment via Service
-	portMapping := map[int32]int32{registryListenPort: 443} //nolint:gomnd
+	// expose the deployment via Service on the default port for the scheme
+	servicePort := int32(443) //nolint:gomnd
+	if a.tlsDisabled {
+		servicePort = 80
+	}
+	portMapping := map[int32]int32{registryListenPort: servicePort}
 	service := generators.NewServiceForDeploymentWithMappedPorts(deployment, corev1.ServiceTypeClusterIP, portMapping)
 	if a.serviceTypeLoadBalancer {
 		service = generators.NewServiceForDeploymentWithMappedPorts(deployment, corev1.ServiceTypeLoadBalancer, portMapping)
@@ -194,66 +232,19 @@ func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
 		return fmt.Errorf("could not create service for registry deployment %s: %w", deployment.Name, err)
 	}
 	a.serviceName = service.Name
-
-	// the loadBalancerAddress is the network address that the registry server can be
-	// reached at. By default this is the cluster IP, but if LoadBalancer type
-	// service option has been flagged, it will later be the LB address instead.
-	loadBalancerAddress := service.Spec.ClusterIP
 	a.clusterIP = service.Spec.ClusterIP
 
-	// create a certificate with cert-manager for HTTPS communication to the registry
-	cert := &certmanagerv1.Certificate{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: "registry-cert",
-		},
-		Spec: certmanagerv1.CertificateSpec{
-			SecretName: "registry-cert-secret",
-			DNSNames: []string{
-				"registry.registry.svc.cluster.local",
-				"registry.registry.svc",
-				"registry",
-			},
-			IssuerRef: cmmeta.ObjectReference{
-				Name:  string(certmanager.DefaultIssuerName),
-				Kind:  "ClusterIssuer",
-				Group: "cert-manager.io",
-			},
-			IPAddresses: []string{
-				service.Spec.ClusterIP,
-			},
-		},
-	}
-	a.certificateName = cert.Name
-
 	// if a LoadBalancer type service was requested, wait for the service to
-	// be properly provisioned and capture the LB address.
+	// be properly provisioned and capture the LB address, which becomes the
+	// main network address for the registry.
+	loadBalancerIsIP := false
 	if a.serviceTypeLoadBalancer {
-		// capture the registry address, overriding the previous clusterIP as
-		// the main network address for the registry.
-		var isIP bool
-		loadBalancerAddress, isIP, err = networking.WaitForServiceLoadBalancerAddress(ctx, cluster.Client(), Namespace, service.Name)
+		a.loadBalancerAddress, loadBalancerIsIP, err = networking.WaitForServiceLoadBalancerAddress(ctx, cluster.Client(), Namespace, service.Name)
 		if err != nil {
 			return fmt.Errorf("could not retrieve loadbalancer address for registry service: %w", err)
 		}
-		a.loadBalancerAddress = loadBalancerAddress
-
-		// ensure the LB address is also covered by the cert, whether its an
-		// IP address or a Host address.
-		if isIP {
-			cert.Spec.IPAddresses = append(cert.Spec.IPAddresses, loadBalancerAddress)
-		} else {
-			cert.Spec.DNSNames = append(cert.Spec.DNSNames, loadBalancerAddress)
-		}
 	}
 
-	// now that the certificate has been configured, create the certificate
-	// object and get the x509 cert for the server generated.
-	certSecret, err := cmutils.CreateCertAndWaitForReadiness(ctx, cluster.Config(), Namespace, cert)
-	if err != nil {
-		return err
-	}
-	a.certSecretName = certSecret.Name
-
 	// create a persistent volume claim for the repository storage using the default
 	// storage provisioner available on the cluster.
 	pvc := &corev1.PersistentVolumeClaim{
@@ -277,7 +268,72 @@ func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
 	}
 	a.pvcName = pvc.Name
 
-	// add the storage and certificate to the registry deployment
+	volumes := []corev1.Volume{{
+		Name: "image-storage",
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: pvc.Name,
+			},
+		},
+	}}
+	volumeMounts := []corev1.VolumeMount{{
+		Name:      "image-storage",
+		MountPath: "/var/lib/registry",
+	}}
+	var env []corev1.EnvVar
+
+	if !a.tlsDisabled {
+		certSecret, err := a.createCertificate(ctx, cluster, loadBalancerIsIP)
+		if err != nil {
+			return err
+		}
+
+		volumes = append(volumes, corev1.Volume{
+			Name: "certs",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: certSecret.Name,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "certs",
+			MountPath: "/certs",
+			ReadOnly:  true,
+		})
+		env = append(env,
+			corev1.EnvVar{Name: "REGISTRY_HTTP_TLS_CERTIFICATE", Value: "/certs/tls.crt"},
+			corev1.EnvVar{Name: "REGISTRY_HTTP_TLS_KEY", Value: "/certs/tls.key"},
+		)
+	}
+
+	if a.basicAuth != nil {
+		authSecret, err := a.createHtpasswdSecret(ctx, cluster)
+		if err != nil {
+			return err
+		}
+
+		volumes = append(volumes, corev1.Volume{
+			Name: "auth",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: authSecret.Name,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "auth",
+			MountPath: "/auth",
+			ReadOnly:  true,
+		})
+		env = append(env,
+			corev1.EnvVar{Name: "REGISTRY_AUTH", Value: "htpasswd"},
+			corev1.EnvVar{Name: "REGISTRY_AUTH_HTPASSWD_REALM", Value: "ktf-registry"},
+			corev1.EnvVar{Name: "REGISTRY_AUTH_HTPASSWD_PATH", Value: "/auth/htpasswd"},
+		)
+	}
+
+	// add the storage, certificate and credentials to the registry deployment
 	deploymentUpdated := false
 	for !deploymentUpdated {
 		select {
@@ -290,50 +346,9 @@ func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
 				return fmt.Errorf("could not retrieve deployment for registry: %w", err)
 			}
 
-			// add the new certificate and storage volumes to the deployment containers
-			deployment.Spec.Template.Spec.Volumes = []corev1.Volume{
-				{
-					Name: "certs",
-					VolumeSource: corev1.VolumeSource{
-						Secret: &corev1.SecretVolumeSource{
-							SecretName: certSecret.Name,
-						},
-					},
-				},
-				{
-					Name: "image-storage",
-					VolumeSource: corev1.VolumeSource{
-						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-							ClaimName: pvc.Name,
-						},
-					},
-				},
-			}
-
-			// add the new certificate and storage volumemounts to the deployment containers
-			deployment.Spec.Template.Spec.Containers[0].VolumeMounts = []corev1.VolumeMount{
-				{
-					Name:      "certs",
-					MountPath: "/certs",
-					ReadOnly:  true,
-				},
-				{
-					Name:      "image-storage",
-					MountPath: "/var/lib/registry",
-				},
-			}
-
-			// update the container's env to configure the certificate location
-			deployment.Spec.Template.Spec.Containers[0].Env = []corev1.EnvVar{
-				{
-					Name:  "REGISTRY_HTTP_TLS_CERTIFICATE",
-					Value: "/certs/tls.crt",
-				},
-				{
-					Name:  "REGISTRY_HTTP_TLS_KEY",
-					Value: "/certs/tls.key",
-				},
-			}
+			deployment.Spec.Template.Spec.Volumes = volumes
+			deployment.Spec.Template.Spec.Containers[0].VolumeMounts = volumeMounts
+			deployment.Spec.Template.Spec.Containers[0].Env = env
 
 			// attempt to update the deployment
 			deployment, err = cluster.Client().AppsV1().Deployments(Namespace).Update(ctx, deployment, metav1.UpdateOptions{})
@@ -347,19 +362,87 @@ func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
 		}
 	}
 
-	// gather the decoded certificate from the secret so that we can copy it to
-	// the kind container's filesystem.
+	// the container runtime of kind nodes is configured to trust the
+	// registry. Other clusters need to be configured to trust it by the caller.
+	if _, ok := cluster.(*kind.Cluster); ok {
+		return a.configureKindNode(ctx, cluster)
+	}
+
+	return nil
+}
+
+// createCertificate creates a certificate with cert-manager for HTTPS
+// communication to the registry and provides the secret it's stored in.
+func (a *Addon) createCertificate(ctx context.Context, cluster clusters.Cluster, loadBalancerIsIP bool) (*corev1.Secret, error) {
+	cert := &certmanagerv1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "registry-cert",
+		},
+		Spec: certmanagerv1.CertificateSpec{
+			SecretName: "registry-cert-secret",
+			DNSNames: []string{
+				"registry.registry.svc.cluster.local",
+				"registry.registry.svc",
+				"registry",
+			},
+			IssuerRef: cmmeta.ObjectReference{
+				Name:  string(certmanager.DefaultIssuerName),
+				Kind:  "ClusterIssuer",
+				Group: "cert-manager.io",
+			},
+			IPAddresses: []string{
+				a.clusterIP,
+			},
+		},
+	}
+	a.certificateName = cert.Name
+
+	// ensure the LB address is also covered by the cert, whether its an
+	// IP address or a Host address.
+	if a.loadBalancerAddress != "" {
+		if loadBalancerIsIP {
+			cert.Spec.IPAddresses = append(cert.Spec.IPAddresses, a.loadBalancerAddress)
+		} else {
+			cert.Spec.DNSNames = append(cert.Spec.DNSNames, a.loadBalancerAddress)
+		}
+	}
+
+	// create the certificate object and get the x509 cert for the server generated.
+	certSecret, err := cmutils.CreateCertAndWaitForReadiness(ctx, cluster.Config(), Namespace, cert)
+	if err != nil {
+		return nil, err
+	}
+	a.certSecretName = certSecret.Name
+
 	crtPEM, ok := certSecret.Data["tls.crt"]
 	if !ok {
-		return fmt.Errorf("tls.crt missing from registry cert secret %s", certSecret.Name)
+		return nil, fmt.Errorf("tls.crt missing from registry cert secret %s", certSecret.Name)
 	}
 	a.certificatePEM = crtPEM
 
-	// write the certificate to a tar archive, as needed for the docker client when
-	// copying files to containers.
+	return certSecret, nil
+}
+
+// configureKindNode configures containerd on the kind node to trust the
+// registry's certificate, or to pull from it over HTTP if TLS is disabled.
+func (a *Addon) configureKindNode(ctx context.Context, cluster clusters.Cluster) error {
 	containerID := dockerutils.GetKindContainerID(cluster.Name())
-	if err := dockerutils.WriteFileToContainer(ctx, containerID, registryCertPath, 0o644, crtPEM); err != nil { //nolint:gomnd
-		return fmt.Errorf("failed to copy certificate to kind container: %w", err)
+
+	var registryOpts string
+	if a.tlsDisabled {
+		registryOpts = fmt.Sprintf(`
+[plugins."io.containerd.grpc.v1.cri".registry.mirrors."%[1]s"]
+  endpoint = ["http://%[1]s"]
+`, a.Address())
+	} else {
+		// write the certificate to the kind container's filesystem.
+		if err := dockerutils.WriteFileToContainer(ctx, containerID, registryCertPath, 0o644, a.certificatePEM); err != nil { //nolint:gomnd
+			return fmt.Errorf("failed to copy certificate to kind container: %w", err)
+		}
+		registryOpts = fmt.Sprintf(`
+[plugins."io.containerd.grpc.v1.cri".registry.configs."%s".tls]
+  ca_file = "%s"
+`, a.Address(), registryCertPath)
 	}
 
 	// pull an archive of the containerd directory from the container
@@ -368,18 +451,14 @@ func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
 		return fmt.Errorf("failed to copy containerd configuration from kind container: %w", err)
 	}
 
-	// append the new SSL certificate trust configuration to the containerd configuration
+	// append the new registry configuration to the containerd configuration
 	containerdConfig := bytes.NewBuffer(oldContainerdConfig.Bytes())
-	certOpts := fmt.Sprintf(`
-[plugins."io.containerd.grpc.v1.cri".registry.configs."%s".tls]
-  ca_file = "%s"
-`, loadBalancerAddress, registryCertPath)
-	wc, err := containerdConfig.WriteString(certOpts)
+	wc, err := containerdConfig.WriteString(registryOpts)
 	if err != nil {
-		return fmt.Errorf("could not append certificate configuration to containerd config in memory: %w", err)
+		return fmt.Errorf("could not append registry configuration to containerd config in memory: %w", err)
 	}
-	if wc != len(certOpts) {
-		return fmt.Errorf("wrote %d bytes to containerd configuration in memory, expected %d", wc, len(certOpts))
+	if wc != len(registryOpts) {
+		return fmt.Errorf("wrote %d bytes to containerd configuration in memory, expected %d", wc, len(registryOpts))
 	}
 
 	// create a new tar archive for the file contents, as required by the docker
@@ -425,27 +504,28 @@ func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
 	}
 
 	// delete the registry certificate
-	if err := cmc.CertmanagerV1().Certificates(Namespace).Delete(ctx, a.certificateName, metav1.DeleteOptions{}); err != nil {
-		if !errors.IsNotFound(err) {
-			return err
+	if a.certificateName != "" {
+		if err := cmc.CertmanagerV1().Certificates(Namespace).Delete(ctx, a.certificateName, metav1.DeleteOptions{}); err != nil {
+			if !errors.IsNotFound(err) {
+				return err
+			}
 		}
 	}
 
-	// delete the registry certificate secret
-	if err := cluster.Client().CoreV1().Secrets(Namespace).Delete(ctx, a.certSecretName, metav1.DeleteOptions{}); err != nil {
-		if !errors.IsNotFound(err) {
-			return err
+	// delete the registry certificate and credentials secrets
+	for _, secretName := range []string{a.certSecretName, a.authSecretName} {
+		if secretName == "" {
+			continue
 		}
-	}
-
-	// delete the registry namespace
-	if err := cluster.Client().CoreV1().Namespaces().Delete(ctx, Namespace, metav1.DeleteOptions{}); err != nil {
-		if !errors.IsNotFound(err) {
-			return err
+		if err := cluster.Client().CoreV1().Secrets(Namespace).Delete(ctx, secretName, metav1.DeleteOptions{}); err != nil {
+			if !errors.IsNotFound(err) {
+				return err
+			}
 		}
 	}
 
-	return nil
+	// delete the registry namespace
+	return clusters.DeleteNamespace(ctx, cluster, Namespace)
 }
 
 func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) (waitForObjects []runtime.Object, ready bool, err error) {
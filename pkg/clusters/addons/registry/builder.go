@@ -13,6 +13,8 @@ type Builder struct {
 	name                    string
 	registryVersion         semver.Version
 	serviceTypeLoadBalancer bool
+	tlsDisabled             bool
+	basicAuth               *BasicAuth
 }
 
 // NewBuilder provides a new Builder object for configuring Registry cluster addons.
@@ -35,6 +37,22 @@ func (b *Builder) WithServiceTypeLoadBalancer() *Builder {
 	return b
 }
 
+// WithTLSDisabled configures the Registry to serve plain HTTP, in which case
+// cert-manager isn't required. The nodes of kind clusters are configured to
+// pull from it over HTTP.
+func (b *Builder) WithTLSDisabled() *Builder {
+	b.tlsDisabled = true
+	return b
+}
+
+// WithBasicAuth configures the Registry to require the provided credentials
+// for all requests, including image pulls. Workloads pulling images from the
+// Registry then need an image pull secret, see Addon.ImagePullSecret.
+func (b *Builder) WithBasicAuth(username, password string) *Builder {
+	b.basicAuth = &BasicAuth{Username: username, Password: password}
+	return b
+}
+
 // Build generates a new kong cluster.Addon which can be loaded and deployed
 // into a test Environment's cluster.Cluster.
 func (b *Builder) Build() *Addon {
@@ -42,5 +60,7 @@ func (b *Builder) Build() *Addon {
 		name:                    b.name,
 		registryVersion:         &b.registryVersion,
 		serviceTypeLoadBalancer: b.serviceTypeLoadBalancer,
+		tlsDisabled:             b.tlsDisabled,
+		basicAuth:               b.basicAuth,
 	}
 }
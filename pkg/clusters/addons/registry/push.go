@@ -0,0 +1,135 @@
+package registry
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	dockerregistry "github.com/docker/docker/api/types/registry"
+	"golang.org/x/crypto/bcrypt"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	dockerutils "github.com/kong/kubernetes-testing-framework/pkg/utils/docker"
+)
+
+// -----------------------------------------------------------------------------
+// Registry Addon - Image Helpers
+// -----------------------------------------------------------------------------
+
+// PushImage tags a local docker image (e.g. "kong/kong:3.4") for the registry
+// under the given repository (e.g. "kong:test") and pushes it using the
+// registry's credentials, if any. The image reference pods should use to pull
+// the image from the registry is returned.
+//
+// The local docker daemon needs to be able to reach the registry address and
+// needs to trust it: either as an insecure registry when TLS is disabled, or
+// by trusting the certificate provided by CertificatePEM().
+func (a *Addon) PushImage(ctx context.Context, image, repository string) (string, error) {
+	if a.Address() == "" {
+		return "", fmt.Errorf("registry addon has not been deployed")
+	}
+	target := fmt.Sprintf("%s/%s", a.Address(), repository)
+
+	var auth dockerregistry.AuthConfig
+	if a.basicAuth != nil {
+		auth.Username = a.basicAuth.Username
+		auth.Password = a.basicAuth.Password
+		auth.ServerAddress = a.Address()
+	}
+
+	if err := dockerutils.PushImage(ctx, image, target, auth); err != nil {
+		return "", err
+	}
+	return target, nil
+}
+
+// ImagePullSecret generates a docker config Secret which pods in the given
+// namespace can reference in their imagePullSecrets to pull images from the
+// registry when it is configured with basic authentication.
+func (a *Addon) ImagePullSecret(namespace, name string) (*corev1.Secret, error) {
+	if a.basicAuth == nil {
+		return nil, fmt.Errorf("registry addon is not configured with basic authentication")
+	}
+	if a.Address() == "" {
+		return nil, fmt.Errorf("registry addon has not been deployed")
+	}
+	return newImagePullSecret(namespace, name, a.Address(), *a.basicAuth)
+}
+
+// -----------------------------------------------------------------------------
+// Registry Addon - Private Functions
+// -----------------------------------------------------------------------------
+
+// createHtpasswdSecret creates the Secret containing the htpasswd file which
+// the registry server uses to authenticate clients.
+func (a *Addon) createHtpasswdSecret(ctx context.Context, cluster clusters.Cluster) (*corev1.Secret, error) {
+	htpasswd, err := newHtpasswd(*a.basicAuth)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate htpasswd for registry: %w", err)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "registry-auth",
+		},
+		Data: map[string][]byte{
+			"htpasswd": htpasswd,
+		},
+	}
+	if _, err := cluster.Client().CoreV1().Secrets(Namespace).Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("could not create htpasswd secret for registry: %w", err)
+		}
+	}
+	a.authSecretName = secret.Name
+
+	return secret, nil
+}
+
+// newHtpasswd generates an htpasswd file entry for the credentials. The
+// registry only supports bcrypt hashed passwords.
+func newHtpasswd(auth BasicAuth) ([]byte, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(auth.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(fmt.Sprintf("%s:%s\n", auth.Username, hash)), nil
+}
+
+// newImagePullSecret generates a kubernetes.io/dockerconfigjson Secret for
+// the given registry server and credentials.
+func newImagePullSecret(namespace, name, server string, auth BasicAuth) (*corev1.Secret, error) {
+	type dockerConfigEntry struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		Auth     string `json:"auth"`
+	}
+	dockerConfig := map[string]map[string]dockerConfigEntry{
+		"auths": {
+			server: {
+				Username: auth.Username,
+				Password: auth.Password,
+				Auth:     base64.StdEncoding.EncodeToString([]byte(auth.Username + ":" + auth.Password)),
+			},
+		},
+	}
+	data, err := json.Marshal(dockerConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: data,
+		},
+	}, nil
+}
@@ -0,0 +1,44 @@
+package registry
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestNewHtpasswd(t *testing.T) {
+	htpasswd, err := newHtpasswd(BasicAuth{Username: "ktf", Password: "s3cr3t"})
+	require.NoError(t, err)
+
+	user, hash, ok := bytes.Cut(bytes.TrimSuffix(htpasswd, []byte("\n")), []byte(":"))
+	require.True(t, ok)
+	assert.Equal(t, "ktf", string(user))
+	assert.NoError(t, bcrypt.CompareHashAndPassword(hash, []byte("s3cr3t")))
+}
+
+func TestNewImagePullSecret(t *testing.T) {
+	secret, err := newImagePullSecret("default", "regcred", "172.18.0.100", BasicAuth{Username: "ktf", Password: "s3cr3t"})
+	require.NoError(t, err)
+	assert.Equal(t, "default", secret.Namespace)
+	assert.Equal(t, "regcred", secret.Name)
+	assert.Equal(t, corev1.SecretTypeDockerConfigJson, secret.Type)
+
+	var dockerConfig struct {
+		Auths map[string]struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+			Auth     string `json:"auth"`
+		} `json:"auths"`
+	}
+	require.NoError(t, json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &dockerConfig))
+	require.Contains(t, dockerConfig.Auths, "172.18.0.100")
+	entry := dockerConfig.Auths["172.18.0.100"]
+	assert.Equal(t, "ktf", entry.Username)
+	assert.Equal(t, "s3cr3t", entry.Password)
+	assert.Equal(t, "a3RmOnMzY3IzdA==", entry.Auth)
+}
@@ -0,0 +1,149 @@
+package streamecho
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/kong/kubernetes-testing-framework/internal/utils"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/kubernetes/generators"
+)
+
+// -----------------------------------------------------------------------------
+// Stream Echo Addon
+// -----------------------------------------------------------------------------
+
+const (
+	// AddonName indicates the unique name of this addon.
+	AddonName clusters.AddonName = "stream-echo"
+
+	// DefaultNamespace indicates the default namespace this addon will be deployed to.
+	DefaultNamespace = "stream-echo"
+
+	// Image is the container image of the echo servers.
+	Image = "kong/go-echo:0.3.0"
+
+	// ServiceName is the name of the echo servers Service.
+	ServiceName = "stream-echo"
+
+	// DefaultTCPPort is the port of the TCP echo server unless configured otherwise.
+	DefaultTCPPort = 1025
+
+	// DefaultUDPPort is the port of the UDP echo server unless configured otherwise.
+	DefaultUDPPort = 1026
+)
+
+// Addon is a TCP and UDP echo server addon, to test TCPRoutes, UDPRoutes and
+// stream listeners with the EchoTCP and EchoUDP helpers.
+type Addon struct {
+	namespace string
+	tcpPort   int32
+	udpPort   int32
+}
+
+// New produces a new clusters.Addon for the TCP/UDP echo servers with the
+// default settings.
+func New() *Addon {
+	return NewBuilder().Build()
+}
+
+// Namespace indicates the namespace where the addon components are to be
+// deployed and managed.
+func (a *Addon) Namespace() string {
+	return a.namespace
+}
+
+// TCPPort provides the port of the TCP echo server on its Service.
+func (a *Addon) TCPPort() int32 {
+	return a.tcpPort
+}
+
+// UDPPort provides the port of the UDP echo server on its Service.
+func (a *Addon) UDPPort() int32 {
+	return a.udpPort
+}
+
+// Service retrieves the echo servers Service from the cluster.
+func (a *Addon) Service(ctx context.Context, cluster clusters.Cluster) (*corev1.Service, error) {
+	return cluster.Client().CoreV1().Services(a.namespace).Get(ctx, ServiceName, metav1.GetOptions{})
+}
+
+// -----------------------------------------------------------------------------
+// Stream Echo Addon - Addon Implementation
+// -----------------------------------------------------------------------------
+
+func (a *Addon) Name() clusters.AddonName {
+	return AddonName
+}
+
+func (a *Addon) Dependencies(_ context.Context, _ clusters.Cluster) []clusters.AddonName {
+	return nil
+}
+
+func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
+	// wait for dependency addons to be ready first
+	if err := clusters.WaitForAddonDependencies(ctx, cluster, a); err != nil {
+		return fmt.Errorf("failure waiting for addon dependencies: %w", err)
+	}
+
+	if err := clusters.CreateNamespace(ctx, cluster, a.namespace); err != nil {
+		return err
+	}
+
+	container := generators.NewContainer(ServiceName, Image, a.tcpPort)
+	container.Ports = []corev1.ContainerPort{
+		{Name: "tcp", ContainerPort: a.tcpPort, Protocol: corev1.ProtocolTCP},
+		{Name: "udp", ContainerPort: a.udpPort, Protocol: corev1.ProtocolUDP},
+	}
+	container.Env = []corev1.EnvVar{
+		{Name: "TCP_PORT", Value: fmt.Sprint(a.tcpPort)},
+		{Name: "UDP_PORT", Value: fmt.Sprint(a.udpPort)},
+		{
+			Name: "POD_NAME",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+			},
+		},
+	}
+	container.ReadinessProbe = &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{
+				Port: intstr.FromInt(int(a.tcpPort)),
+			},
+		},
+	}
+	deployment := generators.NewDeploymentForContainer(container)
+	if _, err := cluster.Client().AppsV1().Deployments(a.namespace).Create(ctx, deployment, metav1.CreateOptions{}); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	service := generators.NewServiceForDeployment(deployment, corev1.ServiceTypeClusterIP)
+	if _, err := cluster.Client().CoreV1().Services(a.namespace).Create(ctx, service, metav1.CreateOptions{}); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
+	return clusters.DeleteNamespace(ctx, cluster, a.namespace)
+}
+
+func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) ([]runtime.Object, bool, error) {
+	return utils.IsNamespaceAvailable(ctx, cluster, a.namespace)
+}
+
+func (a *Addon) DumpDiagnostics(context.Context, clusters.Cluster) (map[string][]byte, error) {
+	diagnostics := make(map[string][]byte)
+	return diagnostics, nil
+}
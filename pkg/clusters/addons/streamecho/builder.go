@@ -0,0 +1,51 @@
+package streamecho
+
+// -----------------------------------------------------------------------------
+// Stream Echo Addon - Builder
+// -----------------------------------------------------------------------------
+
+// Builder is a configuration tool to generate TCP/UDP echo cluster addons.
+type Builder struct {
+	namespace string
+	tcpPort   int32
+	udpPort   int32
+}
+
+// NewBuilder provides a new Builder object for configuring TCP/UDP echo
+// cluster addons.
+func NewBuilder() *Builder {
+	return &Builder{
+		namespace: DefaultNamespace,
+		tcpPort:   DefaultTCPPort,
+		udpPort:   DefaultUDPPort,
+	}
+}
+
+// WithNamespace allows the namespace where the addon should be deployed to be
+// overridden from the default.
+func (b *Builder) WithNamespace(namespace string) *Builder {
+	b.namespace = namespace
+	return b
+}
+
+// WithTCPPort configures the port the TCP echo server listens on.
+func (b *Builder) WithTCPPort(port int32) *Builder {
+	b.tcpPort = port
+	return b
+}
+
+// WithUDPPort configures the port the UDP echo server listens on.
+func (b *Builder) WithUDPPort(port int32) *Builder {
+	b.udpPort = port
+	return b
+}
+
+// Build generates a new TCP/UDP echo cluster.Addon which can be loaded and
+// deployed into a test Environment's cluster.Cluster.
+func (b *Builder) Build() *Addon {
+	return &Addon{
+		namespace: b.namespace,
+		tcpPort:   b.tcpPort,
+		udpPort:   b.udpPort,
+	}
+}
@@ -0,0 +1,66 @@
+package streamecho
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+// Stream Echo Addon - Dial Helpers
+// -----------------------------------------------------------------------------
+
+// DefaultEchoTimeout is how long EchoTCP and EchoUDP wait for the message to
+// be echoed back when the provided context has no deadline.
+const DefaultEchoTimeout = 5 * time.Second
+
+// EchoTCP connects to the TCP echo server at the given address (e.g. through
+// a proxy stream listener), sends the message and verifies that it is echoed
+// back. Anything the server sends before the echo (e.g. a greeting) is ignored.
+func EchoTCP(ctx context.Context, address, message string) error {
+	return echo(ctx, "tcp", address, message)
+}
+
+// EchoUDP sends the message as a datagram to the UDP echo server at the given
+// address and verifies that it is echoed back. As UDP is unreliable, callers
+// should retry on failure.
+func EchoUDP(ctx context.Context, address, message string) error {
+	return echo(ctx, "udp", address, message)
+}
+
+func echo(ctx context.Context, network, address, message string) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultEchoTimeout)
+		defer cancel()
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return fmt.Errorf("could not connect to %s echo server %s: %w", network, address, err)
+	}
+	defer conn.Close()
+
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+
+	if _, err := conn.Write([]byte(message)); err != nil {
+		return fmt.Errorf("could not send message to %s echo server %s: %w", network, address, err)
+	}
+
+	received := new(bytes.Buffer)
+	buf := make([]byte, 4096) //nolint:gomnd
+	for !bytes.Contains(received.Bytes(), []byte(message)) {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return fmt.Errorf("message %q was not echoed by %s echo server %s, received %q: %w", message, network, address, received.String(), err)
+		}
+		received.Write(buf[:n])
+	}
+	return nil
+}
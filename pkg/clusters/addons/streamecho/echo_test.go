@@ -0,0 +1,66 @@
+package streamecho
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEchoTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = conn.Write([]byte("Welcome, you are connected to node kind-control-plane.\n"))
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+
+	assert.NoError(t, EchoTCP(context.Background(), listener.Addr().String(), "hello"))
+}
+
+func TestEchoTCPNotEchoed(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		_, _ = conn.Write([]byte("goodbye"))
+		conn.Close()
+	}()
+
+	assert.Error(t, EchoTCP(context.Background(), listener.Addr().String(), "hello"))
+}
+
+func TestEchoUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			_, _ = conn.WriteTo(buf[:n], addr)
+		}
+	}()
+
+	assert.NoError(t, EchoUDP(context.Background(), conn.LocalAddr().String(), "hello"))
+}
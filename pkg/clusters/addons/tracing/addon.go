@@ -0,0 +1,209 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kong/kubernetes-testing-framework/internal/utils"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/kubernetes/generators"
+)
+
+// -----------------------------------------------------------------------------
+// Tracing Addon
+// -----------------------------------------------------------------------------
+
+const (
+	// AddonName indicates the unique name of this addon.
+	AddonName clusters.AddonName = "tracing"
+
+	// DefaultNamespace indicates the default namespace this addon will be deployed to.
+	DefaultNamespace = "tracing"
+
+	// DefaultJaegerVersion is the version of Jaeger all-in-one deployed by default.
+	DefaultJaegerVersion = "1.53"
+
+	// DefaultCollectorVersion is the version of the OpenTelemetry Collector
+	// deployed by default.
+	DefaultCollectorVersion = "0.92.0"
+
+	// CollectorServiceName is the name of the OpenTelemetry Collector service.
+	CollectorServiceName = "otel-collector"
+
+	// JaegerServiceName is the name of the Jaeger service.
+	JaegerServiceName = "jaeger"
+
+	// OTLPGRPCPort is the port of the collector's OTLP gRPC receiver.
+	OTLPGRPCPort = 4317
+
+	// OTLPHTTPPort is the port of the collector's OTLP HTTP receiver.
+	OTLPHTTPPort = 4318
+
+	// ZipkinPort is the port of the collector's Zipkin receiver.
+	ZipkinPort = 9411
+
+	// JaegerQueryPort is the port of the Jaeger query API and UI.
+	JaegerQueryPort = 16686
+
+	jaegerImage    = "jaegertracing/all-in-one"
+	collectorImage = "otel/opentelemetry-collector"
+	collectorConf  = "otel-collector-config"
+)
+
+// Addon is a tracing addon deploying an OpenTelemetry Collector which exports
+// all the traces it receives (via OTLP or Zipkin) to a Jaeger all-in-one
+// instance, which can be queried by tests.
+type Addon struct {
+	jaegerVersion    string
+	collectorVersion string
+}
+
+// New produces a new clusters.Addon for tracing with the default settings.
+func New() *Addon {
+	return NewBuilder().Build()
+}
+
+// Namespace indicates the namespace where the tracing addon components are to
+// be deployed and managed.
+func (a *Addon) Namespace() string {
+	return DefaultNamespace
+}
+
+// OTLPGRPCEndpoint provides the in-cluster host:port of the collector's OTLP
+// gRPC receiver.
+func (a *Addon) OTLPGRPCEndpoint() string {
+	return fmt.Sprintf("%s.%s.svc:%d", CollectorServiceName, DefaultNamespace, OTLPGRPCPort)
+}
+
+// OTLPHTTPEndpoint provides the in-cluster URL of the collector's OTLP HTTP receiver.
+func (a *Addon) OTLPHTTPEndpoint() string {
+	return fmt.Sprintf("http://%s.%s.svc:%d", CollectorServiceName, DefaultNamespace, OTLPHTTPPort)
+}
+
+// ZipkinEndpoint provides the in-cluster URL of the collector's Zipkin receiver.
+func (a *Addon) ZipkinEndpoint() string {
+	return fmt.Sprintf("http://%s.%s.svc:%d/api/v2/spans", CollectorServiceName, DefaultNamespace, ZipkinPort)
+}
+
+// -----------------------------------------------------------------------------
+// Tracing Addon - Addon Implementation
+// -----------------------------------------------------------------------------
+
+func (a *Addon) Name() clusters.AddonName {
+	return AddonName
+}
+
+func (a *Addon) Dependencies(_ context.Context, _ clusters.Cluster) []clusters.AddonName {
+	return nil
+}
+
+func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
+	if err := clusters.CreateNamespace(ctx, cluster, DefaultNamespace); err != nil {
+		return err
+	}
+
+	// deploy jaeger all-in-one with its OTLP receiver enabled
+	jaeger := generators.NewContainer(JaegerServiceName, fmt.Sprintf("%s:%s", jaegerImage, a.jaegerVersion), JaegerQueryPort)
+	jaeger.Ports[0].Name = "query"
+	jaeger.Ports = append(jaeger.Ports, corev1.ContainerPort{Name: "otlp-grpc", ContainerPort: OTLPGRPCPort})
+	jaeger.Env = []corev1.EnvVar{{Name: "COLLECTOR_OTLP_ENABLED", Value: "true"}}
+	if err := deployContainer(ctx, cluster, jaeger, nil); err != nil {
+		return err
+	}
+
+	// deploy the collector, configured to export to jaeger
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: collectorConf},
+		Data:       map[string]string{"config.yaml": collectorConfig},
+	}
+	if _, err := cluster.Client().CoreV1().ConfigMaps(DefaultNamespace).Create(ctx, configMap, metav1.CreateOptions{}); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	collector := generators.NewContainer(CollectorServiceName, fmt.Sprintf("%s:%s", collectorImage, a.collectorVersion), OTLPGRPCPort)
+	collector.Ports[0].Name = "otlp-grpc"
+	collector.Ports = append(collector.Ports,
+		corev1.ContainerPort{Name: "otlp-http", ContainerPort: OTLPHTTPPort},
+		corev1.ContainerPort{Name: "zipkin", ContainerPort: ZipkinPort},
+	)
+	collector.Args = []string{"--config=/conf/config.yaml"}
+	collector.VolumeMounts = []corev1.VolumeMount{{Name: collectorConf, MountPath: "/conf"}}
+	volumes := []corev1.Volume{{
+		Name: collectorConf,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: collectorConf},
+			},
+		},
+	}}
+
+	return deployContainer(ctx, cluster, collector, volumes)
+}
+
+func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
+	return clusters.DeleteNamespace(ctx, cluster, DefaultNamespace)
+}
+
+func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) ([]runtime.Object, bool, error) {
+	return utils.IsNamespaceAvailable(ctx, cluster, DefaultNamespace)
+}
+
+func (a *Addon) DumpDiagnostics(context.Context, clusters.Cluster) (map[string][]byte, error) {
+	diagnostics := make(map[string][]byte)
+	return diagnostics, nil
+}
+
+// -----------------------------------------------------------------------------
+// Tracing Addon - Private
+// -----------------------------------------------------------------------------
+
+var collectorConfig = fmt.Sprintf(`receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:%[1]d
+      http:
+        endpoint: 0.0.0.0:%[2]d
+  zipkin:
+    endpoint: 0.0.0.0:%[3]d
+processors:
+  batch:
+    timeout: 1s
+exporters:
+  otlp:
+    endpoint: %[4]s.%[5]s.svc:%[1]d
+    tls:
+      insecure: true
+service:
+  pipelines:
+    traces:
+      receivers: [otlp, zipkin]
+      processors: [batch]
+      exporters: [otlp]
+`, OTLPGRPCPort, OTLPHTTPPort, ZipkinPort, JaegerServiceName, DefaultNamespace)
+
+// deployContainer creates a Deployment and Service for the provided container.
+func deployContainer(ctx context.Context, cluster clusters.Cluster, container corev1.Container, volumes []corev1.Volume) error {
+	deployment := generators.NewDeploymentForContainer(container)
+	deployment.Spec.Template.Spec.Volumes = volumes
+	if _, err := cluster.Client().AppsV1().Deployments(DefaultNamespace).Create(ctx, deployment, metav1.CreateOptions{}); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	service := generators.NewServiceForDeployment(deployment, corev1.ServiceTypeClusterIP)
+	if _, err := cluster.Client().CoreV1().Services(DefaultNamespace).Create(ctx, service, metav1.CreateOptions{}); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package tracing
+
+// -----------------------------------------------------------------------------
+// Tracing Addon - Builder
+// -----------------------------------------------------------------------------
+
+// Builder is a configuration tool to generate tracing cluster addons.
+type Builder struct {
+	jaegerVersion    string
+	collectorVersion string
+}
+
+// NewBuilder provides a new Builder object for configuring tracing cluster addons.
+func NewBuilder() *Builder {
+	return &Builder{
+		jaegerVersion:    DefaultJaegerVersion,
+		collectorVersion: DefaultCollectorVersion,
+	}
+}
+
+// WithJaegerVersion configures the version (image tag) of Jaeger all-in-one
+// which should be deployed.
+func (b *Builder) WithJaegerVersion(version string) *Builder {
+	b.jaegerVersion = version
+	return b
+}
+
+// WithCollectorVersion configures the version (image tag) of the OpenTelemetry
+// Collector which should be deployed.
+func (b *Builder) WithCollectorVersion(version string) *Builder {
+	b.collectorVersion = version
+	return b
+}
+
+// Build generates a new tracing cluster.Addon which can be loaded and deployed
+// into a test Environment's cluster.Cluster.
+func (b *Builder) Build() *Addon {
+	return &Addon{
+		jaegerVersion:    b.jaegerVersion,
+		collectorVersion: b.collectorVersion,
+	}
+}
@@ -0,0 +1,139 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+)
+
+// -----------------------------------------------------------------------------
+// Tracing Addon - Trace Queries
+// -----------------------------------------------------------------------------
+
+// defaultQueryTimeout bounds trace queries made against Jaeger.
+const defaultQueryTimeout = time.Minute
+
+// Trace is a trace found in Jaeger.
+type Trace struct {
+	// TraceID is the ID of the trace.
+	TraceID string
+
+	// Spans are the spans of the trace.
+	Spans []Span
+}
+
+// Span is a single span of a Trace.
+type Span struct {
+	// SpanID is the ID of the span.
+	SpanID string
+
+	// ParentSpanID is the ID of the parent span, empty for root spans.
+	ParentSpanID string
+
+	// ServiceName is the name of the service which emitted the span.
+	ServiceName string
+
+	// OperationName is the name of the span's operation.
+	OperationName string
+
+	// StartTime is the time the span started at.
+	StartTime time.Time
+
+	// Duration is the duration of the span.
+	Duration time.Duration
+
+	// Tags are the tags (attributes) of the span.
+	Tags map[string]interface{}
+}
+
+// FindTraces queries Jaeger, through the Kubernetes API server service proxy,
+// for the most recent traces (up to limit) of the given service. If operation
+// is not empty only traces containing that operation are provided.
+func (a *Addon) FindTraces(ctx context.Context, cluster clusters.Cluster, service, operation string, limit int) ([]Trace, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultQueryTimeout)
+	defer cancel()
+
+	params := map[string]string{
+		"service": service,
+		"limit":   strconv.Itoa(limit),
+	}
+	if operation != "" {
+		params["operation"] = operation
+	}
+
+	raw, err := cluster.Client().CoreV1().Services(DefaultNamespace).
+		ProxyGet("http", JaegerServiceName, strconv.Itoa(JaegerQueryPort), "/api/traces", params).
+		DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("jaeger trace query for service %s failed: %w", service, err)
+	}
+	return parseTraces(raw)
+}
+
+type jaegerResponse struct {
+	Data []struct {
+		TraceID string `json:"traceID"`
+		Spans   []struct {
+			SpanID        string `json:"spanID"`
+			OperationName string `json:"operationName"`
+			References    []struct {
+				RefType string `json:"refType"`
+				SpanID  string `json:"spanID"`
+			} `json:"references"`
+			StartTime int64 `json:"startTime"`
+			Duration  int64 `json:"duration"`
+			Tags      []struct {
+				Key   string      `json:"key"`
+				Value interface{} `json:"value"`
+			} `json:"tags"`
+			ProcessID string `json:"processID"`
+		} `json:"spans"`
+		Processes map[string]struct {
+			ServiceName string `json:"serviceName"`
+		} `json:"processes"`
+	} `json:"data"`
+	Errors []struct {
+		Msg string `json:"msg"`
+	} `json:"errors"`
+}
+
+// parseTraces parses a Jaeger query API traces response.
+func parseTraces(raw []byte) ([]Trace, error) {
+	resp := jaegerResponse{}
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("invalid jaeger response: %w", err)
+	}
+	if len(resp.Errors) > 0 {
+		return nil, fmt.Errorf("jaeger query failed: %s", resp.Errors[0].Msg)
+	}
+
+	traces := make([]Trace, 0, len(resp.Data))
+	for _, data := range resp.Data {
+		trace := Trace{TraceID: data.TraceID}
+		for _, s := range data.Spans {
+			span := Span{
+				SpanID:        s.SpanID,
+				ServiceName:   data.Processes[s.ProcessID].ServiceName,
+				OperationName: s.OperationName,
+				StartTime:     time.UnixMicro(s.StartTime),
+				Duration:      time.Duration(s.Duration) * time.Microsecond,
+				Tags:          make(map[string]interface{}, len(s.Tags)),
+			}
+			for _, ref := range s.References {
+				if ref.RefType == "CHILD_OF" {
+					span.ParentSpanID = ref.SpanID
+				}
+			}
+			for _, tag := range s.Tags {
+				span.Tags[tag.Key] = tag.Value
+			}
+			trace.Spans = append(trace.Spans, span)
+		}
+		traces = append(traces, trace)
+	}
+	return traces, nil
+}
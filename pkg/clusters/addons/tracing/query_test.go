@@ -0,0 +1,37 @@
+package tracing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTraces(t *testing.T) {
+	traces, err := parseTraces([]byte(`{
+		"data": [{
+			"traceID": "abc",
+			"spans": [
+				{"spanID": "1", "operationName": "kong", "startTime": 1700000000000000, "duration": 1500, "processID": "p1",
+				 "tags": [{"key": "http.status_code", "type": "int64", "value": 200}]},
+				{"spanID": "2", "operationName": "kong.balancer", "startTime": 1700000000000100, "duration": 500, "processID": "p1",
+				 "references": [{"refType": "CHILD_OF", "spanID": "1"}]}
+			],
+			"processes": {"p1": {"serviceName": "kong"}}
+		}]
+	}`))
+	require.NoError(t, err)
+	require.Len(t, traces, 1)
+	require.Len(t, traces[0].Spans, 2)
+
+	root, child := traces[0].Spans[0], traces[0].Spans[1]
+	assert.Equal(t, "kong", root.ServiceName)
+	assert.Equal(t, 1500*time.Microsecond, root.Duration)
+	assert.Equal(t, float64(200), root.Tags["http.status_code"])
+	assert.Empty(t, root.ParentSpanID)
+	assert.Equal(t, "1", child.ParentSpanID)
+
+	_, err = parseTraces([]byte(`{"data": null, "errors": [{"code": 400, "msg": "parameter 'service' is required"}]}`))
+	require.ErrorContains(t, err, "parameter 'service' is required")
+}
@@ -0,0 +1,228 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kong/kubernetes-testing-framework/internal/utils"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/versions"
+)
+
+// -----------------------------------------------------------------------------
+// Vault Addon
+// -----------------------------------------------------------------------------
+
+const (
+	// AddonName indicates the unique name of this addon.
+	AddonName clusters.AddonName = "vault"
+
+	// DefaultNamespace indicates the default namespace this addon will be deployed to.
+	DefaultNamespace = "vault"
+
+	// HelmRepoURL is the URL of the HashiCorp Helm repository.
+	HelmRepoURL = "https://helm.releases.hashicorp.com"
+
+	// ReleaseName is the name of the Helm release.
+	ReleaseName = "ktf-vault"
+
+	// ServiceName is the name of the Vault service.
+	ServiceName = ReleaseName
+
+	// ServicePort is the port of the Vault service.
+	ServicePort = 8200
+
+	// DefaultRootToken is the root token of the dev mode Vault server.
+	DefaultRootToken = "ktf-root"
+
+	// KubernetesAuthPath is the path the Kubernetes auth method is enabled at.
+	KubernetesAuthPath = "kubernetes"
+)
+
+// Addon is a HashiCorp Vault addon which runs a single Vault server in dev
+// mode: it's unsealed, stores everything in memory and has a KV version 2
+// secrets engine mounted at "secret/". It must never be used for anything
+// other than testing.
+type Addon struct {
+	chartVersion versions.Selector
+	rootToken    string
+}
+
+// New produces a new clusters.Addon for Vault with the default settings.
+func New() *Addon {
+	return NewBuilder().Build()
+}
+
+// Namespace indicates the namespace where the Vault addon components are to
+// be deployed and managed.
+func (a *Addon) Namespace() string {
+	return DefaultNamespace
+}
+
+// RootToken provides the root token of the Vault server.
+func (a *Addon) RootToken() string {
+	return a.rootToken
+}
+
+// Address provides the in-cluster URL of the Vault server.
+func (a *Addon) Address() string {
+	return fmt.Sprintf("http://%s.%s.svc:%d", ServiceName, DefaultNamespace, ServicePort)
+}
+
+// -----------------------------------------------------------------------------
+// Vault Addon - Addon Implementation
+// -----------------------------------------------------------------------------
+
+func (a *Addon) Name() clusters.AddonName {
+	return AddonName
+}
+
+func (a *Addon) Dependencies(_ context.Context, _ clusters.Cluster) []clusters.AddonName {
+	return nil
+}
+
+func (a *Addon) Deploy(ctx context.Context, cluster clusters.Cluster) error {
+	// wait for dependency addons to be ready first
+	if err := clusters.WaitForAddonDependencies(ctx, cluster, a); err != nil {
+		return fmt.Errorf("failure waiting for addon dependencies: %w", err)
+	}
+
+	release := utils.HelmRelease{
+		RepoName:     "hashicorp",
+		RepoURL:      HelmRepoURL,
+		Chart:        "hashicorp/vault",
+		Name:         ReleaseName,
+		Namespace:    DefaultNamespace,
+		ChartVersion: a.chartVersion,
+		Args: []string{
+			"--set", "server.dev.enabled=true",
+			"--set", fmt.Sprintf("server.dev.devRootToken=%s", a.rootToken),
+			"--set", "injector.enabled=false",
+		},
+	}
+
+	return utils.HelmInstall(ctx, cluster, release)
+}
+
+func (a *Addon) Delete(ctx context.Context, cluster clusters.Cluster) error {
+	if err := utils.HelmUninstall(ctx, cluster, ReleaseName, DefaultNamespace); err != nil {
+		return err
+	}
+	return clusters.DeleteNamespace(ctx, cluster, DefaultNamespace)
+}
+
+// Upgrade upgrades the chart of a deployed addon to the provided version,
+// retaining the rest of its configuration.
+func (a *Addon) Upgrade(ctx context.Context, cluster clusters.Cluster, version semver.Version) error {
+	a.chartVersion = versions.Exact(version)
+	return a.Deploy(ctx, cluster)
+}
+
+func (a *Addon) Ready(ctx context.Context, cluster clusters.Cluster) ([]runtime.Object, bool, error) {
+	return utils.IsNamespaceAvailable(ctx, cluster, DefaultNamespace)
+}
+
+func (a *Addon) Conditions(ctx context.Context, cluster clusters.Cluster) (bool, []clusters.Condition, error) {
+	return clusters.AddonConditions(ctx, cluster, a, DefaultNamespace, "")
+}
+
+func (a *Addon) DumpDiagnostics(context.Context, clusters.Cluster) (map[string][]byte, error) {
+	diagnostics := make(map[string][]byte)
+	return diagnostics, nil
+}
+
+// -----------------------------------------------------------------------------
+// Vault Addon - Secrets & Auth
+// -----------------------------------------------------------------------------
+
+// WriteSecret writes the provided key/value pairs as a secret at the given
+// path of the KV secrets engine mounted at "secret/", e.g. a path of
+// "kong/credentials" can be referenced as "secret/kong/credentials".
+func (a *Addon) WriteSecret(ctx context.Context, cluster clusters.Cluster, path string, data map[string]string) error {
+	_, err := a.request(ctx, cluster, http.MethodPost, "secret/data/"+strings.TrimPrefix(path, "/"), map[string]interface{}{
+		"data": data,
+	})
+	return err
+}
+
+// WritePolicy creates or updates the named ACL policy with the provided
+// policy document (HCL), e.g.:
+//
+//	path "secret/data/kong/*" { capabilities = ["read"] }
+func (a *Addon) WritePolicy(ctx context.Context, cluster clusters.Cluster, name, policy string) error {
+	_, err := a.request(ctx, cluster, http.MethodPut, "sys/policies/acl/"+name, map[string]interface{}{
+		"policy": policy,
+	})
+	return err
+}
+
+// EnableKubernetesAuth enables the Kubernetes auth method at KubernetesAuthPath
+// and configures it to validate ServiceAccount tokens against the cluster
+// Vault runs in, so that workloads can log in with their ServiceAccount.
+func (a *Addon) EnableKubernetesAuth(ctx context.Context, cluster clusters.Cluster) error {
+	raw, err := a.request(ctx, cluster, http.MethodGet, "sys/auth", nil)
+	if err != nil {
+		return err
+	}
+	mounts := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &mounts); err != nil {
+		return fmt.Errorf("invalid vault auth methods response: %w", err)
+	}
+
+	if _, enabled := mounts[KubernetesAuthPath+"/"]; !enabled {
+		_, err := a.request(ctx, cluster, http.MethodPost, "sys/auth/"+KubernetesAuthPath, map[string]interface{}{
+			"type": "kubernetes",
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = a.request(ctx, cluster, http.MethodPost, fmt.Sprintf("auth/%s/config", KubernetesAuthPath), map[string]interface{}{
+		"kubernetes_host": "https://kubernetes.default.svc",
+	})
+	return err
+}
+
+// CreateKubernetesAuthRole creates or updates a role of the Kubernetes auth
+// method, which grants the given policies to the given ServiceAccounts in the
+// given namespaces. See EnableKubernetesAuth.
+func (a *Addon) CreateKubernetesAuthRole(ctx context.Context, cluster clusters.Cluster, role string, serviceAccounts, namespaces, policies []string) error {
+	_, err := a.request(ctx, cluster, http.MethodPost, fmt.Sprintf("auth/%s/role/%s", KubernetesAuthPath, role), map[string]interface{}{
+		"bound_service_account_names":      serviceAccounts,
+		"bound_service_account_namespaces": namespaces,
+		"policies":                         policies,
+	})
+	return err
+}
+
+// request makes an authenticated request to the Vault HTTP API, accessed
+// through the Kubernetes API server service proxy, and provides the response body.
+func (a *Addon) request(ctx context.Context, cluster clusters.Cluster, method, path string, body interface{}) ([]byte, error) {
+	req := cluster.Client().CoreV1().RESTClient().Verb(method).
+		Namespace(DefaultNamespace).
+		Resource("services").
+		Name(fmt.Sprintf("http:%s:%d", ServiceName, ServicePort)).
+		SubResource("proxy").
+		Suffix("v1", path).
+		SetHeader("X-Vault-Token", a.rootToken)
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		req = req.SetHeader("Content-Type", "application/json").Body(raw)
+	}
+
+	raw, err := req.DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("vault request %s %s failed: %w", method, path, err)
+	}
+	return raw, nil
+}
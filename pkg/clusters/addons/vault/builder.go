@@ -0,0 +1,53 @@
+package vault
+
+import (
+	"github.com/blang/semver/v4"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/versions"
+)
+
+// -----------------------------------------------------------------------------
+// Vault Addon - Builder
+// -----------------------------------------------------------------------------
+
+// Builder is a configuration tool to generate Vault cluster addons.
+type Builder struct {
+	chartVersion versions.Selector
+	rootToken    string
+}
+
+// NewBuilder provides a new Builder object for configuring Vault cluster addons.
+func NewBuilder() *Builder {
+	return &Builder{
+		rootToken: DefaultRootToken,
+	}
+}
+
+// WithVersion pins the version of the Vault chart which should be deployed,
+// otherwise the latest release is used.
+func (b *Builder) WithVersion(version semver.Version) *Builder {
+	b.chartVersion = versions.Exact(version)
+	return b
+}
+
+// WithMinorVersion selects the latest patch release of the given minor version
+// of the Vault chart which should be deployed.
+func (b *Builder) WithMinorVersion(major, minor uint64) *Builder {
+	b.chartVersion = versions.LatestPatch(major, minor)
+	return b
+}
+
+// WithRootToken configures the root token of the dev mode Vault server.
+func (b *Builder) WithRootToken(token string) *Builder {
+	b.rootToken = token
+	return b
+}
+
+// Build generates a new Vault cluster.Addon which can be loaded and deployed
+// into a test Environment's cluster.Cluster.
+func (b *Builder) Build() *Addon {
+	return &Addon{
+		chartVersion: b.chartVersion,
+		rootToken:    b.rootToken,
+	}
+}
@@ -0,0 +1,33 @@
+package websocket
+
+// -----------------------------------------------------------------------------
+// WebSocket Addon - Builder
+// -----------------------------------------------------------------------------
+
+// Builder is a configuration tool to generate WebSocket echo server cluster addons.
+type Builder struct {
+	namespace string
+}
+
+// NewBuilder provides a new Builder object for configuring WebSocket echo
+// server cluster addons.
+func NewBuilder() *Builder {
+	return &Builder{
+		namespace: DefaultNamespace,
+	}
+}
+
+// WithNamespace allows the namespace where the addon should be deployed to be
+// overridden from the default.
+func (b *Builder) WithNamespace(namespace string) *Builder {
+	b.namespace = namespace
+	return b
+}
+
+// Build generates a new WebSocket echo server cluster.Addon which can be
+// loaded and deployed into a test Environment's cluster.Cluster.
+func (b *Builder) Build() *Addon {
+	return &Addon{
+		namespace: b.namespace,
+	}
+}
@@ -0,0 +1,92 @@
+package websocket
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// -----------------------------------------------------------------------------
+// WebSocket Addon - Client Helpers
+// -----------------------------------------------------------------------------
+
+// DefaultEchoTimeout is how long Echo waits for the message to be echoed back
+// when the provided context has no deadline.
+const DefaultEchoTimeout = 5 * time.Second
+
+// Dial opens a WebSocket connection to the given URL, e.g. the Kong proxy URL
+// with the path of a route to the addon. "http" and "https" URLs are
+// converted to "ws" and "wss" respectively, and certificates are not
+// verified. The handshake response is returned for assertions on the upgrade
+// also if the handshake fails.
+func Dial(ctx context.Context, u *url.URL, header http.Header) (*websocket.Conn, *http.Response, error) {
+	target := *u
+	switch target.Scheme {
+	case "http":
+		target.Scheme = "ws"
+	case "https":
+		target.Scheme = "wss"
+	}
+
+	dialer := websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: DefaultEchoTimeout,
+		TLSClientConfig:  &tls.Config{InsecureSkipVerify: true}, //nolint:gosec
+	}
+	conn, resp, err := dialer.DialContext(ctx, target.String(), header)
+	if err != nil {
+		status := "no response"
+		if resp != nil {
+			status = resp.Status
+		}
+		return nil, resp, fmt.Errorf("websocket handshake with %s failed (%s): %w", target.String(), status, err)
+	}
+	return conn, resp, nil
+}
+
+// Echo opens a WebSocket connection to the given URL (see Dial), verifies the
+// connection was upgraded, sends the message and verifies that it is echoed
+// back. Other messages received before the echo (e.g. a greeting) are ignored.
+func Echo(ctx context.Context, u *url.URL, header http.Header, message string) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultEchoTimeout)
+		defer cancel()
+	}
+
+	conn, resp, err := Dial(ctx, u, header)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		return fmt.Errorf("expected status %d for the websocket upgrade, got %d", http.StatusSwitchingProtocols, resp.StatusCode)
+	}
+	if !strings.EqualFold(resp.Header.Get("Upgrade"), "websocket") {
+		return fmt.Errorf("expected the connection to be upgraded to websocket, got upgrade %q", resp.Header.Get("Upgrade"))
+	}
+
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetReadDeadline(deadline); err != nil {
+		return err
+	}
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(message)); err != nil {
+		return fmt.Errorf("could not send websocket message: %w", err)
+	}
+	for {
+		_, received, err := conn.ReadMessage()
+		if err != nil {
+			return fmt.Errorf("websocket message %q was not echoed: %w", message, err)
+		}
+		if string(received) == message {
+			return nil
+		}
+	}
+}
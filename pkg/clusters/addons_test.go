@@ -0,0 +1,216 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/blang/semver/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type fakeAddon struct {
+	name         AddonName
+	dependencies []AddonName
+}
+
+func (a fakeAddon) Name() AddonName { return a.name }
+
+func (a fakeAddon) Dependencies(context.Context, Cluster) []AddonName { return a.dependencies }
+
+func (a fakeAddon) Deploy(context.Context, Cluster) error { return nil }
+
+func (a fakeAddon) Delete(context.Context, Cluster) error { return nil }
+
+func (a fakeAddon) Ready(context.Context, Cluster) ([]runtime.Object, bool, error) {
+	return nil, true, nil
+}
+
+func (a fakeAddon) DumpDiagnostics(context.Context, Cluster) (map[string][]byte, error) {
+	return nil, nil
+}
+
+func fakeAddons(addons ...fakeAddon) Addons {
+	result := make(Addons, len(addons))
+	for _, addon := range addons {
+		result[addon.name] = addon
+	}
+	return result
+}
+
+func TestSortAddonsByDependencies(t *testing.T) {
+	testcases := []struct {
+		name    string
+		addons  Addons
+		want    []AddonName
+		wantErr string
+	}{
+		{
+			name:   "no dependencies are sorted by name",
+			addons: fakeAddons(fakeAddon{name: "c"}, fakeAddon{name: "a"}, fakeAddon{name: "b"}),
+			want:   []AddonName{"a", "b", "c"},
+		},
+		{
+			name: "dependencies come first",
+			addons: fakeAddons(
+				fakeAddon{name: "kong", dependencies: []AddonName{"metallb", "gateway-api"}},
+				fakeAddon{name: "gateway-api"},
+				fakeAddon{name: "metallb"},
+				fakeAddon{name: "echo", dependencies: []AddonName{"kong"}},
+			),
+			want: []AddonName{"gateway-api", "metallb", "kong", "echo"},
+		},
+		{
+			name: "dependencies outside of the addons are ignored",
+			addons: fakeAddons(
+				fakeAddon{name: "b", dependencies: []AddonName{"a", "missing"}},
+				fakeAddon{name: "a"},
+			),
+			want: []AddonName{"a", "b"},
+		},
+		{
+			name: "cyclic dependencies",
+			addons: fakeAddons(
+				fakeAddon{name: "a", dependencies: []AddonName{"b"}},
+				fakeAddon{name: "b", dependencies: []AddonName{"c"}},
+				fakeAddon{name: "c", dependencies: []AddonName{"a"}},
+			),
+			wantErr: "addon dependencies are cyclic: a -> b -> c -> a",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			sorted, err := SortAddonsByDependencies(context.Background(), nil, tc.addons)
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			names := make([]AddonName, 0, len(sorted))
+			for _, addon := range sorted {
+				names = append(names, addon.Name())
+			}
+			assert.Equal(t, tc.want, names)
+		})
+	}
+}
+
+func TestMissingAddonDependencies(t *testing.T) {
+	addons := fakeAddons(
+		fakeAddon{name: "kong", dependencies: []AddonName{"metallb"}},
+		fakeAddon{name: "echo", dependencies: []AddonName{"kong", "metallb"}},
+	)
+	assert.Equal(t, map[AddonName][]AddonName{
+		"metallb": {"echo", "kong"},
+	}, MissingAddonDependencies(context.Background(), nil, addons))
+}
+
+type fakeUpgradeableAddon struct {
+	fakeAddon
+	upgradedTo *semver.Version
+}
+
+func (a *fakeUpgradeableAddon) Upgrade(_ context.Context, _ Cluster, version semver.Version) error {
+	a.upgradedTo = &version
+	return nil
+}
+
+// fakeAddonsCluster is a Cluster which only provides addons.
+type fakeAddonsCluster struct {
+	Cluster
+	addons Addons
+}
+
+func (c fakeAddonsCluster) GetAddon(name AddonName) (Addon, error) {
+	if addon, ok := c.addons[name]; ok {
+		return addon, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrAddonNotFound, name)
+}
+
+func TestUpgradeAddon(t *testing.T) {
+	upgradeable := &fakeUpgradeableAddon{fakeAddon: fakeAddon{name: "upgradeable"}}
+	cluster := fakeAddonsCluster{addons: Addons{
+		"upgradeable": upgradeable,
+		"static":      fakeAddon{name: "static"},
+	}}
+	ctx := context.Background()
+
+	require.NoError(t, UpgradeAddon(ctx, cluster, "upgradeable", semver.MustParse("1.2.3")))
+	require.NotNil(t, upgradeable.upgradedTo)
+	assert.Equal(t, "1.2.3", upgradeable.upgradedTo.String())
+
+	require.EqualError(t, UpgradeAddon(ctx, cluster, "static", semver.MustParse("1.2.3")), "addon static does not support upgrades")
+	require.EqualError(t, UpgradeAddon(ctx, cluster, "missing", semver.MustParse("1.2.3")), "addon not found: missing")
+}
+
+// deployRecordingCluster is a Cluster which records addon deployments.
+type deployRecordingCluster struct {
+	Cluster
+
+	lock      sync.Mutex
+	deployed  []AddonName
+	running   int
+	maxActive int
+	failing   AddonName
+}
+
+func (c *deployRecordingCluster) DeployAddon(_ context.Context, addon Addon) error {
+	c.lock.Lock()
+	c.running++
+	if c.running > c.maxActive {
+		c.maxActive = c.running
+	}
+	c.lock.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.running--
+	if addon.Name() == c.failing {
+		return fmt.Errorf("broken")
+	}
+	c.deployed = append(c.deployed, addon.Name())
+	return nil
+}
+
+func TestDeployAddons(t *testing.T) {
+	ctx := context.Background()
+	addons := fakeAddons(
+		fakeAddon{name: "kong", dependencies: []AddonName{"metallb", "gateway-api"}},
+		fakeAddon{name: "gateway-api"},
+		fakeAddon{name: "metallb"},
+		fakeAddon{name: "cert-manager"},
+		fakeAddon{name: "registry", dependencies: []AddonName{"cert-manager"}},
+	)
+	indexOf := func(names []AddonName, name AddonName) int {
+		for i, n := range names {
+			if n == name {
+				return i
+			}
+		}
+		return -1
+	}
+
+	cluster := &deployRecordingCluster{}
+	require.NoError(t, DeployAddons(ctx, cluster, addons, 2))
+	require.Len(t, cluster.deployed, 5)
+	assert.Equal(t, 2, cluster.maxActive)
+	assert.Greater(t, indexOf(cluster.deployed, "kong"), indexOf(cluster.deployed, "metallb"))
+	assert.Greater(t, indexOf(cluster.deployed, "kong"), indexOf(cluster.deployed, "gateway-api"))
+	assert.Greater(t, indexOf(cluster.deployed, "registry"), indexOf(cluster.deployed, "cert-manager"))
+
+	cluster = &deployRecordingCluster{}
+	require.NoError(t, DeployAddons(ctx, cluster, addons, 1))
+	assert.Equal(t, 1, cluster.maxActive)
+
+	cluster = &deployRecordingCluster{failing: "cert-manager"}
+	require.EqualError(t, DeployAddons(ctx, cluster, addons, 0), "failed to deploy addon cert-manager: broken")
+	assert.NotContains(t, cluster.deployed, AddonName("registry"))
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"testing"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 	corev1 "k8s.io/api/core/v1"
@@ -13,6 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
@@ -26,11 +29,12 @@ import (
 // Cleaner holds namespaces and objects for later cleanup. This is generally
 // used during integration tests to clean up test resources.
 type Cleaner struct {
-	cluster    Cluster
-	objects    []client.Object
-	manifests  []string
-	namespaces []*corev1.Namespace
-	lock       sync.RWMutex
+	cluster       Cluster
+	objects       []client.Object
+	manifests     []string
+	namespaces    []*corev1.Namespace
+	keepOnFailure bool
+	lock          sync.RWMutex
 }
 
 // NewCleaner provides a new initialized *Cleaner object.
@@ -38,32 +42,49 @@ func NewCleaner(cluster Cluster) *Cleaner {
 	return &Cleaner{cluster: cluster}
 }
 
+// KeepOnFailure makes CleanupAfterTest skip the cleanup when the test failed,
+// leaving the objects in the cluster for inspection after their diagnostics
+// were dumped.
+func (c *Cleaner) KeepOnFailure() *Cleaner {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.keepOnFailure = true
+	return c
+}
+
 // -----------------------------------------------------------------------------
 // Cleaner - Public
 // -----------------------------------------------------------------------------
 
+// Add registers an object for cleanup. Objects are deleted in the reverse
+// order they were added, so that dependent objects are deleted first.
 func (c *Cleaner) Add(obj client.Object) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 	c.objects = append([]client.Object{obj}, c.objects...)
 }
 
+// AddManifest registers the objects of a YAML manifest for cleanup, manifests
+// are deleted in the reverse order they were added, after objects.
 func (c *Cleaner) AddManifest(manifest string) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 	c.manifests = append(c.manifests, manifest)
 }
 
+// AddNamespace registers a namespace for cleanup, namespaces are deleted
+// last and waited for to be gone.
 func (c *Cleaner) AddNamespace(namespace *corev1.Namespace) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 	c.namespaces = append(c.namespaces, namespace)
 }
 
+// Cleanup deletes the registered objects, manifests and namespaces.
 func (c *Cleaner) Cleanup(ctx context.Context) error {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
-	dyn, err := dynamic.NewForConfig(c.cluster.Config())
+	dyn, err := c.cluster.DynamicClient()
 	if err != nil {
 		return err
 	}
@@ -78,8 +99,8 @@ func (c *Cleaner) Cleanup(ctx context.Context) error {
 		}
 	}
 
-	for _, manifest := range c.manifests {
-		err := DeleteManifestByYAML(ctx, c.cluster, manifest)
+	for i := len(c.manifests) - 1; i >= 0; i-- {
+		err := DeleteManifestByYAML(ctx, c.cluster, c.manifests[i])
 		if err != nil {
 			return err
 		}
@@ -129,12 +150,45 @@ func (c *Cleaner) Cleanup(ctx context.Context) error {
 	return g.Wait()
 }
 
+// cleanupAfterTestTimeout is the maximum amount of time allowed to dump
+// diagnostics and clean up after a test.
+const cleanupAfterTestTimeout = time.Minute * 5
+
+// CleanupAfterTest registers the cleanup with the test (or benchmark), so that
+// the registered objects are deleted when the test and its subtests
+// completed. If the test failed and KeepOnFailure was set, the cluster's
+// diagnostics are dumped and the cleanup is skipped instead.
+func (c *Cleaner) CleanupAfterTest(t testing.TB) {
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cleanupAfterTestTimeout)
+		defer cancel()
+
+		c.lock.RLock()
+		keep := c.keepOnFailure
+		c.lock.RUnlock()
+		if t.Failed() && keep {
+			output, err := c.cluster.DumpDiagnostics(ctx, t.Name())
+			if err != nil {
+				t.Logf("failed to dump diagnostics: %v", err)
+			} else {
+				t.Logf("test failed, dumped diagnostics to %s", output)
+			}
+			t.Log("test failed, skipping cleanup to keep its objects for inspection")
+			return
+		}
+
+		if err := c.Cleanup(ctx); err != nil {
+			t.Errorf("failed to clean up test objects: %v", err)
+		}
+	})
+}
+
 // fixupObjKinds takes a client.Object and checks if it's of one of the gateway
 // API types and if so then it adjusts that object's Kind and APIVersion.
 // This possibly might also need other types to be included but those are enough
 // for our needs for now especially since that will help cleaning up non-namespaced
 // GatewayClasses which are not cleaned up on namespace removal also done in
-// Cleanup().
+// Cleanup(). The kind of other types is looked up in the client-go scheme.
 //
 // The reason we need this is that when decoding to go structs APIVersion and Kind
 // are dropper because the type info is inherent in the object.
@@ -185,6 +239,10 @@ func fixupObjKinds(obj client.Object) client.Object {
 		return o
 
 	default:
+		// built-in types (e.g. ConfigMaps) are registered with the client-go scheme.
+		if gvks, _, err := scheme.Scheme.ObjectKinds(obj); err == nil && len(gvks) > 0 {
+			obj.GetObjectKind().SetGroupVersionKind(gvks[0])
+		}
 		return obj
 	}
 }
@@ -193,7 +251,7 @@ type deleter interface {
 	Delete(ctx context.Context, name string, options metav1.DeleteOptions, subresources ...string) error
 }
 
-func resourceDeleterForObj(dyn *dynamic.DynamicClient, obj client.Object) deleter {
+func resourceDeleterForObj(dyn dynamic.Interface, obj client.Object) deleter {
 	obj = fixupObjKinds(obj)
 
 	var (
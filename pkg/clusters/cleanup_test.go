@@ -1,10 +1,12 @@
 package clusters
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -111,6 +113,19 @@ func TestFixupObjKinds(t *testing.T) {
 				Kind:    "ReferenceGrant",
 			},
 		},
+		{
+			name: "configmap",
+			obj: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-configmap",
+				},
+			},
+			expected: schema.GroupVersionKind{
+				Group:   "",
+				Version: "v1",
+				Kind:    "ConfigMap",
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -142,3 +157,46 @@ func TestCleanerCanBeUsedConcurrently(*testing.T) {
 		}()
 	}
 }
+
+// diagnosticsRecordingCluster is a Cluster which records diagnostics dumps.
+type diagnosticsRecordingCluster struct {
+	Cluster
+	dumped []string
+}
+
+func (c *diagnosticsRecordingCluster) DumpDiagnostics(_ context.Context, meta string) (string, error) {
+	c.dumped = append(c.dumped, meta)
+	return "/tmp/diagnostics", nil
+}
+
+// failedTest is a failed test which records its cleanups and logs.
+type failedTest struct {
+	testing.TB
+	cleanups []func()
+	logs     []string
+}
+
+func (t *failedTest) Name() string            { return "TestFailed" }
+func (t *failedTest) Failed() bool            { return true }
+func (t *failedTest) Cleanup(f func())        { t.cleanups = append(t.cleanups, f) }
+func (t *failedTest) Log(args ...interface{}) { t.logs = append(t.logs, fmt.Sprint(args...)) }
+func (t *failedTest) Logf(f string, args ...interface{}) {
+	t.logs = append(t.logs, fmt.Sprintf(f, args...))
+}
+
+func TestCleanerKeepsObjectsOfFailedTests(t *testing.T) {
+	cluster := &diagnosticsRecordingCluster{}
+	cleaner := NewCleaner(cluster).KeepOnFailure()
+	cleaner.AddNamespace(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kept"}})
+
+	test := &failedTest{}
+	cleaner.CleanupAfterTest(test)
+	require.Len(t, test.cleanups, 1)
+	test.cleanups[0]() // the cleanup would fail as the cluster has no config
+
+	assert.Equal(t, []string{"TestFailed"}, cluster.dumped)
+	assert.Equal(t, []string{
+		"test failed, dumped diagnostics to /tmp/diagnostics",
+		"test failed, skipping cleanup to keep its objects for inspection",
+	}, test.logs)
+}
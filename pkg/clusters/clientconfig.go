@@ -0,0 +1,121 @@
+package clusters
+
+import (
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+// -----------------------------------------------------------------------------
+// Client Options
+// -----------------------------------------------------------------------------
+
+const (
+	// EnvClientQPS is the environment variable which can be used to configure
+	// the default ClientOptions.QPS, e.g. "50".
+	EnvClientQPS = "KTF_CLIENT_QPS"
+
+	// EnvClientBurst is the environment variable which can be used to
+	// configure the default ClientOptions.Burst, e.g. "100".
+	EnvClientBurst = "KTF_CLIENT_BURST"
+
+	// EnvClientTimeout is the environment variable which can be used to
+	// configure the default ClientOptions.Timeout, e.g. "30s".
+	EnvClientTimeout = "KTF_CLIENT_TIMEOUT"
+
+	// EnvClientUserAgent is the environment variable which can be used to
+	// configure the default ClientOptions.UserAgent.
+	EnvClientUserAgent = "KTF_CLIENT_USER_AGENT"
+)
+
+// ClientOptions tune the clients of the clusters built by cluster builders, by
+// configuring their *rest.Config. Zero values keep the client-go defaults
+// (5 QPS, bursts of 10 requests, no timeout and the user agent of the binary),
+// which throttle tests creating many objects.
+type ClientOptions struct {
+	// QPS is the maximum number of queries per second sent to the API server.
+	QPS float32
+
+	// Burst is the maximum number of queries sent at once, above QPS.
+	Burst int
+
+	// Timeout is the maximum amount of time of each request.
+	Timeout time.Duration
+
+	// UserAgent is the User-Agent of requests, e.g. to tell apart the requests
+	// of tests in audit logs.
+	UserAgent string
+}
+
+// Apply configures the config with the options which are not zero.
+func (o ClientOptions) Apply(cfg *rest.Config) {
+	if o.QPS != 0 {
+		cfg.QPS = o.QPS
+	}
+	if o.Burst != 0 {
+		cfg.Burst = o.Burst
+	}
+	if o.Timeout != 0 {
+		cfg.Timeout = o.Timeout
+	}
+	if o.UserAgent != "" {
+		cfg.UserAgent = o.UserAgent
+	}
+}
+
+var (
+	defaultClientOptions     ClientOptions
+	defaultClientOptionsLock sync.RWMutex
+	defaultClientOptionsInit sync.Once
+)
+
+// loadDefaultClientOptions populates the default options from the
+// environment, once. Invalid values are ignored.
+func loadDefaultClientOptions() {
+	defaultClientOptionsInit.Do(func() {
+		defaultClientOptionsLock.Lock()
+		defer defaultClientOptionsLock.Unlock()
+
+		if qps, err := strconv.ParseFloat(os.Getenv(EnvClientQPS), 32); err == nil {
+			defaultClientOptions.QPS = float32(qps)
+		}
+		if burst, err := strconv.Atoi(os.Getenv(EnvClientBurst)); err == nil {
+			defaultClientOptions.Burst = burst
+		}
+		if timeout, err := time.ParseDuration(os.Getenv(EnvClientTimeout)); err == nil {
+			defaultClientOptions.Timeout = timeout
+		}
+		defaultClientOptions.UserAgent = os.Getenv(EnvClientUserAgent)
+	})
+}
+
+// SetDefaultClientOptions configures the options of the clients of all the
+// clusters built or loaded from then on, unless their builder overrides them.
+// The defaults are otherwise configured by the EnvClient* environment
+// variables.
+func SetDefaultClientOptions(opts ClientOptions) {
+	loadDefaultClientOptions()
+	defaultClientOptionsLock.Lock()
+	defer defaultClientOptionsLock.Unlock()
+	defaultClientOptions = opts
+}
+
+// DefaultClientOptions provides the default options of the clients of clusters.
+func DefaultClientOptions() ClientOptions {
+	loadDefaultClientOptions()
+	defaultClientOptionsLock.RLock()
+	defer defaultClientOptionsLock.RUnlock()
+	return defaultClientOptions
+}
+
+// ConfigureClient configures the config with the default client options and
+// then with the provided options, for use by Cluster implementations.
+func ConfigureClient(cfg *rest.Config, opts ...ClientOptions) {
+	DefaultClientOptions().Apply(cfg)
+	for _, o := range opts {
+		o.Apply(cfg)
+	}
+}
@@ -0,0 +1,29 @@
+package clusters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/rest"
+)
+
+func TestConfigureClient(t *testing.T) {
+	defaults := DefaultClientOptions()
+	t.Cleanup(func() { SetDefaultClientOptions(defaults) })
+
+	SetDefaultClientOptions(ClientOptions{QPS: 50, Burst: 100, UserAgent: "ktf-tests"})
+	cfg := &rest.Config{Timeout: time.Minute}
+	ConfigureClient(cfg, ClientOptions{Burst: 200, Timeout: 30 * time.Second})
+
+	assert.Equal(t, float32(50), cfg.QPS)
+	assert.Equal(t, 200, cfg.Burst)
+	assert.Equal(t, 30*time.Second, cfg.Timeout)
+	assert.Equal(t, "ktf-tests", cfg.UserAgent)
+}
+
+func TestClientOptionsApplyKeepsZeroValues(t *testing.T) {
+	cfg := &rest.Config{QPS: 5, Burst: 10, UserAgent: "agent"}
+	ClientOptions{}.Apply(cfg)
+	assert.Equal(t, &rest.Config{QPS: 5, Burst: 10, UserAgent: "agent"}, cfg)
+}
@@ -0,0 +1,72 @@
+package clusters
+
+import (
+	"sync"
+
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+)
+
+// -----------------------------------------------------------------------------
+// Clients
+// -----------------------------------------------------------------------------
+
+// Clients lazily constructs and caches the clients of a cluster besides its
+// typed clientset, for use by Cluster implementations. The zero value is ready
+// for use.
+type Clients struct {
+	lock          sync.Mutex
+	dynamic       dynamic.Interface
+	discovery     discovery.CachedDiscoveryInterface
+	apiextensions apiextensionsclient.Interface
+}
+
+// Dynamic provides the dynamic client for the cluster of the configuration,
+// constructing it on first use.
+func (c *Clients) Dynamic(cfg *rest.Config) (dynamic.Interface, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.dynamic == nil {
+		client, err := dynamic.NewForConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		c.dynamic = client
+	}
+	return c.dynamic, nil
+}
+
+// Discovery provides the discovery client for the cluster of the
+// configuration, constructing it on first use. Discovery information is
+// cached in memory, Invalidate() it to discover resources added later (e.g.
+// by CRDs).
+func (c *Clients) Discovery(cfg *rest.Config) (discovery.CachedDiscoveryInterface, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.discovery == nil {
+		client, err := discovery.NewDiscoveryClientForConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		c.discovery = memory.NewMemCacheClient(client)
+	}
+	return c.discovery, nil
+}
+
+// APIExtensions provides the apiextensions client (for CRDs) for the cluster
+// of the configuration, constructing it on first use.
+func (c *Clients) APIExtensions(cfg *rest.Config) (apiextensionsclient.Interface, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.apiextensions == nil {
+		client, err := apiextensionsclient.NewForConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		c.apiextensions = client
+	}
+	return c.apiextensions, nil
+}
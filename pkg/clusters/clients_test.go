@@ -0,0 +1,32 @@
+package clusters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+)
+
+func TestClientsAreCached(t *testing.T) {
+	cfg := &rest.Config{Host: "https://127.0.0.1:6443"}
+	var clients Clients
+
+	dynamicClient, err := clients.Dynamic(cfg)
+	require.NoError(t, err)
+	cached, err := clients.Dynamic(cfg)
+	require.NoError(t, err)
+	assert.Same(t, dynamicClient, cached)
+
+	discoveryClient, err := clients.Discovery(cfg)
+	require.NoError(t, err)
+	cachedDiscovery, err := clients.Discovery(cfg)
+	require.NoError(t, err)
+	assert.Same(t, discoveryClient, cachedDiscovery)
+
+	apiextensionsClient, err := clients.APIExtensions(cfg)
+	require.NoError(t, err)
+	cachedAPIExtensions, err := clients.APIExtensions(cfg)
+	require.NoError(t, err)
+	assert.Same(t, apiextensionsClient, cachedAPIExtensions)
+}
@@ -2,8 +2,12 @@ package clusters
 
 import (
 	"context"
+	"time"
 
 	"github.com/blang/semver/v4"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 )
@@ -34,8 +38,8 @@ type Cluster interface {
 	// Type indicates the type of Kubernetes Cluster (e.g. Kind, GKE, e.t.c.)
 	Type() Type
 
-	// Version indicates the Kubernetes server version of the cluster.
-	Version() (semver.Version, error)
+	// Version queries the Kubernetes version of the cluster's live API server.
+	Version(ctx context.Context) (semver.Version, error)
 
 	// Client is the configured *kubernetes.Clientset which can be used to access the Cluster's API
 	Client() *kubernetes.Clientset
@@ -43,19 +47,36 @@ type Cluster interface {
 	// Config provides the *rest.Config for the cluster which is convenient for initiating custom kubernetes.Clientsets.
 	Config() *rest.Config
 
+	// DynamicClient provides a dynamic client for the cluster, e.g. for custom
+	// resources. It's constructed on first use and cached (see Clients).
+	DynamicClient() (dynamic.Interface, error)
+
+	// DiscoveryClient provides a discovery client for the cluster, which
+	// caches discovery information. It's constructed on first use and cached.
+	DiscoveryClient() (discovery.CachedDiscoveryInterface, error)
+
+	// APIExtensionsClient provides a client for the apiextensions API of the
+	// cluster, e.g. for CRDs. It's constructed on first use and cached.
+	APIExtensionsClient() (apiextensionsclient.Interface, error)
+
 	// Cleanup performance any cleanup and teardown needed to destroy the cluster.
 	Cleanup(ctx context.Context) error
 
 	// GetAddon retrieves and Addon object from the cluster if that addon was previously loaded.
+	// The error wraps ErrAddonNotFound if it wasn't.
 	GetAddon(name AddonName) (Addon, error)
 
-	// ListAddons lists the addon components currently loaded into the cluster.
+	// ListAddons lists the addon components currently loaded into the cluster, sorted by name.
 	ListAddons() []Addon
 
-	// DeployAddon deploys a new addon component to the cluster.
+	// DeployAddon deploys a new addon component to the cluster. The error wraps
+	// ErrAddonAlreadyDeployed if an addon of the same name was deployed already.
+	// It's safe to deploy addons concurrently, see DeployedAddons.
 	DeployAddon(ctx context.Context, addon Addon) error
 
-	// DeleteAddon removes an existing cluster Addon.
+	// DeleteAddon removes an existing cluster Addon, including its namespace
+	// unless that's shared with other components, so that tests can verify
+	// behavior when a dependency disappears mid-run.
 	DeleteAddon(ctx context.Context, addon Addon) error
 
 	// DumpDiagnostics dumps the diagnostic data to temporary directory and return the name
@@ -65,6 +86,33 @@ type Cluster interface {
 
 	// IPFamily returns the cluster's IP networking capabilities.
 	IPFamily() IPFamily
+
+	// Metadata describes the cluster, e.g. its provider, location and labels,
+	// so that tooling can introspect clusters generically.
+	Metadata() Metadata
+}
+
+// Metadata describes a cluster, e.g. for test reports or janitor tooling.
+type Metadata struct {
+	// Provider is the type of the cluster (e.g. kind or gke).
+	Provider Type
+
+	// Region is the cloud region of the cluster, if any.
+	Region string
+
+	// Zone is the cloud zone of the cluster, if it's zonal.
+	Zone string
+
+	// NodeImage is the image of the cluster's nodes, e.g. the kind node image
+	// or the GKE node image type.
+	NodeImage string
+
+	// CreationTime is when the cluster was created, zero if unknown.
+	CreationTime time.Time
+
+	// Labels are the labels of the cluster, including any custom labels it was
+	// built with.
+	Labels map[string]string
 }
 
 type Builder interface {
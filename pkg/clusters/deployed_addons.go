@@ -0,0 +1,139 @@
+package clusters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+// Deployed Addons - Errors
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrAddonNotFound is wrapped by the errors of Cluster.GetAddon when no
+	// addon of the given name is deployed to the cluster.
+	ErrAddonNotFound = errors.New("addon not found")
+
+	// ErrAddonAlreadyDeployed is wrapped by the errors of Cluster.DeployAddon
+	// when an addon of the same name is deployed to the cluster already.
+	ErrAddonAlreadyDeployed = errors.New("addon already deployed")
+)
+
+// MissingAddonDependencyError is returned when an addon can't be deployed
+// because an addon it depends on was never deployed to the cluster.
+type MissingAddonDependencyError struct {
+	// Addon is the name of the addon being deployed.
+	Addon AddonName
+
+	// Dependency is the name of the missing addon.
+	Dependency AddonName
+
+	// Err is the reason for giving up waiting for the dependency, e.g. the
+	// context being done.
+	Err error
+}
+
+func (e *MissingAddonDependencyError) Error() string {
+	return fmt.Sprintf("addon %s depends on addon %s which is not deployed: %v", e.Addon, e.Dependency, e.Err)
+}
+
+func (e *MissingAddonDependencyError) Unwrap() error {
+	return e.Err
+}
+
+// -----------------------------------------------------------------------------
+// Deployed Addons
+// -----------------------------------------------------------------------------
+
+// DeployedAddons keeps track of the addons deployed to a cluster, for use by
+// Cluster implementations. It's safe for concurrent use and the zero value is
+// ready for use.
+type DeployedAddons struct {
+	lock   sync.RWMutex
+	addons Addons
+}
+
+// Get provides the deployed addon of the given name, or an error wrapping
+// ErrAddonNotFound.
+func (d *DeployedAddons) Get(name AddonName) (Addon, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	addon, ok := d.addons[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrAddonNotFound, name)
+	}
+	return addon, nil
+}
+
+// List provides the deployed addons sorted by name.
+func (d *DeployedAddons) List() []Addon {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	addons := make([]Addon, 0, len(d.addons))
+	for _, addon := range d.addons {
+		addons = append(addons, addon)
+	}
+	sort.Slice(addons, func(i, j int) bool { return addons[i].Name() < addons[j].Name() })
+	return addons
+}
+
+// Deploy deploys the addon to the cluster, failing with an error wrapping
+// ErrAddonAlreadyDeployed if an addon of the same name was deployed already.
+// The addon is tracked from before its deployment starts, so that addons
+// depending on it can find it, and remains tracked if its deployment fails so
+// that it's deleted along with the others.
+func (d *DeployedAddons) Deploy(ctx context.Context, cluster Cluster, addon Addon) error {
+	d.lock.Lock()
+	if _, ok := d.addons[addon.Name()]; ok {
+		d.lock.Unlock()
+		return fmt.Errorf("addon %s can not be deployed to cluster %s: %w", addon.Name(), cluster.Name(), ErrAddonAlreadyDeployed)
+	}
+	if d.addons == nil {
+		d.addons = make(Addons)
+	}
+	d.addons[addon.Name()] = addon
+	d.lock.Unlock()
+
+	logger := Logger(cluster).WithValues("cluster", cluster.Name(), "addon", addon.Name())
+	logger.Info("deploying addon")
+	EmitEvent(Event{Type: EventAddonDeploying, Cluster: cluster.Name(), Addon: addon.Name()})
+	start := time.Now()
+	err := addon.Deploy(ctx, cluster)
+	EmitEvent(Event{Type: EventAddonDeployed, Cluster: cluster.Name(), Addon: addon.Name(), Duration: time.Since(start), Err: err})
+	if err != nil {
+		logger.Error(err, "failed to deploy addon")
+		return err
+	}
+	logger.Info("deployed addon", "duration", time.Since(start))
+	return nil
+}
+
+// Delete deletes the addon from the cluster if it's deployed and stops
+// tracking it once that succeeded. The lock isn't held while the addon is
+// deleted, so the addon may look up other addons of the cluster meanwhile.
+func (d *DeployedAddons) Delete(ctx context.Context, cluster Cluster, addon Addon) error {
+	d.lock.RLock()
+	_, ok := d.addons[addon.Name()]
+	d.lock.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	logger := Logger(cluster).WithValues("cluster", cluster.Name(), "addon", addon.Name())
+	logger.Info("deleting addon")
+	if err := addon.Delete(ctx, cluster); err != nil {
+		logger.Error(err, "failed to delete addon")
+		return err
+	}
+
+	d.lock.Lock()
+	delete(d.addons, addon.Name())
+	d.lock.Unlock()
+	return nil
+}
@@ -0,0 +1,131 @@
+package clusters
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hookAddon is an addon whose deployment and deletion run the given
+// functions, if any.
+type hookAddon struct {
+	fakeAddon
+	deploy func(context.Context, Cluster) error
+	delete func(context.Context, Cluster) error
+}
+
+func (a hookAddon) Deploy(ctx context.Context, cluster Cluster) error {
+	if a.deploy == nil {
+		return nil
+	}
+	return a.deploy(ctx, cluster)
+}
+
+func (a hookAddon) Delete(ctx context.Context, cluster Cluster) error {
+	if a.delete == nil {
+		return nil
+	}
+	return a.delete(ctx, cluster)
+}
+
+// deployedAddonsCluster is a Cluster whose addons are tracked by
+// DeployedAddons.
+type deployedAddonsCluster struct {
+	fixedCluster
+	addons *DeployedAddons
+}
+
+func (c deployedAddonsCluster) GetAddon(name AddonName) (Addon, error) { return c.addons.Get(name) }
+
+func TestDeployedAddons(t *testing.T) {
+	ctx := context.Background()
+	cluster := deployedAddonsCluster{addons: &DeployedAddons{}}
+	addons := cluster.addons
+
+	_, err := addons.Get("kong")
+	require.ErrorIs(t, err, ErrAddonNotFound)
+	assert.Empty(t, addons.List())
+
+	require.NoError(t, addons.Deploy(ctx, cluster, fakeAddon{name: "kong"}))
+	require.NoError(t, addons.Deploy(ctx, cluster, fakeAddon{name: "metallb"}))
+	err = addons.Deploy(ctx, cluster, fakeAddon{name: "kong"})
+	require.ErrorIs(t, err, ErrAddonAlreadyDeployed)
+	assert.EqualError(t, err, "addon kong can not be deployed to cluster fixed: addon already deployed")
+
+	addon, err := addons.Get("kong")
+	require.NoError(t, err)
+	assert.Equal(t, AddonName("kong"), addon.Name())
+
+	// failed deployments remain tracked, so that they're deleted.
+	require.Error(t, addons.Deploy(ctx, cluster, hookAddon{
+		fakeAddon: fakeAddon{name: "broken"},
+		deploy:    func(context.Context, Cluster) error { return errors.New("broken") },
+	}))
+	names := func() []AddonName {
+		var names []AddonName
+		for _, addon := range addons.List() {
+			names = append(names, addon.Name())
+		}
+		return names
+	}
+	assert.Equal(t, []AddonName{"broken", "kong", "metallb"}, names())
+
+	// deleting an addon can look up the other addons of the cluster.
+	require.NoError(t, addons.Delete(ctx, cluster, hookAddon{
+		fakeAddon: fakeAddon{name: "kong"},
+		delete: func(_ context.Context, cluster Cluster) error {
+			_, err := cluster.GetAddon("metallb")
+			return err
+		},
+	}))
+	require.NoError(t, addons.Delete(ctx, cluster, fakeAddon{name: "missing"}))
+	assert.Equal(t, []AddonName{"broken", "metallb"}, names())
+}
+
+func TestDeployedAddonsConcurrentDeploys(t *testing.T) {
+	ctx := context.Background()
+	cluster := deployedAddonsCluster{addons: &DeployedAddons{}}
+
+	const attempts = 10
+	var (
+		wg     sync.WaitGroup
+		lock   sync.Mutex
+		failed int
+	)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := cluster.addons.Deploy(ctx, cluster, fakeAddon{name: "kong"})
+			if err != nil {
+				assert.ErrorIs(t, err, ErrAddonAlreadyDeployed)
+				lock.Lock()
+				failed++
+				lock.Unlock()
+			}
+			_ = cluster.addons.List()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, attempts-1, failed)
+	assert.Len(t, cluster.addons.List(), 1)
+}
+
+func TestWaitForAddonDependenciesMissing(t *testing.T) {
+	cluster := deployedAddonsCluster{addons: &DeployedAddons{}}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := WaitForAddonDependencies(ctx, cluster, fakeAddon{name: "kong", dependencies: []AddonName{"metallb"}})
+	var missing *MissingAddonDependencyError
+	require.ErrorAs(t, err, &missing)
+	assert.Equal(t, AddonName("kong"), missing.Addon)
+	assert.Equal(t, AddonName("metallb"), missing.Dependency)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -3,11 +3,19 @@ package clusters
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"text/tabwriter"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/kubernetes/events"
 )
 
 // DiagnosticOutDirectoryPrefix is the tmpdir prefix used for diagnostic dumps.
@@ -138,6 +146,14 @@ func DumpDiagnostics(ctx context.Context, c Cluster, meta string, outDir string)
 		return err
 	}
 
+	// write errors if we failed to dump the state of the cluster, which is
+	// partially written in that case.
+	if err := DumpClusterState(ctx, c, outDir); err != nil {
+		if writeErr := os.WriteFile(filepath.Join(outDir, "cluster_state_error.txt"), []byte(err.Error()), 0o600); writeErr != nil { //nolint:gomnd
+			return writeErr
+		}
+	}
+
 	err = DumpAllDescribeAll(ctx, c, outDir)
 	// write errors if we failed to dump results of `kubectl get all` or `kubectl describe all`.
 	// in cases where kubernetes cluster may not be correctly created.
@@ -155,3 +171,179 @@ func DumpDiagnostics(ctx context.Context, c Cluster, meta string, outDir string)
 
 	return nil
 }
+
+// DumpClusterState writes a structured snapshot of the state of the cluster to
+// the "cluster" directory within outDir: the status of nodes (nodes.txt), the
+// pods of all namespaces (pods.txt), the events of all namespaces sorted by
+// time (events.txt) and the logs of the containers of failing pods, including
+// the previous logs of restarted containers (failing_pod_logs/<namespace>/<pod>).
+func DumpClusterState(ctx context.Context, c Cluster, outDir string) error {
+	stateDir := filepath.Join(outDir, "cluster")
+	if err := os.MkdirAll(stateDir, 0o750); err != nil { //nolint:gomnd
+		return err
+	}
+
+	nodes, err := c.Client().CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("could not list nodes: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(stateDir, "nodes.txt"), []byte(formatNodes(nodes.Items)), 0o600); err != nil { //nolint:gomnd
+		return err
+	}
+
+	pods, err := c.Client().CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("could not list pods: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(stateDir, "pods.txt"), []byte(formatPods(pods.Items)), 0o600); err != nil { //nolint:gomnd
+		return err
+	}
+
+	events, err := c.Client().CoreV1().Events(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("could not list events: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(stateDir, "events.txt"), []byte(formatEvents(events.Items)), 0o600); err != nil { //nolint:gomnd
+		return err
+	}
+
+	var errs []error
+	for _, pod := range pods.Items {
+		if !isFailingPod(pod) {
+			continue
+		}
+		if err := dumpPodLogs(ctx, c, pod, filepath.Join(stateDir, "failing_pod_logs", pod.Namespace, pod.Name)); err != nil {
+			errs = append(errs, fmt.Errorf("could not dump logs of pod %s/%s: %w", pod.Namespace, pod.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// dumpPodLogs writes the logs of all containers of a pod to outDir, as
+// <container>.log and <container>.previous.log for restarted containers.
+func dumpPodLogs(ctx context.Context, c Cluster, pod corev1.Pod, outDir string) error {
+	if err := os.MkdirAll(outDir, 0o750); err != nil { //nolint:gomnd
+		return err
+	}
+
+	restarted := make(map[string]bool)
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		restarted[status.Name] = status.RestartCount > 0
+	}
+
+	var errs []error
+	for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		previous := []bool{false}
+		if restarted[container.Name] {
+			previous = append(previous, true)
+		}
+		for _, prev := range previous {
+			filename := container.Name + ".log"
+			if prev {
+				filename = container.Name + ".previous.log"
+			}
+			logs, err := c.Client().CoreV1().Pods(pod.Namespace).
+				GetLogs(pod.Name, &corev1.PodLogOptions{Container: container.Name, Previous: prev}).
+				DoRaw(ctx)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("container %s: %w", container.Name, err))
+				continue
+			}
+			if err := os.WriteFile(filepath.Join(outDir, filename), logs, 0o600); err != nil { //nolint:gomnd
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// isFailingPod indicates whether a pod is failed, or has containers which are
+// not ready or have restarted. Completed pods aren't failing.
+func isFailingPod(pod corev1.Pod) bool {
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		return false
+	case corev1.PodFailed, corev1.PodPending, corev1.PodUnknown:
+		return true
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if !status.Ready || status.RestartCount > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// formatNodes describes the status of nodes, one line per node followed by
+// its conditions which aren't nominal.
+func formatNodes(nodes []corev1.Node) string {
+	out := new(strings.Builder)
+	for _, node := range nodes {
+		ready := "Unknown"
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady {
+				ready = string(condition.Status)
+			}
+		}
+		fmt.Fprintf(out, "%s ready=%s kubelet=%s unschedulable=%t\n", node.Name, ready, node.Status.NodeInfo.KubeletVersion, node.Spec.Unschedulable)
+		for _, condition := range node.Status.Conditions {
+			nominal := condition.Status == corev1.ConditionFalse
+			if condition.Type == corev1.NodeReady {
+				nominal = condition.Status == corev1.ConditionTrue
+			}
+			if !nominal {
+				fmt.Fprintf(out, "  %s=%s %s: %s\n", condition.Type, condition.Status, condition.Reason, condition.Message)
+			}
+		}
+	}
+	return out.String()
+}
+
+// formatPods describes pods similarly to "kubectl get pods -A -o wide".
+func formatPods(pods []corev1.Pod) string {
+	out := new(strings.Builder)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0) //nolint:gomnd
+	fmt.Fprintln(w, "NAMESPACE\tNAME\tREADY\tPHASE\tRESTARTS\tNODE\tREASON")
+	for _, pod := range pods {
+		var ready int
+		var restarts int32
+		reasons := make([]string, 0)
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Ready {
+				ready++
+			}
+			restarts += status.RestartCount
+			if status.State.Waiting != nil && status.State.Waiting.Reason != "" {
+				reasons = append(reasons, status.State.Waiting.Reason)
+			}
+		}
+		if pod.Status.Reason != "" {
+			reasons = append(reasons, pod.Status.Reason)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d/%d\t%s\t%d\t%s\t%s\n", pod.Namespace, pod.Name, ready, len(pod.Spec.Containers),
+			pod.Status.Phase, restarts, pod.Spec.NodeName, strings.Join(reasons, ","))
+	}
+	_ = w.Flush()
+	return out.String()
+}
+
+// formatEvents describes events sorted by the time they were last seen.
+func formatEvents(items []corev1.Event) string {
+	sorted := make([]corev1.Event, len(items))
+	copy(sorted, items)
+	events.SortByLastSeen(sorted)
+
+	out := new(strings.Builder)
+	for _, event := range sorted {
+		object := fmt.Sprintf("%s %s/%s", event.InvolvedObject.Kind, event.InvolvedObject.Namespace, event.InvolvedObject.Name)
+		if event.InvolvedObject.Namespace == "" {
+			object = fmt.Sprintf("%s %s", event.InvolvedObject.Kind, event.InvolvedObject.Name)
+		}
+		count := event.Count
+		if count == 0 {
+			count = 1
+		}
+		fmt.Fprintf(out, "%s %s %s %s (x%d): %s\n", events.LastSeen(event).UTC().Format(time.RFC3339), event.Type, object, event.Reason, count, event.Message)
+	}
+	return out.String()
+}
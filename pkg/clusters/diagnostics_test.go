@@ -0,0 +1,84 @@
+package clusters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIsFailingPod(t *testing.T) {
+	running := func(ready bool, restarts int32) corev1.Pod {
+		return corev1.Pod{Status: corev1.PodStatus{
+			Phase:             corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "app", Ready: ready, RestartCount: restarts}},
+		}}
+	}
+
+	assert.False(t, isFailingPod(running(true, 0)))
+	assert.True(t, isFailingPod(running(false, 0)))
+	assert.True(t, isFailingPod(running(true, 2)))
+	assert.True(t, isFailingPod(corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodPending}}))
+	assert.False(t, isFailingPod(corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodSucceeded}}))
+}
+
+func TestFormatPods(t *testing.T) {
+	pods := []corev1.Pod{{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kong", Name: "proxy"},
+		Spec:       corev1.PodSpec{NodeName: "node-1", Containers: []corev1.Container{{Name: "proxy"}, {Name: "controller"}}},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "proxy", Ready: true},
+				{Name: "controller", RestartCount: 3, State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+			},
+		},
+	}}
+
+	assert.Equal(t, "NAMESPACE  NAME   READY  PHASE    RESTARTS  NODE    REASON\n"+
+		"kong       proxy  1/2    Running  3         node-1  CrashLoopBackOff\n", formatPods(pods))
+}
+
+func TestFormatEvents(t *testing.T) {
+	at := func(minute int) metav1.Time {
+		return metav1.NewTime(time.Date(2024, 1, 1, 0, minute, 0, 0, time.UTC))
+	}
+	events := []corev1.Event{
+		{
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "kong", Name: "proxy"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "BackOff",
+			Message:        "Back-off restarting failed container",
+			Count:          4,
+			LastTimestamp:  at(5),
+		},
+		{
+			InvolvedObject: corev1.ObjectReference{Kind: "Node", Name: "node-1"},
+			Type:           corev1.EventTypeNormal,
+			Reason:         "Starting",
+			Message:        "Starting kubelet.",
+			LastTimestamp:  at(1),
+		},
+	}
+
+	assert.Equal(t, "2024-01-01T00:01:00Z Normal Node node-1 Starting (x1): Starting kubelet.\n"+
+		"2024-01-01T00:05:00Z Warning Pod kong/proxy BackOff (x4): Back-off restarting failed container\n", formatEvents(events))
+}
+
+func TestFormatNodes(t *testing.T) {
+	nodes := []corev1.Node{{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Status: corev1.NodeStatus{
+			NodeInfo: corev1.NodeSystemInfo{KubeletVersion: "v1.29.1"},
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionFalse, Reason: "KubeletNotReady", Message: "container runtime network not ready"},
+				{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionFalse},
+			},
+		},
+	}}
+
+	assert.Equal(t, "node-1 ready=False kubelet=v1.29.1 unschedulable=false\n"+
+		"  Ready=False KubeletNotReady: container runtime network not ready\n", formatNodes(nodes))
+}
@@ -0,0 +1,126 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/images"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/wait"
+)
+
+// -----------------------------------------------------------------------------
+// In-Cluster DNS
+// -----------------------------------------------------------------------------
+
+// DNSUtilsImage is the image of the pods resolving names with
+// ResolveInCluster, which provides dig.
+const DNSUtilsImage = "registry.k8s.io/e2e-test-images/jessie-dnsutils:1.3"
+
+// DNSResult is the outcome of resolving a name from inside the cluster.
+type DNSResult struct {
+	// Name is the resolved name.
+	Name string
+
+	// Addresses are the IPv4 and IPv6 addresses the name resolved to, sorted.
+	// It's empty if the name doesn't exist.
+	Addresses []string
+
+	// CanonicalNames are the names the name is an alias (CNAME) of, e.g. the
+	// external name of an ExternalName Service, in the order they were
+	// followed.
+	CanonicalNames []string
+}
+
+// Found indicates whether the name resolved to any address.
+func (r DNSResult) Found() bool {
+	return len(r.Addresses) > 0
+}
+
+// ResolveInCluster resolves the names from a short-lived pod in the namespace,
+// as workloads of the namespace would (i.e. using the search domains of the
+// namespace, so "service" and "service.namespace" are resolved too). This
+// allows asserting the DNS records of Services, e.g. of ExternalName or
+// headless Services.
+func ResolveInCluster(ctx context.Context, cluster Cluster, namespace string, names ...string) ([]DNSResult, error) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "ktf-dns-" + uuid.NewString()[:8]},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:    "dnsutils",
+				Image:   images.Mirror(DNSUtilsImage),
+				Command: []string{"sleep", "3600"},
+			}},
+			RestartPolicy:                 corev1.RestartPolicyNever,
+			TerminationGracePeriodSeconds: ptr.To(int64(0)),
+		},
+	}
+	pods := cluster.Client().CoreV1().Pods(namespace)
+	pod, err := pods.Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("could not create pod to resolve names: %w", err)
+	}
+	defer func() {
+		// the pod is deleted even if the context is done already.
+		_ = pods.Delete(context.WithoutCancel(ctx), pod.Name, metav1.DeleteOptions{})
+	}()
+
+	if err := wait.Until(ctx, fmt.Sprintf("pod %s/%s to run", namespace, pod.Name), func(ctx context.Context) (bool, string, error) {
+		current, err := pods.Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, "", err
+		}
+		return current.Status.Phase == corev1.PodRunning, string(current.Status.Phase), nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ResolveInPod(ctx, cluster, namespace, pod.Name, "", names...)
+}
+
+// ResolveInPod resolves the names by executing dig in a container of an
+// existing pod (the default container of the pod if no container is
+// provided), e.g. to resolve names as the pod of a controller does.
+func ResolveInPod(ctx context.Context, cluster Cluster, namespace, pod, container string, names ...string) ([]DNSResult, error) {
+	results := make([]DNSResult, 0, len(names))
+	for _, name := range names {
+		// A and AAAA records are queried, following the search domains.
+		result, err := ExecInPod(ctx, cluster, namespace, pod, container, "dig", "+search", "+short", name, "A", name, "AAAA")
+		if err != nil {
+			return nil, err
+		}
+		if result.ExitCode != 0 {
+			return nil, fmt.Errorf("could not resolve %s in pod %s/%s (exit code %d): %s", name, namespace, pod, result.ExitCode, result.Stderr)
+		}
+		results = append(results, parseDigShort(name, result.Stdout))
+	}
+	return results, nil
+}
+
+// parseDigShort parses the output of "dig +short", which lists the canonical
+// names followed by the addresses of each query.
+func parseDigShort(name, output string) DNSResult {
+	result := DNSResult{Name: name}
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, ";") || seen[line] {
+			continue
+		}
+		seen[line] = true
+		if net.ParseIP(line) != nil {
+			result.Addresses = append(result.Addresses, line)
+		} else {
+			result.CanonicalNames = append(result.CanonicalNames, strings.TrimSuffix(line, "."))
+		}
+	}
+	sort.Strings(result.Addresses)
+	return result
+}
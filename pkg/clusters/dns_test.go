@@ -0,0 +1,49 @@
+package clusters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDigShort(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		output   string
+		expected DNSResult
+	}{
+		{
+			name:     "service",
+			output:   "10.96.0.1\n",
+			expected: DNSResult{Name: "service", Addresses: []string{"10.96.0.1"}},
+		},
+		{
+			name:   "headless",
+			output: "10.244.0.7\n10.244.0.5\nfd00:10:244::5\n",
+			expected: DNSResult{
+				Name:      "headless",
+				Addresses: []string{"10.244.0.5", "10.244.0.7", "fd00:10:244::5"},
+			},
+		},
+		{
+			name:   "external",
+			output: "example.com.\n93.184.216.34\nexample.com.\n2606:2800:220:1:248:1893:25c8:1946\n",
+			expected: DNSResult{
+				Name:           "external",
+				Addresses:      []string{"2606:2800:220:1:248:1893:25c8:1946", "93.184.216.34"},
+				CanonicalNames: []string{"example.com"},
+			},
+		},
+		{
+			name:     "missing",
+			output:   "",
+			expected: DNSResult{Name: "missing"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			result := parseDigShort(tc.name, tc.output)
+			assert.Equal(t, tc.expected, result)
+			assert.Equal(t, len(tc.expected.Addresses) > 0, result.Found())
+		})
+	}
+}
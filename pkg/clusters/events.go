@@ -0,0 +1,152 @@
+package clusters
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+// Events
+// -----------------------------------------------------------------------------
+
+// EventType is the type of an Event.
+type EventType string
+
+const (
+	// EventClusterCreating is emitted when the creation of a cluster starts.
+	EventClusterCreating EventType = "ClusterCreating"
+
+	// EventClusterCreated is emitted when a cluster was created, or failed to
+	// be (see Event.Err).
+	EventClusterCreated EventType = "ClusterCreated"
+
+	// EventAddonDeploying is emitted when the deployment of an addon starts.
+	EventAddonDeploying EventType = "AddonDeploying"
+
+	// EventAddonDeployed is emitted when an addon was deployed, or failed to
+	// be (see Event.Err).
+	EventAddonDeployed EventType = "AddonDeployed"
+
+	// EventReady is emitted when a cluster and its addons became ready after
+	// waiting for them.
+	EventReady EventType = "Ready"
+
+	// EventReadinessCheckFailed is emitted when waiting for a cluster and its
+	// addons to be ready fails, e.g. because they didn't become ready in time.
+	EventReadinessCheckFailed EventType = "ReadinessCheckFailed"
+
+	// EventCleanupStarted is emitted when the cleanup of a cluster starts.
+	EventCleanupStarted EventType = "CleanupStarted"
+
+	// EventCleanupFinished is emitted when a cluster was cleaned up, or failed
+	// to be (see Event.Err).
+	EventCleanupFinished EventType = "CleanupFinished"
+)
+
+// Event reports the progress of a long-running operation, e.g. for progress
+// reporting or to time operations.
+type Event struct {
+	// Type is the type of the event.
+	Type EventType
+
+	// Time is when the event was emitted.
+	Time time.Time
+
+	// Cluster is the name of the cluster of the operation.
+	Cluster string
+
+	// Addon is the name of the addon of the operation, if any.
+	Addon AddonName
+
+	// Duration is how long the operation took, for the events emitted when an
+	// operation is done.
+	Duration time.Duration
+
+	// Err is the error which failed the operation, if any.
+	Err error
+}
+
+// EventHandler handles the events it subscribed to. Handlers are called
+// synchronously by the goroutine emitting events, so they must be quick and
+// safe for concurrent use.
+type EventHandler func(Event)
+
+// EventBus dispatches events to their subscribers. The zero value is ready
+// for use.
+type EventBus struct {
+	lock     sync.RWMutex
+	nextID   int
+	handlers map[int]EventHandler
+}
+
+// Subscribe registers the handler to be called with every event emitted from
+// then on, until the returned function is called.
+func (b *EventBus) Subscribe(handler EventHandler) (unsubscribe func()) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.handlers == nil {
+		b.handlers = make(map[int]EventHandler)
+	}
+	id := b.nextID
+	b.nextID++
+	b.handlers[id] = handler
+
+	return func() {
+		b.lock.Lock()
+		defer b.lock.Unlock()
+		delete(b.handlers, id)
+	}
+}
+
+// Emit calls the subscribed handlers with the event, in the order of their
+// subscription. The time of the event is set if it's zero.
+func (b *EventBus) Emit(event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	b.lock.RLock()
+	ids := make([]int, 0, len(b.handlers))
+	for id := range b.handlers {
+		ids = append(ids, id)
+	}
+	handlers := make([]EventHandler, 0, len(ids))
+	sort.Ints(ids)
+	for _, id := range ids {
+		handlers = append(handlers, b.handlers[id])
+	}
+	b.lock.RUnlock()
+
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
+
+// Events is the bus which clusters, their builders and addons emit events to.
+var Events = &EventBus{}
+
+// SubscribeEvents registers the handler to be called with every event emitted
+// to Events from then on, until the returned function is called. Events are
+// emitted for all clusters, see SubscribeClusterEvents to only handle the
+// events of one (e.g. with tests running in parallel).
+func SubscribeEvents(handler EventHandler) (unsubscribe func()) {
+	return Events.Subscribe(handler)
+}
+
+// SubscribeClusterEvents registers the handler to be called with the events
+// of the named cluster emitted to Events from then on, until the returned
+// function is called.
+func SubscribeClusterEvents(cluster string, handler EventHandler) (unsubscribe func()) {
+	return Events.Subscribe(func(event Event) {
+		if event.Cluster == cluster {
+			handler(event)
+		}
+	})
+}
+
+// EmitEvent emits the event to Events, for use by Cluster, Builder and Addon
+// implementations.
+func EmitEvent(event Event) {
+	Events.Emit(event)
+}
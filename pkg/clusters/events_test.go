@@ -0,0 +1,52 @@
+package clusters
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventBus(t *testing.T) {
+	var bus EventBus
+	var received []string
+	unsubscribeFirst := bus.Subscribe(func(e Event) { received = append(received, "first:"+string(e.Type)) })
+	unsubscribeSecond := bus.Subscribe(func(e Event) {
+		assert.False(t, e.Time.IsZero())
+		received = append(received, "second:"+string(e.Type))
+	})
+	defer unsubscribeSecond()
+
+	bus.Emit(Event{Type: EventClusterCreating})
+	unsubscribeFirst()
+	bus.Emit(Event{Type: EventClusterCreated})
+
+	assert.Equal(t, []string{"first:ClusterCreating", "second:ClusterCreating", "second:ClusterCreated"}, received)
+}
+
+func TestDeployedAddonsEmitEvents(t *testing.T) {
+	var (
+		lock   sync.Mutex
+		events []Event
+	)
+	unsubscribe := SubscribeClusterEvents("fixed", func(e Event) {
+		lock.Lock()
+		defer lock.Unlock()
+		events = append(events, e)
+	})
+	defer unsubscribe()
+
+	var addons DeployedAddons
+	cluster := deployedAddonsCluster{addons: &addons}
+	failing := hookAddon{fakeAddon: fakeAddon{name: "failing"}, deploy: func(context.Context, Cluster) error { return errors.New("boom") }}
+	require.Error(t, addons.Deploy(context.Background(), cluster, failing))
+
+	require.Len(t, events, 2)
+	assert.Equal(t, EventAddonDeploying, events[0].Type)
+	assert.Equal(t, AddonName("failing"), events[0].Addon)
+	assert.Equal(t, EventAddonDeployed, events[1].Type)
+	assert.EqualError(t, events[1].Err, "boom")
+}
@@ -0,0 +1,61 @@
+package clusters
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/util/exec"
+)
+
+// -----------------------------------------------------------------------------
+// Pod Exec
+// -----------------------------------------------------------------------------
+
+// ExecResult is the outcome of a command executed in a pod.
+type ExecResult struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+}
+
+// ExecInPod executes a command in a container of a pod (the default container
+// of the pod if no container is provided), e.g. to curl a service from inside
+// the cluster network. A command which ran but exited with a non-zero code is
+// not an error, its ExitCode is set in the result instead.
+func ExecInPod(ctx context.Context, cluster Cluster, namespace, pod, container string, command ...string) (*ExecResult, error) {
+	if len(command) == 0 {
+		return nil, fmt.Errorf("no command provided to execute in pod %s/%s", namespace, pod)
+	}
+
+	req := cluster.Client().CoreV1().RESTClient().Post().
+		Resource("pods").Namespace(namespace).Name(pod).SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+	executor, err := remotecommand.NewSPDYExecutor(cluster.Config(), http.MethodPost, req.URL())
+	if err != nil {
+		return nil, err
+	}
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{Stdout: stdout, Stderr: stderr})
+	result := &ExecResult{Stdout: stdout.String(), Stderr: stderr.String()}
+	if err != nil {
+		var exitErr exec.CodeExitError
+		if errors.As(err, &exitErr) {
+			result.ExitCode = exitErr.Code
+			return result, nil
+		}
+		return result, fmt.Errorf("could not execute %q in pod %s/%s: %w", command, namespace, pod, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,107 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// -----------------------------------------------------------------------------
+// Ingress Addresses
+// -----------------------------------------------------------------------------
+
+// IngressAddressProvider is an Addon serving ingress traffic (e.g. a proxy or
+// a gateway) which provides the URL at which its data plane is reachable from
+// tests, so that tests don't depend on how it's exposed by the cluster.
+type IngressAddressProvider interface {
+	Addon
+
+	// IngressURL provides the URL of the data plane of the addon. It may
+	// start forwarding a port, which stops once the provided context is done.
+	IngressURL(ctx context.Context, cluster Cluster) (*url.URL, error)
+}
+
+// NodePortAddresser is a Cluster whose NodePort Services are reachable from
+// the host running the tests, e.g. kind clusters.
+type NodePortAddresser interface {
+	// NodePortAddress provides the host:port address at which the given port
+	// of a NodePort (or LoadBalancer) Service can be reached.
+	NodePortAddress(ctx context.Context, namespace, name string, port int32) (string, error)
+}
+
+// IngressURL provides the URL of the data plane of the addon of the cluster
+// which is an IngressAddressProvider. It fails unless exactly one addon of the
+// cluster is.
+func IngressURL(ctx context.Context, cluster Cluster) (*url.URL, error) {
+	var providers []IngressAddressProvider
+	for _, addon := range cluster.ListAddons() {
+		if provider, ok := addon.(IngressAddressProvider); ok {
+			providers = append(providers, provider)
+		}
+	}
+
+	switch len(providers) {
+	case 0:
+		return nil, fmt.Errorf("no addon of cluster %s provides an ingress address", cluster.Name())
+	case 1:
+		return providers[0].IngressURL(ctx, cluster)
+	default:
+		names := make([]string, 0, len(providers))
+		for _, provider := range providers {
+			names = append(names, string(provider.Name()))
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("multiple addons of cluster %s provide an ingress address: %s", cluster.Name(), strings.Join(names, ", "))
+	}
+}
+
+// ServiceAddress provides the host:port address at which the given port of a
+// Service can be reached from the host running the tests, for implementations
+// of IngressAddressProvider. In order of preference that's the address of its
+// load balancer, its node port if the cluster is a NodePortAddresser, or a
+// local port forwarded to it until the provided context is done.
+func ServiceAddress(ctx context.Context, cluster Cluster, namespace, name string, port int32) (string, error) {
+	service, err := cluster.Client().CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	address, err := exposedServiceAddress(ctx, cluster, service, port)
+	if err != nil || address != "" {
+		return address, err
+	}
+
+	address, _, err = PortForward(ctx, cluster, namespace, "service/"+name, int(port))
+	return address, err
+}
+
+// exposedServiceAddress provides the address of the load balancer or node
+// port of a Service, if it's exposed outside the cluster.
+func exposedServiceAddress(ctx context.Context, cluster Cluster, service *corev1.Service, port int32) (string, error) {
+	if service.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		for _, ingress := range service.Status.LoadBalancer.Ingress {
+			host := ingress.IP
+			if host == "" {
+				host = ingress.Hostname
+			}
+			if host != "" {
+				return net.JoinHostPort(host, strconv.Itoa(int(port))), nil
+			}
+		}
+	}
+
+	if addresser, ok := cluster.(NodePortAddresser); ok {
+		if service.Spec.Type == corev1.ServiceTypeNodePort || service.Spec.Type == corev1.ServiceTypeLoadBalancer {
+			return addresser.NodePortAddress(ctx, service.Namespace, service.Name, port)
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,122 @@
+package clusters
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// fakeIngressAddon is an addon providing a fixed ingress URL.
+type fakeIngressAddon struct {
+	fakeAddon
+	url *url.URL
+}
+
+func (a fakeIngressAddon) IngressURL(context.Context, Cluster) (*url.URL, error) { return a.url, nil }
+
+// listAddonsCluster is a Cluster which lists the given addons.
+type listAddonsCluster struct {
+	fakeAddonsCluster
+}
+
+func (c listAddonsCluster) Name() string { return "ingress" }
+func (c listAddonsCluster) ListAddons() []Addon {
+	addons := make([]Addon, 0, len(c.addons))
+	for _, addon := range c.addons {
+		addons = append(addons, addon)
+	}
+	return addons
+}
+
+func TestIngressURL(t *testing.T) {
+	ctx := context.Background()
+	proxyURL := &url.URL{Scheme: "http", Host: "172.18.0.100"}
+	cluster := listAddonsCluster{fakeAddonsCluster{addons: Addons{
+		"static": fakeAddon{name: "static"},
+	}}}
+
+	_, err := IngressURL(ctx, cluster)
+	require.EqualError(t, err, "no addon of cluster ingress provides an ingress address")
+
+	cluster.addons["proxy"] = fakeIngressAddon{fakeAddon: fakeAddon{name: "proxy"}, url: proxyURL}
+	u, err := IngressURL(ctx, cluster)
+	require.NoError(t, err)
+	assert.Equal(t, proxyURL, u)
+
+	cluster.addons["gateway"] = fakeIngressAddon{fakeAddon: fakeAddon{name: "gateway"}, url: proxyURL}
+	_, err = IngressURL(ctx, cluster)
+	require.EqualError(t, err, "multiple addons of cluster ingress provide an ingress address: gateway, proxy")
+}
+
+// nodePortCluster is a Cluster whose node ports are reachable at a fixed host.
+type nodePortCluster struct {
+	Cluster
+}
+
+func (nodePortCluster) NodePortAddress(context.Context, string, string, int32) (string, error) {
+	return "172.18.0.2:30080", nil
+}
+
+func TestExposedServiceAddress(t *testing.T) {
+	ctx := context.Background()
+	service := func(serviceType corev1.ServiceType, ingress ...corev1.LoadBalancerIngress) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kong", Name: "proxy"},
+			Spec:       corev1.ServiceSpec{Type: serviceType},
+			Status:     corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{Ingress: ingress}},
+		}
+	}
+
+	for _, tc := range []struct {
+		name     string
+		cluster  Cluster
+		service  *corev1.Service
+		expected string
+	}{
+		{
+			name:     "load balancer IP",
+			cluster:  nodePortCluster{},
+			service:  service(corev1.ServiceTypeLoadBalancer, corev1.LoadBalancerIngress{IP: "172.18.0.100"}),
+			expected: "172.18.0.100:80",
+		},
+		{
+			name:     "load balancer hostname",
+			cluster:  fixedCluster{},
+			service:  service(corev1.ServiceTypeLoadBalancer, corev1.LoadBalancerIngress{Hostname: "lb.example.com"}),
+			expected: "lb.example.com:80",
+		},
+		{
+			name:     "pending load balancer on cluster with node ports",
+			cluster:  nodePortCluster{},
+			service:  service(corev1.ServiceTypeLoadBalancer),
+			expected: "172.18.0.2:30080",
+		},
+		{
+			name:     "node port",
+			cluster:  nodePortCluster{},
+			service:  service(corev1.ServiceTypeNodePort),
+			expected: "172.18.0.2:30080",
+		},
+		{
+			name:    "node port on cluster without reachable node ports",
+			cluster: fixedCluster{},
+			service: service(corev1.ServiceTypeNodePort),
+		},
+		{
+			name:    "cluster IP",
+			cluster: nodePortCluster{},
+			service: service(corev1.ServiceTypeClusterIP),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			address, err := exposedServiceAddress(ctx, tc.cluster, tc.service, 80)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, address)
+		})
+	}
+}
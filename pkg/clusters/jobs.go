@@ -0,0 +1,159 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/images"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/wait"
+)
+
+// -----------------------------------------------------------------------------
+// One-Shot Jobs
+// -----------------------------------------------------------------------------
+
+// DefaultJobTimeout is the maximum amount of time RunJob waits for a Job to
+// complete when neither the options nor the context provide one.
+const DefaultJobTimeout = 5 * time.Minute
+
+// JobOptions configure the Job run by RunJob.
+type JobOptions struct {
+	// Namespace is the namespace of the Job, "default" if empty.
+	Namespace string
+
+	// Env is the environment of the container.
+	Env []corev1.EnvVar
+
+	// Timeout is the maximum amount of time waited for the Job to complete, or
+	// until the context is done if it has a deadline, DefaultJobTimeout
+	// otherwise.
+	Timeout time.Duration
+
+	// KeepJob keeps the Job and its pod once it completed, e.g. to inspect
+	// them. They're deleted by default.
+	KeepJob bool
+}
+
+// JobResult is the outcome of a Job run by RunJob.
+type JobResult struct {
+	// Name is the name of the Job.
+	Name string
+
+	// Logs are the logs of the container.
+	Logs string
+
+	// ExitCode is the exit code of the container.
+	ExitCode int
+
+	// Succeeded indicates whether the Job completed successfully.
+	Succeeded bool
+}
+
+// RunJob runs the command in a container of the image as a Job, waits for it
+// to complete and provides its logs and exit code, e.g. to run migrations,
+// smoke commands or requests from inside the cluster network. The container
+// runs once and a command which exits with a non-zero code is not an error,
+// the result reports it instead.
+func RunJob(ctx context.Context, cluster Cluster, image string, command []string, opts JobOptions) (*JobResult, error) {
+	return runJob(ctx, cluster.Client(), image, command, opts)
+}
+
+func runJob(ctx context.Context, c kubernetes.Interface, image string, command []string, opts JobOptions) (*JobResult, error) {
+	if len(command) == 0 {
+		return nil, fmt.Errorf("no command provided to run as a job")
+	}
+	namespace := opts.Namespace
+	if namespace == "" {
+		namespace = corev1.NamespaceDefault
+	}
+	timeout := opts.Timeout
+	if _, ok := ctx.Deadline(); !ok && timeout <= 0 {
+		timeout = DefaultJobTimeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	jobs := c.BatchV1().Jobs(namespace)
+	job, err := jobs.Create(ctx, &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: "ktf-job-"},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To(int32(0)),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:    "job",
+						Image:   images.Mirror(image),
+						Command: command,
+						Env:     opts.Env,
+					}},
+					RestartPolicy: corev1.RestartPolicyNever,
+				},
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("could not create job: %w", err)
+	}
+	if !opts.KeepJob {
+		defer func() {
+			// the job is deleted even if the context is done already.
+			_ = jobs.Delete(context.WithoutCancel(ctx), job.Name, metav1.DeleteOptions{
+				PropagationPolicy: ptr.To(metav1.DeletePropagationBackground),
+			})
+		}()
+	}
+
+	result := &JobResult{Name: job.Name}
+	if err := wait.Until(ctx, fmt.Sprintf("job %s/%s to complete", namespace, job.Name), func(ctx context.Context) (bool, string, error) {
+		current, err := jobs.Get(ctx, job.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, "", err
+		}
+		for _, condition := range current.Status.Conditions {
+			if condition.Status != corev1.ConditionTrue {
+				continue
+			}
+			switch condition.Type {
+			case batchv1.JobComplete:
+				result.Succeeded = true
+				return true, "", nil
+			case batchv1.JobFailed:
+				return true, "", nil
+			}
+		}
+		return false, fmt.Sprintf("%d active", current.Status.Active), nil
+	}); err != nil {
+		return nil, err
+	}
+
+	pods, err := c.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: "job-name=" + job.Name})
+	if err != nil {
+		return nil, fmt.Errorf("could not list pods of job %s/%s: %w", namespace, job.Name, err)
+	}
+	if len(pods.Items) == 0 {
+		return result, fmt.Errorf("job %s/%s has no pods", namespace, job.Name)
+	}
+	pod := pods.Items[0]
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Terminated != nil {
+			result.ExitCode = int(status.State.Terminated.ExitCode)
+		}
+	}
+
+	logs, err := c.CoreV1().Pods(namespace).GetLogs(pod.Name, &corev1.PodLogOptions{}).DoRaw(ctx)
+	if err != nil {
+		return result, fmt.Errorf("could not get logs of job %s/%s: %w", namespace, job.Name, err)
+	}
+	result.Logs = string(logs)
+	return result, nil
+}
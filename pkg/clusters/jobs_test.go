@@ -0,0 +1,69 @@
+package clusters
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// completingJobs makes the created jobs of the clientset complete at once
+// with the given condition, with a pod whose container exited with the given
+// code.
+func completingJobs(c *fake.Clientset, condition batchv1.JobConditionType, exitCode int32) {
+	c.PrependReactor("create", "jobs", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		job := action.(k8stesting.CreateAction).GetObject().(*batchv1.Job)
+		job.Name = job.GenerateName + "test"
+		job.Status.Conditions = []batchv1.JobCondition{{Type: condition, Status: corev1.ConditionTrue}}
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: action.GetNamespace(), Name: job.Name + "-abcde", Labels: map[string]string{"job-name": job.Name}},
+			Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "job",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode}},
+			}}},
+		}
+		return false, nil, c.Tracker().Add(pod)
+	})
+}
+
+func TestRunJob(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("succeeded", func(t *testing.T) {
+		c := fake.NewSimpleClientset()
+		completingJobs(c, batchv1.JobComplete, 0)
+
+		result, err := runJob(ctx, c, "curlimages/curl", []string{"curl", "http://kong-proxy.kong"}, JobOptions{Namespace: "kong", KeepJob: true})
+		require.NoError(t, err)
+		assert.Equal(t, &JobResult{Name: "ktf-job-test", Logs: "fake logs", Succeeded: true}, result)
+
+		job, err := c.BatchV1().Jobs("kong").Get(ctx, "ktf-job-test", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"curl", "http://kong-proxy.kong"}, job.Spec.Template.Spec.Containers[0].Command)
+		assert.Equal(t, int32(0), *job.Spec.BackoffLimit)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		c := fake.NewSimpleClientset()
+		completingJobs(c, batchv1.JobFailed, 7)
+
+		result, err := runJob(ctx, c, "busybox", []string{"false"}, JobOptions{})
+		require.NoError(t, err)
+		assert.False(t, result.Succeeded)
+		assert.Equal(t, 7, result.ExitCode)
+
+		jobs, err := c.BatchV1().Jobs(corev1.NamespaceDefault).List(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, jobs.Items, "the job is deleted")
+	})
+
+	_, err := runJob(ctx, fake.NewSimpleClientset(), "busybox", nil, JobOptions{})
+	require.EqualError(t, err, "no command provided to run as a job")
+}
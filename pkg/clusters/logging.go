@@ -0,0 +1,26 @@
+package clusters
+
+import (
+	"github.com/go-logr/logr"
+)
+
+// -----------------------------------------------------------------------------
+// Logging
+// -----------------------------------------------------------------------------
+
+// LoggerCluster is a Cluster which logs its progress (e.g. of its creation,
+// addon deployments and cleanup) to a logger, configured by its builder.
+type LoggerCluster interface {
+	// Logger provides the logger of the cluster.
+	Logger() logr.Logger
+}
+
+// Logger provides the logger of the cluster, for addons and other components
+// to log their progress along with the cluster's. It discards all logs if the
+// cluster doesn't support logging or wasn't configured with a logger.
+func Logger(cluster Cluster) logr.Logger {
+	if loggerCluster, ok := cluster.(LoggerCluster); ok {
+		return loggerCluster.Logger()
+	}
+	return logr.Discard()
+}
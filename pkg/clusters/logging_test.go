@@ -0,0 +1,38 @@
+package clusters
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// loggerCluster is a Cluster logging to the given logger.
+type loggerCluster struct {
+	fixedCluster
+	logger logr.Logger
+}
+
+func (c loggerCluster) Logger() logr.Logger { return c.logger }
+
+func TestLogger(t *testing.T) {
+	assert.Nil(t, Logger(fixedCluster{}).GetSink(), "clusters which don't support logging discard logs")
+
+	var logs []string
+	logger := funcr.New(func(prefix, args string) { logs = append(logs, args) }, funcr.Options{})
+	cluster := loggerCluster{logger: logger}
+
+	var addons DeployedAddons
+	require.NoError(t, addons.Deploy(context.Background(), cluster, hookAddon{fakeAddon: fakeAddon{name: "ok"}}))
+	failing := hookAddon{fakeAddon: fakeAddon{name: "failing"}, deploy: func(context.Context, Cluster) error { return errors.New("boom") }}
+	require.Error(t, addons.Deploy(context.Background(), cluster, failing))
+
+	require.Len(t, logs, 4)
+	assert.Contains(t, logs[0], `"msg"="deploying addon" "cluster"="fixed" "addon"="ok"`)
+	assert.Contains(t, logs[1], `"msg"="deployed addon" "cluster"="fixed" "addon"="ok" "duration"=`)
+	assert.Contains(t, logs[3], `"msg"="failed to deploy addon" "error"="boom" "cluster"="fixed" "addon"="failing"`)
+}
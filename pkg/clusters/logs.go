@@ -0,0 +1,294 @@
+package clusters
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+)
+
+// -----------------------------------------------------------------------------
+// Pod Logs - Options
+// -----------------------------------------------------------------------------
+
+// LogOptions select which logs of pods are captured or streamed.
+type LogOptions struct {
+	// Container limits the logs to the container of the given name, the logs
+	// of all containers are provided if empty.
+	Container string
+
+	// Since limits the logs to the ones newer than the given duration.
+	Since time.Duration
+
+	// TailLines limits the logs to the given number of most recent lines of
+	// each container, if greater than 0.
+	TailLines int64
+
+	// Previous provides the logs of the previous instance of restarted
+	// containers. It only applies to captured logs.
+	Previous bool
+}
+
+// podLogOptions provides the options to get the logs of a container.
+func (o LogOptions) podLogOptions(container string) *corev1.PodLogOptions {
+	logOpts := &corev1.PodLogOptions{Container: container, Previous: o.Previous}
+	if o.Since > 0 {
+		logOpts.SinceSeconds = ptr.To(max(int64(o.Since.Seconds()), 1))
+	}
+	if o.TailLines > 0 {
+		logOpts.TailLines = ptr.To(o.TailLines)
+	}
+	return logOpts
+}
+
+// DeploymentPodSelector provides the label selector of the pods of a
+// deployment, to capture or stream their logs.
+func DeploymentPodSelector(ctx context.Context, cluster Cluster, namespace, name string) (string, error) {
+	deployment, err := cluster.Client().AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return "", fmt.Errorf("invalid selector for deployment %s/%s: %w", namespace, name, err)
+	}
+	return selector.String(), nil
+}
+
+// -----------------------------------------------------------------------------
+// Pod Logs - Capture
+// -----------------------------------------------------------------------------
+
+// CaptureLogs writes the current logs of the pods matching the label selector
+// in the namespace to w, each line prefixed with "[<pod>/<container>] ".
+func CaptureLogs(ctx context.Context, cluster Cluster, namespace, selector string, opts LogOptions, w io.Writer) error {
+	out := &logLineWriter{w: w}
+	return captureLogs(ctx, cluster.Client(), namespace, selector, opts, func(pod, container string, logs []byte) error {
+		return out.copyLines(fmt.Sprintf("[%s/%s] ", pod, container), bytes.NewReader(logs))
+	})
+}
+
+// CaptureLogsToDir writes the current logs of the pods matching the label
+// selector in the namespace to dir, as <pod>/<container>.log.
+func CaptureLogsToDir(ctx context.Context, cluster Cluster, namespace, selector string, opts LogOptions, dir string) error {
+	return captureLogs(ctx, cluster.Client(), namespace, selector, opts, func(pod, container string, logs []byte) error {
+		podDir := filepath.Join(dir, pod)
+		if err := os.MkdirAll(podDir, 0o750); err != nil { //nolint:gomnd
+			return err
+		}
+		return os.WriteFile(filepath.Join(podDir, container+".log"), logs, 0o600) //nolint:gomnd
+	})
+}
+
+// captureLogs gets the logs of the containers of the pods matching the label
+// selector and hands them to write.
+func captureLogs(ctx context.Context, c kubernetes.Interface, namespace, selector string, opts LogOptions,
+	write func(pod, container string, logs []byte) error,
+) error {
+	pods, err := c.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, pod := range pods.Items {
+		for _, container := range logContainers(pod, opts, true) {
+			logs, err := c.CoreV1().Pods(namespace).GetLogs(pod.Name, opts.podLogOptions(container)).DoRaw(ctx)
+			if err == nil {
+				err = write(pod.Name, container, logs)
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("could not capture logs of %s/%s container %s: %w", namespace, pod.Name, container, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// -----------------------------------------------------------------------------
+// Pod Logs - Streaming
+// -----------------------------------------------------------------------------
+
+// logFollowInterval is the interval at which new pods and restarted
+// containers are looked for while streaming logs.
+const logFollowInterval = time.Second
+
+// StreamLogs follows the logs of the pods matching the label selector in the
+// namespace and writes them to w, each line prefixed with "[<pod>/<container>] ",
+// until the provided context is done. Pods created later (e.g. by a rollout)
+// are followed as well, as are containers after they restarted.
+func StreamLogs(ctx context.Context, cluster Cluster, namespace, selector string, opts LogOptions, w io.Writer) error {
+	return streamLogs(ctx, cluster.Client(), namespace, selector, opts, &logLineWriter{w: w})
+}
+
+// StreamLogsToTest streams the logs of the pods matching the label selector
+// in the namespace (see StreamLogs) to the output of the test, until the test
+// and its subtests completed.
+func StreamLogsToTest(ctx context.Context, t testing.TB, cluster Cluster, namespace, selector string, opts LogOptions) {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := StreamLogs(ctx, cluster, namespace, selector, opts, testLogWriter{t}); err != nil {
+			t.Logf("streaming logs of pods %q in namespace %s failed: %v", selector, namespace, err)
+		}
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+func streamLogs(ctx context.Context, c kubernetes.Interface, namespace, selector string, opts LogOptions, out *logLineWriter) error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	ticker := time.NewTicker(logFollowInterval)
+	defer ticker.Stop()
+
+	following := make(map[string]bool)
+	for {
+		pods, err := c.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		for _, pod := range pods.Items {
+			for _, container := range logContainers(pod, opts, false) {
+				key := string(pod.UID) + "/" + container
+				if following[key] {
+					continue
+				}
+				following[key] = true
+				wg.Add(1)
+				go func(pod, container string) {
+					defer wg.Done()
+					followContainerLogs(ctx, c, namespace, pod, container, opts, out)
+				}(pod.Name, container)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// followContainerLogs follows the logs of a container, including after it
+// restarted, until the pod is gone or finished or the context is done.
+func followContainerLogs(ctx context.Context, c kubernetes.Interface, namespace, pod, container string, opts LogOptions, out *logLineWriter) {
+	logOpts := opts.podLogOptions(container)
+	logOpts.Previous = false
+	logOpts.Follow = true
+	prefix := fmt.Sprintf("[%s/%s] ", pod, container)
+
+	restarts := int32(-1)
+	for {
+		current, err := c.CoreV1().Pods(namespace).Get(ctx, pod, metav1.GetOptions{})
+		if err != nil && (apierrors.IsNotFound(err) || ctx.Err() != nil) {
+			return
+		}
+		if err == nil {
+			finished := current.Status.Phase == corev1.PodSucceeded || current.Status.Phase == corev1.PodFailed
+			if finished && restarts >= 0 {
+				return
+			}
+			// a container instance is streamed once it started, restarts
+			// provide a new instance whose logs are streamed from then on.
+			status := containerStatus(current, container)
+			if status != nil && status.State.Waiting == nil && status.RestartCount != restarts {
+				restarts = status.RestartCount
+				if stream, err := c.CoreV1().Pods(namespace).GetLogs(pod, logOpts).Stream(ctx); err == nil {
+					_ = out.copyLines(prefix, stream)
+					stream.Close()
+				}
+				logOpts.SinceTime = ptr.To(metav1.Now())
+				logOpts.SinceSeconds, logOpts.TailLines = nil, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(logFollowInterval):
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Pod Logs - Private
+// -----------------------------------------------------------------------------
+
+// logContainers provides the names of the containers of a pod whose logs are
+// selected by the options, optionally including init containers.
+func logContainers(pod corev1.Pod, opts LogOptions, includeInit bool) []string {
+	containers := pod.Spec.Containers
+	if includeInit {
+		containers = append(append([]corev1.Container{}, pod.Spec.InitContainers...), containers...)
+	}
+	var names []string
+	for _, container := range containers {
+		if opts.Container == "" || opts.Container == container.Name {
+			names = append(names, container.Name)
+		}
+	}
+	return names
+}
+
+func containerStatus(pod *corev1.Pod, container string) *corev1.ContainerStatus {
+	for i := range pod.Status.ContainerStatuses {
+		if pod.Status.ContainerStatuses[i].Name == container {
+			return &pod.Status.ContainerStatuses[i]
+		}
+	}
+	return nil
+}
+
+// logLineWriter writes prefixed lines of logs to a writer, which may be
+// shared by several containers whose lines aren't interleaved.
+type logLineWriter struct {
+	lock sync.Mutex
+	w    io.Writer
+}
+
+func (l *logLineWriter) copyLines(prefix string, r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), 1024*1024) //nolint:gomnd
+	for scanner.Scan() {
+		l.lock.Lock()
+		_, err := fmt.Fprintf(l.w, "%s%s\n", prefix, scanner.Text())
+		l.lock.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// testLogWriter writes lines to the output of a test.
+type testLogWriter struct {
+	t testing.TB
+}
+
+func (w testLogWriter) Write(p []byte) (int, error) {
+	w.t.Log(strings.TrimSuffix(string(p), "\n"))
+	return len(p), nil
+}
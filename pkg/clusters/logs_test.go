@@ -0,0 +1,79 @@
+package clusters
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+func TestLogOptions(t *testing.T) {
+	assert.Equal(t, &corev1.PodLogOptions{Container: "proxy"}, LogOptions{}.podLogOptions("proxy"))
+	assert.Equal(t, &corev1.PodLogOptions{
+		Container:    "proxy",
+		Previous:     true,
+		SinceSeconds: ptr.To(int64(1)),
+		TailLines:    ptr.To(int64(10)),
+	}, LogOptions{Since: time.Millisecond, TailLines: 10, Previous: true}.podLogOptions("proxy"))
+}
+
+// logsPod provides a running pod with an init container and two containers.
+func logsPod(name string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kong", Name: name, UID: types.UID("uid-" + name), Labels: map[string]string{"app": "kong"}},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "migrations"}},
+			Containers:     []corev1.Container{{Name: "proxy"}, {Name: "controller"}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "proxy", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+				{Name: "controller", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{}}},
+			},
+		},
+	}
+}
+
+func TestCaptureLogs(t *testing.T) {
+	c := fake.NewSimpleClientset(logsPod("kong-1"))
+
+	captured := make(map[string]string)
+	require.NoError(t, captureLogs(context.Background(), c, "kong", "app=kong", LogOptions{}, func(pod, container string, logs []byte) error {
+		captured[pod+"/"+container] = string(logs)
+		return nil
+	}))
+	assert.Equal(t, map[string]string{
+		"kong-1/migrations": "fake logs",
+		"kong-1/proxy":      "fake logs",
+		"kong-1/controller": "fake logs",
+	}, captured)
+
+	captured = make(map[string]string)
+	require.NoError(t, captureLogs(context.Background(), c, "kong", "app=kong", LogOptions{Container: "proxy"}, func(pod, container string, logs []byte) error {
+		captured[pod+"/"+container] = string(logs)
+		return nil
+	}))
+	assert.Equal(t, map[string]string{"kong-1/proxy": "fake logs"}, captured)
+}
+
+func TestStreamLogs(t *testing.T) {
+	c := fake.NewSimpleClientset(logsPod("kong-1"), logsPod("kong-2"))
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// only started containers are streamed, each instance once.
+	out := new(bytes.Buffer)
+	require.NoError(t, streamLogs(ctx, c, "kong", "app=kong", LogOptions{}, &logLineWriter{w: out}))
+	assert.ElementsMatch(t, []string{"[kong-1/proxy] fake logs", "[kong-2/proxy] fake logs"},
+		strings.Split(strings.TrimSpace(out.String()), "\n"))
+}
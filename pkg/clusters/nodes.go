@@ -0,0 +1,82 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/wait"
+)
+
+// -----------------------------------------------------------------------------
+// Node Scaling
+// -----------------------------------------------------------------------------
+
+// NodeScaler is a Cluster which can change its number of worker nodes, so
+// that node churn scenarios can be tested regardless of the provider.
+type NodeScaler interface {
+	// ScaleTo adds or removes worker nodes until the cluster has n worker
+	// nodes, and waits for all of them to be Ready. Control plane nodes aren't
+	// counted.
+	ScaleTo(ctx context.Context, n int) error
+}
+
+// nodeReadyTimeout is the maximum amount of time waited for nodes to be Ready
+// when the provided context doesn't have a deadline, as nodes take longer to
+// start than most objects.
+const nodeReadyTimeout = 10 * time.Minute
+
+// ScaleNodes scales the cluster to n worker nodes if it's a NodeScaler.
+func ScaleNodes(ctx context.Context, cluster Cluster, n int) error {
+	scaler, ok := cluster.(NodeScaler)
+	if !ok {
+		return fmt.Errorf("cluster %s of type %s does not support scaling nodes", cluster.Name(), cluster.Type())
+	}
+	return scaler.ScaleTo(ctx, n)
+}
+
+// WaitForWorkerNodes waits until the cluster has exactly n worker nodes, all
+// of which are Ready, for implementations of NodeScaler.
+func WaitForWorkerNodes(ctx context.Context, c kubernetes.Interface, n int) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, nodeReadyTimeout)
+		defer cancel()
+	}
+
+	return wait.Until(ctx, fmt.Sprintf("%d ready worker nodes", n), func(ctx context.Context) (bool, string, error) {
+		nodes, err := c.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return false, "", err
+		}
+		total, ready := countWorkerNodes(nodes.Items)
+		return total == n && ready == n, fmt.Sprintf("%d nodes, %d ready", total, ready), nil
+	})
+}
+
+// countWorkerNodes counts the nodes which aren't control plane nodes, and the
+// ones of them which are Ready.
+func countWorkerNodes(nodes []corev1.Node) (total, ready int) {
+	for _, node := range nodes {
+		if isControlPlaneNode(node) {
+			continue
+		}
+		total++
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+				ready++
+			}
+		}
+	}
+	return total, ready
+}
+
+func isControlPlaneNode(node corev1.Node) bool {
+	_, controlPlane := node.Labels["node-role.kubernetes.io/control-plane"]
+	_, master := node.Labels["node-role.kubernetes.io/master"]
+	return controlPlane || master
+}
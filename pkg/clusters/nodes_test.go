@@ -0,0 +1,64 @@
+package clusters
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testNode(name string, ready bool, labels map[string]string) *corev1.Node {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}},
+		},
+	}
+}
+
+func TestCountWorkerNodes(t *testing.T) {
+	total, ready := countWorkerNodes([]corev1.Node{
+		*testNode("control-plane", true, map[string]string{"node-role.kubernetes.io/control-plane": ""}),
+		*testNode("worker", true, nil),
+		*testNode("worker2", false, nil),
+	})
+	assert.Equal(t, 2, total)
+	assert.Equal(t, 1, ready)
+}
+
+func TestWaitForWorkerNodes(t *testing.T) {
+	c := fake.NewSimpleClientset(
+		testNode("control-plane", true, map[string]string{"node-role.kubernetes.io/master": ""}),
+		testNode("worker", true, nil),
+	)
+	require.NoError(t, WaitForWorkerNodes(context.Background(), c, 1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := WaitForWorkerNodes(ctx, c, 2)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "(1 nodes, 1 ready)")
+}
+
+// fixedCluster is a Cluster which can't scale its nodes.
+type fixedCluster struct {
+	Cluster
+}
+
+func (fixedCluster) Name() string { return "fixed" }
+func (fixedCluster) Type() Type   { return "fake" }
+
+func TestScaleNodes(t *testing.T) {
+	err := ScaleNodes(context.Background(), fixedCluster{}, 3)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not support scaling nodes")
+}
@@ -0,0 +1,185 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// -----------------------------------------------------------------------------
+// Port Forwarding
+// -----------------------------------------------------------------------------
+
+// PortForward forwards a random local port to a port of a pod or service in
+// the given namespace, so that it can be reached from outside the cluster on
+// clusters without LoadBalancer support. The target is "pod/<name>" (or just
+// "<name>") with a container port, or "service/<name>" (or "svc/<name>") with a
+// service port which is forwarded to a running pod of the service.
+//
+// It provides the local address ("localhost:<port>") and a function which
+// stops forwarding, which also stops once the provided context is done.
+func PortForward(ctx context.Context, cluster Cluster, namespace, target string, port int) (string, func(), error) {
+	return PortForwardFromLocalPort(ctx, cluster, namespace, target, 0, port)
+}
+
+// PortForwardFromLocalPort is PortForward forwarding the given local port
+// (a random one if 0).
+func PortForwardFromLocalPort(ctx context.Context, cluster Cluster, namespace, target string, localPort, port int) (string, func(), error) {
+	kind, name, err := parsePortForwardTarget(target)
+	if err != nil {
+		return "", nil, err
+	}
+
+	podName, podPort := name, port
+	if kind == "service" {
+		podName, podPort, err = resolveServicePort(ctx, cluster, namespace, name, port)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	transport, upgrader, err := spdy.RoundTripperFor(cluster.Config())
+	if err != nil {
+		return "", nil, err
+	}
+	req := cluster.Client().CoreV1().RESTClient().Post().
+		Resource("pods").Namespace(namespace).Name(podName).SubResource("portforward")
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, req.URL())
+
+	stopCh, readyCh := make(chan struct{}), make(chan struct{})
+	var stopOnce sync.Once
+	stop := func() { stopOnce.Do(func() { close(stopCh) }) }
+	forwarder, err := portforward.New(dialer, []string{fmt.Sprintf("%d:%d", localPort, podPort)}, stopCh, readyCh, io.Discard, io.Discard)
+	if err != nil {
+		return "", nil, err
+	}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- forwarder.ForwardPorts() }()
+	go func() {
+		select {
+		case <-ctx.Done():
+			stop()
+		case <-stopCh:
+		}
+	}()
+
+	select {
+	case <-readyCh:
+	case err := <-errCh:
+		stop()
+		return "", nil, fmt.Errorf("port forwarding to %s/%s failed: %w", namespace, target, err)
+	case <-ctx.Done():
+		stop()
+		return "", nil, ctx.Err()
+	}
+
+	ports, err := forwarder.GetPorts()
+	if err != nil {
+		stop()
+		return "", nil, err
+	}
+	return fmt.Sprintf("localhost:%d", ports[0].Local), stop, nil
+}
+
+// parsePortForwardTarget parses a port forwarding target into the kind of
+// object ("pod" or "service") and its name.
+func parsePortForwardTarget(target string) (string, string, error) {
+	kind, name, found := strings.Cut(target, "/")
+	if !found {
+		kind, name = "pod", target
+	}
+	switch kind {
+	case "pod", "pods", "po":
+		kind = "pod"
+	case "service", "services", "svc":
+		kind = "service"
+	default:
+		return "", "", fmt.Errorf("can't port forward to %s, only pods and services are supported", target)
+	}
+	if name == "" {
+		return "", "", fmt.Errorf("can't port forward to %s, no name provided", target)
+	}
+	return kind, name, nil
+}
+
+// resolveServicePort finds a running pod of a service and the port of the pod
+// the given service port targets.
+func resolveServicePort(ctx context.Context, cluster Cluster, namespace, name string, port int) (string, int, error) {
+	service, err := cluster.Client().CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", 0, err
+	}
+	if len(service.Spec.Selector) == 0 {
+		return "", 0, fmt.Errorf("can't port forward to service %s/%s without a selector", namespace, name)
+	}
+	pods, err := cluster.Client().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(service.Spec.Selector).String(),
+		FieldSelector: "status.phase=Running",
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	if len(pods.Items) == 0 {
+		return "", 0, fmt.Errorf("no running pods found for service %s/%s", namespace, name)
+	}
+	pod := pods.Items[0]
+	for _, p := range pods.Items {
+		if isPodReady(p) {
+			pod = p
+			break
+		}
+	}
+
+	podPort, err := serviceTargetPort(service, pod, port)
+	if err != nil {
+		return "", 0, err
+	}
+	return pod.Name, podPort, nil
+}
+
+// serviceTargetPort provides the port of a pod targeted by a service port,
+// resolving named target ports using the container ports of the pod.
+func serviceTargetPort(service *corev1.Service, pod corev1.Pod, port int) (int, error) {
+	for _, servicePort := range service.Spec.Ports {
+		if int(servicePort.Port) != port {
+			continue
+		}
+		switch {
+		case servicePort.TargetPort.Type == intstr.String && servicePort.TargetPort.StrVal != "":
+			for _, container := range pod.Spec.Containers {
+				for _, containerPort := range container.Ports {
+					if containerPort.Name == servicePort.TargetPort.StrVal {
+						return int(containerPort.ContainerPort), nil
+					}
+				}
+			}
+			return 0, fmt.Errorf("pod %s/%s has no port named %s", pod.Namespace, pod.Name, servicePort.TargetPort.StrVal)
+		case servicePort.TargetPort.IntValue() != 0:
+			return servicePort.TargetPort.IntValue(), nil
+		default:
+			return port, nil
+		}
+	}
+	return 0, fmt.Errorf("service %s/%s has no port %d", service.Namespace, service.Name, port)
+}
+
+// isPodReady indicates whether a pod has the Ready condition.
+func isPodReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package clusters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestParsePortForwardTarget(t *testing.T) {
+	for target, expected := range map[string][2]string{
+		"proxy-1":     {"pod", "proxy-1"},
+		"pod/proxy-1": {"pod", "proxy-1"},
+		"service/web": {"service", "web"},
+		"svc/web":     {"service", "web"},
+	} {
+		kind, name, err := parsePortForwardTarget(target)
+		require.NoError(t, err, target)
+		assert.Equal(t, expected, [2]string{kind, name}, target)
+	}
+
+	_, _, err := parsePortForwardTarget("deployment/web")
+	assert.EqualError(t, err, "can't port forward to deployment/web, only pods and services are supported")
+	_, _, err = parsePortForwardTarget("svc/")
+	assert.EqualError(t, err, "can't port forward to svc/, no name provided")
+}
+
+func TestServiceTargetPort(t *testing.T) {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+		Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{
+			{Port: 80, TargetPort: intstr.FromString("http")},
+			{Port: 443, TargetPort: intstr.FromInt32(8443)},
+			{Port: 9090},
+			{Port: 8080, TargetPort: intstr.FromString("missing")},
+		}},
+	}
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-1"},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{
+			{Name: "web", Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8000}}},
+		}},
+	}
+
+	for port, expected := range map[int]int{80: 8000, 443: 8443, 9090: 9090} {
+		podPort, err := serviceTargetPort(service, pod, port)
+		require.NoError(t, err)
+		assert.Equal(t, expected, podPort)
+	}
+
+	_, err := serviceTargetPort(service, pod, 8080)
+	assert.EqualError(t, err, "pod default/web-1 has no port named missing")
+	_, err = serviceTargetPort(service, pod, 22)
+	assert.EqualError(t, err, "service default/web has no port 22")
+}
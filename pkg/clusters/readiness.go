@@ -0,0 +1,259 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+)
+
+// -----------------------------------------------------------------------------
+// Public Types - Addon Readiness
+// -----------------------------------------------------------------------------
+
+// Condition describes the readiness of a component of an addon.
+type Condition struct {
+	// Component identifies the component, e.g. "Deployment kong/proxy-kong".
+	Component string
+
+	// Ready indicates whether the component is ready.
+	Ready bool
+
+	// Message describes why the component isn't ready, if it isn't.
+	Message string
+}
+
+func (c Condition) String() string {
+	if c.Ready {
+		return c.Component + ": ready"
+	}
+	return c.Component + ": " + c.Message
+}
+
+// ConditionsAddon is an Addon which reports detailed readiness status of its
+// components, beyond the objects reported by Ready.
+type ConditionsAddon interface {
+	Addon
+
+	// Conditions is a non-blocking call which checks the status of the addon
+	// on the cluster and provides a condition for each of its components,
+	// or at least for the ones which aren't ready.
+	Conditions(ctx context.Context, cluster Cluster) (ready bool, conditions []Condition, err error)
+}
+
+// -----------------------------------------------------------------------------
+// Public Functions - Addon Readiness
+// -----------------------------------------------------------------------------
+
+// AddonReadiness checks the readiness of an addon and provides the conditions
+// of its components which are not ready. Unless the addon is a ConditionsAddon
+// the conditions are derived from the objects reported by its Ready method.
+func AddonReadiness(ctx context.Context, cluster Cluster, addon Addon) (bool, []Condition, error) {
+	if conditionsAddon, ok := addon.(ConditionsAddon); ok {
+		return conditionsAddon.Conditions(ctx, cluster)
+	}
+
+	waitingForObjects, ready, err := addon.Ready(ctx, cluster)
+	if err != nil {
+		return false, nil, err
+	}
+	return ready, ReadyConditions(addon, waitingForObjects, ready), nil
+}
+
+// ReadyConditions derives the conditions of the components of an addon which
+// are not ready from the results of its Ready method.
+func ReadyConditions(addon Addon, waitingForObjects []runtime.Object, ready bool) []Condition {
+	conditions := make([]Condition, 0, len(waitingForObjects))
+	for _, obj := range waitingForObjects {
+		conditions = append(conditions, ObjectCondition(obj))
+	}
+	if !ready && len(conditions) == 0 {
+		conditions = append(conditions, Condition{
+			Component: fmt.Sprintf("addon %s", addon.Name()),
+			Message:   "not ready",
+		})
+	}
+	return conditions
+}
+
+// AddonConditions provides the readiness conditions of an addon deployed to the
+// given namespace, e.g. for the Conditions method of a ConditionsAddon: along
+// with the objects its Ready method waits for, the pods of the namespace
+// (matching the label selector, if any) which fail to start are reported, as
+// they're usually the reason (e.g. an image which can't be pulled).
+func AddonConditions(ctx context.Context, cluster Cluster, addon Addon, namespace, selector string) (bool, []Condition, error) {
+	waitingForObjects, ready, err := addon.Ready(ctx, cluster)
+	if err != nil || ready {
+		return ready, nil, err
+	}
+
+	conditions := make([]Condition, 0, len(waitingForObjects))
+	for _, obj := range waitingForObjects {
+		conditions = append(conditions, ObjectCondition(obj))
+	}
+	podConditions, err := failingPodConditions(ctx, cluster.Client(), namespace, selector)
+	if err != nil {
+		return false, nil, err
+	}
+	conditions = append(conditions, podConditions...)
+
+	if len(conditions) == 0 {
+		conditions = append(conditions, Condition{
+			Component: fmt.Sprintf("addon %s", addon.Name()),
+			Message:   fmt.Sprintf("no workloads available in namespace %s", namespace),
+		})
+	}
+	return false, conditions, nil
+}
+
+// WaitForAddonReady waits for an addon to be ready on the cluster. If the
+// context is done before, the error reports the components which weren't ready.
+func WaitForAddonReady(ctx context.Context, cluster Cluster, addon Addon) error {
+	ticker := time.NewTicker(addonDependencyWaitTick)
+	defer ticker.Stop()
+
+	for {
+		ready, conditions, err := AddonReadiness(ctx, cluster, addon)
+		if err != nil {
+			return fmt.Errorf("failure to check addon %s's readiness: %w", addon.Name(), err)
+		}
+		if ready {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("addon %s not ready (%s): %w", addon.Name(), FormatConditions(conditions), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// FormatConditions formats the conditions which aren't ready for messages.
+func FormatConditions(conditions []Condition) string {
+	messages := make([]string, 0, len(conditions))
+	for _, condition := range conditions {
+		if !condition.Ready {
+			messages = append(messages, condition.String())
+		}
+	}
+	return strings.Join(messages, ", ")
+}
+
+// ObjectCondition describes why an object reported by an addon's Ready method
+// isn't ready, based on the status of the well known kinds of objects.
+func ObjectCondition(obj runtime.Object) Condition {
+	condition := Condition{Component: objectComponent(obj), Message: "not ready"}
+
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		replicas := int32(1)
+		if o.Spec.Replicas != nil {
+			replicas = *o.Spec.Replicas
+		}
+		condition.Message = fmt.Sprintf("%d/%d replicas available", o.Status.AvailableReplicas, replicas)
+	case *appsv1.DaemonSet:
+		condition.Message = fmt.Sprintf("%d/%d pods available", o.Status.NumberAvailable, o.Status.DesiredNumberScheduled)
+	case *appsv1.StatefulSet:
+		replicas := int32(1)
+		if o.Spec.Replicas != nil {
+			replicas = *o.Spec.Replicas
+		}
+		condition.Message = fmt.Sprintf("%d/%d replicas ready", o.Status.ReadyReplicas, replicas)
+	case *batchv1.Job:
+		condition.Message = fmt.Sprintf("not complete (%d succeeded, %d failed)", o.Status.Succeeded, o.Status.Failed)
+	case *corev1.Pod:
+		condition.Message = fmt.Sprintf("phase %s", o.Status.Phase)
+		for _, status := range o.Status.ContainerStatuses {
+			if status.State.Waiting != nil && status.State.Waiting.Reason != "" {
+				condition.Message += fmt.Sprintf(", container %s %s", status.Name, status.State.Waiting.Reason)
+			}
+		}
+	case *corev1.Service:
+		if o.Spec.Type == corev1.ServiceTypeLoadBalancer && len(o.Status.LoadBalancer.Ingress) == 0 {
+			condition.Message = "no load balancer address provisioned"
+		}
+	case *corev1.Namespace:
+		if o.CreationTimestamp.IsZero() {
+			condition.Message = "not found"
+		}
+	case *corev1.Node:
+		for _, c := range o.Status.Conditions {
+			if c.Type == corev1.NodeReady && c.Message != "" {
+				condition.Message = c.Message
+			}
+		}
+	case *unstructured.Unstructured:
+		conditions, _, _ := unstructured.NestedSlice(o.Object, "status", "conditions")
+		for _, c := range conditions {
+			if c, ok := c.(map[string]interface{}); ok && c["type"] == "Ready" {
+				if message, ok := c["message"].(string); ok && message != "" {
+					condition.Message = message
+				}
+			}
+		}
+	}
+
+	return condition
+}
+
+// -----------------------------------------------------------------------------
+// Private Functions - Addon Readiness
+// -----------------------------------------------------------------------------
+
+// failingPodConditions provides the conditions of the pods of the namespace
+// which are failing to start: pods which are pending or failed, or which have
+// containers waiting for a reason (e.g. ImagePullBackOff or CrashLoopBackOff).
+func failingPodConditions(ctx context.Context, client kubernetes.Interface, namespace, selector string) ([]Condition, error) {
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+
+	var conditions []Condition
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		failing := pod.Status.Phase == corev1.PodPending || pod.Status.Phase == corev1.PodFailed
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Waiting != nil && status.State.Waiting.Reason != "" {
+				failing = true
+			}
+		}
+		if failing {
+			conditions = append(conditions, ObjectCondition(pod))
+		}
+	}
+	return conditions, nil
+}
+
+// objectComponent describes an object as "<Kind> <namespace>/<name>", typed
+// objects retrieved with the clientset don't have their kind set.
+func objectComponent(obj runtime.Object) string {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if kind == "" {
+		t := reflect.TypeOf(obj)
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		kind = t.Name()
+	}
+
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return kind
+	}
+	if accessor.GetNamespace() == "" {
+		return fmt.Sprintf("%s %s", kind, accessor.GetName())
+	}
+	return fmt.Sprintf("%s %s/%s", kind, accessor.GetNamespace(), accessor.GetName())
+}
@@ -0,0 +1,148 @@
+package clusters
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+type notReadyAddon struct {
+	fakeAddon
+	waitingForObjects []runtime.Object
+}
+
+func (a notReadyAddon) Ready(context.Context, Cluster) ([]runtime.Object, bool, error) {
+	return a.waitingForObjects, false, nil
+}
+
+func TestObjectCondition(t *testing.T) {
+	kafka := &unstructured.Unstructured{}
+	kafka.SetAPIVersion("kafka.strimzi.io/v1beta2")
+	kafka.SetKind("Kafka")
+	kafka.SetNamespace("kafka")
+	kafka.SetName("ktf")
+	require.NoError(t, unstructured.SetNestedSlice(kafka.Object, []interface{}{
+		map[string]interface{}{"type": "Ready", "status": "False", "message": "waiting for brokers"},
+	}, "status", "conditions"))
+
+	for _, tc := range []struct {
+		obj      runtime.Object
+		expected string
+	}{
+		{
+			obj: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "kong", Name: "proxy-kong"},
+				Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(2))},
+				Status:     appsv1.DeploymentStatus{AvailableReplicas: 1},
+			},
+			expected: "Deployment kong/proxy-kong: 1/2 replicas available",
+		},
+		{
+			obj: &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "calico-node"},
+				Status:     appsv1.DaemonSetStatus{NumberAvailable: 0, DesiredNumberScheduled: 3},
+			},
+			expected: "DaemonSet kube-system/calico-node: 0/3 pods available",
+		},
+		{
+			obj: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod"},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodPending,
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name:  "app",
+						State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+					}},
+				},
+			},
+			expected: "Pod default/pod: phase Pending, container app ImagePullBackOff",
+		},
+		{
+			obj:      &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "missing"}},
+			expected: "Namespace missing: not found",
+		},
+		{
+			obj: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "kong", Name: "proxy"},
+				Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+			},
+			expected: "Service kong/proxy: no load balancer address provisioned",
+		},
+		{
+			obj:      kafka,
+			expected: "Kafka kafka/ktf: waiting for brokers",
+		},
+	} {
+		t.Run(tc.expected, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ObjectCondition(tc.obj).String())
+		})
+	}
+}
+
+func TestAddonReadiness(t *testing.T) {
+	ctx := context.Background()
+
+	ready, conditions, err := AddonReadiness(ctx, nil, fakeAddon{name: "ready"})
+	require.NoError(t, err)
+	assert.True(t, ready)
+	assert.Empty(t, conditions)
+
+	ready, conditions, err = AddonReadiness(ctx, nil, notReadyAddon{fakeAddon: fakeAddon{name: "opaque"}})
+	require.NoError(t, err)
+	assert.False(t, ready)
+	assert.Equal(t, []Condition{{Component: "addon opaque", Message: "not ready"}}, conditions)
+
+	addon := notReadyAddon{
+		fakeAddon: fakeAddon{name: "waiting"},
+		waitingForObjects: []runtime.Object{
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "waiting"}},
+		},
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	err = WaitForAddonReady(ctx, nil, addon)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "addon waiting not ready (Namespace waiting: not found)")
+}
+
+func TestFailingPodConditions(t *testing.T) {
+	pod := func(name string, phase corev1.PodPhase, waiting string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kong", Name: name, Labels: map[string]string{"app": "kong"}},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+		if waiting != "" {
+			pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+				Name:  "proxy",
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: waiting}},
+			}}
+		}
+		return pod
+	}
+	client := fake.NewSimpleClientset(
+		pod("running", corev1.PodRunning, ""),
+		pod("crashing", corev1.PodRunning, "CrashLoopBackOff"),
+		pod("pulling", corev1.PodPending, "ImagePullBackOff"),
+		pod("completed", corev1.PodSucceeded, ""),
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "kong", Name: "other"}, Status: corev1.PodStatus{Phase: corev1.PodFailed}},
+	)
+
+	conditions, err := failingPodConditions(context.Background(), client, "kong", "app=kong")
+	require.NoError(t, err)
+	assert.Equal(t, "Pod kong/crashing: phase Running, container proxy CrashLoopBackOff, "+
+		"Pod kong/pulling: phase Pending, container proxy ImagePullBackOff", FormatConditions(conditions))
+
+	conditions, err = failingPodConditions(context.Background(), client, "kong", "")
+	require.NoError(t, err)
+	assert.Len(t, conditions, 3, "all the pods of the namespace are checked without a selector")
+}
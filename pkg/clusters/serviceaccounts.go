@@ -0,0 +1,156 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// -----------------------------------------------------------------------------
+// Service Accounts - RBAC
+// -----------------------------------------------------------------------------
+
+// DefaultServiceAccountTokenExpiration is the lifetime of the tokens minted by
+// ServiceAccountConfig.
+const DefaultServiceAccountTokenExpiration = time.Hour
+
+// CreateServiceAccount creates a ServiceAccount of the given name in the
+// namespace, or provides it if it exists already.
+func CreateServiceAccount(ctx context.Context, cluster Cluster, namespace, name string) (*corev1.ServiceAccount, error) {
+	return createServiceAccount(ctx, cluster.Client(), namespace, name)
+}
+
+// BindServiceAccount grants the given Role or ClusterRole to the service
+// account: a Role is bound within the namespace of the service account and a
+// ClusterRole is bound cluster wide. The binding is provided so that it can be
+// cleaned up, e.g. with a Cleaner, as ClusterRoleBindings aren't deleted along
+// with the namespace.
+func BindServiceAccount(ctx context.Context, cluster Cluster, serviceAccount *corev1.ServiceAccount, role rbacv1.RoleRef) (client.Object, error) {
+	return bindServiceAccount(ctx, cluster.Client(), serviceAccount, role)
+}
+
+// ServiceAccountToken mints a token for the service account which expires
+// after the given duration.
+func ServiceAccountToken(ctx context.Context, cluster Cluster, namespace, name string, expiration time.Duration) (string, error) {
+	return serviceAccountToken(ctx, cluster.Client(), namespace, name, expiration)
+}
+
+// ServiceAccountConfig provides a configuration for the cluster which
+// authenticates as the service account, using a token minted for it which
+// expires after DefaultServiceAccountTokenExpiration. Clients built from it
+// only have the permissions granted to the service account, which makes
+// permission boundaries testable.
+func ServiceAccountConfig(ctx context.Context, cluster Cluster, namespace, name string) (*rest.Config, error) {
+	token, err := ServiceAccountToken(ctx, cluster, namespace, name, DefaultServiceAccountTokenExpiration)
+	if err != nil {
+		return nil, err
+	}
+	return serviceAccountConfig(cluster.Config(), token), nil
+}
+
+// ServiceAccountWithRole creates a service account bound to the given Role or
+// ClusterRole (see BindServiceAccount) and provides a configuration which
+// authenticates as it (see ServiceAccountConfig), along with the objects
+// created so that they can be cleaned up.
+func ServiceAccountWithRole(ctx context.Context, cluster Cluster, namespace, name string, role rbacv1.RoleRef) (*rest.Config, []client.Object, error) {
+	serviceAccount, err := CreateServiceAccount(ctx, cluster, namespace, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	created := []client.Object{serviceAccount}
+
+	binding, err := BindServiceAccount(ctx, cluster, serviceAccount, role)
+	if err != nil {
+		return nil, created, err
+	}
+	created = append(created, binding)
+
+	cfg, err := ServiceAccountConfig(ctx, cluster, namespace, name)
+	return cfg, created, err
+}
+
+// -----------------------------------------------------------------------------
+// Service Accounts - Private
+// -----------------------------------------------------------------------------
+
+func createServiceAccount(ctx context.Context, c kubernetes.Interface, namespace, name string) (*corev1.ServiceAccount, error) {
+	serviceAccount := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	created, err := c.CoreV1().ServiceAccounts(namespace).Create(ctx, serviceAccount, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		created, err = c.CoreV1().ServiceAccounts(namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not create service account %s/%s: %w", namespace, name, err)
+	}
+	return created, nil
+}
+
+func bindServiceAccount(ctx context.Context, c kubernetes.Interface, serviceAccount *corev1.ServiceAccount, role rbacv1.RoleRef) (client.Object, error) {
+	if role.APIGroup == "" {
+		role.APIGroup = rbacv1.GroupName
+	}
+	subjects := []rbacv1.Subject{{
+		Kind:      rbacv1.ServiceAccountKind,
+		Namespace: serviceAccount.Namespace,
+		Name:      serviceAccount.Name,
+	}}
+
+	switch role.Kind {
+	case "Role":
+		binding := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Namespace: serviceAccount.Namespace, Name: serviceAccount.Name + "-" + role.Name},
+			RoleRef:    role,
+			Subjects:   subjects,
+		}
+		created, err := c.RbacV1().RoleBindings(serviceAccount.Namespace).Create(ctx, binding, metav1.CreateOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("could not bind role %s to service account %s/%s: %w", role.Name, serviceAccount.Namespace, serviceAccount.Name, err)
+		}
+		return created, nil
+	case "ClusterRole":
+		binding := &rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: serviceAccount.Namespace + "-" + serviceAccount.Name + "-" + role.Name},
+			RoleRef:    role,
+			Subjects:   subjects,
+		}
+		created, err := c.RbacV1().ClusterRoleBindings().Create(ctx, binding, metav1.CreateOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("could not bind cluster role %s to service account %s/%s: %w", role.Name, serviceAccount.Namespace, serviceAccount.Name, err)
+		}
+		return created, nil
+	default:
+		return nil, fmt.Errorf("can not bind %q to a service account, only Role and ClusterRole can be", role.Kind)
+	}
+}
+
+func serviceAccountToken(ctx context.Context, c kubernetes.Interface, namespace, name string, expiration time.Duration) (string, error) {
+	request := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{ExpirationSeconds: ptr.To(int64(expiration.Seconds()))},
+	}
+	response, err := c.CoreV1().ServiceAccounts(namespace).CreateToken(ctx, name, request, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("could not create token for service account %s/%s: %w", namespace, name, err)
+	}
+	if response.Status.Token == "" {
+		return "", fmt.Errorf("no token was created for service account %s/%s", namespace, name)
+	}
+	return response.Status.Token, nil
+}
+
+// serviceAccountConfig provides a copy of the configuration which only
+// authenticates with the given token.
+func serviceAccountConfig(cfg *rest.Config, token string) *rest.Config {
+	saCfg := rest.AnonymousClientConfig(cfg)
+	saCfg.BearerToken = token
+	return saCfg
+}
@@ -0,0 +1,70 @@
+package clusters
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestServiceAccountRBAC(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset()
+
+	serviceAccount, err := createServiceAccount(ctx, c, "kong", "controller")
+	require.NoError(t, err)
+	_, err = createServiceAccount(ctx, c, "kong", "controller")
+	require.NoError(t, err, "existing service accounts are provided")
+
+	binding, err := bindServiceAccount(ctx, c, serviceAccount, rbacv1.RoleRef{Kind: "Role", Name: "reader"})
+	require.NoError(t, err)
+	roleBinding := binding.(*rbacv1.RoleBinding)
+	assert.Equal(t, "kong", roleBinding.Namespace)
+	assert.Equal(t, "controller-reader", roleBinding.Name)
+	assert.Equal(t, rbacv1.GroupName, roleBinding.RoleRef.APIGroup)
+	assert.Equal(t, []rbacv1.Subject{{Kind: "ServiceAccount", Namespace: "kong", Name: "controller"}}, roleBinding.Subjects)
+
+	binding, err = bindServiceAccount(ctx, c, serviceAccount, rbacv1.RoleRef{Kind: "ClusterRole", Name: "view"})
+	require.NoError(t, err)
+	assert.Equal(t, "kong-controller-view", binding.(*rbacv1.ClusterRoleBinding).Name)
+
+	_, err = bindServiceAccount(ctx, c, serviceAccount, rbacv1.RoleRef{Kind: "Group", Name: "admins"})
+	require.EqualError(t, err, `can not bind "Group" to a service account, only Role and ClusterRole can be`)
+}
+
+func TestServiceAccountToken(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset()
+	c.PrependReactor("create", "serviceaccounts", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "token" {
+			return false, nil, nil
+		}
+		request := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenRequest)
+		assert.Equal(t, int64(600), *request.Spec.ExpirationSeconds)
+		request.Status.Token = "minted"
+		return true, request, nil
+	})
+
+	token, err := serviceAccountToken(ctx, c, "kong", "controller", 10*time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "minted", token)
+
+	cfg := serviceAccountConfig(&rest.Config{
+		Host:            "https://127.0.0.1:6443",
+		BearerToken:     "admin",
+		TLSClientConfig: rest.TLSClientConfig{CAData: []byte("ca"), CertData: []byte("cert"), KeyData: []byte("key")},
+	}, token)
+	assert.Equal(t, "https://127.0.0.1:6443", cfg.Host)
+	assert.Equal(t, "minted", cfg.BearerToken)
+	assert.Equal(t, []byte("ca"), cfg.CAData)
+	assert.Empty(t, cfg.CertData)
+	assert.Empty(t, cfg.KeyData)
+}
@@ -0,0 +1,158 @@
+package clusters
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+)
+
+// -----------------------------------------------------------------------------
+// Namespace Snapshots - Options
+// -----------------------------------------------------------------------------
+
+// SnapshotOptions select which resources are part of a namespace snapshot and
+// how they're exported.
+type SnapshotOptions struct {
+	// Resources limits the snapshot to the resources of the given names, as
+	// plural resource names optionally qualified by their group (e.g.
+	// "configmaps" or "httproutes.gateway.networking.k8s.io"). All resources
+	// which can be listed are exported if empty.
+	Resources []string
+
+	// ExcludeResources excludes the resources of the given names, in the same
+	// format as Resources (e.g. "events").
+	ExcludeResources []string
+
+	// LabelSelector limits the snapshot to the objects matching the selector.
+	LabelSelector string
+
+	// Filter limits the snapshot to the objects it returns true for, if set.
+	Filter func(obj *unstructured.Unstructured) bool
+
+	// StripVolatileFields strips the fields which differ between otherwise
+	// identical objects (e.g. the UID, resource version, creation timestamp
+	// and the status), for comparison with golden files.
+	StripVolatileFields bool
+}
+
+// includes indicates whether the resource is part of the snapshot.
+func (o SnapshotOptions) includes(gvr schema.GroupVersionResource) bool {
+	matches := func(names []string) bool {
+		for _, name := range names {
+			if name == gvr.Resource || name == gvr.GroupResource().String() {
+				return true
+			}
+		}
+		return false
+	}
+	if len(o.Resources) > 0 && !matches(o.Resources) {
+		return false
+	}
+	return !matches(o.ExcludeResources)
+}
+
+// volatileFields are the fields stripped from objects with StripVolatileFields.
+var volatileFields = [][]string{
+	{"metadata", "uid"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "generation"},
+	{"metadata", "creationTimestamp"},
+	{"metadata", "selfLink"},
+	{"metadata", "ownerReferences"},
+	{"metadata", "annotations", "kubectl.kubernetes.io/last-applied-configuration"},
+	{"status"},
+}
+
+// -----------------------------------------------------------------------------
+// Namespace Snapshots
+// -----------------------------------------------------------------------------
+
+// SnapshotNamespaces exports the objects of all namespaced resources in the
+// given namespaces as a multi-document YAML stream, for comparison with golden
+// files or post-mortem debugging. Managed fields are stripped and the objects
+// are sorted by namespace, resource and name so that snapshots of the same
+// state are identical.
+func SnapshotNamespaces(ctx context.Context, c Cluster, opts SnapshotOptions, namespaces ...string) ([]byte, error) {
+	discoveryClient, err := c.DiscoveryClient()
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := c.DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+
+	// groups which can't be discovered (e.g. of an unavailable aggregated
+	// API) are skipped, as there's nothing to export from them anyway.
+	resourceLists, err := discoveryClient.ServerPreferredNamespacedResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, fmt.Errorf("could not discover namespaced resources: %w", err)
+	}
+
+	return snapshotNamespaces(ctx, dynamicClient, resourceLists, opts, namespaces)
+}
+
+// snapshotNamespaces exports the objects of the listable resources among the
+// discovered ones in the namespaces.
+func snapshotNamespaces(ctx context.Context, c dynamic.Interface, resourceLists []*metav1.APIResourceList, opts SnapshotOptions, namespaces []string) ([]byte, error) {
+	listable := discovery.FilteredBy(discovery.SupportsAllVerbs{Verbs: []string{"list"}}, resourceLists)
+	resources, err := discovery.GroupVersionResources(listable)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse discovered resources: %w", err)
+	}
+	gvrs := make([]schema.GroupVersionResource, 0, len(resources))
+	for gvr := range resources {
+		if opts.includes(gvr) {
+			gvrs = append(gvrs, gvr)
+		}
+	}
+	sort.Slice(gvrs, func(i, j int) bool { return gvrs[i].String() < gvrs[j].String() })
+
+	var out bytes.Buffer
+	for _, namespace := range namespaces {
+		for _, gvr := range gvrs {
+			if err := ctx.Err(); err != nil {
+				return nil, fmt.Errorf("context done while taking snapshot of namespace %s: %w", namespace, err)
+			}
+			list, err := c.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{LabelSelector: opts.LabelSelector})
+			if err != nil {
+				return nil, fmt.Errorf("could not list %s in namespace %s: %w", gvr.GroupResource(), namespace, err)
+			}
+
+			items := list.Items
+			sort.Slice(items, func(i, j int) bool { return items[i].GetName() < items[j].GetName() })
+			for i := range items {
+				obj := &items[i]
+				if opts.Filter != nil && !opts.Filter(obj) {
+					continue
+				}
+				unstructured.RemoveNestedField(obj.Object, "metadata", "managedFields")
+				if opts.StripVolatileFields {
+					for _, field := range volatileFields {
+						unstructured.RemoveNestedField(obj.Object, field...)
+					}
+					if len(obj.GetAnnotations()) == 0 {
+						unstructured.RemoveNestedField(obj.Object, "metadata", "annotations")
+					}
+				}
+
+				doc, err := yaml.Marshal(obj.Object)
+				if err != nil {
+					return nil, fmt.Errorf("could not marshal %s %s/%s: %w", gvr.GroupResource(), namespace, obj.GetName(), err)
+				}
+				out.WriteString("---\n")
+				out.Write(doc)
+			}
+		}
+	}
+
+	return out.Bytes(), nil
+}
@@ -0,0 +1,101 @@
+package clusters
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestSnapshotNamespaces(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	configMap := func(namespace, name string, labels map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       namespace,
+				Name:            name,
+				Labels:          labels,
+				UID:             "1234",
+				ResourceVersion: "42",
+				ManagedFields:   []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+			},
+			Data: map[string]string{"key": "value"},
+		}
+	}
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme,
+		map[schema.GroupVersionResource]string{
+			{Version: "v1", Resource: "configmaps"}: "ConfigMapList",
+			{Version: "v1", Resource: "events"}:     "EventList",
+		},
+		configMap("kong", "b", map[string]string{"app": "kong"}),
+		configMap("kong", "a", map[string]string{"app": "kong"}),
+		configMap("kong", "other", nil),
+		configMap("elsewhere", "c", map[string]string{"app": "kong"}),
+		&corev1.Event{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Event"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kong", Name: "event"},
+		},
+	)
+	resourceLists := []*metav1.APIResourceList{{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{
+			{Name: "configmaps", Namespaced: true, Kind: "ConfigMap", Verbs: []string{"get", "list"}},
+			{Name: "events", Namespaced: true, Kind: "Event", Verbs: []string{"get", "list"}},
+			{Name: "pods/log", Namespaced: true, Kind: "Pod", Verbs: []string{"get"}},
+		},
+	}}
+
+	snapshot, err := snapshotNamespaces(ctx, client, resourceLists, SnapshotOptions{
+		ExcludeResources:    []string{"events"},
+		LabelSelector:       "app=kong",
+		StripVolatileFields: true,
+	}, []string{"kong"})
+	require.NoError(t, err)
+	assert.Equal(t, `---
+apiVersion: v1
+data:
+  key: value
+kind: ConfigMap
+metadata:
+  labels:
+    app: kong
+  name: a
+  namespace: kong
+---
+apiVersion: v1
+data:
+  key: value
+kind: ConfigMap
+metadata:
+  labels:
+    app: kong
+  name: b
+  namespace: kong
+`, string(snapshot))
+
+	snapshot, err = snapshotNamespaces(ctx, client, resourceLists, SnapshotOptions{
+		Resources: []string{"configmaps"},
+		Filter:    func(obj *unstructured.Unstructured) bool { return obj.GetName() == "c" },
+	}, []string{"kong", "elsewhere"})
+	require.NoError(t, err)
+	assert.Contains(t, string(snapshot), "name: c\n")
+	assert.Contains(t, string(snapshot), "uid: \"1234\"\n")
+	assert.NotContains(t, string(snapshot), "managedFields")
+	assert.NotContains(t, string(snapshot), "name: a\n")
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = snapshotNamespaces(canceled, client, resourceLists, SnapshotOptions{}, []string{"kong"})
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,81 @@
+package clusters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// -----------------------------------------------------------------------------
+// Teardown Hooks
+// -----------------------------------------------------------------------------
+
+// TeardownHook is a function run before a cluster is torn down, e.g. to flush
+// logs, collect metrics or deregister external resources.
+type TeardownHook func(ctx context.Context, cluster Cluster) error
+
+// TeardownHookRegisterer is a Cluster which runs teardown hooks when it's
+// cleaned up, before it's deleted.
+type TeardownHookRegisterer interface {
+	// RegisterTeardownHook registers a hook to run when the cluster is cleaned
+	// up. Hooks run in the reverse order of their registration.
+	RegisterTeardownHook(hook TeardownHook)
+}
+
+// RegisterTeardownHook registers a hook to run when the cluster is cleaned up,
+// if the cluster supports teardown hooks.
+func RegisterTeardownHook(cluster Cluster, hook TeardownHook) error {
+	registerer, ok := cluster.(TeardownHookRegisterer)
+	if !ok {
+		return fmt.Errorf("cluster %s of type %s does not support teardown hooks", cluster.Name(), cluster.Type())
+	}
+	registerer.RegisterTeardownHook(hook)
+	return nil
+}
+
+// TeardownHooks keeps track of the teardown hooks of a cluster, for use by
+// Cluster implementations. It's safe for concurrent use and the zero value is
+// ready for use.
+type TeardownHooks struct {
+	lock  sync.Mutex
+	hooks []TeardownHook
+}
+
+// Register registers a hook to be run by Run.
+func (h *TeardownHooks) Register(hook TeardownHook) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.hooks = append(h.hooks, hook)
+}
+
+// Run runs the registered hooks in the reverse order of their registration
+// and unregisters them, so that they run once. All hooks run even if some of
+// them fail or panic, their failures are returned.
+func (h *TeardownHooks) Run(ctx context.Context, cluster Cluster) error {
+	h.lock.Lock()
+	hooks := h.hooks
+	h.hooks = nil
+	h.lock.Unlock()
+
+	var errs []error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if err := runTeardownHook(ctx, cluster, hooks[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// runTeardownHook runs a teardown hook, converting a panic into an error.
+func runTeardownHook(ctx context.Context, cluster Cluster, hook TeardownHook) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("teardown hook panicked: %v", r)
+		}
+	}()
+	if err := hook(ctx, cluster); err != nil {
+		return fmt.Errorf("teardown hook failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package clusters
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// teardownCluster is a Cluster which runs its teardown hooks on cleanup.
+type teardownCluster struct {
+	fixedCluster
+	hooks *TeardownHooks
+}
+
+func (c teardownCluster) RegisterTeardownHook(hook TeardownHook) { c.hooks.Register(hook) }
+
+func TestTeardownHooks(t *testing.T) {
+	ctx := context.Background()
+	cluster := teardownCluster{hooks: &TeardownHooks{}}
+
+	var ran []string
+	hook := func(name string, err error) TeardownHook {
+		return func(context.Context, Cluster) error {
+			ran = append(ran, name)
+			return err
+		}
+	}
+	require.NoError(t, RegisterTeardownHook(cluster, hook("flush logs", nil)))
+	require.NoError(t, RegisterTeardownHook(cluster, hook("collect metrics", errors.New("metrics unavailable"))))
+	require.NoError(t, RegisterTeardownHook(cluster, func(context.Context, Cluster) error {
+		ran = append(ran, "deregister")
+		panic("boom")
+	}))
+
+	err := cluster.hooks.Run(ctx, cluster)
+	assert.Equal(t, []string{"deregister", "collect metrics", "flush logs"}, ran)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "teardown hook panicked: boom")
+	assert.Contains(t, err.Error(), "teardown hook failed: metrics unavailable")
+
+	// hooks only run once.
+	require.NoError(t, cluster.hooks.Run(ctx, cluster))
+	assert.Len(t, ran, 3)
+
+	require.EqualError(t, RegisterTeardownHook(fixedCluster{}, hook("unsupported", nil)),
+		"cluster fixed of type fake does not support teardown hooks")
+}
@@ -11,9 +11,13 @@ import (
 
 	"cloud.google.com/go/container/apiv1/containerpb"
 	"github.com/blang/semver/v4"
+	"github.com/go-logr/logr"
 	"github.com/google/uuid"
 	"github.com/samber/lo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"github.com/kong/kubernetes-testing-framework/internal/retry"
 	"github.com/kong/kubernetes-testing-framework/internal/utils"
 	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
 )
@@ -33,6 +37,8 @@ type Builder struct {
 	nodeMachineType string
 	labels          map[string]string
 	releaseChannel  *ReleaseChannel
+	clientOptions   clusters.ClientOptions
+	logger          logr.Logger
 }
 
 const (
@@ -51,6 +57,22 @@ func NewBuilder(gkeJSONCredentials []byte, project, location string) *Builder {
 	}
 }
 
+// WithLogger configures the logger which the cluster logs its progress to
+// (e.g. its creation, addon deployments and cleanup), see clusters.Logger.
+// Nothing is logged by default.
+func (b *Builder) WithLogger(logger logr.Logger) *Builder {
+	b.logger = logger
+	return b
+}
+
+// WithClientOptions tunes the clients of the cluster (e.g. their QPS and
+// burst), overriding the options which are not zero of
+// clusters.DefaultClientOptions.
+func (b *Builder) WithClientOptions(opts clusters.ClientOptions) *Builder {
+	b.clientOptions = opts
+	return b
+}
+
 // WithName indicates a custom name to use for the cluster.
 func (b *Builder) WithName(name string) *Builder {
 	b.Name = name
@@ -206,38 +228,40 @@ func (b *Builder) Build(ctx context.Context) (clusters.Cluster, error) {
 		}
 	}
 
-	if _, err = mgrc.CreateCluster(ctx, req); err != nil {
+	logger := b.logger.WithValues("cluster", b.Name)
+	logger.Info("creating GKE cluster", "project", b.project, "location", b.location, "version", pbcluster.InitialClusterVersion)
+	clusters.EmitEvent(clusters.Event{Type: clusters.EventClusterCreating, Cluster: b.Name})
+	start := time.Now()
+	err = createCluster(ctx, logger, b.Name, func(ctx context.Context) error {
+		_, err := mgrc.CreateCluster(ctx, req)
+		return err
+	})
+	if err != nil {
+		clusters.EmitEvent(clusters.Event{Type: clusters.EventClusterCreated, Cluster: b.Name, Duration: time.Since(start), Err: err})
 		return nil, err
 	}
 
 	// wait for cluster readiness
-	clusterReady := false
-	for !clusterReady {
-		select {
-		case <-ctx.Done():
-			if err := ctx.Err(); err != nil {
-				return nil, fmt.Errorf("failed to build cluster: %w", err)
-			}
-			return nil, fmt.Errorf("failed to build cluster: context completed")
-		default:
-			req := containerpb.GetClusterRequest{Name: fmt.Sprintf("%s/clusters/%s", parent, b.Name)}
-			cluster, err := mgrc.GetCluster(ctx, &req)
-			if err != nil {
-				if _, deleteErr := deleteCluster(ctx, mgrc, b.Name, b.project, b.location); deleteErr != nil {
-					return nil, fmt.Errorf("failed to retrieve cluster after building (%s), then failed to clean up: %w", err, deleteErr)
-				}
-				return nil, err
-			}
-			if cluster.Status == containerpb.Cluster_RUNNING {
-				clusterReady = true
-				break
-			}
-			time.Sleep(waitForClusterTick)
+	err = retry.Poll(ctx, logger, waitForClusterTick, "waiting for GKE cluster "+b.Name+" to run", func(ctx context.Context) (bool, error) {
+		req := containerpb.GetClusterRequest{Name: fmt.Sprintf("%s/clusters/%s", parent, b.Name)}
+		cluster, err := mgrc.GetCluster(ctx, &req)
+		if err != nil {
+			return false, err
 		}
+		return cluster.Status == containerpb.Cluster_RUNNING, nil
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("failed to build cluster: %w", err)
+		}
+		if _, deleteErr := deleteCluster(ctx, mgrc, b.Name, b.project, b.location); deleteErr != nil {
+			return nil, fmt.Errorf("failed to retrieve cluster after building (%s), then failed to clean up: %w", err, deleteErr)
+		}
+		return nil, err
 	}
 
 	// get the restconfig and kubernetes client for the cluster
-	restCFG, k8s, err := clientForCluster(ctx, mgrc, authToken, b.Name, b.project, b.location)
+	restCFG, k8s, createdCluster, err := clientForCluster(ctx, mgrc, authToken, b.Name, b.project, b.location, b.clientOptions)
 	if err != nil {
 		if _, deleteErr := deleteCluster(ctx, mgrc, b.Name, b.project, b.location); deleteErr != nil {
 			return nil, fmt.Errorf("failed to get cluster client (%s), then failed to clean up: %w", err, deleteErr)
@@ -253,11 +277,14 @@ func (b *Builder) Build(ctx context.Context) (clusters.Cluster, error) {
 		waitForTeardown: b.waitForTeardown,
 		client:          k8s,
 		cfg:             restCFG,
-		addons:          make(clusters.Addons),
 		l:               &sync.RWMutex{},
 		// we simply set this directly for GKE as we lack the ability to create other types of cluster
 		ipFamily: clusters.IPv4,
+		metadata: metadataForCluster(createdCluster),
+		logger:   b.logger,
 	}
+	logger.Info("created GKE cluster", "duration", time.Since(start))
+	clusters.EmitEvent(clusters.Event{Type: clusters.EventClusterCreated, Cluster: b.Name, Duration: time.Since(start)})
 
 	if err := utils.ClusterInitHooks(ctx, cluster); err != nil {
 		if cleanupErr := cluster.Cleanup(ctx); cleanupErr != nil {
@@ -269,6 +296,22 @@ func (b *Builder) Build(ctx context.Context) (clusters.Cluster, error) {
 	return cluster, nil
 }
 
+// createCluster creates the named cluster with create, retrying on transient
+// errors. As a transient error (e.g. an unavailable API) doesn't mean the
+// creation wasn't accepted, a retry failing because the cluster already exists
+// is a success.
+func createCluster(ctx context.Context, logger logr.Logger, name string, create func(ctx context.Context) error) error {
+	attempts := 0
+	return retry.OnTransient(ctx, logger, "creating GKE cluster "+name, func(ctx context.Context) error {
+		attempts++
+		err := create(ctx)
+		if attempts > 1 && status.Code(err) == codes.AlreadyExists {
+			return nil
+		}
+		return err
+	})
+}
+
 // sanitizeCreatedByID modifies the clientID to comply with GKE label values constraints.
 func sanitizeCreatedByID(id string) string {
 	var builder strings.Builder
@@ -1,9 +1,13 @@
 package gke
 
 import (
+	"context"
 	"testing"
 
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestSanitizeCreatedByID(t *testing.T) {
@@ -32,3 +36,52 @@ func TestSanitizeCreatedByID(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateCluster(t *testing.T) {
+	testCases := []struct {
+		name             string
+		errors           []error
+		expectedAttempts int
+		expectedCode     codes.Code
+	}{
+		{
+			name:             "created",
+			errors:           []error{nil},
+			expectedAttempts: 1,
+		},
+		{
+			name:             "retried after being unavailable",
+			errors:           []error{status.Error(codes.Unavailable, "unavailable"), nil},
+			expectedAttempts: 2,
+		},
+		{
+			name:             "created by an attempt which was aborted",
+			errors:           []error{status.Error(codes.Aborted, "aborted"), status.Error(codes.AlreadyExists, "exists")},
+			expectedAttempts: 2,
+		},
+		{
+			name:             "already existing",
+			errors:           []error{status.Error(codes.AlreadyExists, "exists")},
+			expectedAttempts: 1,
+			expectedCode:     codes.AlreadyExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			attempts := 0
+			err := createCluster(context.Background(), logr.Discard(), "test", func(context.Context) error {
+				err := tc.errors[attempts]
+				attempts++
+				return err
+			})
+			require.Equal(t, tc.expectedAttempts, attempts)
+			if tc.expectedCode == codes.OK {
+				require.NoError(t, err)
+			} else {
+				require.Equal(t, tc.expectedCode, status.Code(err))
+			}
+		})
+	}
+}
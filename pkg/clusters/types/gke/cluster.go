@@ -2,19 +2,24 @@ package gke
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"strings"
 	"sync"
 	"time"
 
 	container "cloud.google.com/go/container/apiv1"
 	"cloud.google.com/go/container/apiv1/containerpb"
 	"github.com/blang/semver/v4"
+	"github.com/go-logr/logr"
 	"google.golang.org/api/option"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
@@ -34,9 +39,13 @@ type Cluster struct {
 	waitForTeardown bool
 	client          *kubernetes.Clientset
 	cfg             *rest.Config
-	addons          clusters.Addons
+	clients         clusters.Clients
+	addons          clusters.DeployedAddons
+	teardownHooks   clusters.TeardownHooks
 	l               *sync.RWMutex
 	ipFamily        clusters.IPFamily
+	metadata        clusters.Metadata
+	logger          logr.Logger
 }
 
 // NewFromExistingWithEnv provides a new clusters.Cluster backed by an existing GKE cluster,
@@ -69,7 +78,7 @@ func NewFromExisting(ctx context.Context, name, project, location string, jsonCr
 	defer mgrc.Close()
 
 	// get the restconfig and kubernetes client for the cluster
-	cfg, client, err := clientForCluster(ctx, mgrc, authToken, name, project, location)
+	cfg, client, pbcluster, err := clientForCluster(ctx, mgrc, authToken, name, project, location)
 	if err != nil {
 		return nil, err
 	}
@@ -81,8 +90,8 @@ func NewFromExisting(ctx context.Context, name, project, location string, jsonCr
 		jsonCreds: jsonCreds,
 		client:    client,
 		cfg:       cfg,
-		addons:    make(clusters.Addons),
 		l:         &sync.RWMutex{},
+		metadata:  metadataForCluster(pbcluster),
 	}, nil
 }
 
@@ -98,15 +107,30 @@ func (c *Cluster) Type() clusters.Type {
 	return GKEClusterType
 }
 
-func (c *Cluster) Version() (semver.Version, error) {
-	versionInfo, err := c.Client().ServerVersion()
-	if err != nil {
-		return semver.Version{}, err
-	}
-	return semver.Parse(strings.TrimPrefix(versionInfo.String(), "v"))
+func (c *Cluster) Version(ctx context.Context) (semver.Version, error) {
+	return clusters.ServerVersion(ctx, c.client)
 }
 
-func (c *Cluster) Cleanup(ctx context.Context) error {
+// RegisterTeardownHook registers a hook to run when the cluster is cleaned
+// up, before it's deleted. Hooks run in the reverse order of their
+// registration, even if the cluster is kept.
+func (c *Cluster) RegisterTeardownHook(hook clusters.TeardownHook) {
+	c.teardownHooks.Register(hook)
+}
+
+func (c *Cluster) Cleanup(ctx context.Context) (err error) {
+	clusters.EmitEvent(clusters.Event{Type: clusters.EventCleanupStarted, Cluster: c.name})
+	start := time.Now()
+	defer func() {
+		clusters.EmitEvent(clusters.Event{Type: clusters.EventCleanupFinished, Cluster: c.name, Duration: time.Since(start), Err: err})
+	}()
+
+	hooksErr := c.teardownHooks.Run(ctx, c)
+	return errors.Join(hooksErr, c.teardown(ctx))
+}
+
+// teardown deletes the cluster unless it's to be kept.
+func (c *Cluster) teardown(ctx context.Context) error {
 	c.l.Lock()
 	defer c.l.Unlock()
 
@@ -118,6 +142,7 @@ func (c *Cluster) Cleanup(ctx context.Context) error {
 		}
 		defer mgrc.Close()
 
+		c.logger.Info("deleting GKE cluster", "cluster", c.name)
 		teardownOp, err := deleteCluster(ctx, mgrc, c.name, c.project, c.location)
 		if err != nil {
 			return err
@@ -125,7 +150,7 @@ func (c *Cluster) Cleanup(ctx context.Context) error {
 
 		if c.waitForTeardown {
 			fullTeardownOpName := fmt.Sprintf("projects/%s/locations/%s/operations/%s", c.project, c.location, teardownOp.Name)
-			if err := waitForTeardown(ctx, mgrc, fullTeardownOpName); err != nil {
+			if err := waitForOperation(ctx, mgrc, fullTeardownOpName); err != nil {
 				return err
 			}
 		}
@@ -136,7 +161,8 @@ func (c *Cluster) Cleanup(ctx context.Context) error {
 	return nil
 }
 
-func waitForTeardown(ctx context.Context, mgrc *container.ClusterManagerClient, teardownOpName string) error {
+// waitForOperation waits for the GKE operation of the given full name to be done.
+func waitForOperation(ctx context.Context, mgrc *container.ClusterManagerClient, opName string) error {
 	ticker := time.NewTicker(time.Second)
 	defer ticker.Stop()
 
@@ -145,7 +171,7 @@ func waitForTeardown(ctx context.Context, mgrc *container.ClusterManagerClient,
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-ticker.C:
-			op, err := mgrc.GetOperation(ctx, &containerpb.GetOperationRequest{Name: teardownOpName})
+			op, err := mgrc.GetOperation(ctx, &containerpb.GetOperationRequest{Name: opName})
 			if err != nil {
 				return err
 			}
@@ -156,6 +182,11 @@ func waitForTeardown(ctx context.Context, mgrc *container.ClusterManagerClient,
 	}
 }
 
+// Logger provides the logger of the cluster, see Builder.WithLogger.
+func (c *Cluster) Logger() logr.Logger {
+	return c.logger
+}
+
 func (c *Cluster) Client() *kubernetes.Clientset {
 	return c.client
 }
@@ -164,58 +195,32 @@ func (c *Cluster) Config() *rest.Config {
 	return c.cfg
 }
 
-func (c *Cluster) GetAddon(name clusters.AddonName) (clusters.Addon, error) {
-	c.l.RLock()
-	defer c.l.RUnlock()
-
-	for addonName, addon := range c.addons {
-		if addonName == name {
-			return addon, nil
-		}
-	}
+func (c *Cluster) DynamicClient() (dynamic.Interface, error) {
+	return c.clients.Dynamic(c.cfg)
+}
 
-	return nil, fmt.Errorf("addon %s not found", name)
+func (c *Cluster) DiscoveryClient() (discovery.CachedDiscoveryInterface, error) {
+	return c.clients.Discovery(c.cfg)
 }
 
-func (c *Cluster) ListAddons() []clusters.Addon {
-	c.l.RLock()
-	defer c.l.RUnlock()
+func (c *Cluster) APIExtensionsClient() (apiextensionsclient.Interface, error) {
+	return c.clients.APIExtensions(c.cfg)
+}
 
-	addonList := make([]clusters.Addon, 0, len(c.addons))
-	for _, v := range c.addons {
-		addonList = append(addonList, v)
-	}
+func (c *Cluster) GetAddon(name clusters.AddonName) (clusters.Addon, error) {
+	return c.addons.Get(name)
+}
 
-	return addonList
+func (c *Cluster) ListAddons() []clusters.Addon {
+	return c.addons.List()
 }
 
 func (c *Cluster) DeployAddon(ctx context.Context, addon clusters.Addon) error {
-	c.l.Lock()
-	if _, ok := c.addons[addon.Name()]; ok {
-		c.l.Unlock()
-		return fmt.Errorf("addon component %s is already loaded into cluster %s", addon.Name(), c.Name())
-	}
-	c.addons[addon.Name()] = addon
-	c.l.Unlock()
-
-	return addon.Deploy(ctx, c)
+	return c.addons.Deploy(ctx, c, addon)
 }
 
 func (c *Cluster) DeleteAddon(ctx context.Context, addon clusters.Addon) error {
-	c.l.Lock()
-	defer c.l.Unlock()
-
-	if _, ok := c.addons[addon.Name()]; !ok {
-		return nil
-	}
-
-	if err := addon.Delete(ctx, c); err != nil {
-		return err
-	}
-
-	delete(c.addons, addon.Name())
-
-	return nil
+	return c.addons.Delete(ctx, c, addon)
 }
 
 // DumpDiagnostics produces diagnostics data for the cluster at a given time.
@@ -283,3 +288,9 @@ func (c *Cluster) DumpDiagnostics(ctx context.Context, meta string) (string, err
 func (c *Cluster) IPFamily() clusters.IPFamily {
 	return c.ipFamily
 }
+
+func (c *Cluster) Metadata() clusters.Metadata {
+	metadata := c.metadata
+	metadata.Labels = maps.Clone(c.metadata.Labels)
+	return metadata
+}
@@ -0,0 +1,51 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+
+	container "cloud.google.com/go/container/apiv1"
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"google.golang.org/api/option"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+)
+
+// -----------------------------------------------------------------------------
+// GKE Cluster - Node Scaling
+// -----------------------------------------------------------------------------
+
+// defaultNodePool is the name of the node pool GKE creates for the initial
+// nodes of a cluster.
+const defaultNodePool = "default-pool"
+
+// ScaleTo resizes the default node pool of the cluster to n nodes and waits
+// for them to be Ready. For regional clusters n is the number of nodes in each
+// of the zones of the node pool, as with the GKE API.
+func (c *Cluster) ScaleTo(ctx context.Context, n int) error {
+	if n < 0 {
+		return fmt.Errorf("invalid number of nodes %d", n)
+	}
+
+	mgrc, err := container.NewClusterManagerClient(ctx, option.WithCredentialsJSON(c.jsonCreds))
+	if err != nil {
+		return err
+	}
+	defer mgrc.Close()
+
+	poolName := fmt.Sprintf("projects/%s/locations/%s/clusters/%s/nodePools/%s", c.project, c.location, c.name, defaultNodePool)
+	pool, err := mgrc.GetNodePool(ctx, &containerpb.GetNodePoolRequest{Name: poolName})
+	if err != nil {
+		return fmt.Errorf("failed to get node pool %s of cluster %s: %w", defaultNodePool, c.name, err)
+	}
+
+	op, err := mgrc.SetNodePoolSize(ctx, &containerpb.SetNodePoolSizeRequest{Name: poolName, NodeCount: int32(n)})
+	if err != nil {
+		return fmt.Errorf("failed to resize node pool %s of cluster %s: %w", defaultNodePool, c.name, err)
+	}
+	if err := waitForOperation(ctx, mgrc, c.operationName(op)); err != nil {
+		return fmt.Errorf("failed to wait for resizing node pool %s of cluster %s: %w", defaultNodePool, c.name, err)
+	}
+
+	return clusters.WaitForWorkerNodes(ctx, c.client, n*max(len(pool.Locations), 1))
+}
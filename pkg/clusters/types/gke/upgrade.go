@@ -0,0 +1,60 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+
+	container "cloud.google.com/go/container/apiv1"
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/blang/semver/v4"
+	"google.golang.org/api/option"
+)
+
+// -----------------------------------------------------------------------------
+// GKE Cluster - Upgrades
+// -----------------------------------------------------------------------------
+
+// Upgrade upgrades the control plane of the cluster and then its default node
+// pool to the given Kubernetes version, which has to be available in GKE
+// (either a full GKE version, e.g. "1.28.3-gke.1203001", or a patch version to
+// use the latest GKE version of).
+func (c *Cluster) Upgrade(ctx context.Context, version semver.Version) error {
+	mgrc, err := container.NewClusterManagerClient(ctx, option.WithCredentialsJSON(c.jsonCreds))
+	if err != nil {
+		return err
+	}
+	defer mgrc.Close()
+
+	clusterName := fmt.Sprintf("projects/%s/locations/%s/clusters/%s", c.project, c.location, c.name)
+	op, err := mgrc.UpdateMaster(ctx, &containerpb.UpdateMasterRequest{Name: clusterName, MasterVersion: version.String()})
+	if err != nil {
+		return fmt.Errorf("failed to upgrade control plane of cluster %s to %s: %w", c.name, version, err)
+	}
+	if err := waitForOperation(ctx, mgrc, c.operationName(op)); err != nil {
+		return fmt.Errorf("failed to wait for upgrading control plane of cluster %s: %w", c.name, err)
+	}
+
+	// the image type of the nodes is required, it's kept as it is.
+	poolName := fmt.Sprintf("%s/nodePools/%s", clusterName, defaultNodePool)
+	pool, err := mgrc.GetNodePool(ctx, &containerpb.GetNodePoolRequest{Name: poolName})
+	if err != nil {
+		return fmt.Errorf("failed to get node pool %s of cluster %s: %w", defaultNodePool, c.name, err)
+	}
+	op, err = mgrc.UpdateNodePool(ctx, &containerpb.UpdateNodePoolRequest{
+		Name:        poolName,
+		NodeVersion: version.String(),
+		ImageType:   pool.GetConfig().GetImageType(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upgrade node pool %s of cluster %s to %s: %w", defaultNodePool, c.name, version, err)
+	}
+	if err := waitForOperation(ctx, mgrc, c.operationName(op)); err != nil {
+		return fmt.Errorf("failed to wait for upgrading node pool %s of cluster %s: %w", defaultNodePool, c.name, err)
+	}
+	return nil
+}
+
+// operationName provides the full name of an operation on the cluster.
+func (c *Cluster) operationName(op *containerpb.Operation) string {
+	return fmt.Sprintf("projects/%s/locations/%s/operations/%s", c.project, c.location, op.Name)
+}
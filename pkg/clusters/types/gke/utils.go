@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"strings"
+	"time"
 
 	container "cloud.google.com/go/container/apiv1"
 	containerpb "cloud.google.com/go/container/apiv1/containerpb"
@@ -13,6 +15,8 @@ import (
 	"google.golang.org/api/transport"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
 )
 
 // -----------------------------------------------------------------------------
@@ -32,32 +36,35 @@ func deleteCluster(
 }
 
 // clientForCluster provides a *kubernetes.Clientset for a GKE cluster provided the cluster name
-// and an oauth token for the gcloud API. This client will only be valid for 1 hour.
+// and an oauth token for the gcloud API, along with the record of the cluster.
+// This client will only be valid for 1 hour. It's configured with the default client options
+// and then the provided ones.
 func clientForCluster(
 	ctx context.Context,
 	mgrc *container.ClusterManagerClient,
 	oauthToken, name, project, location string,
-) (*rest.Config, *kubernetes.Clientset, error) {
+	opts ...clusters.ClientOptions,
+) (*rest.Config, *kubernetes.Clientset, *containerpb.Cluster, error) {
 	// pull the record of the cluster from the gke API
 	fullname := fmt.Sprintf("projects/%s/locations/%s/clusters/%s", project, location, name)
 	getClusterReq := containerpb.GetClusterRequest{Name: fullname}
 	cluster, err := mgrc.GetCluster(ctx, &getClusterReq)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	// decode the TLS data needed to communicate with the cluster securely
 	decodedClientCert, err := base64.StdEncoding.DecodeString(cluster.MasterAuth.ClientCertificate)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	decodedClientKey, err := base64.StdEncoding.DecodeString(cluster.MasterAuth.ClientKey)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	decodedCA, err := base64.StdEncoding.DecodeString(cluster.MasterAuth.ClusterCaCertificate)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	// generate the *rest.Config and kubernetes.Clientset
@@ -71,12 +78,13 @@ func clientForCluster(
 			CAData:   decodedCA,
 		},
 	}
+	clusters.ConfigureClient(&cfg, opts...)
 	k, err := kubernetes.NewForConfig(&cfg)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
-	return &cfg, k, nil
+	return &cfg, k, cluster, nil
 }
 
 // listLatestClusterPatchVersions provides a map which provides the semver (and api tag) of the latest
@@ -140,3 +148,27 @@ func clientAuthFromCreds(ctx context.Context, jsonCreds []byte) (*container.Clus
 
 	return mgrc, oauthToken.AccessToken, nil
 }
+
+// metadataForCluster describes a GKE cluster given its record.
+func metadataForCluster(cluster *containerpb.Cluster) clusters.Metadata {
+	metadata := clusters.Metadata{
+		Provider: GKEClusterType,
+		Labels:   cluster.GetResourceLabels(),
+	}
+
+	// the location of zonal clusters is a zone (e.g. us-central1-a) of
+	// a region, the location of regional clusters is the region.
+	location := cluster.GetLocation()
+	if strings.Count(location, "-") > 1 {
+		metadata.Zone = location
+		metadata.Region = location[:strings.LastIndex(location, "-")]
+	} else {
+		metadata.Region = location
+	}
+
+	metadata.NodeImage = cluster.GetNodeConfig().GetImageType()
+	if created, err := time.Parse(time.RFC3339, cluster.GetCreateTime()); err == nil {
+		metadata.CreationTime = created.UTC()
+	}
+	return metadata
+}
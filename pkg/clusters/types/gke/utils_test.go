@@ -0,0 +1,33 @@
+package gke
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+)
+
+func TestMetadataForCluster(t *testing.T) {
+	zonal := metadataForCluster(&containerpb.Cluster{
+		Location:       "us-central1-a",
+		CreateTime:     "2024-02-01T10:00:00+00:00",
+		NodeConfig:     &containerpb.NodeConfig{ImageType: "COS_CONTAINERD"},
+		ResourceLabels: map[string]string{GKECreateLabel: "ci", "team": "gateway"},
+	})
+	require.Equal(t, clusters.Metadata{
+		Provider:     GKEClusterType,
+		Region:       "us-central1",
+		Zone:         "us-central1-a",
+		NodeImage:    "COS_CONTAINERD",
+		CreationTime: time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC),
+		Labels:       map[string]string{GKECreateLabel: "ci", "team": "gateway"},
+	}, zonal)
+
+	regional := metadataForCluster(&containerpb.Cluster{Location: "europe-west1"})
+	require.Equal(t, "europe-west1", regional.Region)
+	require.Empty(t, regional.Zone)
+	require.True(t, regional.CreationTime.IsZero())
+}
@@ -0,0 +1,101 @@
+package kind
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/docker/docker/client"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/docker"
+)
+
+// -----------------------------------------------------------------------------
+// Kind Cluster - Air-Gapped Mode
+// -----------------------------------------------------------------------------
+
+// airGappedProxy is an unreachable proxy address which is given to the kind
+// nodes in air-gapped mode so that any attempt to pull an image from an
+// external registry fails immediately instead of timing out.
+const airGappedProxy = "http://127.0.0.1:9"
+
+// WithAirGapped configures the Builder to create the cluster without access to
+// any external image registries. All image archives (as produced by
+// "docker save") found in bundleDir with a ".tar" extension are loaded into the
+// local docker environment before the cluster is created (this must include
+// the kind node image) and into the cluster nodes once they're created
+// (this should include the images of all addons that are going to be deployed).
+//
+// Image pulls from the kind nodes are routed to an unreachable proxy so
+// that anything missing from the bundle fails fast.
+func (b *Builder) WithAirGapped(bundleDir string) *Builder {
+	b.imageBundleDir = &bundleDir
+	return b
+}
+
+// imageBundleArchives lists the image archives in the Builder's bundle directory.
+func (b *Builder) imageBundleArchives() ([]string, error) {
+	archives, err := filepath.Glob(filepath.Join(*b.imageBundleDir, "*.tar"))
+	if err != nil {
+		return nil, err
+	}
+	if len(archives) == 0 {
+		return nil, fmt.Errorf("no image archives found in %s", *b.imageBundleDir)
+	}
+	return archives, nil
+}
+
+// loadImageArchivesIntoDocker loads the provided image archives into the local
+// docker environment.
+func loadImageArchivesIntoDocker(ctx context.Context, archives []string) error {
+	dockerc, err := docker.NewNegotiatedClientWithOpts(ctx, client.FromEnv)
+	if err != nil {
+		return err
+	}
+	defer dockerc.Close()
+
+	for _, archive := range archives {
+		if err := loadImageArchiveIntoDocker(ctx, dockerc, archive); err != nil {
+			return fmt.Errorf("failed to load image archive %s: %w", archive, err)
+		}
+	}
+	return nil
+}
+
+func loadImageArchiveIntoDocker(ctx context.Context, dockerc *client.Client, archive string) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	resp, err := dockerc.ImageLoad(ctx, f, true)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// the load is only complete once the response has been consumed
+	_, err = io.Copy(io.Discard, resp.Body)
+	return err
+}
+
+// loadImageArchivesIntoCluster loads the provided image archives into the given
+// nodes of the kind cluster, all of them if none are given.
+func loadImageArchivesIntoCluster(ctx context.Context, name string, archives []string, nodes ...string) error {
+	for _, archive := range archives {
+		stderr := new(bytes.Buffer)
+		args := append([]string{"load", "image-archive", archive, "--name", name}, nodesArgs(nodes)...)
+		cmd := exec.CommandContext(ctx, "kind", args...)
+		cmd.Stdout = io.Discard
+		cmd.Stderr = stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("failed to load image archive %s into cluster %s: %s: %w", archive, name, stderr.String(), err)
+		}
+	}
+	return nil
+}
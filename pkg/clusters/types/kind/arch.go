@@ -0,0 +1,81 @@
+package kind
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+
+	"github.com/docker/docker/client"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/docker"
+)
+
+// -----------------------------------------------------------------------------
+// Kind Cluster - Architecture
+// -----------------------------------------------------------------------------
+
+// dockerArchitectures maps the architecture names reported by the docker
+// daemon (uname style) to GOARCH naming.
+var dockerArchitectures = map[string]string{
+	"x86_64":  "amd64",
+	"aarch64": "arm64",
+	"armv7l":  "arm",
+}
+
+// parseArchitecture converts an architecture name as reported by docker for
+// the daemon or an image to GOARCH naming. GOARCH names are left unchanged.
+func parseArchitecture(arch string) string {
+	if goarch, ok := dockerArchitectures[arch]; ok {
+		return goarch
+	}
+	return arch
+}
+
+// detectArchitecture determines the CPU architecture (using GOARCH naming) of
+// the docker environment the kind nodes will run in. This can differ from the
+// architecture of the test binary, e.g. with a remote docker daemon or under
+// emulation, so the architecture runtime reports is only used as a fallback.
+func detectArchitecture(ctx context.Context) string {
+	dockerc, err := docker.NewNegotiatedClientWithOpts(ctx, client.FromEnv)
+	if err != nil {
+		return runtime.GOARCH
+	}
+	defer dockerc.Close()
+
+	info, err := dockerc.Info(ctx)
+	if err != nil || info.Architecture == "" {
+		return runtime.GOARCH
+	}
+	return parseArchitecture(info.Architecture)
+}
+
+// ensureNodeImageArchitecture pulls the node image (if not present already)
+// and verifies that it's built for the architecture of the docker environment.
+// Kind would otherwise only fail once the node container doesn't boot, e.g.
+// for old kindest/node images which were only published for amd64.
+func ensureNodeImageArchitecture(ctx context.Context, image, arch string) error {
+	if err := docker.EnsureImages(ctx, image); err != nil {
+		return err
+	}
+
+	dockerc, err := docker.NewNegotiatedClientWithOpts(ctx, client.FromEnv)
+	if err != nil {
+		return err
+	}
+	defer dockerc.Close()
+
+	inspect, _, err := dockerc.ImageInspectWithRaw(ctx, image)
+	if err != nil {
+		return fmt.Errorf("failed to inspect node image %s: %w", image, err)
+	}
+	if imageArch := parseArchitecture(inspect.Architecture); imageArch != arch {
+		return fmt.Errorf("node image %s is built for %s but the docker environment runs %s, use one of the multi-arch SupportedNodeImages instead", image, imageArch, arch)
+	}
+	return nil
+}
+
+// Architecture provides the CPU architecture of the cluster's nodes using
+// GOARCH naming (e.g. "amd64", "arm64") so that matching images can be used.
+func (c *Cluster) Architecture() string {
+	return c.arch
+}
@@ -0,0 +1,20 @@
+package kind
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseArchitecture(t *testing.T) {
+	for arch, expected := range map[string]string{
+		"x86_64":  "amd64",
+		"aarch64": "arm64",
+		"armv7l":  "arm",
+		"amd64":   "amd64",
+		"arm64":   "arm64",
+		"s390x":   "s390x",
+	} {
+		assert.Equal(t, expected, parseArchitecture(arch), arch)
+	}
+}
@@ -5,14 +5,20 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/blang/semver/v4"
+	"github.com/go-logr/logr"
 	"github.com/google/uuid"
 
 	"github.com/kong/kubernetes-testing-framework/internal/utils"
 	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/docker"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/images"
 )
 
 // Builder generates clusters.Cluster objects backed by Kind given
@@ -25,7 +31,68 @@ type Builder struct {
 	configPath     *string
 	configReader   io.Reader
 	calicoCNI      bool
+	defaultCNIOff  bool
+	kubeProxyOff   bool
 	ipv6Only       bool
+	proxy          *ProxyConfig
+
+	// generatedConfigPath is the config file created by ensureConfigFile.
+	generatedConfigPath *string
+
+	containerdConfigPatches  []string
+	kubeadmConfigPatches     []string
+	nodeKubeadmConfigPatches map[string][]string
+	preflightChecksDisabled  bool
+	imageBundleDir           *string
+	nodeResources            *NodeResources
+	dockerNetwork            string
+	dockerNetworkSubnet      string
+	owner                    string
+	testID                   string
+	preloadedImages          []string
+	labels                   map[string]string
+	clientOptions            clusters.ClientOptions
+	logger                   logr.Logger
+}
+
+// NodeResources are the docker resource limits applied to each kind node.
+type NodeResources struct {
+	// CPUs is the number of CPUs each node may use, e.g. 1.5.
+	CPUs float64
+
+	// Memory is the amount of memory (in bytes) each node may use.
+	Memory int64
+}
+
+// ProxyConfig configures the HTTP proxies which the kind nodes and their
+// container runtime will use to reach the outside world (e.g. for image pulls).
+type ProxyConfig struct {
+	// HTTPProxy is the proxy to use for HTTP requests.
+	HTTPProxy string
+
+	// HTTPSProxy is the proxy to use for HTTPS requests.
+	HTTPSProxy string
+
+	// NoProxy is a comma separated list of hosts, domains and CIDRs which
+	// should be reached directly. Kind adds the cluster's internal ranges.
+	NoProxy string
+}
+
+// env provides the proxy configuration as environment variables, in both the
+// upper and lower case forms.
+func (p ProxyConfig) env() []string {
+	var env []string
+	for key, value := range map[string]string{
+		"HTTP_PROXY":  p.HTTPProxy,
+		"HTTPS_PROXY": p.HTTPSProxy,
+		"NO_PROXY":    p.NoProxy,
+	} {
+		if value == "" {
+			continue
+		}
+		env = append(env, key+"="+value, strings.ToLower(key)+"="+value)
+	}
+	return env
 }
 
 // NewBuilder provides a new *Builder object.
@@ -36,6 +103,22 @@ func NewBuilder() *Builder {
 	}
 }
 
+// WithLogger configures the logger which the cluster logs its progress to
+// (e.g. its creation, addon deployments and cleanup), see clusters.Logger.
+// Nothing is logged by default.
+func (b *Builder) WithLogger(logger logr.Logger) *Builder {
+	b.logger = logger
+	return b
+}
+
+// WithClientOptions tunes the clients of the cluster (e.g. their QPS and
+// burst), overriding the options which are not zero of
+// clusters.DefaultClientOptions.
+func (b *Builder) WithClientOptions(opts clusters.ClientOptions) *Builder {
+	b.clientOptions = opts
+	return b
+}
+
 // WithName indicates a custom name to use for the cluster.
 func (b *Builder) WithName(name string) *Builder {
 	b.Name = name
@@ -75,36 +158,198 @@ func (b *Builder) WithCalicoCNI() *Builder {
 	return b
 }
 
+// WithDefaultCNIDisabled disables the default CNI (kindnet) for the kind
+// cluster, so that a different CNI can be deployed as an addon (e.g. the
+// calico or cilium addons). Nodes won't become ready until a CNI is deployed.
+func (b *Builder) WithDefaultCNIDisabled() *Builder {
+	b.defaultCNIOff = true
+	return b
+}
+
+// WithKubeProxyDisabled creates the kind cluster without kube-proxy, for use
+// with a CNI that replaces it (e.g. the cilium addon with kube-proxy
+// replacement enabled). This is normally combined with WithDefaultCNIDisabled.
+func (b *Builder) WithKubeProxyDisabled() *Builder {
+	b.kubeProxyOff = true
+	return b
+}
+
 // WithIPv6Only configures KIND to only use IPv6.
 func (b *Builder) WithIPv6Only() *Builder {
 	b.ipv6Only = true
 	return b
 }
 
+// nodeProxy provides the proxy configuration for the kind nodes, if any.
+func (b *Builder) nodeProxy() *ProxyConfig {
+	if b.imageBundleDir == nil {
+		return b.proxy
+	}
+
+	// in air-gapped mode all external traffic is sent to an unreachable proxy.
+	proxy := ProxyConfig{HTTPProxy: airGappedProxy, HTTPSProxy: airGappedProxy}
+	if b.proxy != nil {
+		proxy.NoProxy = b.proxy.NoProxy
+	}
+	return &proxy
+}
+
+// WithProxy configures HTTP_PROXY, HTTPS_PROXY and NO_PROXY for the kind nodes
+// and containerd so that image pulls work behind a corporate proxy.
+func (b *Builder) WithProxy(proxy ProxyConfig) *Builder {
+	b.proxy = &proxy
+	return b
+}
+
+// WithContainerdConfigPatch adds a TOML patch which is merged into the
+// containerd configuration of every kind node, allowing e.g. registry mirrors,
+// insecure registries or sandbox image overrides to be configured.
+// See: https://kind.sigs.k8s.io/docs/user/configuration/#containerd-config-patches
+// This can be called multiple times, patches are applied in order.
+func (b *Builder) WithContainerdConfigPatch(toml string) *Builder {
+	b.containerdConfigPatches = append(b.containerdConfigPatches, toml)
+	return b
+}
+
+// WithKubeadmPatch adds a kubeadm config patch (e.g. for a ClusterConfiguration
+// or KubeletConfiguration) which is applied to every node in the cluster.
+// See: https://kind.sigs.k8s.io/docs/user/configuration/#kubeadm-config-patches
+// This can be called multiple times, patches are applied in order.
+func (b *Builder) WithKubeadmPatch(patch string) *Builder {
+	b.kubeadmConfigPatches = append(b.kubeadmConfigPatches, patch)
+	return b
+}
+
+// WithNodeKubeadmPatch adds a kubeadm config patch which is only applied to
+// the nodes of the given role ("control-plane" or "worker").
+// This can be called multiple times, patches are applied in order.
+func (b *Builder) WithNodeKubeadmPatch(role, patch string) *Builder {
+	if b.nodeKubeadmConfigPatches == nil {
+		b.nodeKubeadmConfigPatches = make(map[string][]string)
+	}
+	b.nodeKubeadmConfigPatches[role] = append(b.nodeKubeadmConfigPatches[role], patch)
+	return b
+}
+
+// WithNodeResources limits the CPUs and memory (in bytes) available to each
+// node container of the cluster, so that many small clusters can share a host
+// without starving each other. The limits are applied once the nodes are created.
+func (b *Builder) WithNodeResources(cpus float64, memory int64) *Builder {
+	b.nodeResources = &NodeResources{CPUs: cpus, Memory: memory}
+	return b
+}
+
+// WithDockerNetwork configures the docker network the kind nodes are attached
+// to. If the network doesn't exist it's created, using the provided subnet
+// (in CIDR notation) if one is given.
+func (b *Builder) WithDockerNetwork(name, subnet string) *Builder {
+	b.dockerNetwork = name
+	b.dockerNetworkSubnet = subnet
+	return b
+}
+
+// WithPreflightChecksDisabled skips the environment validation which is
+// otherwise performed prior to creating the cluster. See Preflight.
+func (b *Builder) WithPreflightChecksDisabled() *Builder {
+	b.preflightChecksDisabled = true
+	return b
+}
+
 // Build creates and configures clients for a Kind-based Kubernetes clusters.Cluster.
 func (b *Builder) Build(ctx context.Context) (clusters.Cluster, error) {
-	deployArgs := make([]string, 0)
-	if b.clusterVersion != nil {
-		deployArgs = append(deployArgs, "--image", "kindest/node:v"+b.clusterVersion.String())
+	if !b.preflightChecksDisabled {
+		warnings, err := b.Preflight(ctx)
+		for _, warning := range warnings {
+			b.logger.Info("preflight check warning", "cluster", b.Name, "warning", warning)
+		}
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	if b.calicoCNI {
+	deployArgs := make([]string, 0)
+	mirrors := images.RegistryMirrors()
+	_, mirrored := mirrors[images.DefaultRegistry]
+
+	if b.calicoCNI || b.defaultCNIOff {
 		if err := b.disableDefaultCNI(); err != nil {
 			return nil, fmt.Errorf("failed disabling default CNI for kind cluster: %w", err)
 		}
 
-		// if calico is enabled, we can't effectively wait for the cluster to
-		// be ready because it wont be possible for it to become ready until we
-		// deploy calico, as the default CNI has been disabled.
+		// if the default CNI is disabled, we can't effectively wait for the
+		// cluster to be ready because it wont be possible for it to become
+		// ready until we deploy another CNI (e.g. calico).
 		deployArgs = append(deployArgs, "--wait", "1s")
 	}
 
+	if b.kubeProxyOff {
+		if err := b.disableKubeProxy(); err != nil {
+			return nil, fmt.Errorf("failed disabling kube-proxy for kind cluster: %w", err)
+		}
+	}
+
 	if b.ipv6Only {
 		if err := b.useIPv6Only(); err != nil {
 			return nil, fmt.Errorf("failed configuring IPv6-only networking: %w", err)
 		}
 	}
 
+	containerdConfigPatches := b.containerdConfigPatches
+	if len(mirrors) > 0 {
+		containerdConfigPatches = append([]string{registryMirrorsPatch(mirrors)}, containerdConfigPatches...)
+	}
+	if len(containerdConfigPatches) > 0 {
+		if err := b.addContainerdConfigPatches(containerdConfigPatches); err != nil {
+			return nil, fmt.Errorf("failed configuring containerd config patches: %w", err)
+		}
+	}
+
+	if len(b.kubeadmConfigPatches) > 0 || len(b.nodeKubeadmConfigPatches) > 0 {
+		if err := b.addKubeadmConfigPatches(); err != nil {
+			return nil, fmt.Errorf("failed configuring kubeadm config patches: %w", err)
+		}
+	}
+
+	var imageArchives []string
+	if b.imageBundleDir != nil {
+		var err error
+		if imageArchives, err = b.imageBundleArchives(); err != nil {
+			return nil, err
+		}
+		if err := loadImageArchivesIntoDocker(ctx, imageArchives); err != nil {
+			return nil, err
+		}
+	}
+
+	// The node image is overridden for all nodes if it's mirrored or a
+	// version was requested. If labels were requested the nodes are labelled
+	// through their images (see useLabelledNodeImages), which are removed
+	// again if the cluster can't be created.
+	ownershipLabels := b.ownershipLabels()
+	nodeImage := images.Mirror(NodeImage(b.clusterVersion))
+	overrideNodeImage := mirrored || b.clusterVersion != nil
+	arch := detectArchitecture(ctx)
+	if overrideNodeImage {
+		if err := ensureNodeImageArchitecture(ctx, nodeImage, arch); err != nil {
+			return nil, err
+		}
+	}
+	if b.labelsRequested() {
+		if err := b.buildLabelledNodeImages(ctx, nodeImage, overrideNodeImage, ownershipLabels); err != nil {
+			return nil, cleanupLabelledNodeImages(ctx, b.Name, fmt.Errorf("failed labelling node images for kind cluster: %w", err))
+		}
+	} else if overrideNodeImage {
+		deployArgs = append(deployArgs, "--image", nodeImage)
+	}
+
+	dockerNetwork := DefaultKindDockerNetwork
+	if b.dockerNetwork != "" {
+		dockerNetwork = b.dockerNetwork
+		if err := docker.EnsureNetwork(ctx, b.dockerNetwork, b.dockerNetworkSubnet, ownershipLabels); err != nil {
+			return nil, cleanupLabelledNodeImages(ctx, b.Name, err)
+		}
+	}
+
 	var stdin io.Reader
 	if b.configPath != nil {
 		deployArgs = append(deployArgs, "--config", *b.configPath)
@@ -113,19 +358,38 @@ func (b *Builder) Build(ctx context.Context) (clusters.Cluster, error) {
 		stdin = b.configReader
 	}
 
+	logger := b.logger.WithValues("cluster", b.Name)
+	logger.Info("creating kind cluster", "args", deployArgs)
+	clusters.EmitEvent(clusters.Event{Type: clusters.EventClusterCreating, Cluster: b.Name})
+	start := time.Now()
+
 	args := append([]string{"create", "cluster", "--name", b.Name}, deployArgs...)
 	stderr := new(bytes.Buffer)
 	cmd := exec.CommandContext(ctx, "kind", args...)
 	cmd.Stdout = io.Discard
 	cmd.Stderr = stderr
 	cmd.Stdin = stdin
+	cmd.Env = os.Environ()
+	if proxy := b.nodeProxy(); proxy != nil {
+		// kind propagates the proxy environment of the create command to the
+		// node containers, where it's picked up by containerd.
+		cmd.Env = append(cmd.Env, proxy.env()...)
+	}
+	if b.dockerNetwork != "" {
+		cmd.Env = append(cmd.Env, kindDockerNetworkEnv+"="+b.dockerNetwork)
+	}
 
 	if err := cmd.Run(); err != nil {
-		return nil, fmt.Errorf("failed to create cluster %s: %s: %w", b.Name, stderr.String(), err)
+		err = cleanupLabelledNodeImages(ctx, b.Name, fmt.Errorf("failed to create cluster %s: %s: %w", b.Name, stderr.String(), err))
+		clusters.EmitEvent(clusters.Event{Type: clusters.EventClusterCreated, Cluster: b.Name, Duration: time.Since(start), Err: err})
+		return nil, err
 	}
 
-	cfg, kc, err := clientForCluster(b.Name)
+	cfg, kc, err := clientForCluster(b.Name, b.clientOptions)
 	if err != nil {
+		if deleteErr := deleteKindCluster(context.WithoutCancel(ctx), b.Name); deleteErr != nil {
+			return nil, fmt.Errorf("multiple errors occurred BUILD_ERROR=(%s) CLEANUP_ERROR=(%s)", err, deleteErr)
+		}
 		return nil, err
 	}
 
@@ -135,17 +399,55 @@ func (b *Builder) Build(ctx context.Context) (clusters.Cluster, error) {
 	}
 
 	cluster := &Cluster{
-		name:       b.Name,
-		client:     kc,
-		cfg:        cfg,
-		addons:     make(clusters.Addons),
-		deployArgs: deployArgs,
-		l:          &sync.RWMutex{},
-		ipFamily:   ipFamily,
+		name:          b.Name,
+		client:        kc,
+		cfg:           cfg,
+		deployArgs:    deployArgs,
+		l:             &sync.RWMutex{},
+		ipFamily:      ipFamily,
+		dockerNetwork: dockerNetwork,
+		metadata:      clusterMetadata(ownershipLabels, nodeImage),
+		arch:          arch,
+		logger:        b.logger,
+
+		preloadedImages: b.preloadedImages,
+		imageArchives:   imageArchives,
+	}
+	logger.Info("created kind cluster", "duration", time.Since(start))
+	clusters.EmitEvent(clusters.Event{Type: clusters.EventClusterCreated, Cluster: b.Name, Duration: time.Since(start)})
+
+	if b.nodeResources != nil {
+		if err := limitNodeResources(ctx, b.Name, *b.nodeResources); err != nil {
+			if cleanupErr := cluster.Cleanup(ctx); cleanupErr != nil {
+				return nil, fmt.Errorf("multiple errors occurred BUILD_ERROR=(%s) CLEANUP_ERROR=(%s)", err, cleanupErr)
+			}
+			return nil, err
+		}
+	}
+
+	if len(imageArchives) > 0 {
+		if err := loadImageArchivesIntoCluster(ctx, b.Name, imageArchives); err != nil {
+			if cleanupErr := cluster.Cleanup(ctx); cleanupErr != nil {
+				return nil, fmt.Errorf("multiple errors occurred BUILD_ERROR=(%s) CLEANUP_ERROR=(%s)", err, cleanupErr)
+			}
+			return nil, err
+		}
+	}
+
+	if len(b.preloadedImages) > 0 {
+		if err := loadDockerImagesIntoCluster(ctx, b.Name, b.preloadedImages); err != nil {
+			if cleanupErr := cluster.Cleanup(ctx); cleanupErr != nil {
+				return nil, fmt.Errorf("multiple errors occurred BUILD_ERROR=(%s) CLEANUP_ERROR=(%s)", err, cleanupErr)
+			}
+			return nil, err
+		}
 	}
 
 	if b.calicoCNI {
 		if err := clusters.ApplyManifestByURL(ctx, cluster, defaultCalicoManifests); err != nil {
+			if cleanupErr := cluster.Cleanup(ctx); cleanupErr != nil {
+				return nil, fmt.Errorf("multiple errors occurred BUILD_ERROR=(%s) CLEANUP_ERROR=(%s)", err, cleanupErr)
+			}
 			return nil, err
 		}
 	}
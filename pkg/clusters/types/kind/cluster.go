@@ -2,12 +2,17 @@ package kind
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"maps"
 	"os"
-	"strings"
 	"sync"
+	"time"
 
 	"github.com/blang/semver/v4"
+	"github.com/go-logr/logr"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
@@ -28,17 +33,33 @@ const (
 
 	// DefaultKindDockerNetwork is the Docker network that a kind cluster uses by default.
 	DefaultKindDockerNetwork = "kind"
+
+	// kindDockerNetworkEnv is the environment variable kind uses to select the
+	// docker network which nodes are attached to.
+	kindDockerNetworkEnv = "KIND_EXPERIMENTAL_DOCKER_NETWORK"
 )
 
 // Cluster is a clusters.Cluster implementation backed by Kubernetes In Docker (KIND)
 type Cluster struct {
-	name       string
-	client     *kubernetes.Clientset
-	cfg        *rest.Config
-	addons     clusters.Addons
-	deployArgs []string
-	l          *sync.RWMutex
-	ipFamily   clusters.IPFamily
+	name          string
+	client        *kubernetes.Clientset
+	cfg           *rest.Config
+	clients       clusters.Clients
+	addons        clusters.DeployedAddons
+	teardownHooks clusters.TeardownHooks
+	deployArgs    []string
+	l             *sync.RWMutex
+	ipFamily      clusters.IPFamily
+
+	dockerNetwork string
+	metadata      clusters.Metadata
+	arch          string
+	logger        logr.Logger
+
+	// preloadedImages and imageArchives are loaded into the nodes added when
+	// scaling the cluster, like into the nodes created with it.
+	preloadedImages []string
+	imageArchives   []string
 }
 
 // New provides a new clusters.Cluster backed by a Kind based Kubernetes Cluster.
@@ -59,23 +80,42 @@ func (c *Cluster) Type() clusters.Type {
 	return KindClusterType
 }
 
-func (c *Cluster) Version() (semver.Version, error) {
-	versionInfo, err := c.Client().ServerVersion()
-	if err != nil {
-		return semver.Version{}, err
-	}
-	return semver.Parse(strings.TrimPrefix(versionInfo.String(), "v"))
+func (c *Cluster) Version(ctx context.Context) (semver.Version, error) {
+	return clusters.ServerVersion(ctx, c.client)
+}
+
+// RegisterTeardownHook registers a hook to run when the cluster is cleaned
+// up, before it's deleted. Hooks run in the reverse order of their
+// registration, even if the cluster is kept.
+func (c *Cluster) RegisterTeardownHook(hook clusters.TeardownHook) {
+	c.teardownHooks.Register(hook)
 }
 
-func (c *Cluster) Cleanup(ctx context.Context) error {
+func (c *Cluster) Cleanup(ctx context.Context) (err error) {
+	clusters.EmitEvent(clusters.Event{Type: clusters.EventCleanupStarted, Cluster: c.name})
+	start := time.Now()
+	defer func() {
+		clusters.EmitEvent(clusters.Event{Type: clusters.EventCleanupFinished, Cluster: c.name, Duration: time.Since(start), Err: err})
+	}()
+
+	hooksErr := c.teardownHooks.Run(ctx, c)
+
 	c.l.Lock()
 	defer c.l.Unlock()
 
 	if os.Getenv(EnvKeepCluster) == "" {
-		return deleteKindCluster(ctx, c.name)
+		c.logger.Info("deleting kind cluster", "cluster", c.name)
+		if err := deleteKindCluster(ctx, c.name); err != nil {
+			return errors.Join(hooksErr, err)
+		}
 	}
 
-	return nil
+	return hooksErr
+}
+
+// Logger provides the logger of the cluster, see Builder.WithLogger.
+func (c *Cluster) Logger() logr.Logger {
+	return c.logger
 }
 
 func (c *Cluster) Client() *kubernetes.Clientset {
@@ -86,58 +126,32 @@ func (c *Cluster) Config() *rest.Config {
 	return c.cfg
 }
 
-func (c *Cluster) GetAddon(name clusters.AddonName) (clusters.Addon, error) {
-	c.l.RLock()
-	defer c.l.RUnlock()
-
-	for addonName, addon := range c.addons {
-		if addonName == name {
-			return addon, nil
-		}
-	}
+func (c *Cluster) DynamicClient() (dynamic.Interface, error) {
+	return c.clients.Dynamic(c.cfg)
+}
 
-	return nil, fmt.Errorf("addon %s not found", name)
+func (c *Cluster) DiscoveryClient() (discovery.CachedDiscoveryInterface, error) {
+	return c.clients.Discovery(c.cfg)
 }
 
-func (c *Cluster) ListAddons() []clusters.Addon {
-	c.l.RLock()
-	defer c.l.RUnlock()
+func (c *Cluster) APIExtensionsClient() (apiextensionsclient.Interface, error) {
+	return c.clients.APIExtensions(c.cfg)
+}
 
-	addonList := make([]clusters.Addon, 0, len(c.addons))
-	for _, v := range c.addons {
-		addonList = append(addonList, v)
-	}
+func (c *Cluster) GetAddon(name clusters.AddonName) (clusters.Addon, error) {
+	return c.addons.Get(name)
+}
 
-	return addonList
+func (c *Cluster) ListAddons() []clusters.Addon {
+	return c.addons.List()
 }
 
 func (c *Cluster) DeployAddon(ctx context.Context, addon clusters.Addon) error {
-	c.l.Lock()
-	if _, ok := c.addons[addon.Name()]; ok {
-		c.l.Unlock()
-		return fmt.Errorf("addon component %s is already loaded into cluster %s", addon.Name(), c.Name())
-	}
-	c.addons[addon.Name()] = addon
-	c.l.Unlock()
-
-	return addon.Deploy(ctx, c)
+	return c.addons.Deploy(ctx, c, addon)
 }
 
 func (c *Cluster) DeleteAddon(ctx context.Context, addon clusters.Addon) error {
-	c.l.Lock()
-	defer c.l.Unlock()
-
-	if _, ok := c.addons[addon.Name()]; !ok {
-		return nil
-	}
-
-	if err := addon.Delete(ctx, c); err != nil {
-		return err
-	}
-
-	delete(c.addons, addon.Name())
-
-	return nil
+	return c.addons.Delete(ctx, c, addon)
 }
 
 // DumpDiagnostics produces diagnostics data for the cluster at a given time.
@@ -163,3 +177,18 @@ func (c *Cluster) DumpDiagnostics(ctx context.Context, meta string) (string, err
 func (c *Cluster) IPFamily() clusters.IPFamily {
 	return c.ipFamily
 }
+
+func (c *Cluster) Metadata() clusters.Metadata {
+	metadata := c.metadata
+	metadata.Labels = maps.Clone(c.metadata.Labels)
+	return metadata
+}
+
+// DockerNetwork provides the name of the docker network the cluster's nodes
+// are attached to.
+func (c *Cluster) DockerNetwork() string {
+	if c.dockerNetwork == "" {
+		return DefaultKindDockerNetwork
+	}
+	return c.dockerNetwork
+}
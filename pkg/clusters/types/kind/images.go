@@ -0,0 +1,81 @@
+package kind
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"sigs.k8s.io/kind/pkg/apis/config/defaults"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/docker"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/images"
+)
+
+// -----------------------------------------------------------------------------
+// Kind Cluster - Images
+// -----------------------------------------------------------------------------
+
+// NodeImage provides the kindest/node image for the given Kubernetes version,
+// pinned by digest if it's one of the SupportedNodeImages.
+// If no version is provided the default image of the kind library KTF is
+// built with is provided, which may differ from the installed kind binary.
+func NodeImage(version *semver.Version) string {
+	if version == nil {
+		return defaults.Image
+	}
+	if image, ok := supportedNodeImage(*version); ok {
+		return image
+	}
+	return "kindest/node:v" + version.String()
+}
+
+// WarmUp pulls the node image for the given Kubernetes version (see NodeImage,
+// using the configured registry mirror if any) and any other provided images
+// into the local docker environment, if they aren't present yet. This can be run once before building many clusters
+// in parallel so that the images are only downloaded once and are then shared
+// by all clusters (see Builder.WithPreloadedImages).
+func WarmUp(ctx context.Context, version *semver.Version, otherImages ...string) error {
+	return docker.EnsureImages(ctx, append([]string{images.Mirror(NodeImage(version))}, otherImages...)...)
+}
+
+// WithPreloadedImages configures images which are pulled into the local docker
+// environment (if not present already) and loaded into the cluster's nodes
+// once they are created. The local docker environment acts as a cache shared
+// by all clusters, so addon images aren't downloaded again by every cluster.
+func (b *Builder) WithPreloadedImages(preloadedImages ...string) *Builder {
+	b.preloadedImages = append(b.preloadedImages, preloadedImages...)
+	return b
+}
+
+// loadDockerImagesIntoCluster loads the provided images from the local docker
+// environment into the given nodes of the kind cluster, all of them if none
+// are given.
+func loadDockerImagesIntoCluster(ctx context.Context, name string, dockerImages []string, nodes ...string) error {
+	if err := docker.EnsureImages(ctx, dockerImages...); err != nil {
+		return err
+	}
+
+	args := append([]string{"load", "docker-image", "--name", name}, nodesArgs(nodes)...)
+	args = append(args, dockerImages...)
+	stderr := new(bytes.Buffer)
+	cmd := exec.CommandContext(ctx, "kind", args...)
+	cmd.Stdout = io.Discard
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to load images into cluster %s: %s: %w", name, stderr.String(), err)
+	}
+	return nil
+}
+
+// nodesArgs provides the arguments selecting the given nodes for the kind load
+// commands, which load into all nodes if none are selected.
+func nodesArgs(nodes []string) []string {
+	if len(nodes) == 0 {
+		return nil
+	}
+	return []string{"--nodes", strings.Join(nodes, ",")}
+}
@@ -0,0 +1,80 @@
+package kind
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+)
+
+// -----------------------------------------------------------------------------
+// Kind Cluster - NodePort Services
+// -----------------------------------------------------------------------------
+
+// NodePortAddress provides the host:port address at which the given port of a
+// NodePort (or LoadBalancer) Service can be reached from the host running the
+// cluster, which is useful for clusters that don't have MetalLB deployed.
+// The address of the node container on the cluster's docker network is used,
+// which is reachable on Linux hosts but not from Docker Desktop's host.
+func (c *Cluster) NodePortAddress(ctx context.Context, namespace, name string, port int32) (string, error) {
+	return nodePortAddress(ctx, c.client, c.ipFamily, namespace, name, port)
+}
+
+// NodePortURL provides a URL with the given scheme at which the given port of a
+// NodePort (or LoadBalancer) Service can be reached. See NodePortAddress.
+func (c *Cluster) NodePortURL(ctx context.Context, scheme, namespace, name string, port int32) (*url.URL, error) {
+	addr, err := c.NodePortAddress(ctx, namespace, name, port)
+	if err != nil {
+		return nil, err
+	}
+	return &url.URL{Scheme: scheme, Host: addr}, nil
+}
+
+func nodePortAddress(ctx context.Context, kc kubernetes.Interface, ipFamily clusters.IPFamily, namespace, name string, port int32) (string, error) {
+	service, err := kc.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	var nodePort int32
+	for _, servicePort := range service.Spec.Ports {
+		if servicePort.Port == port {
+			nodePort = servicePort.NodePort
+			break
+		}
+	}
+	if nodePort == 0 {
+		return "", fmt.Errorf("service %s/%s has no node port allocated for port %d", namespace, name, port)
+	}
+
+	nodes, err := kc.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	for _, node := range nodes.Items {
+		for _, addr := range node.Status.Addresses {
+			if addr.Type != corev1.NodeInternalIP {
+				continue
+			}
+			ip := net.ParseIP(addr.Address)
+			if ip == nil {
+				continue
+			}
+			// prefer IPv4 unless the cluster only supports IPv6
+			if (ip.To4() == nil) != (ipFamily == clusters.IPv6) {
+				continue
+			}
+			return net.JoinHostPort(ip.String(), strconv.Itoa(int(nodePort))), nil
+		}
+	}
+
+	return "", fmt.Errorf("no node addresses found to reach service %s/%s", namespace, name)
+}
@@ -0,0 +1,447 @@
+package kind
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kong/kubernetes-testing-framework/internal/retry"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/docker"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/wait"
+)
+
+// -----------------------------------------------------------------------------
+// Kind Cluster - Node Scaling
+// -----------------------------------------------------------------------------
+
+const (
+	// kindClusterLabel and kindRoleLabel are the docker labels with which kind
+	// identifies the node containers of a cluster and their role.
+	kindClusterLabel = "io.x-k8s.kind.cluster"
+	kindRoleLabel    = "io.x-k8s.kind.role"
+
+	// workerNodeInfix is what kind puts between the cluster name and the index
+	// in the names of worker nodes, e.g. "test-worker2".
+	workerNodeInfix = "-worker"
+
+	// kindKubeadmConfigPath is where kind writes the kubeadm configuration it
+	// initializes and joins each node with.
+	kindKubeadmConfigPath = "/kind/kubeadm.conf"
+
+	// containerdConfigPath is the path of the containerd configuration of the
+	// nodes.
+	containerdConfigPath = "/etc/containerd/config.toml"
+
+	// joinTokenTTL is the lifetime of the bootstrap tokens created to join
+	// worker nodes.
+	joinTokenTTL = "15m"
+)
+
+// ignoredJoinPreflightErrors are the kubeadm preflight checks which fail in
+// kind node containers, as they share the kernel of the docker host: its
+// configuration isn't available, swap may be enabled and bridge netfilter
+// isn't visible from the container.
+var ignoredJoinPreflightErrors = []string{
+	"SystemVerification",
+	"Swap",
+	"FileContent--proc-sys-net-bridge-bridge-nf-call-iptables",
+}
+
+// containerdCAFilePattern matches the CA files referred to by a containerd
+// configuration, e.g. by the registry addon.
+var containerdCAFilePattern = regexp.MustCompile(`(?m)^\s*ca_file\s*=\s*"([^"]+)"`)
+
+// ScaleTo adds or removes worker node containers until the cluster has n
+// worker nodes and waits for them to be Ready. Added workers are started from
+// the node image of the control plane, configured like the nodes created with
+// the cluster (containerd configuration, resource limits, preloaded images) and
+// joined with kind's kubeadm configuration. The most recently added workers are
+// removed first.
+func (c *Cluster) ScaleTo(ctx context.Context, n int) error {
+	if n < 0 {
+		return fmt.Errorf("invalid number of nodes %d", n)
+	}
+
+	nodes, err := listKindNodes(ctx, c.name)
+	if err != nil {
+		return err
+	}
+	workers := workerNodes(c.name, nodes)
+
+	for next := nextWorkerIndex(c.name, workers); len(workers) < n; next++ {
+		name := workerNodeName(c.name, next)
+		if err := c.addWorkerNode(ctx, name); err != nil {
+			return fmt.Errorf("failed to add worker node %s: %w", name, err)
+		}
+		workers = append(workers, name)
+	}
+	for len(workers) > n {
+		name := workers[len(workers)-1]
+		if err := c.removeWorkerNode(ctx, name); err != nil {
+			return fmt.Errorf("failed to remove worker node %s: %w", name, err)
+		}
+		workers = workers[:len(workers)-1]
+	}
+
+	return clusters.WaitForWorkerNodes(ctx, c.client, n)
+}
+
+// addWorkerNode starts a worker node container like kind does, configures it
+// like the existing nodes and joins it to the cluster. If any of this fails
+// the container is removed again, see cleanupFailedWorkerNode.
+func (c *Cluster) addWorkerNode(ctx context.Context, name string) (err error) {
+	controlPlaneID := docker.GetKindContainerID(c.name)
+	controlPlane, err := docker.InspectDockerContainer(controlPlaneID)
+	if err != nil {
+		return err
+	}
+
+	dockerc, err := docker.NewNegotiatedClientWithOpts(ctx, client.FromEnv)
+	if err != nil {
+		return err
+	}
+	defer dockerc.Close()
+
+	// the labels (e.g. ownership), environment (e.g. proxy) and resource
+	// limits of the control plane are the ones the builder applied to all
+	// nodes.
+	labels := make(map[string]string, len(controlPlane.Config.Labels))
+	for k, v := range controlPlane.Config.Labels {
+		labels[k] = v
+	}
+	labels[kindRoleLabel] = "worker"
+
+	_, err = dockerc.ContainerCreate(ctx,
+		&container.Config{
+			Image:    controlPlane.Config.Image,
+			Hostname: name,
+			Labels:   labels,
+			Env:      controlPlane.Config.Env,
+			Volumes:  map[string]struct{}{"/var": {}},
+			Tty:      true,
+		},
+		&container.HostConfig{
+			Privileged:    true,
+			SecurityOpt:   []string{"seccomp=unconfined", "apparmor=unconfined"},
+			Tmpfs:         map[string]string{"/tmp": "", "/run": ""},
+			Binds:         []string{"/lib/modules:/lib/modules:ro"},
+			CgroupnsMode:  container.CgroupnsModePrivate,
+			RestartPolicy: container.RestartPolicy{Name: container.RestartPolicyOnFailure, MaximumRetryCount: 1},
+			Resources: container.Resources{
+				NanoCPUs:   controlPlane.HostConfig.NanoCPUs,
+				Memory:     controlPlane.HostConfig.Memory,
+				MemorySwap: controlPlane.HostConfig.MemorySwap,
+			},
+		},
+		&network.NetworkingConfig{
+			EndpointsConfig: map[string]*network.EndpointSettings{c.DockerNetwork(): {}},
+		},
+		nil, name,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create container: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if cleanupErr := cleanupFailedWorkerNode(ctx, c.client, name, docker.RemoveContainer); cleanupErr != nil {
+				err = errors.Join(err, cleanupErr)
+			}
+		}
+	}()
+
+	if err := dockerc.ContainerStart(ctx, name, container.StartOptions{}); err != nil {
+		return fmt.Errorf("failed to start container: %w", err)
+	}
+
+	// the node boots systemd, containerd has to be running before it's
+	// configured.
+	if err := waitForContainerd(ctx, name); err != nil {
+		return err
+	}
+	if err := copyContainerdConfig(ctx, controlPlaneID, name); err != nil {
+		return err
+	}
+	if len(c.imageArchives) > 0 {
+		if err := loadImageArchivesIntoCluster(ctx, c.name, c.imageArchives, name); err != nil {
+			return err
+		}
+	}
+	if len(c.preloadedImages) > 0 {
+		if err := loadDockerImagesIntoCluster(ctx, c.name, c.preloadedImages, name); err != nil {
+			return err
+		}
+	}
+
+	return c.joinWorkerNode(ctx, controlPlaneID, name)
+}
+
+// joinWorkerNode joins a worker node to the cluster with the kubeadm
+// configuration kind wrote to the control plane node, adapted to the worker.
+func (c *Cluster) joinWorkerNode(ctx context.Context, controlPlaneID, name string) error {
+	node, err := docker.InspectDockerContainer(name)
+	if err != nil {
+		return err
+	}
+	endpoint, ok := node.NetworkSettings.Networks[c.DockerNetwork()]
+	if !ok {
+		return fmt.Errorf("node %s is not attached to docker network %s", name, c.DockerNetwork())
+	}
+	nodeIP := endpoint.IPAddress
+	if c.ipFamily == clusters.IPv6 {
+		nodeIP = endpoint.GlobalIPv6Address
+	}
+
+	// kind's bootstrap token may have expired, a short-lived one is created
+	// for the join.
+	token, err := docker.RunPrivilegedCommandWithOutput(ctx, controlPlaneID, "kubeadm", "token", "create", "--ttl", joinTokenTTL)
+	if err != nil {
+		return fmt.Errorf("failed to create join token: %w", err)
+	}
+
+	kubeadmConfig, err := docker.ReadFileFromContainer(ctx, controlPlaneID, kindKubeadmConfigPath)
+	if err != nil {
+		return fmt.Errorf("failed to read kubeadm configuration: %w", err)
+	}
+	joinConfig, err := workerKubeadmConfig(kubeadmConfig.Bytes(), c.name, name, nodeIP, strings.TrimSpace(string(token)))
+	if err != nil {
+		return err
+	}
+	if err := docker.WriteFileToContainer(ctx, name, kindKubeadmConfigPath, 0o644, joinConfig); err != nil { //nolint:gomnd
+		return fmt.Errorf("failed to write kubeadm configuration: %w", err)
+	}
+
+	if _, err := docker.RunPrivilegedCommandWithOutput(ctx, name, "kubeadm", "join",
+		"--config", kindKubeadmConfigPath,
+		"--ignore-preflight-errors="+strings.Join(ignoredJoinPreflightErrors, ","),
+	); err != nil {
+		return fmt.Errorf("failed to join cluster: %w", err)
+	}
+	return nil
+}
+
+// cleanupFailedWorkerNode deletes the Node (if the join got that far) and
+// removes the container of a worker node which failed to be added. The
+// container keeps the cluster's labels, so it would otherwise be counted as a
+// worker node which never becomes Ready by the next ScaleTo. The cleanup is
+// done even if the context was cancelled.
+func cleanupFailedWorkerNode(ctx context.Context, c kubernetes.Interface, name string, removeContainer func(context.Context, string) error) error {
+	ctx = context.WithoutCancel(ctx)
+	var errs []error
+	if err := c.CoreV1().Nodes().Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		errs = append(errs, fmt.Errorf("failed to delete node %s: %w", name, err))
+	}
+	if err := removeContainer(ctx, name); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// waitForContainerd waits for containerd to be running on the node.
+func waitForContainerd(ctx context.Context, node string) error {
+	return wait.Until(ctx, fmt.Sprintf("containerd to run on node %s", node), func(ctx context.Context) (bool, string, error) {
+		_, err := docker.RunPrivilegedCommandWithOutput(ctx, node, "systemctl", "is-active", "containerd")
+		return err == nil, "", nil
+	})
+}
+
+// copyContainerdConfig copies the containerd configuration of a node (which
+// includes the builder's containerd patches and registry mirrors, and the
+// configuration added by addons such as the registry addon) along with the
+// CA files it refers to, to another node and restarts containerd there.
+func copyContainerdConfig(ctx context.Context, from, to string) error {
+	config, err := docker.ReadFileFromContainer(ctx, from, containerdConfigPath)
+	if err != nil {
+		return fmt.Errorf("failed to read containerd configuration: %w", err)
+	}
+	for _, path := range containerdCAFiles(config.Bytes()) {
+		ca, err := docker.ReadFileFromContainer(ctx, from, path)
+		if err != nil {
+			return fmt.Errorf("failed to read CA file %s: %w", path, err)
+		}
+		if err := docker.WriteFileToContainer(ctx, to, path, 0o644, ca.Bytes()); err != nil { //nolint:gomnd
+			return fmt.Errorf("failed to write CA file %s: %w", path, err)
+		}
+	}
+	if err := docker.WriteFileToContainer(ctx, to, containerdConfigPath, 0o644, config.Bytes()); err != nil { //nolint:gomnd
+		return fmt.Errorf("failed to write containerd configuration: %w", err)
+	}
+	if err := docker.RunPrivilegedCommand(ctx, to, "systemctl", "restart", "containerd"); err != nil {
+		return fmt.Errorf("failed to restart containerd: %w", err)
+	}
+	return waitForContainerd(ctx, to)
+}
+
+// containerdCAFiles provides the paths of the CA files referred to by the
+// containerd configuration.
+func containerdCAFiles(config []byte) []string {
+	var paths []string
+	for _, match := range containerdCAFilePattern.FindAllSubmatch(config, -1) {
+		paths = append(paths, string(match[1]))
+	}
+	return paths
+}
+
+// workerKubeadmConfig adapts the kubeadm configuration kind wrote to a node
+// (see kindKubeadmConfigPath) to join the given worker node: the
+// JoinConfiguration's control plane settings and node labels are dropped, and
+// its node IP, provider ID and bootstrap token are set. The other documents
+// are kept, as kind does.
+func workerKubeadmConfig(kubeadmConfig []byte, clusterName, node, nodeIP, token string) ([]byte, error) {
+	documents := strings.Split(string(kubeadmConfig), "\n---\n")
+	found := false
+	for i, document := range documents {
+		var obj map[string]interface{}
+		if err := yaml.Unmarshal([]byte(document), &obj); err != nil {
+			return nil, fmt.Errorf("failed to parse kubeadm configuration: %w", err)
+		}
+		if obj["kind"] != "JoinConfiguration" {
+			continue
+		}
+		found = true
+
+		delete(obj, "controlPlane")
+		registration, _ := obj["nodeRegistration"].(map[string]interface{})
+		if registration == nil {
+			registration = make(map[string]interface{})
+			obj["nodeRegistration"] = registration
+		}
+		registration["name"] = node
+		registration["kubeletExtraArgs"] = setKubeletExtraArgs(registration["kubeletExtraArgs"], map[string]string{
+			"node-ip":     nodeIP,
+			"provider-id": fmt.Sprintf("kind://docker/%s/%s", clusterName, node),
+			"node-labels": "",
+		})
+		discovery, _ := obj["discovery"].(map[string]interface{})
+		bootstrapToken, _ := discovery["bootstrapToken"].(map[string]interface{})
+		if bootstrapToken == nil {
+			return nil, fmt.Errorf("kubeadm JoinConfiguration has no bootstrap token discovery")
+		}
+		bootstrapToken["token"] = token
+
+		b, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		documents[i] = string(b)
+	}
+	if !found {
+		return nil, fmt.Errorf("kubeadm configuration has no JoinConfiguration")
+	}
+	return []byte(strings.Join(documents, "\n---\n")), nil
+}
+
+// setKubeletExtraArgs sets the given kubelet arguments in the kubeletExtraArgs
+// of a kubeadm configuration, which are a map up to v1beta3 and a list of
+// name and value pairs since v1beta4.
+func setKubeletExtraArgs(args interface{}, values map[string]string) interface{} {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	list, ok := args.([]interface{})
+	if !ok {
+		m, _ := args.(map[string]interface{})
+		if m == nil {
+			m = make(map[string]interface{}, len(values))
+		}
+		for _, name := range names {
+			m[name] = values[name]
+		}
+		return m
+	}
+
+	for _, name := range names {
+		set := false
+		for _, arg := range list {
+			if arg, ok := arg.(map[string]interface{}); ok && arg["name"] == name {
+				arg["value"] = values[name]
+				set = true
+			}
+		}
+		if !set {
+			list = append(list, map[string]interface{}{"name": name, "value": values[name]})
+		}
+	}
+	return list
+}
+
+// removeWorkerNode deletes a worker node from the cluster and removes its
+// container.
+func (c *Cluster) removeWorkerNode(ctx context.Context, name string) error {
+	err := retry.OnTransient(ctx, c.logger, "deleting node "+name, func(ctx context.Context) error {
+		return c.client.CoreV1().Nodes().Delete(ctx, name, metav1.DeleteOptions{})
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return docker.RemoveContainer(ctx, name)
+}
+
+// workerNodes provides the worker nodes among the nodes of a cluster, ordered
+// by their index.
+func workerNodes(clusterName string, nodes []string) []string {
+	var workers []string
+	for _, node := range nodes {
+		if _, ok := workerNodeIndex(clusterName, node); ok {
+			workers = append(workers, node)
+		}
+	}
+	sort.Slice(workers, func(i, j int) bool {
+		a, _ := workerNodeIndex(clusterName, workers[i])
+		b, _ := workerNodeIndex(clusterName, workers[j])
+		return a < b
+	})
+	return workers
+}
+
+// nextWorkerIndex provides the index of the next worker node to add.
+func nextWorkerIndex(clusterName string, workers []string) int {
+	next := 1
+	for _, worker := range workers {
+		if index, ok := workerNodeIndex(clusterName, worker); ok && index >= next {
+			next = index + 1
+		}
+	}
+	return next
+}
+
+// workerNodeName provides the name of the worker node of the given index,
+// e.g. "test-worker" for the first and "test-worker2" for the second.
+func workerNodeName(clusterName string, index int) string {
+	if index == 1 {
+		return clusterName + workerNodeInfix
+	}
+	return clusterName + workerNodeInfix + strconv.Itoa(index)
+}
+
+// workerNodeIndex provides the index of a worker node by name, and whether the
+// node is a worker node of the cluster.
+func workerNodeIndex(clusterName, node string) (int, bool) {
+	suffix, ok := strings.CutPrefix(node, clusterName+workerNodeInfix)
+	if !ok {
+		return 0, false
+	}
+	if suffix == "" {
+		return 1, true
+	}
+	index, err := strconv.Atoi(suffix)
+	if err != nil || index < 2 {
+		return 0, false
+	}
+	return index, true
+}
@@ -0,0 +1,339 @@
+package kind
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/docker"
+)
+
+// -----------------------------------------------------------------------------
+// Kind Cluster - Ownership
+// -----------------------------------------------------------------------------
+
+const (
+	// OwnerLabel is the label identifying who created a kind cluster or docker network.
+	OwnerLabel = "ktf.owner"
+
+	// TestIDLabel is the label identifying the test run which created a kind
+	// cluster or docker network.
+	TestIDLabel = "ktf.test-id"
+
+	// CreatedAtLabel is the label holding the time (RFC 3339) a kind cluster or
+	// docker network was created at.
+	CreatedAtLabel = "ktf.created-at"
+
+	// DefaultOwner is the value of the OwnerLabel unless configured otherwise.
+	DefaultOwner = "ktf"
+
+	// nodeImageLabel is the label holding the node image a kind cluster was
+	// created from, as its nodes are created from labelled node images.
+	nodeImageLabel = "ktf.node-image"
+
+	// labelledNodeImageRepository is the repository of the node images built
+	// for each cluster, which are the node images with the ownership labels
+	// added. Kind doesn't support adding labels to the node containers, but
+	// containers inherit the labels of their image.
+	labelledNodeImageRepository = "ktf/kind-node"
+
+	// kindLabelPrefix is the prefix of the labels kind adds to node containers.
+	kindLabelPrefix = "io.x-k8s.kind."
+)
+
+// Orphan is a kind cluster or docker network created by KTF which was left
+// behind, e.g. by a test run which crashed before cleaning up.
+type Orphan struct {
+	// ClusterName is the name of the orphaned kind cluster, if any.
+	ClusterName string
+
+	// NetworkName is the name of the orphaned docker network, if any.
+	NetworkName string
+
+	// Labels are the ownership labels of the orphan.
+	Labels map[string]string
+}
+
+// WithOwner configures the value of the OwnerLabel for the cluster and any
+// docker network created for it. Defaults to DefaultOwner. The cluster's node
+// containers are only labelled if WithOwner, WithTestID or WithLabels is used,
+// as kind can't label them and a labelled node image has to be built.
+func (b *Builder) WithOwner(owner string) *Builder {
+	b.owner = owner
+	return b
+}
+
+// WithTestID configures the value of the TestIDLabel for the cluster and any
+// docker network created for it. Defaults to the name of the cluster.
+func (b *Builder) WithTestID(testID string) *Builder {
+	b.testID = testID
+	return b
+}
+
+// WithLabels adds custom labels which are stored along with the ownership
+// labels of the cluster and any docker network created for it, and are
+// provided by the cluster's Metadata.
+func (b *Builder) WithLabels(labels map[string]string) *Builder {
+	if b.labels == nil {
+		b.labels = make(map[string]string, len(labels))
+	}
+	maps.Copy(b.labels, labels)
+	return b
+}
+
+// labelsRequested indicates whether an owner, test ID or custom labels were
+// configured. The node containers are only labelled then, as labelling them
+// requires building node images.
+func (b *Builder) labelsRequested() bool {
+	return b.owner != "" || b.testID != "" || len(b.labels) > 0
+}
+
+// ownershipLabels provides the ownership labels for resources created by the
+// Builder, along with any custom labels (which can't override them).
+func (b *Builder) ownershipLabels() map[string]string {
+	owner, testID := b.owner, b.testID
+	if owner == "" {
+		owner = DefaultOwner
+	}
+	if testID == "" {
+		testID = b.Name
+	}
+	labels := maps.Clone(b.labels)
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[OwnerLabel] = owner
+	labels[TestIDLabel] = testID
+	labels[CreatedAtLabel] = time.Now().UTC().Format(time.RFC3339)
+	return labels
+}
+
+// labelledNodeImage provides the tag of the i-th labelled node image of the
+// named cluster.
+func labelledNodeImage(name string, i int) string {
+	return fmt.Sprintf("%s:%s-%d", labelledNodeImageRepository, name, i)
+}
+
+// useLabelledNodeImages configures the nodes of the kind config to be created
+// from labelled node images, which are built from the node image configured
+// for each node (or the provided image if there's none or override is set).
+// It provides the labelled node images, keyed by the image they're built from.
+func useLabelledNodeImages(kindConfig *v1alpha4.Cluster, name, image string, override bool) map[string]string {
+	// kind provisions a single control plane node when none are configured,
+	// it has to be declared explicitly for its image to be configured.
+	if len(kindConfig.Nodes) == 0 {
+		kindConfig.Nodes = []v1alpha4.Node{{Role: v1alpha4.ControlPlaneRole}}
+	}
+
+	labelled := make(map[string]string)
+	for i := range kindConfig.Nodes {
+		base := kindConfig.Nodes[i].Image
+		if base == "" || override {
+			base = image
+		}
+		if _, ok := labelled[base]; !ok {
+			labelled[base] = labelledNodeImage(name, len(labelled))
+		}
+		kindConfig.Nodes[i].Image = labelled[base]
+	}
+	return labelled
+}
+
+// buildLabelledNodeImages configures the nodes of the cluster to be created
+// from node images with the ownership labels added (see useLabelledNodeImages)
+// and builds those images.
+func (b *Builder) buildLabelledNodeImages(ctx context.Context, image string, override bool, labels map[string]string) error {
+	var labelled map[string]string
+	if err := b.updateConfig(func(kindConfig *v1alpha4.Cluster) {
+		labelled = useLabelledNodeImages(kindConfig, b.Name, image, override)
+	}); err != nil {
+		return err
+	}
+
+	for base, tag := range labelled {
+		imageLabels := maps.Clone(labels)
+		imageLabels[nodeImageLabel] = base
+		if err := docker.LabelImage(ctx, base, tag, imageLabels); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeLabelledNodeImages removes the labelled node images of the named
+// cluster.
+func removeLabelledNodeImages(ctx context.Context, name string) error {
+	return docker.RemoveImages(ctx, fmt.Sprintf("%s:%s-*", labelledNodeImageRepository, name))
+}
+
+// cleanupLabelledNodeImages removes the labelled node images of the named
+// cluster which failed to be created, even if the context was cancelled, and
+// adds any failure to do so to err.
+func cleanupLabelledNodeImages(ctx context.Context, name string, err error) error {
+	if removeErr := removeLabelledNodeImages(context.WithoutCancel(ctx), name); removeErr != nil {
+		return errors.Join(err, removeErr)
+	}
+	return err
+}
+
+// containerOwnershipLabels provides the ownership labels of a node container
+// given its labels, without the labels added by kind. Containers not created
+// by KTF have no ownership labels.
+func containerOwnershipLabels(containerLabels map[string]string) map[string]string {
+	if _, ok := containerLabels[OwnerLabel]; !ok {
+		return nil
+	}
+	labels := make(map[string]string, len(containerLabels))
+	for key, value := range containerLabels {
+		if !strings.HasPrefix(key, kindLabelPrefix) && key != nodeImageLabel {
+			labels[key] = value
+		}
+	}
+	return labels
+}
+
+// clusterMetadata describes a kind cluster given its labels and node image.
+func clusterMetadata(labels map[string]string, nodeImage string) clusters.Metadata {
+	metadata := clusters.Metadata{
+		Provider:  KindClusterType,
+		NodeImage: nodeImage,
+		Labels:    labels,
+	}
+	if createdAt, err := time.Parse(time.RFC3339, labels[CreatedAtLabel]); err == nil {
+		metadata.CreationTime = createdAt
+	}
+	return metadata
+}
+
+// readClusterMetadata describes the named existing kind cluster, given the
+// labels and image of its control plane node.
+func readClusterMetadata(ctx context.Context, name string) (clusters.Metadata, error) {
+	dockerc, err := docker.NewNegotiatedClientWithOpts(ctx, client.FromEnv)
+	if err != nil {
+		return clusters.Metadata{}, err
+	}
+	defer dockerc.Close()
+
+	node, err := dockerc.ContainerInspect(ctx, docker.GetKindContainerID(name))
+	if err != nil {
+		return clusters.Metadata{}, err
+	}
+	nodeImage := node.Config.Image
+	if image, ok := node.Config.Labels[nodeImageLabel]; ok {
+		nodeImage = image
+	}
+	return clusterMetadata(containerOwnershipLabels(node.Config.Labels), nodeImage), nil
+}
+
+// createdBefore indicates whether the CreatedAtLabel is before the cutoff.
+func createdBefore(labels map[string]string, cutoff time.Time) bool {
+	createdAt, err := time.Parse(time.RFC3339, labels[CreatedAtLabel])
+	return err == nil && createdAt.Before(cutoff)
+}
+
+// ListOrphans provides the kind clusters and docker networks created by KTF
+// more than olderThan ago. Clusters which are in use should be given
+// a long enough olderThan to not be reported. Only the clusters built with
+// WithOwner, WithTestID or WithLabels are labelled, and can be listed.
+func ListOrphans(ctx context.Context, olderThan time.Duration) ([]Orphan, error) {
+	cutoff := time.Now().Add(-olderThan)
+	var orphans []Orphan
+
+	dockerc, err := docker.NewNegotiatedClientWithOpts(ctx, client.FromEnv)
+	if err != nil {
+		return nil, err
+	}
+	defer dockerc.Close()
+
+	// clusters are identified by their control plane node
+	nodes, err := dockerc.ContainerList(ctx, container.ListOptions{
+		All: true,
+		Filters: filters.NewArgs(
+			filters.Arg("label", OwnerLabel),
+			filters.Arg("label", kindRoleLabel+"="+string(v1alpha4.ControlPlaneRole)),
+		),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes {
+		labels := containerOwnershipLabels(node.Labels)
+		if createdBefore(labels, cutoff) {
+			orphans = append(orphans, Orphan{ClusterName: node.Labels[kindClusterLabel], Labels: labels})
+		}
+	}
+
+	networks, err := dockerc.NetworkList(ctx, types.NetworkListOptions{
+		Filters: filters.NewArgs(filters.Arg("label", OwnerLabel)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, network := range networks {
+		if createdBefore(network.Labels, cutoff) {
+			orphans = append(orphans, Orphan{NetworkName: network.Name, Labels: network.Labels})
+		}
+	}
+
+	return orphans, nil
+}
+
+// CleanupOrphans deletes the kind clusters and docker networks reported by
+// ListOrphans and provides what was deleted. Networks that are still in use
+// by other containers are not deleted.
+func CleanupOrphans(ctx context.Context, olderThan time.Duration) ([]Orphan, error) {
+	orphans, err := ListOrphans(ctx, olderThan)
+	if err != nil {
+		return nil, err
+	}
+
+	dockerc, err := docker.NewNegotiatedClientWithOpts(ctx, client.FromEnv)
+	if err != nil {
+		return nil, err
+	}
+	defer dockerc.Close()
+
+	// clusters are deleted before networks as they may be using them
+	var deleted []Orphan
+	var errs []error
+	for _, orphan := range orphans {
+		if orphan.ClusterName == "" {
+			continue
+		}
+		if err := deleteKindCluster(ctx, orphan.ClusterName); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete cluster %s: %w", orphan.ClusterName, err))
+			continue
+		}
+		deleted = append(deleted, orphan)
+	}
+	for _, orphan := range orphans {
+		if orphan.NetworkName == "" {
+			continue
+		}
+		network, err := dockerc.NetworkInspect(ctx, orphan.NetworkName, types.NetworkInspectOptions{})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to inspect network %s: %w", orphan.NetworkName, err))
+			continue
+		}
+		if len(network.Containers) > 0 {
+			continue
+		}
+		if err := dockerc.NetworkRemove(ctx, orphan.NetworkName); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete network %s: %w", orphan.NetworkName, err))
+			continue
+		}
+		deleted = append(deleted, orphan)
+	}
+
+	return deleted, errors.Join(errs...)
+}
@@ -0,0 +1,170 @@
+package kind
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/docker/docker/api/types/system"
+	"github.com/docker/docker/client"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/docker"
+)
+
+// -----------------------------------------------------------------------------
+// Kind Cluster - Preflight Checks
+// -----------------------------------------------------------------------------
+
+const (
+	// MinimumDockerMemory is the minimum amount of memory (in bytes) which the
+	// docker daemon should have available to run a kind cluster.
+	MinimumDockerMemory int64 = 2 * 1024 * 1024 * 1024
+
+	// RequiredDockerMemory is the amount of memory (in bytes) below which the
+	// control plane of a kind cluster can't boot, failing the preflight checks.
+	RequiredDockerMemory int64 = 1024 * 1024 * 1024
+
+	// MinimumInotifyMaxUserWatches is the minimum fs.inotify.max_user_watches
+	// recommended by kind.
+	// See: https://kind.sigs.k8s.io/docs/user/known-issues/#pod-errors-due-to-too-many-open-files
+	MinimumInotifyMaxUserWatches = 524288
+
+	// MinimumInotifyMaxUserInstances is the minimum fs.inotify.max_user_instances
+	// recommended by kind.
+	// See: https://kind.sigs.k8s.io/docs/user/known-issues/#pod-errors-due-to-too-many-open-files
+	MinimumInotifyMaxUserInstances = 512
+
+	// RequiredInotifyMaxUserWatches and RequiredInotifyMaxUserInstances are the
+	// kernel's default inotify limits, below which the kubelet and kube-proxy
+	// of a kind node fail with "too many open files", failing the preflight
+	// checks.
+	RequiredInotifyMaxUserWatches   = 8192
+	RequiredInotifyMaxUserInstances = 128
+)
+
+// minimumCgroupV2Version is the earliest Kubernetes version supporting cgroup v2
+// hosts in kind.
+var minimumCgroupV2Version = semver.MustParse("1.19.0")
+
+// Preflight validates that the local environment is able to run a kind cluster
+// with the Builder's configuration, so that problems are reported quickly and
+// with actionable errors rather than kind failing part way through creation.
+//
+// Problems which prevent kind from creating the cluster fail the checks: kind
+// not being installed, docker not running or having less than
+// RequiredDockerMemory, a cgroup v2 host for Kubernetes versions which don't
+// support it, and inotify limits below the kernel's defaults. All of them are
+// reported together in the error. Settings which are only below kind's
+// recommendations (MinimumDockerMemory and the Minimum inotify limits) are
+// reported as warnings, as clusters often work fine despite them.
+func (b *Builder) Preflight(ctx context.Context) (warnings []string, err error) {
+	var errs []error
+
+	if _, err := exec.LookPath("kind"); err != nil {
+		errs = append(errs, fmt.Errorf("kind binary not found in PATH, see https://kind.sigs.k8s.io/docs/user/quick-start/#installation: %w", err))
+	}
+
+	dockerWarnings, err := b.checkDocker(ctx)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	warnings = append(warnings, dockerWarnings...)
+
+	// the inotify limits only apply if the docker daemon is running locally
+	// on a linux host.
+	if runtime.GOOS == "linux" {
+		inotifyWarnings, err := checkInotifyLimits("/proc/sys/fs/inotify")
+		if err != nil {
+			errs = append(errs, err)
+		}
+		warnings = append(warnings, inotifyWarnings...)
+	}
+
+	if len(errs) > 0 {
+		return warnings, fmt.Errorf("preflight checks failed: %w", errors.Join(errs...))
+	}
+	return warnings, nil
+}
+
+// checkDocker verifies that docker is running and has the resources kind
+// needs, see checkDockerInfo.
+func (b *Builder) checkDocker(ctx context.Context) ([]string, error) {
+	dockerc, err := docker.NewNegotiatedClientWithOpts(ctx, client.FromEnv)
+	if err != nil {
+		return nil, fmt.Errorf("could not create docker client: %w", err)
+	}
+	defer dockerc.Close()
+
+	info, err := dockerc.Info(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("docker does not appear to be running, ensure the docker daemon is started: %w", err)
+	}
+	return checkDockerInfo(info, b.clusterVersion)
+}
+
+// checkDockerInfo verifies that the docker daemon described by info can run a
+// kind cluster of the given Kubernetes version (the default if nil). It fails
+// if docker has less memory than required or the version doesn't support the
+// daemon's cgroup v2 host, and warns if it has less memory than recommended.
+func checkDockerInfo(info system.Info, clusterVersion *semver.Version) ([]string, error) {
+	var errs []error
+	var warnings []string
+
+	if info.MemTotal < RequiredDockerMemory {
+		errs = append(errs, fmt.Errorf("docker has %d MiB of memory available but kind needs at least %d MiB, increase the memory available to docker",
+			info.MemTotal/1024/1024, RequiredDockerMemory/1024/1024)) //nolint:gomnd
+	} else if info.MemTotal < MinimumDockerMemory {
+		warnings = append(warnings, fmt.Sprintf("docker has %d MiB of memory available but at least %d MiB are recommended, consider increasing the memory available to docker",
+			info.MemTotal/1024/1024, MinimumDockerMemory/1024/1024)) //nolint:gomnd
+	}
+
+	if info.CgroupVersion == "2" && clusterVersion != nil && clusterVersion.LT(minimumCgroupV2Version) {
+		errs = append(errs, fmt.Errorf("kubernetes %s does not support cgroup v2 hosts, use kubernetes %s or later",
+			clusterVersion, minimumCgroupV2Version))
+	}
+
+	return warnings, errors.Join(errs...)
+}
+
+// checkInotifyLimits verifies the inotify limits found in the provided
+// directory (normally /proc/sys/fs/inotify). It fails for limits below the
+// kernel's defaults and warns about limits lower than recommended by kind.
+func checkInotifyLimits(dir string) ([]string, error) {
+	var errs []error
+	var warnings []string
+	for _, limit := range []struct {
+		name     string
+		required int
+		minimum  int
+	}{
+		{name: "max_user_watches", required: RequiredInotifyMaxUserWatches, minimum: MinimumInotifyMaxUserWatches},
+		{name: "max_user_instances", required: RequiredInotifyMaxUserInstances, minimum: MinimumInotifyMaxUserInstances},
+	} {
+		raw, err := os.ReadFile(filepath.Join(dir, limit.name))
+		if err != nil {
+			// the limits can't be verified, e.g. due to sandboxing.
+			continue
+		}
+		value, err := strconv.Atoi(strings.TrimSpace(string(raw)))
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("could not parse fs.inotify.%s: %v", limit.name, err))
+			continue
+		}
+		switch {
+		case value < limit.required:
+			errs = append(errs, fmt.Errorf("fs.inotify.%s is %d but kind needs at least %d, run: sysctl fs.inotify.%s=%d",
+				limit.name, value, limit.required, limit.name, limit.minimum))
+		case value < limit.minimum:
+			warnings = append(warnings, fmt.Sprintf("fs.inotify.%s is %d but at least %d is recommended, run: sysctl fs.inotify.%s=%d",
+				limit.name, value, limit.minimum, limit.name, limit.minimum))
+		}
+	}
+	return warnings, errors.Join(errs...)
+}
@@ -0,0 +1,84 @@
+package kind
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/docker/docker/api/types/system"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckDockerInfo(t *testing.T) {
+	oldVersion := semver.MustParse("1.18.20")
+	newVersion := semver.MustParse("1.27.3")
+
+	t.Run("sufficient memory", func(t *testing.T) {
+		warnings, err := checkDockerInfo(system.Info{MemTotal: MinimumDockerMemory}, nil)
+		require.NoError(t, err)
+		require.Empty(t, warnings)
+	})
+
+	t.Run("memory below recommendation", func(t *testing.T) {
+		warnings, err := checkDockerInfo(system.Info{MemTotal: 1536 * 1024 * 1024}, nil)
+		require.NoError(t, err)
+		require.Len(t, warnings, 1)
+		require.Contains(t, warnings[0], "docker has 1536 MiB of memory available")
+	})
+
+	t.Run("memory below requirement", func(t *testing.T) {
+		warnings, err := checkDockerInfo(system.Info{MemTotal: 512 * 1024 * 1024}, nil)
+		require.ErrorContains(t, err, "docker has 512 MiB of memory available but kind needs at least 1024 MiB")
+		require.Empty(t, warnings)
+	})
+
+	t.Run("cgroup v2 with a supported version", func(t *testing.T) {
+		_, err := checkDockerInfo(system.Info{MemTotal: MinimumDockerMemory, CgroupVersion: "2"}, &newVersion)
+		require.NoError(t, err)
+		_, err = checkDockerInfo(system.Info{MemTotal: MinimumDockerMemory, CgroupVersion: "2"}, nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("cgroup v2 with an unsupported version", func(t *testing.T) {
+		_, err := checkDockerInfo(system.Info{MemTotal: MinimumDockerMemory, CgroupVersion: "2"}, &oldVersion)
+		require.ErrorContains(t, err, "kubernetes 1.18.20 does not support cgroup v2 hosts")
+		_, err = checkDockerInfo(system.Info{MemTotal: MinimumDockerMemory, CgroupVersion: "1"}, &oldVersion)
+		require.NoError(t, err)
+	})
+}
+
+func TestCheckInotifyLimits(t *testing.T) {
+	writeLimits := func(t *testing.T, watches, instances string) string {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "max_user_watches"), []byte(watches), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "max_user_instances"), []byte(instances), 0o600))
+		return dir
+	}
+
+	t.Run("sufficient limits", func(t *testing.T) {
+		warnings, err := checkInotifyLimits(writeLimits(t, "524288\n", "512\n"))
+		require.NoError(t, err)
+		require.Empty(t, warnings)
+	})
+
+	t.Run("limits below recommendation", func(t *testing.T) {
+		warnings, err := checkInotifyLimits(writeLimits(t, "8192\n", "128\n"))
+		require.NoError(t, err)
+		require.Len(t, warnings, 2)
+		require.Contains(t, warnings[0], "fs.inotify.max_user_watches is 8192")
+		require.Contains(t, warnings[1], "fs.inotify.max_user_instances is 128")
+	})
+
+	t.Run("limits below requirement", func(t *testing.T) {
+		warnings, err := checkInotifyLimits(writeLimits(t, "4096\n", "512\n"))
+		require.ErrorContains(t, err, "fs.inotify.max_user_watches is 4096 but kind needs at least 8192")
+		require.Empty(t, warnings)
+	})
+
+	t.Run("limits unavailable", func(t *testing.T) {
+		warnings, err := checkInotifyLimits(t.TempDir())
+		require.NoError(t, err)
+		require.Empty(t, warnings)
+	})
+}
@@ -0,0 +1,114 @@
+package kind
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kong/kubernetes-testing-framework/internal/retry"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/docker"
+)
+
+// -----------------------------------------------------------------------------
+// Kind Cluster - Etcd Snapshots
+// -----------------------------------------------------------------------------
+
+const (
+	// etcdSnapshotDirectory is the directory on the control plane node where
+	// etcd snapshots are stored. It resides within the etcd data directory so
+	// that it's mounted into the etcd static pod.
+	etcdSnapshotDirectory = "/var/lib/etcd/ktf-snapshots"
+
+	// etcdRestoreDirectory is the temporary data directory that snapshots are
+	// restored into before they replace the live etcd data.
+	etcdRestoreDirectory = "/var/lib/etcd/ktf-restore"
+
+	// etcdctlFlags are the connection flags needed for etcdctl to communicate
+	// with the etcd server provisioned by kubeadm.
+	etcdctlFlags = "--endpoints=https://127.0.0.1:2379 " +
+		"--cacert=/etc/kubernetes/pki/etcd/ca.crt " +
+		"--cert=/etc/kubernetes/pki/etcd/healthcheck-client.crt " +
+		"--key=/etc/kubernetes/pki/etcd/healthcheck-client.key"
+)
+
+// EtcdSnapshot represents a snapshot of the etcd database of a kind cluster
+// stored on the cluster's control plane node.
+type EtcdSnapshot struct {
+	// Name is the unique name of the snapshot.
+	Name string
+
+	// Path is the location of the snapshot file on the control plane node.
+	Path string
+}
+
+// Snapshot takes a snapshot of the cluster's etcd database which can later be
+// provided to RestoreSnapshot to roll the cluster back to its current state.
+func (c *Cluster) Snapshot(ctx context.Context) (EtcdSnapshot, error) {
+	name := uuid.NewString()
+	snap := EtcdSnapshot{
+		Name: name,
+		Path: path.Join(etcdSnapshotDirectory, name+".db"),
+	}
+
+	script := fmt.Sprintf(`set -e
+mkdir -p %[1]s
+crictl exec "$(crictl ps --name etcd -q)" etcdctl %[2]s snapshot save %[3]s
+`, etcdSnapshotDirectory, etcdctlFlags, snap.Path)
+
+	if _, err := c.runOnControlPlane(ctx, script); err != nil {
+		return EtcdSnapshot{}, fmt.Errorf("failed to snapshot etcd for cluster %s: %w", c.name, err)
+	}
+
+	return snap, nil
+}
+
+// RestoreSnapshot restores the cluster's etcd database to the state captured
+// in the provided snapshot. The API server and etcd are stopped while the data
+// is replaced, and this waits for the API server to become available again.
+func (c *Cluster) RestoreSnapshot(ctx context.Context, snap EtcdSnapshot) error {
+	script := fmt.Sprintf(`set -e
+manifest=/etc/kubernetes/manifests/etcd.yaml
+name=$(sed -n 's/.*--name=//p' "$manifest")
+peer=$(sed -n 's/.*--initial-advertise-peer-urls=//p' "$manifest")
+rm -rf %[1]s
+crictl exec "$(crictl ps --name etcd -q)" etcdctl snapshot restore %[2]s \
+  --data-dir %[1]s --name "$name" \
+  --initial-cluster "$name=$peer" --initial-advertise-peer-urls "$peer"
+mv /etc/kubernetes/manifests/kube-apiserver.yaml /etc/kubernetes/kube-apiserver.yaml
+mv "$manifest" /etc/kubernetes/etcd.yaml
+while [ -n "$(crictl ps --name 'etcd|kube-apiserver' -q)" ]; do sleep 1; done
+rm -rf /var/lib/etcd/member
+mv %[1]s/member /var/lib/etcd/member
+rm -rf %[1]s
+mv /etc/kubernetes/etcd.yaml "$manifest"
+mv /etc/kubernetes/kube-apiserver.yaml /etc/kubernetes/manifests/kube-apiserver.yaml
+`, etcdRestoreDirectory, snap.Path)
+
+	if _, err := c.runOnControlPlane(ctx, script); err != nil {
+		return fmt.Errorf("failed to restore etcd snapshot %s for cluster %s: %w", snap.Name, c.name, err)
+	}
+
+	return waitForAPIServer(ctx, c.logger, c.client, "waiting for the API server after restoring snapshot "+snap.Name)
+}
+
+// waitForAPIServer waits for the API server to serve requests again after it
+// was restarted, or for the context to be done.
+func waitForAPIServer(ctx context.Context, logger logr.Logger, client kubernetes.Interface, description string) error {
+	// the API server restarts, so any error means it's not available yet.
+	return retry.Poll(ctx, logger, time.Second, description, func(ctx context.Context) (bool, error) {
+		_, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{Limit: 1})
+		return err == nil, nil
+	})
+}
+
+// runOnControlPlane runs the provided shell script on the control plane node
+// of the cluster.
+func (c *Cluster) runOnControlPlane(ctx context.Context, script string) ([]byte, error) {
+	return docker.RunPrivilegedCommandWithOutput(ctx, docker.GetKindContainerID(c.name), "sh", "-c", script)
+}
@@ -0,0 +1,36 @@
+package kind
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestWaitForAPIServer(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	failures := 1
+	client.PrependReactor("list", "namespaces", func(k8stesting.Action) (bool, runtime.Object, error) {
+		if failures > 0 {
+			failures--
+			return true, nil, errors.New("connection refused")
+		}
+		return false, nil, nil
+	})
+	require.NoError(t, waitForAPIServer(context.Background(), logr.Discard(), client, "waiting for the API server"))
+	assert.Zero(t, failures)
+
+	client.PrependReactor("list", "namespaces", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := waitForAPIServer(ctx, logr.Discard(), client, "waiting for the API server")
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -5,10 +5,17 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net"
 	"os"
 	"os/exec"
+	"sort"
+	"strings"
 	"sync"
 
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -16,6 +23,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/docker"
 )
 
 // -----------------------------------------------------------------------------
@@ -23,17 +31,47 @@ import (
 // -----------------------------------------------------------------------------
 
 // NewFromExisting provides a Cluster object for a given kind cluster by name.
+// Nothing is created: the kubeconfig for the cluster is retrieved from kind,
+// which makes it possible to re-use a single cluster across multiple test runs.
 func NewFromExisting(name string) (clusters.Cluster, error) {
+	existingClusters, err := listKindClusters()
+	if err != nil {
+		return nil, err
+	}
+	found := false
+	for _, existingCluster := range existingClusters {
+		if existingCluster == name {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("kind cluster %s does not exist", name)
+	}
+
 	cfg, kc, err := clientForCluster(name)
 	if err != nil {
 		return nil, err
 	}
+
+	ipFamily, err := detectIPFamily(context.Background(), kc)
+	if err != nil {
+		return nil, fmt.Errorf("could not determine IP family for kind cluster %s: %w", name, err)
+	}
+
+	metadata, err := readClusterMetadata(context.Background(), name)
+	if err != nil {
+		return nil, fmt.Errorf("could not read metadata of kind cluster %s: %w", name, err)
+	}
+
 	return &Cluster{
-		name:   name,
-		client: kc,
-		cfg:    cfg,
-		l:      &sync.RWMutex{},
-		addons: make(clusters.Addons),
+		name:     name,
+		client:   kc,
+		cfg:      cfg,
+		l:        &sync.RWMutex{},
+		ipFamily: ipFamily,
+		metadata: metadata,
+		arch:     detectArchitecture(context.Background()),
 	}, nil
 }
 
@@ -59,11 +97,106 @@ func deleteKindCluster(ctx context.Context, name string) error {
 		return fmt.Errorf("%s: %w", stderr.String(), err)
 	}
 
+	return removeLabelledNodeImages(ctx, name)
+}
+
+// listKindClusters provides the names of all the kind clusters present in the
+// local docker environment.
+func listKindClusters() ([]string, error) {
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	cmd := exec.Command("kind", "get", "clusters")
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("command %q failed STDERR=(%s): %w", cmd.String(), stderr.String(), err)
+	}
+
+	return strings.Fields(stdout.String()), nil
+}
+
+// listKindNodes provides the names of the node containers of a kind cluster.
+func listKindNodes(ctx context.Context, name string) ([]string, error) {
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	cmd := exec.CommandContext(ctx, "kind", "get", "nodes", "--name", name)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("command %q failed STDERR=(%s): %w", cmd.String(), stderr.String(), err)
+	}
+
+	return strings.Fields(stdout.String()), nil
+}
+
+// limitNodeResources applies docker resource limits to all the node containers
+// of a kind cluster.
+func limitNodeResources(ctx context.Context, name string, resources NodeResources) error {
+	nodes, err := listKindNodes(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	dockerc, err := docker.NewNegotiatedClientWithOpts(ctx, client.FromEnv)
+	if err != nil {
+		return err
+	}
+	defer dockerc.Close()
+
+	update := container.UpdateConfig{Resources: container.Resources{
+		NanoCPUs: int64(resources.CPUs * 1e9), //nolint:gomnd
+		Memory:   resources.Memory,
+		// swap is disabled so that the memory limit is enforced
+		MemorySwap: resources.Memory,
+	}}
+	for _, node := range nodes {
+		if _, err := dockerc.ContainerUpdate(ctx, node, update); err != nil {
+			return fmt.Errorf("failed to limit resources for node %s: %w", node, err)
+		}
+	}
+
 	return nil
 }
 
-// clientForCluster provides a *kubernetes.Clientset for a KIND cluster provided the cluster name.
-func clientForCluster(name string) (*rest.Config, *kubernetes.Clientset, error) {
+// detectIPFamily determines the IP networking capabilities of an existing
+// cluster by inspecting the internal addresses of its nodes.
+func detectIPFamily(ctx context.Context, kc kubernetes.Interface) (clusters.IPFamily, error) {
+	nodes, err := kc.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	var hasIPv4, hasIPv6 bool
+	for _, node := range nodes.Items {
+		for _, addr := range node.Status.Addresses {
+			if addr.Type != corev1.NodeInternalIP {
+				continue
+			}
+			ip := net.ParseIP(addr.Address)
+			if ip == nil {
+				continue
+			}
+			if ip.To4() != nil {
+				hasIPv4 = true
+			} else {
+				hasIPv6 = true
+			}
+		}
+	}
+
+	switch {
+	case hasIPv4 && hasIPv6:
+		return clusters.Dual, nil
+	case hasIPv6:
+		return clusters.IPv6, nil
+	case hasIPv4:
+		return clusters.IPv4, nil
+	default:
+		return "", fmt.Errorf("no internal node addresses found")
+	}
+}
+
+// clientForCluster provides a *kubernetes.Clientset for a KIND cluster provided the cluster name,
+// configured with the default client options and then the provided ones.
+func clientForCluster(name string, opts ...clusters.ClientOptions) (*rest.Config, *kubernetes.Clientset, error) {
 	kubeconfig := new(bytes.Buffer)
 	stderr := new(bytes.Buffer)
 	cmd := exec.Command("kind", "get", "kubeconfig", "--name", name)
@@ -82,6 +215,7 @@ func clientForCluster(name string) (*rest.Config, *kubernetes.Clientset, error)
 	if err != nil {
 		return nil, nil, err
 	}
+	clusters.ConfigureClient(cfg, opts...)
 
 	clientset, err := kubernetes.NewForConfig(cfg)
 	return cfg, clientset, err
@@ -92,27 +226,47 @@ kind: Cluster
 apiVersion: kind.x-k8s.io/v1alpha4
 `
 
+// ensureConfigFile makes the Builder use a kind config file of its own, which
+// further config changes are made to.
 func (b *Builder) ensureConfigFile() error {
-	if b.configPath == nil {
-		f, err := os.CreateTemp(os.TempDir(), "ktf-kind-config")
-		if err != nil {
-			return fmt.Errorf("failed creating temp file for kind config: %w", err)
-		}
-		defer f.Close()
+	if b.configPath != nil && b.configPath == b.generatedConfigPath {
+		return nil
+	}
 
-		_, err = f.WriteString(defaultKindConfig)
-		if err != nil {
-			return err
+	f, err := os.CreateTemp(os.TempDir(), "ktf-kind-config")
+	if err != nil {
+		return fmt.Errorf("failed creating temp file for kind config: %w", err)
+	}
+	defer f.Close()
+
+	// if a config was provided (by file or by reader) it's used as the base
+	// for the file so that it isn't discarded by any further config changes,
+	// and a provided file isn't modified.
+	switch {
+	case b.configPath != nil:
+		var configYAML []byte
+		if configYAML, err = os.ReadFile(*b.configPath); err == nil {
+			_, err = f.Write(configYAML)
 		}
-
-		filename := f.Name()
-		b.configPath = &filename
+	case b.configReader != nil:
+		_, err = io.Copy(f, b.configReader)
+		b.configReader = nil
+	default:
+		_, err = f.WriteString(defaultKindConfig)
+	}
+	if err != nil {
+		return err
 	}
 
+	filename := f.Name()
+	b.configPath = &filename
+	b.generatedConfigPath = b.configPath
 	return nil
 }
 
-func (b *Builder) disableDefaultCNI() error {
+// updateConfig applies the provided mutation to the kind config file used by
+// the Builder, creating the file first if needed.
+func (b *Builder) updateConfig(mutate func(*v1alpha4.Cluster)) error {
 	if err := b.ensureConfigFile(); err != nil {
 		return err
 	}
@@ -127,7 +281,7 @@ func (b *Builder) disableDefaultCNI() error {
 		return fmt.Errorf("failed unmarshalling kind config: %w", err)
 	}
 
-	kindConfig.Networking.DisableDefaultCNI = true
+	mutate(&kindConfig)
 
 	configYAML, err = yaml.Marshal(kindConfig)
 	if err != nil {
@@ -141,36 +295,70 @@ func (b *Builder) disableDefaultCNI() error {
 	return nil
 }
 
+func (b *Builder) disableDefaultCNI() error {
+	return b.updateConfig(func(kindConfig *v1alpha4.Cluster) {
+		kindConfig.Networking.DisableDefaultCNI = true
+	})
+}
+
+func (b *Builder) disableKubeProxy() error {
+	return b.updateConfig(func(kindConfig *v1alpha4.Cluster) {
+		kindConfig.Networking.KubeProxyMode = "none"
+	})
+}
+
 func (b *Builder) useIPv6Only() error {
-	if err := b.ensureConfigFile(); err != nil {
-		return err
-	}
+	return b.updateConfig(func(kindConfig *v1alpha4.Cluster) {
+		kindConfig.Networking.IPFamily = v1alpha4.IPv6Family
+		// For Windows/OS X Docker compatibility:
+		// https://kind.sigs.k8s.io/docs/user/configuration/#ip-family
+		kindConfig.Networking.APIServerAddress = "127.0.0.1"
+	})
+}
 
-	configYAML, err := os.ReadFile(*b.configPath)
-	if err != nil {
-		return fmt.Errorf("failed reading kind config from %s: %w", *b.configPath, err)
-	}
+func (b *Builder) addKubeadmConfigPatches() error {
+	return b.updateConfig(func(kindConfig *v1alpha4.Cluster) {
+		kindConfig.KubeadmConfigPatches = append(kindConfig.KubeadmConfigPatches, b.kubeadmConfigPatches...)
 
-	kindConfig := v1alpha4.Cluster{}
-	if err := yaml.Unmarshal(configYAML, &kindConfig); err != nil {
-		return fmt.Errorf("failed unmarshalling kind config: %w", err)
-	}
+		if len(b.nodeKubeadmConfigPatches) == 0 {
+			return
+		}
+		// kind provisions a single control plane node when none are configured,
+		// it has to be declared explicitly for node patches to be applied.
+		if len(kindConfig.Nodes) == 0 {
+			kindConfig.Nodes = []v1alpha4.Node{{Role: v1alpha4.ControlPlaneRole}}
+		}
+		for i := range kindConfig.Nodes {
+			patches := b.nodeKubeadmConfigPatches[string(kindConfig.Nodes[i].Role)]
+			kindConfig.Nodes[i].KubeadmConfigPatches = append(kindConfig.Nodes[i].KubeadmConfigPatches, patches...)
+		}
+	})
+}
 
-	kindConfig.Networking.IPFamily = v1alpha4.IPv6Family
-	// For Windows/OS X Docker compatibility:
-	// https://kind.sigs.k8s.io/docs/user/configuration/#ip-family
-	kindConfig.Networking.APIServerAddress = "127.0.0.1"
+func (b *Builder) addContainerdConfigPatches(patches []string) error {
+	return b.updateConfig(func(kindConfig *v1alpha4.Cluster) {
+		kindConfig.ContainerdConfigPatches = append(kindConfig.ContainerdConfigPatches, patches...)
+	})
+}
 
-	configYAML, err = yaml.Marshal(kindConfig)
-	if err != nil {
-		return fmt.Errorf("failed marshalling kind config: %w", err)
+// registryMirrorsPatch provides a containerd config patch which configures
+// the provided registry mirrors (keyed by the registry they mirror).
+func registryMirrorsPatch(mirrors map[string]string) string {
+	registries := make([]string, 0, len(mirrors))
+	for registry := range mirrors {
+		registries = append(registries, registry)
 	}
+	sort.Strings(registries)
 
-	err = os.WriteFile(*b.configPath, configYAML, 0o600) //nolint:gomnd
-	if err != nil {
-		return fmt.Errorf("failed writing kind config %s: %w", *b.configPath, err)
+	patch := new(strings.Builder)
+	for _, registry := range registries {
+		endpoint := mirrors[registry]
+		if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+			endpoint = "https://" + endpoint
+		}
+		fmt.Fprintf(patch, "[plugins.\"io.containerd.grpc.v1.cri\".registry.mirrors.%q]\n  endpoint = [%q]\n", registry, endpoint)
 	}
-	return nil
+	return patch.String()
 }
 
 // exportLogs dumps a kind cluster logs to the specified directory
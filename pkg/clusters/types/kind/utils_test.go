@@ -0,0 +1,309 @@
+package kind
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+)
+
+func TestDetectIPFamily(t *testing.T) {
+	node := func(name string, addresses ...string) *corev1.Node {
+		n := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		for _, addr := range addresses {
+			n.Status.Addresses = append(n.Status.Addresses, corev1.NodeAddress{
+				Type:    corev1.NodeInternalIP,
+				Address: addr,
+			})
+		}
+		n.Status.Addresses = append(n.Status.Addresses, corev1.NodeAddress{
+			Type:    corev1.NodeHostName,
+			Address: name,
+		})
+		return n
+	}
+
+	testCases := []struct {
+		name     string
+		nodes    []*corev1.Node
+		expected clusters.IPFamily
+		wantErr  bool
+	}{
+		{
+			name:     "ipv4",
+			nodes:    []*corev1.Node{node("control-plane", "172.18.0.2")},
+			expected: clusters.IPv4,
+		},
+		{
+			name:     "ipv6",
+			nodes:    []*corev1.Node{node("control-plane", "fc00:f853:ccd:e793::2")},
+			expected: clusters.IPv6,
+		},
+		{
+			name:     "dual",
+			nodes:    []*corev1.Node{node("control-plane", "172.18.0.2", "fc00:f853:ccd:e793::2")},
+			expected: clusters.Dual,
+		},
+		{
+			name:    "no addresses",
+			nodes:   []*corev1.Node{node("control-plane")},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			kc := fake.NewSimpleClientset()
+			for _, n := range tc.nodes {
+				_, err := kc.CoreV1().Nodes().Create(context.Background(), n, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+
+			ipFamily, err := detectIPFamily(context.Background(), kc)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, ipFamily)
+		})
+	}
+}
+
+func TestNodePortAddress(t *testing.T) {
+	ctx := context.Background()
+	kc := fake.NewSimpleClientset(
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "control-plane"},
+			Status: corev1.NodeStatus{Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeInternalIP, Address: "fc00:f853:ccd:e793::2"},
+				{Type: corev1.NodeInternalIP, Address: "172.18.0.2"},
+			}},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "httpbin"},
+			Spec: corev1.ServiceSpec{
+				Type: corev1.ServiceTypeNodePort,
+				Ports: []corev1.ServicePort{
+					{Name: "http", Port: 80, NodePort: 30080},
+					{Name: "metrics", Port: 9090},
+				},
+			},
+		},
+	)
+
+	addr, err := nodePortAddress(ctx, kc, clusters.Dual, "default", "httpbin", 80)
+	require.NoError(t, err)
+	require.Equal(t, "172.18.0.2:30080", addr)
+
+	addr, err = nodePortAddress(ctx, kc, clusters.IPv6, "default", "httpbin", 80)
+	require.NoError(t, err)
+	require.Equal(t, "[fc00:f853:ccd:e793::2]:30080", addr)
+
+	_, err = nodePortAddress(ctx, kc, clusters.IPv4, "default", "httpbin", 9090)
+	require.Error(t, err)
+}
+
+func TestRegistryMirrorsPatch(t *testing.T) {
+	patch := registryMirrorsPatch(map[string]string{
+		"quay.io":   "http://quay.example.com",
+		"docker.io": "dockerhub.example.com:5000",
+	})
+	require.Equal(t, `[plugins."io.containerd.grpc.v1.cri".registry.mirrors."docker.io"]
+  endpoint = ["https://dockerhub.example.com:5000"]
+[plugins."io.containerd.grpc.v1.cri".registry.mirrors."quay.io"]
+  endpoint = ["http://quay.example.com"]
+`, patch)
+}
+
+func TestClusterMetadata(t *testing.T) {
+	labels := NewBuilder().WithName("ktf-test").WithTestID("TestMetadata").
+		WithLabels(map[string]string{"team": "gateway", OwnerLabel: "ignored"}).
+		ownershipLabels()
+	require.Equal(t, "gateway", labels["team"])
+	require.Equal(t, DefaultOwner, labels[OwnerLabel])
+	require.Equal(t, "TestMetadata", labels[TestIDLabel])
+
+	metadata := clusterMetadata(labels, "kindest/node:v1.29.1")
+	require.Equal(t, KindClusterType, metadata.Provider)
+	require.Equal(t, "kindest/node:v1.29.1", metadata.NodeImage)
+	require.Equal(t, labels, metadata.Labels)
+	require.WithinDuration(t, time.Now(), metadata.CreationTime, time.Minute)
+
+	require.True(t, clusterMetadata(nil, "").CreationTime.IsZero())
+
+	require.False(t, NewBuilder().labelsRequested(), "node images are only labelled if labels were requested")
+	require.True(t, NewBuilder().WithOwner("ci").labelsRequested())
+	require.True(t, NewBuilder().WithLabels(map[string]string{"team": "gateway"}).labelsRequested())
+}
+
+func TestUseLabelledNodeImages(t *testing.T) {
+	kindConfig := v1alpha4.Cluster{}
+	labelled := useLabelledNodeImages(&kindConfig, "test", "kindest/node:v1.29.1", false)
+	require.Equal(t, map[string]string{"kindest/node:v1.29.1": "ktf/kind-node:test-0"}, labelled)
+	require.Equal(t, []v1alpha4.Node{{Role: v1alpha4.ControlPlaneRole, Image: "ktf/kind-node:test-0"}}, kindConfig.Nodes)
+
+	kindConfig = v1alpha4.Cluster{Nodes: []v1alpha4.Node{
+		{Role: v1alpha4.ControlPlaneRole},
+		{Role: v1alpha4.WorkerRole, Image: "kindest/node:v1.28.0"},
+	}}
+	labelled = useLabelledNodeImages(&kindConfig, "test", "kindest/node:v1.29.1", false)
+	require.Equal(t, map[string]string{
+		"kindest/node:v1.29.1": "ktf/kind-node:test-0",
+		"kindest/node:v1.28.0": "ktf/kind-node:test-1",
+	}, labelled)
+	require.Equal(t, "ktf/kind-node:test-0", kindConfig.Nodes[0].Image)
+	require.Equal(t, "ktf/kind-node:test-1", kindConfig.Nodes[1].Image)
+
+	t.Log("the image of all nodes is overridden if requested")
+	kindConfig = v1alpha4.Cluster{Nodes: []v1alpha4.Node{
+		{Role: v1alpha4.ControlPlaneRole},
+		{Role: v1alpha4.WorkerRole, Image: "kindest/node:v1.28.0"},
+	}}
+	labelled = useLabelledNodeImages(&kindConfig, "test", "kindest/node:v1.29.1", true)
+	require.Equal(t, map[string]string{"kindest/node:v1.29.1": "ktf/kind-node:test-0"}, labelled)
+	require.Equal(t, "ktf/kind-node:test-0", kindConfig.Nodes[1].Image)
+}
+
+func TestContainerOwnershipLabels(t *testing.T) {
+	require.Nil(t, containerOwnershipLabels(map[string]string{kindClusterLabel: "test"}))
+	require.Equal(t, map[string]string{
+		OwnerLabel: DefaultOwner,
+		"team":     "gateway",
+	}, containerOwnershipLabels(map[string]string{
+		kindClusterLabel: "test",
+		kindRoleLabel:    "control-plane",
+		nodeImageLabel:   "kindest/node:v1.29.1",
+		OwnerLabel:       DefaultOwner,
+		"team":           "gateway",
+	}))
+}
+
+func TestWorkerNodes(t *testing.T) {
+	nodes := []string{"test-worker10", "test-control-plane", "test-worker", "test-worker2", "test-worker-x", "other-worker"}
+	workers := workerNodes("test", nodes)
+	require.Equal(t, []string{"test-worker", "test-worker2", "test-worker10"}, workers)
+	require.Equal(t, 11, nextWorkerIndex("test", workers))
+	require.Equal(t, 1, nextWorkerIndex("test", nil))
+
+	require.Equal(t, "test-worker", workerNodeName("test", 1))
+	require.Equal(t, "test-worker3", workerNodeName("test", 3))
+}
+
+func TestCleanupFailedWorkerNode(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var removed []string
+	removeContainer := func(ctx context.Context, name string) error {
+		require.NoError(t, ctx.Err(), "the cleanup must not be cancelled")
+		removed = append(removed, name)
+		return nil
+	}
+
+	t.Log("the node and its container are removed if the join got that far")
+	c := fake.NewSimpleClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test-worker2"}})
+	require.NoError(t, cleanupFailedWorkerNode(ctx, c, "test-worker2", removeContainer))
+	_, err := c.CoreV1().Nodes().Get(context.Background(), "test-worker2", metav1.GetOptions{})
+	require.True(t, apierrors.IsNotFound(err))
+	require.Equal(t, []string{"test-worker2"}, removed)
+
+	t.Log("the container is removed if the node never joined")
+	require.NoError(t, cleanupFailedWorkerNode(ctx, fake.NewSimpleClientset(), "test-worker3", removeContainer))
+	require.Equal(t, []string{"test-worker2", "test-worker3"}, removed)
+
+	t.Log("failures to remove the container are reported")
+	failure := errors.New("container in use")
+	err = cleanupFailedWorkerNode(ctx, fake.NewSimpleClientset(), "test-worker4", func(context.Context, string) error {
+		return failure
+	})
+	require.ErrorIs(t, err, failure)
+}
+
+func TestWorkerKubeadmConfig(t *testing.T) {
+	kubeadmConfig := `apiVersion: kubeadm.k8s.io/v1beta3
+kind: ClusterConfiguration
+clusterName: test
+---
+apiVersion: kubeadm.k8s.io/v1beta3
+kind: JoinConfiguration
+controlPlane:
+  localAPIEndpoint:
+    advertiseAddress: 172.18.0.2
+    bindPort: 6443
+nodeRegistration:
+  criSocket: unix:///run/containerd/containerd.sock
+  kubeletExtraArgs:
+    node-ip: 172.18.0.2
+    node-labels: ingress-ready=true
+    provider-id: kind://docker/test/test-control-plane
+discovery:
+  bootstrapToken:
+    apiServerEndpoint: test-control-plane:6443
+    token: abcdef.0123456789abcdef
+    unsafeSkipCAVerification: true
+`
+	config, err := workerKubeadmConfig([]byte(kubeadmConfig), "test", "test-worker", "172.18.0.3", "123456.fedcba9876543210")
+	require.NoError(t, err)
+
+	documents := strings.Split(string(config), "\n---\n")
+	require.Len(t, documents, 2)
+	require.Contains(t, documents[0], "kind: ClusterConfiguration")
+	var join map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(documents[1]), &join))
+	require.NotContains(t, join, "controlPlane")
+	require.Equal(t, map[string]interface{}{
+		"criSocket": "unix:///run/containerd/containerd.sock",
+		"name":      "test-worker",
+		"kubeletExtraArgs": map[string]interface{}{
+			"node-ip":     "172.18.0.3",
+			"node-labels": "",
+			"provider-id": "kind://docker/test/test-worker",
+		},
+	}, join["nodeRegistration"])
+	require.Equal(t, map[string]interface{}{
+		"apiServerEndpoint":        "test-control-plane:6443",
+		"token":                    "123456.fedcba9876543210",
+		"unsafeSkipCAVerification": true,
+	}, join["discovery"].(map[string]interface{})["bootstrapToken"])
+
+	t.Run("kubelet arguments as list", func(t *testing.T) {
+		args := setKubeletExtraArgs([]interface{}{
+			map[string]interface{}{"name": "node-ip", "value": "172.18.0.2"},
+			map[string]interface{}{"name": "v", "value": "2"},
+		}, map[string]string{"node-ip": "172.18.0.3", "provider-id": "kind://docker/test/test-worker"})
+		require.Equal(t, []interface{}{
+			map[string]interface{}{"name": "node-ip", "value": "172.18.0.3"},
+			map[string]interface{}{"name": "v", "value": "2"},
+			map[string]interface{}{"name": "provider-id", "value": "kind://docker/test/test-worker"},
+		}, args)
+	})
+
+	t.Run("no JoinConfiguration", func(t *testing.T) {
+		_, err := workerKubeadmConfig([]byte("kind: ClusterConfiguration\n"), "test", "test-worker", "172.18.0.3", "token")
+		require.Error(t, err)
+	})
+}
+
+func TestContainerdCAFiles(t *testing.T) {
+	config := `version = 2
+[plugins."io.containerd.grpc.v1.cri".registry.mirrors."docker.io"]
+  endpoint = ["https://mirror.example"]
+[plugins."io.containerd.grpc.v1.cri".registry.configs."registry.example:443".tls]
+  ca_file = "/usr/share/ca-certificates/registry.crt"
+`
+	require.Equal(t, []string{"/usr/share/ca-certificates/registry.crt"}, containerdCAFiles([]byte(config)))
+	require.Empty(t, containerdCAFiles([]byte("version = 2\n")))
+}
@@ -0,0 +1,79 @@
+package kind
+
+import (
+	"github.com/blang/semver/v4"
+)
+
+// -----------------------------------------------------------------------------
+// Kind Cluster - Supported Versions
+// -----------------------------------------------------------------------------
+
+// NodeImageVersion is a Kubernetes version with its known-good node image.
+type NodeImageVersion struct {
+	// Version is the Kubernetes version of the node image.
+	Version semver.Version
+
+	// Image is the digest pinned kindest/node image.
+	Image string
+}
+
+// supportedNodeImages are the node images published for the kind library
+// version KTF is built with (v0.20.0), newest first.
+// See: https://github.com/kubernetes-sigs/kind/releases/tag/v0.20.0
+var supportedNodeImages = []NodeImageVersion{
+	{
+		Version: semver.MustParse("1.27.3"),
+		Image:   "kindest/node:v1.27.3@sha256:3966ac761ae0136263ffdb6cfd4db23ef8a83cba8a463690e98317add2c9ba72",
+	},
+	{
+		Version: semver.MustParse("1.26.6"),
+		Image:   "kindest/node:v1.26.6@sha256:6e2d8b28a5b601defe327b98bd1c2d1930b49e5d8c512e1895099e4504007adb",
+	},
+	{
+		Version: semver.MustParse("1.25.11"),
+		Image:   "kindest/node:v1.25.11@sha256:227fa11ce74ea76a0474eeefb84cb75d8dad1b08638371ecf0e86259b35be0c8",
+	},
+	{
+		Version: semver.MustParse("1.24.15"),
+		Image:   "kindest/node:v1.24.15@sha256:7db4f8bea3e14b82d12e044e25e34bd53754b7f2b0e9d56df21774e6f66a70ab",
+	},
+	{
+		Version: semver.MustParse("1.23.17"),
+		Image:   "kindest/node:v1.23.17@sha256:59c989ff8a517a93127d4a536e7014d28e235fb3529d9fba91b3951d461edfdb",
+	},
+	{
+		Version: semver.MustParse("1.22.17"),
+		Image:   "kindest/node:v1.22.17@sha256:f5b2e5698c6c9d6d0adc419c0deae21a425c07d81bbf3b6a6834042f25d4fba2",
+	},
+	{
+		Version: semver.MustParse("1.21.14"),
+		Image:   "kindest/node:v1.21.14@sha256:8a4e9bb3f415d2bb81629ce33ef9c76ba514c14d707f9797a01e3216376ba093",
+	},
+}
+
+// SupportedNodeImages provides the Kubernetes versions and known-good node
+// images supported by the kind version KTF is built with, newest first, so
+// that test matrices can be generated instead of hard-coding image tags.
+func SupportedNodeImages() []NodeImageVersion {
+	return append([]NodeImageVersion(nil), supportedNodeImages...)
+}
+
+// SupportedVersions provides the Kubernetes versions supported by the kind
+// version KTF is built with, newest first.
+func SupportedVersions() []semver.Version {
+	versions := make([]semver.Version, 0, len(supportedNodeImages))
+	for _, nodeImage := range supportedNodeImages {
+		versions = append(versions, nodeImage.Version)
+	}
+	return versions
+}
+
+// supportedNodeImage provides the known-good node image for a Kubernetes version.
+func supportedNodeImage(version semver.Version) (string, bool) {
+	for _, nodeImage := range supportedNodeImages {
+		if nodeImage.Version.Equals(version) {
+			return nodeImage.Image, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,28 @@
+package kind
+
+import (
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kind/pkg/apis/config/defaults"
+)
+
+func TestSupportedNodeImages(t *testing.T) {
+	// the supported images need to be updated whenever the kind library is
+	// upgraded, its default node image is always the newest supported one.
+	nodeImages := SupportedNodeImages()
+	require.NotEmpty(t, nodeImages)
+	assert.Equal(t, defaults.Image, nodeImages[0].Image, "supported node images need to be updated for the new kind version")
+
+	for i, nodeImage := range nodeImages {
+		if i > 0 {
+			assert.True(t, nodeImage.Version.LT(nodeImages[i-1].Version), "node images must be ordered newest first")
+		}
+		assert.Equal(t, nodeImage.Image, NodeImage(&nodeImage.Version))
+	}
+
+	unsupported := semver.MustParse("1.18.0")
+	assert.Equal(t, "kindest/node:v1.18.0", NodeImage(&unsupported))
+}
@@ -0,0 +1,46 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blang/semver/v4"
+)
+
+// -----------------------------------------------------------------------------
+// Cluster Upgrades
+// -----------------------------------------------------------------------------
+
+// Upgrader is a Cluster which can be upgraded in place to a newer Kubernetes
+// version, so that upgrade scenarios can be tested regardless of the provider.
+type Upgrader interface {
+	// Upgrade upgrades the control plane and then the nodes of the cluster to
+	// the given Kubernetes version and waits for the upgrade to complete.
+	Upgrade(ctx context.Context, version semver.Version) error
+}
+
+// UpgradeCluster upgrades the cluster to the given Kubernetes version if it's
+// an Upgrader. Downgrades are rejected, upgrading to the current version of
+// the cluster does nothing.
+func UpgradeCluster(ctx context.Context, cluster Cluster, version semver.Version) error {
+	upgrader, ok := cluster.(Upgrader)
+	if !ok {
+		return fmt.Errorf("cluster %s of type %s does not support upgrades", cluster.Name(), cluster.Type())
+	}
+
+	current, err := cluster.Version(ctx)
+	if err != nil {
+		return fmt.Errorf("could not determine the version of cluster %s: %w", cluster.Name(), err)
+	}
+	// pre-release and build metadata (e.g. "-gke.1203001") is ignored, as
+	// providers may only accept the plain version.
+	current.Pre, current.Build = nil, nil
+	switch target := (semver.Version{Major: version.Major, Minor: version.Minor, Patch: version.Patch}); {
+	case target.LT(current):
+		return fmt.Errorf("cluster %s can not be downgraded from %s to %s", cluster.Name(), current, version)
+	case target.EQ(current):
+		return nil
+	}
+
+	return upgrader.Upgrade(ctx, version)
+}
@@ -0,0 +1,39 @@
+package clusters
+
+import (
+	"context"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// upgradeRecordingCluster is a Cluster which records the versions it was
+// upgraded to.
+type upgradeRecordingCluster struct {
+	versionCluster
+	upgrades []string
+}
+
+func (c *upgradeRecordingCluster) Name() string { return "upgradable" }
+
+func (c *upgradeRecordingCluster) Upgrade(_ context.Context, version semver.Version) error {
+	c.upgrades = append(c.upgrades, version.String())
+	return nil
+}
+
+func TestUpgradeCluster(t *testing.T) {
+	ctx := context.Background()
+	err := UpgradeCluster(ctx, fixedCluster{}, semver.MustParse("1.29.0"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not support upgrades")
+
+	cluster := &upgradeRecordingCluster{versionCluster: versionCluster{version: semver.MustParse("1.28.3-gke.1203001")}}
+	require.NoError(t, UpgradeCluster(ctx, cluster, semver.MustParse("1.28.3")))
+	require.NoError(t, UpgradeCluster(ctx, cluster, semver.MustParse("1.29.1-gke.1589017")))
+	assert.Equal(t, []string{"1.29.1-gke.1589017"}, cluster.upgrades)
+
+	err = UpgradeCluster(ctx, cluster, semver.MustParse("1.27.0"))
+	require.EqualError(t, err, "cluster upgradable can not be downgraded from 1.28.3 to 1.27.0")
+}
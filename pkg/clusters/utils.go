@@ -3,23 +3,29 @@ package clusters
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
+	"regexp"
 	"strings"
+	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	corev1 "k8s.io/api/core/v1"
 	extv1beta1 "k8s.io/api/extensions/v1beta1"
 	netv1 "k8s.io/api/networking/v1"
 	netv1beta1 "k8s.io/api/networking/v1beta1"
-	"k8s.io/apimachinery/pkg/api/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/kong/kubernetes-testing-framework/internal/conversion"
 	"github.com/kong/kubernetes-testing-framework/pkg/utils/kubernetes/generators"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/kubernetes/manifests"
 )
 
 // -----------------------------------------------------------------------------
@@ -106,7 +112,7 @@ func CreateNamespace(ctx context.Context, cluster Cluster, namespace string) err
 	nsName := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
 	_, err := cluster.Client().CoreV1().Namespaces().Create(ctx, nsName, metav1.CreateOptions{})
 	if err != nil {
-		if !errors.IsAlreadyExists(err) {
+		if !apierrors.IsAlreadyExists(err) {
 			return err
 		}
 	}
@@ -114,30 +120,135 @@ func CreateNamespace(ctx context.Context, cluster Cluster, namespace string) err
 	return nil
 }
 
-// TempKubeconfig produces a kubeconfig tempfile given a cluster.
-// the caller is responsible for cleaning up the file if they want it removed.
-func TempKubeconfig(cluster Cluster) (*os.File, error) {
-	// generate a kubeconfig from the cluster rest.Config
-	kubeconfigBytes, err := generators.NewKubeConfigForRestConfig(cluster.Name(), cluster.Config())
+// Architecture provides the CPU architecture of the nodes of the given cluster
+// using GOARCH naming (e.g. "amd64", "arm64"), allowing addons to select images
+// that match. Clusters which know their architecture (e.g. kind clusters)
+// provide it, otherwise it's determined from the cluster's nodes and an error
+// is returned if they don't share an architecture.
+func Architecture(ctx context.Context, cluster Cluster) (string, error) {
+	if c, ok := cluster.(interface{ Architecture() string }); ok && c.Architecture() != "" {
+		return c.Architecture(), nil
+	}
+	return nodesArchitecture(ctx, cluster.Client(), cluster.Name())
+}
+
+// nodesArchitecture provides the architecture shared by the nodes of the
+// named cluster.
+func nodesArchitecture(ctx context.Context, c kubernetes.Interface, name string) (string, error) {
+	nodes, err := c.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	// create a tempfile to store the kubeconfig contents
-	kubeconfig, err := os.CreateTemp(os.TempDir(), fmt.Sprintf("-kubeconfig-%s", cluster.Name()))
+	var arch string
+	for _, node := range nodes.Items {
+		nodeArch := node.Status.NodeInfo.Architecture
+		if arch != "" && nodeArch != arch {
+			return "", fmt.Errorf("cluster %s has nodes with mixed architectures (%s, %s)", name, arch, nodeArch)
+		}
+		arch = nodeArch
+	}
+	if arch == "" {
+		return "", fmt.Errorf("could not determine the architecture of cluster %s", name)
+	}
+
+	return arch, nil
+}
+
+// NamespacedAddon is an Addon which deploys its components to a namespace,
+// possibly shared with other addons.
+type NamespacedAddon interface {
+	Addon
+
+	// Namespace indicates the namespace where the addon's components are
+	// deployed.
+	Namespace() string
+}
+
+// DeleteAddonNamespace deletes the namespace of an addon being deleted from
+// the cluster (see DeleteNamespace), unless another addon deployed to the
+// cluster shares that namespace (see NamespacedAddon), in which case it's
+// left in place for that addon.
+func DeleteAddonNamespace(ctx context.Context, cluster Cluster, addon Addon, namespace string) error {
+	return deleteAddonNamespace(ctx, cluster.Client(), cluster.ListAddons(), addon, namespace)
+}
+
+func deleteAddonNamespace(ctx context.Context, c kubernetes.Interface, deployed []Addon, addon Addon, namespace string) error {
+	for _, other := range deployed {
+		if other.Name() == addon.Name() {
+			continue
+		}
+		if namespaced, ok := other.(NamespacedAddon); ok && namespaced.Namespace() == namespace {
+			return nil
+		}
+	}
+	return deleteNamespace(ctx, c, namespace)
+}
+
+// DeleteNamespace deletes a namespace (if it exists) and waits for it and all
+// its contents to be removed from the cluster, so that addons removed from a
+// running cluster are actually gone once their Delete method returns.
+func DeleteNamespace(ctx context.Context, cluster Cluster, namespace string) error {
+	return deleteNamespace(ctx, cluster.Client(), namespace)
+}
+
+func deleteNamespace(ctx context.Context, c kubernetes.Interface, namespace string) error {
+	namespaces := c.CoreV1().Namespaces()
+	if err := namespaces.Delete(ctx, namespace, metav1.DeleteOptions{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("could not delete namespace %s: %w", namespace, err)
+	}
+
+	ticker := time.NewTicker(namespaceDeletionWaitTick)
+	defer ticker.Stop()
+	for {
+		if _, err := namespaces.Get(ctx, namespace, metav1.GetOptions{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("context done before namespace %s was deleted: %w", namespace, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// WriteKubeconfig writes a kubeconfig for the cluster to w, so that external
+// tools (e.g. kubectl or helm) can be pointed at it. Its context is named
+// after the cluster and is the current context.
+func WriteKubeconfig(cluster Cluster, w io.Writer) error {
+	kubeconfigBytes, err := generators.NewKubeConfigForRestConfig(cluster.Name(), cluster.Config())
 	if err != nil {
-		return nil, err
+		return err
 	}
+	_, err = w.Write(kubeconfigBytes)
+	return err
+}
 
-	// write the contents
-	c, err := kubeconfig.Write(kubeconfigBytes)
+// TempKubeconfig produces a kubeconfig tempfile given a cluster (see
+// WriteKubeconfig). The file is closed once written, its Name() provides its
+// path. The caller is responsible for cleaning up the file if they want it
+// removed.
+func TempKubeconfig(cluster Cluster) (*os.File, error) {
+	kubeconfig, err := os.CreateTemp(os.TempDir(), fmt.Sprintf("-kubeconfig-%s", cluster.Name()))
 	if err != nil {
 		return nil, err
 	}
 
-	// validate the file integrity
-	if c != len(kubeconfigBytes) {
-		return nil, fmt.Errorf("failed to write kubeconfig to %s (only %d/%d written)", kubeconfig.Name(), c, len(kubeconfigBytes))
+	if err := WriteKubeconfig(cluster, kubeconfig); err != nil {
+		kubeconfig.Close()
+		os.Remove(kubeconfig.Name())
+		return nil, fmt.Errorf("failed to write kubeconfig to %s: %w", kubeconfig.Name(), err)
+	}
+	if err := kubeconfig.Close(); err != nil {
+		os.Remove(kubeconfig.Name())
+		return nil, err
 	}
 
 	return kubeconfig, nil
@@ -164,6 +275,71 @@ func GenerateNamespace(ctx context.Context, cluster Cluster, creatorID string) (
 	return cluster.Client().CoreV1().Namespaces().Create(ctx, namespace, metav1.CreateOptions{})
 }
 
+// EphemeralNamespace generates a namespace (see GenerateNamespace) which is
+// deleted once the provided context is done. The result of the deletion is
+// delivered on the returned channel, so that callers can wait for the
+// namespace to be gone.
+func EphemeralNamespace(ctx context.Context, cluster Cluster, creatorID string) (*corev1.Namespace, <-chan error, error) {
+	namespace, err := GenerateNamespace(ctx, cluster, creatorID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deleted := make(chan error, 1)
+	go func() {
+		defer close(deleted)
+		<-ctx.Done()
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), namespaceCleanupTimeout)
+		defer cancel()
+		deleted <- DeleteNamespace(cleanupCtx, cluster, namespace.Name)
+	}()
+	return namespace, deleted, nil
+}
+
+// NamespaceForTest creates a uniquely named namespace for a test (or
+// benchmark), named and labeled (see TestResourceLabel) after the test, and
+// deletes it once the test and its subtests completed. The test fails if the
+// namespace can't be created or removed.
+func NamespaceForTest(ctx context.Context, t testing.TB, cluster Cluster) *corev1.Namespace {
+	t.Helper()
+
+	creatorID := testNamespacePrefix(t.Name())
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: creatorID + "-" + uuid.NewString()[:8],
+			Labels: map[string]string{
+				TestResourceLabel: creatorID,
+			},
+		},
+	}
+	namespace, err := cluster.Client().CoreV1().Namespaces().Create(ctx, namespace, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("could not create namespace for test: %v", err)
+	}
+
+	t.Cleanup(func() {
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), namespaceCleanupTimeout)
+		defer cancel()
+		if err := DeleteNamespace(cleanupCtx, cluster, namespace.Name); err != nil {
+			t.Errorf("could not clean up namespace %s: %v", namespace.Name, err)
+		}
+	})
+	return namespace
+}
+
+// testNamespacePrefix converts the name of a test into a valid namespace
+// name and label value, leaving room for a random suffix.
+func testNamespacePrefix(testName string) string {
+	prefix := strings.Trim(invalidNamespaceChars.ReplaceAllString(strings.ToLower(testName), "-"), "-")
+	if len(prefix) > maxTestNamespacePrefixLength {
+		prefix = strings.TrimRight(prefix[:maxTestNamespacePrefixLength], "-")
+	}
+	if prefix == "" {
+		return "test"
+	}
+	return prefix
+}
+
 // CleanupGeneratedResources cleans up all resources created by the given creator ID.
 func CleanupGeneratedResources(ctx context.Context, cluster Cluster, creatorID string) error {
 	if creatorID == "" {
@@ -195,7 +371,7 @@ func CleanupGeneratedResources(ctx context.Context, cluster Cluster, creatorID s
 		default:
 			for _, namespace := range namespaceList.Items {
 				if err := cluster.Client().CoreV1().Namespaces().Delete(ctx, namespace.Name, metav1.DeleteOptions{}); err != nil {
-					if errors.IsNotFound(err) {
+					if apierrors.IsNotFound(err) {
 						delete(namespacesToCleanup, namespace.Name)
 					} else {
 						return fmt.Errorf("failed to delete namespace resource %s: %w", namespace.Name, err)
@@ -238,6 +414,42 @@ func DeleteManifestByYAML(ctx context.Context, cluster Cluster, yaml string) err
 	return kubectlSubcommandWithYAML(ctx, cluster, "delete", yaml)
 }
 
+// ApplyManifests applies the manifests of the provided sources (see the
+// manifests package for files, directories, URLs, readers and filesystems such
+// as an embed.FS) to the cluster in order using the Kubernetes API, with server
+// side apply for objects which exist already. Manifests may contain multiple
+// documents.
+func ApplyManifests(ctx context.Context, cluster Cluster, sources ...manifests.Source) error {
+	objects, err := manifests.Load(ctx, sources...)
+	if err != nil {
+		return err
+	}
+	c, err := manifests.NewClient(cluster.Config())
+	if err != nil {
+		return err
+	}
+	for _, obj := range objects {
+		if _, _, err := c.Apply(ctx, obj); err != nil {
+			return fmt.Errorf("could not apply %s %s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// DeleteManifests deletes the objects of the manifests of the provided sources
+// from the cluster in reverse order, objects which don't exist are ignored.
+func DeleteManifests(ctx context.Context, cluster Cluster, sources ...manifests.Source) error {
+	objects, err := manifests.Load(ctx, sources...)
+	if err != nil {
+		return err
+	}
+	c, err := manifests.NewClient(cluster.Config())
+	if err != nil {
+		return err
+	}
+	return c.DeleteObjects(ctx, objects)
+}
+
 // WaitForCondition waits for a condition to be true for an object on the
 // cluster given that objects namespace, type and name.
 func WaitForCondition(ctx context.Context, cluster Cluster, namespace, objectType, object, condition string, seconds int) error {
@@ -353,27 +565,55 @@ func kubectlSubcommandWithYAML(ctx context.Context, cluster Cluster, subcommand,
 
 // WaitForAddonDependencies is a convenience method to wait for all dependencies
 // of a given addon to be ready on the cluster according to a given context.
+// If a dependency still isn't deployed once the context is done, a
+// *MissingAddonDependencyError is returned.
 func WaitForAddonDependencies(ctx context.Context, cluster Cluster, addon Addon) error {
 	for _, dependency := range addon.Dependencies(ctx, cluster) {
-		dependencyReady := false
-		for !dependencyReady {
-			select {
-			case <-ctx.Done():
-				return fmt.Errorf("context completed while waiting for addon dependency (%s): %w", dependency, ctx.Err())
-			default:
-				addon, err := cluster.GetAddon(dependency)
-				if err != nil {
-					if strings.Contains(err.Error(), "not found") {
-						continue // the addon may not be present yet
-					}
-					return fmt.Errorf("could not retrieve dependency addon %s from the cluster: %w", dependency, err)
-				}
-				_, dependencyReady, err = addon.Ready(ctx, cluster)
+		for {
+			dependencyAddon, err := cluster.GetAddon(dependency)
+			if err != nil && !errors.Is(err, ErrAddonNotFound) {
+				return fmt.Errorf("could not retrieve dependency addon %s from the cluster: %w", dependency, err)
+			}
+			// the addon may not be present yet
+			if err == nil {
+				_, dependencyReady, err := dependencyAddon.Ready(ctx, cluster)
 				if err != nil {
 					return fmt.Errorf("failure to check dependency addon %s's readiness: %w", dependency, err)
 				}
+				if dependencyReady {
+					break
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				if dependencyAddon == nil {
+					return &MissingAddonDependencyError{Addon: addon.Name(), Dependency: dependency, Err: ctx.Err()}
+				}
+				return fmt.Errorf("context completed while waiting for addon dependency (%s): %w", dependency, ctx.Err())
+			case <-time.After(addonDependencyWaitTick):
 			}
 		}
 	}
 	return nil
 }
+
+// addonDependencyWaitTick is the interval at which WaitForAddonDependencies
+// checks whether dependencies are ready.
+const addonDependencyWaitTick = time.Second
+
+// namespaceDeletionWaitTick is the interval between checks whether a deleted
+// namespace is gone.
+const namespaceDeletionWaitTick = time.Second
+
+// namespaceCleanupTimeout is the maximum amount of time allowed to delete
+// ephemeral and test namespaces.
+const namespaceCleanupTimeout = time.Minute * 5
+
+// maxTestNamespacePrefixLength is the maximum length of the part of test
+// namespace names derived from the test name, so that along with the random
+// suffix it's a valid namespace name (63 characters at most).
+const maxTestNamespacePrefixLength = 54
+
+// invalidNamespaceChars matches the characters not allowed in namespace names.
+var invalidNamespaceChars = regexp.MustCompile(`[^a-z0-9-]+`)
@@ -0,0 +1,129 @@
+package clusters
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestTestNamespacePrefix(t *testing.T) {
+	assert.Equal(t, "testkong-dbless-postgres", testNamespacePrefix("TestKong/DBLess_postgres"))
+	assert.Equal(t, "test", testNamespacePrefix("/_/"))
+
+	prefix := testNamespacePrefix("TestKong/" + strings.Repeat("a", 60) + "_b")
+	assert.Len(t, prefix, maxTestNamespacePrefixLength)
+	assert.Equal(t, "testkong-"+strings.Repeat("a", 45), prefix)
+}
+
+// configCluster is a Cluster with a name and a REST config.
+type configCluster struct {
+	Cluster
+	name   string
+	config *rest.Config
+}
+
+func (c *configCluster) Name() string         { return c.name }
+func (c *configCluster) Config() *rest.Config { return c.config }
+
+func TestWriteKubeconfig(t *testing.T) {
+	cluster := &configCluster{name: "gke-test", config: &rest.Config{
+		Host:        "https://10.0.0.1",
+		BearerToken: "token",
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData:     []byte("ca"),
+			ServerName: "kubernetes.default",
+		},
+		ExecProvider: &clientcmdapi.ExecConfig{
+			Command:         "gke-gcloud-auth-plugin",
+			APIVersion:      "client.authentication.k8s.io/v1beta1",
+			InteractiveMode: clientcmdapi.NeverExecInteractiveMode,
+		},
+	}}
+
+	kubeconfig := new(bytes.Buffer)
+	require.NoError(t, WriteKubeconfig(cluster, kubeconfig))
+	cfg, err := clientcmd.Load(kubeconfig.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, "gke-test", cfg.CurrentContext)
+
+	restConfig, err := clientcmd.NewDefaultClientConfig(*cfg, nil).ClientConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "https://10.0.0.1", restConfig.Host)
+	assert.Equal(t, "token", restConfig.BearerToken)
+	assert.Equal(t, []byte("ca"), restConfig.CAData)
+	assert.Equal(t, "kubernetes.default", restConfig.ServerName)
+	require.NotNil(t, restConfig.ExecProvider)
+	assert.Equal(t, "gke-gcloud-auth-plugin", restConfig.ExecProvider.Command)
+
+	file, err := TempKubeconfig(cluster)
+	require.NoError(t, err)
+	defer os.Remove(file.Name())
+	written, err := os.ReadFile(file.Name())
+	require.NoError(t, err)
+	assert.Equal(t, kubeconfig.String(), string(written))
+}
+
+// namespacedAddon is an addon deployed to the given namespace.
+type namespacedAddon struct {
+	fakeAddon
+	namespace string
+}
+
+func (a namespacedAddon) Namespace() string { return a.namespace }
+
+func TestDeleteAddonNamespace(t *testing.T) {
+	ctx := context.Background()
+	prometheus := namespacedAddon{fakeAddon: fakeAddon{name: "prometheus"}, namespace: "monitoring"}
+	kubeStateMetrics := namespacedAddon{fakeAddon: fakeAddon{name: "kube-state-metrics"}, namespace: "monitoring"}
+	monitoring := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "monitoring"}}
+
+	t.Run("namespace shared with another deployed addon is kept", func(t *testing.T) {
+		c := fake.NewSimpleClientset(monitoring.DeepCopy())
+		deployed := []Addon{prometheus, kubeStateMetrics, namespacedAddon{fakeAddon: fakeAddon{name: "echo"}, namespace: "echo"}}
+
+		require.NoError(t, deleteAddonNamespace(ctx, c, deployed, prometheus, "monitoring"))
+		_, err := c.CoreV1().Namespaces().Get(ctx, "monitoring", metav1.GetOptions{})
+		require.NoError(t, err)
+	})
+
+	t.Run("namespace is deleted with the last addon using it", func(t *testing.T) {
+		c := fake.NewSimpleClientset(monitoring.DeepCopy())
+		deployed := []Addon{prometheus, fakeAddon{name: "static"}}
+
+		require.NoError(t, deleteAddonNamespace(ctx, c, deployed, prometheus, "monitoring"))
+		_, err := c.CoreV1().Namespaces().Get(ctx, "monitoring", metav1.GetOptions{})
+		require.True(t, apierrors.IsNotFound(err))
+	})
+}
+
+func TestNodesArchitecture(t *testing.T) {
+	node := func(name, arch string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{Architecture: arch}},
+		}
+	}
+	ctx := context.Background()
+
+	arch, err := nodesArchitecture(ctx, fake.NewSimpleClientset(node("a", "arm64"), node("b", "arm64")), "test")
+	require.NoError(t, err)
+	assert.Equal(t, "arm64", arch)
+
+	_, err = nodesArchitecture(ctx, fake.NewSimpleClientset(node("a", "arm64"), node("b", "amd64")), "test")
+	assert.ErrorContains(t, err, "mixed architectures")
+
+	_, err = nodesArchitecture(ctx, fake.NewSimpleClientset(), "test")
+	assert.Error(t, err)
+}
@@ -0,0 +1,63 @@
+package clusters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/kubernetes"
+)
+
+// -----------------------------------------------------------------------------
+// Cluster Version
+// -----------------------------------------------------------------------------
+
+// ServerVersion queries the Kubernetes version of the live API server of
+// a cluster, for implementations of Cluster.Version.
+func ServerVersion(ctx context.Context, c kubernetes.Interface) (semver.Version, error) {
+	body, err := c.Discovery().RESTClient().Get().AbsPath("/version").Do(ctx).Raw()
+	if err != nil {
+		return semver.Version{}, err
+	}
+	var info version.Info
+	if err := json.Unmarshal(body, &info); err != nil {
+		return semver.Version{}, fmt.Errorf("invalid server version response: %w", err)
+	}
+	return parseServerVersion(info)
+}
+
+// SkipUnlessVersion skips the test unless the Kubernetes version of the
+// cluster is in the given range (e.g. ">=1.28.0 <1.30.0"), so that tests of
+// features which aren't available on all versions can branch on the version.
+// Pre-release and build metadata of versions (e.g. of GKE versions such as
+// "1.28.3-gke.1203001") is ignored.
+func SkipUnlessVersion(ctx context.Context, t testing.TB, cluster Cluster, versionRange string) {
+	t.Helper()
+
+	inRange, err := semver.ParseRange(versionRange)
+	if err != nil {
+		t.Fatalf("invalid version range %q: %v", versionRange, err)
+	}
+	clusterVersion, err := cluster.Version(ctx)
+	if err != nil {
+		t.Fatalf("could not determine the version of cluster %s: %v", cluster.Name(), err)
+	}
+
+	clusterVersion.Pre, clusterVersion.Build = nil, nil
+	if !inRange(clusterVersion) {
+		t.Skipf("cluster version %s is not in range %s", clusterVersion, versionRange)
+	}
+}
+
+// parseServerVersion provides the semantic version of an API server.
+func parseServerVersion(info version.Info) (semver.Version, error) {
+	v, err := semver.Parse(strings.TrimPrefix(info.GitVersion, "v"))
+	if err != nil {
+		return semver.Version{}, fmt.Errorf("invalid server version %s: %w", info.GitVersion, err)
+	}
+	return v, nil
+}
@@ -0,0 +1,51 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+func TestParseServerVersion(t *testing.T) {
+	v, err := parseServerVersion(version.Info{GitVersion: "v1.28.3-gke.1203001"})
+	require.NoError(t, err)
+	assert.Equal(t, semver.MustParse("1.28.3-gke.1203001"), v)
+
+	_, err = parseServerVersion(version.Info{GitVersion: "v1.28"})
+	assert.ErrorContains(t, err, "invalid server version v1.28")
+}
+
+// versionCluster is a Cluster of a given version.
+type versionCluster struct {
+	Cluster
+	version semver.Version
+}
+
+func (c *versionCluster) Version(_ context.Context) (semver.Version, error) { return c.version, nil }
+
+// skipRecordingTest is a test which records whether it was skipped.
+type skipRecordingTest struct {
+	testing.TB
+	skipped string
+}
+
+func (t *skipRecordingTest) Helper() {}
+func (t *skipRecordingTest) Skipf(format string, args ...interface{}) {
+	t.skipped = fmt.Sprintf(format, args...)
+}
+
+func TestSkipUnlessVersion(t *testing.T) {
+	cluster := &versionCluster{version: semver.MustParse("1.28.3-gke.1203001")}
+
+	test := &skipRecordingTest{}
+	SkipUnlessVersion(context.Background(), test, cluster, ">=1.28.3")
+	assert.Empty(t, test.skipped)
+
+	SkipUnlessVersion(context.Background(), test, cluster, ">=1.29.0")
+	assert.Equal(t, "cluster version 1.28.3 is not in range >=1.29.0", test.skipped)
+}
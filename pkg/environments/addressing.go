@@ -0,0 +1,142 @@
+package environments
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/wait"
+)
+
+// -----------------------------------------------------------------------------
+// Multi-Cluster Environment - Cross-Cluster Addressing
+// -----------------------------------------------------------------------------
+
+// exposedServiceManager identifies the EndpointSlices created by ExposeService.
+const exposedServiceManager = "ktf.konghq.com"
+
+// LoadBalancerAddress waits for the LoadBalancer Service of the given namespace
+// and name to be provisioned an address (e.g. by the metallb addon), and
+// provides it. This is the address at which other clusters reach the Service,
+// e.g. kind clusters which share the same docker network.
+func LoadBalancerAddress(ctx context.Context, cluster clusters.Cluster, namespace, name string) (string, error) {
+	return loadBalancerAddress(ctx, cluster.Client(), namespace, name)
+}
+
+// ExposeService makes the LoadBalancer Service of the given namespace and name
+// of the source cluster addressable from the target cluster, through a Service
+// of the same namespace and name in the target cluster, so that workloads of
+// the target cluster reach it with the same in-cluster DNS name (e.g.
+// "name.namespace.svc") as workloads of the source cluster do. The namespace
+// is created in the target cluster if needed.
+//
+// The Service of the target cluster is an ExternalName Service if the source
+// Service is provisioned a hostname, or a Service without selector whose
+// endpoint is the provisioned IP otherwise.
+func ExposeService(ctx context.Context, source clusters.Cluster, namespace, name string, target clusters.Cluster) (*corev1.Service, error) {
+	return exposeService(ctx, source.Client(), target.Client(), namespace, name)
+}
+
+func loadBalancerAddress(ctx context.Context, c kubernetes.Interface, namespace, name string) (string, error) {
+	var address string
+	err := wait.Until(ctx, fmt.Sprintf("service %s/%s to be provisioned a load balancer address", namespace, name), func(ctx context.Context) (bool, string, error) {
+		service, err := c.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, "not found", nil
+			}
+			return false, "", err
+		}
+		if service.Spec.Type != corev1.ServiceTypeLoadBalancer {
+			return false, "", fmt.Errorf("service %s/%s is of type %s, not %s", namespace, name, service.Spec.Type, corev1.ServiceTypeLoadBalancer)
+		}
+		for _, ingress := range service.Status.LoadBalancer.Ingress {
+			if ingress.IP != "" {
+				address = ingress.IP
+				return true, "", nil
+			}
+			if ingress.Hostname != "" {
+				address = ingress.Hostname
+				return true, "", nil
+			}
+		}
+		return false, "no address", nil
+	})
+	return address, err
+}
+
+func exposeService(ctx context.Context, source, target kubernetes.Interface, namespace, name string) (*corev1.Service, error) {
+	address, err := loadBalancerAddress(ctx, source, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	sourceService, err := source.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+	if _, err := target.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("could not create namespace %s: %w", namespace, err)
+	}
+
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	for _, port := range sourceService.Spec.Ports {
+		service.Spec.Ports = append(service.Spec.Ports, corev1.ServicePort{
+			Name:       port.Name,
+			Protocol:   port.Protocol,
+			Port:       port.Port,
+			TargetPort: intstr.FromInt32(port.Port),
+		})
+	}
+
+	ip := net.ParseIP(address)
+	if ip == nil {
+		service.Spec.Type = corev1.ServiceTypeExternalName
+		service.Spec.ExternalName = address
+	}
+	service, err = target.CoreV1().Services(namespace).Create(ctx, service, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("could not create service %s/%s: %w", namespace, name, err)
+	}
+	if ip == nil {
+		return service, nil
+	}
+
+	addressType := discoveryv1.AddressTypeIPv4
+	if ip.To4() == nil {
+		addressType = discoveryv1.AddressTypeIPv6
+	}
+	endpointSlice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels: map[string]string{
+				discoveryv1.LabelServiceName: name,
+				discoveryv1.LabelManagedBy:   exposedServiceManager,
+			},
+		},
+		AddressType: addressType,
+		Endpoints:   []discoveryv1.Endpoint{{Addresses: []string{address}}},
+	}
+	for _, port := range sourceService.Spec.Ports {
+		endpointSlice.Ports = append(endpointSlice.Ports, discoveryv1.EndpointPort{
+			Name:     ptr.To(port.Name),
+			Protocol: ptr.To(port.Protocol),
+			Port:     ptr.To(port.Port),
+		})
+	}
+	if _, err := target.DiscoveryV1().EndpointSlices(namespace).Create(ctx, endpointSlice, metav1.CreateOptions{}); err != nil {
+		return nil, fmt.Errorf("could not create endpoints of service %s/%s: %w", namespace, name, err)
+	}
+	return service, nil
+}
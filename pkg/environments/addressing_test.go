@@ -0,0 +1,83 @@
+package environments
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func loadBalancerService(name string, ingress corev1.LoadBalancerIngress) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kong", Name: name},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeLoadBalancer,
+			Ports: []corev1.ServicePort{
+				{Name: "http", Protocol: corev1.ProtocolTCP, Port: 80},
+				{Name: "tls", Protocol: corev1.ProtocolTCP, Port: 443},
+			},
+		},
+		Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{ingress}}},
+	}
+}
+
+func TestExposeService(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	source := fake.NewSimpleClientset(
+		loadBalancerService("proxy", corev1.LoadBalancerIngress{IP: "172.18.0.100"}),
+		loadBalancerService("admin", corev1.LoadBalancerIngress{Hostname: "admin.example.com"}),
+	)
+	target := fake.NewSimpleClientset()
+
+	t.Run("ip", func(t *testing.T) {
+		service, err := exposeService(ctx, source, target, "kong", "proxy")
+		require.NoError(t, err)
+		assert.Equal(t, corev1.ServiceType(""), service.Spec.Type)
+		assert.Empty(t, service.Spec.Selector)
+		require.Len(t, service.Spec.Ports, 2)
+		assert.Equal(t, int32(443), service.Spec.Ports[1].Port)
+
+		_, err = target.CoreV1().Namespaces().Get(ctx, "kong", metav1.GetOptions{})
+		require.NoError(t, err)
+
+		endpointSlice, err := target.DiscoveryV1().EndpointSlices("kong").Get(ctx, "proxy", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "proxy", endpointSlice.Labels[discoveryv1.LabelServiceName])
+		assert.Equal(t, discoveryv1.AddressTypeIPv4, endpointSlice.AddressType)
+		assert.Equal(t, []string{"172.18.0.100"}, endpointSlice.Endpoints[0].Addresses)
+		require.Len(t, endpointSlice.Ports, 2)
+		assert.Equal(t, "tls", *endpointSlice.Ports[1].Name)
+	})
+
+	t.Run("hostname", func(t *testing.T) {
+		service, err := exposeService(ctx, source, target, "kong", "admin")
+		require.NoError(t, err)
+		assert.Equal(t, corev1.ServiceTypeExternalName, service.Spec.Type)
+		assert.Equal(t, "admin.example.com", service.Spec.ExternalName)
+	})
+}
+
+func TestLoadBalancerAddress(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	pending := loadBalancerService("pending", corev1.LoadBalancerIngress{})
+	clusterIP := loadBalancerService("cluster-ip", corev1.LoadBalancerIngress{})
+	clusterIP.Spec.Type = corev1.ServiceTypeClusterIP
+	c := fake.NewSimpleClientset(pending, clusterIP)
+
+	_, err := loadBalancerAddress(ctx, c, "kong", "cluster-ip")
+	assert.ErrorContains(t, err, "service kong/cluster-ip is of type ClusterIP, not LoadBalancer")
+
+	_, err = loadBalancerAddress(ctx, c, "kong", "pending")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "(no address)")
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/blang/semver/v4"
+	"github.com/go-logr/logr"
 	"github.com/google/uuid"
 
 	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
@@ -17,23 +20,31 @@ import (
 // Environment Builder
 // -----------------------------------------------------------------------------
 
+// DefaultAddonDeployConcurrency is the default maximum number of addons which
+// are deployed concurrently when building an Environment.
+const DefaultAddonDeployConcurrency = 4
+
 // Builder is a toolkit for building a new test Environment.
 type Builder struct {
 	Name string
 
 	addons            clusters.Addons
+	registeredAddons  []registeredAddon
+	addonConcurrency  int
 	existingCluster   clusters.Cluster
 	clusterBuilder    clusters.Builder
 	kubernetesVersion *semver.Version
 	calicoCNI         bool
 	ipv6Only          bool
+	logger            logr.Logger
 }
 
 // NewBuilder generates a new empty Builder for creating Environments.
 func NewBuilder() *Builder {
 	return &Builder{
-		Name:   uuid.NewString(),
-		addons: make(clusters.Addons),
+		Name:             uuid.NewString(),
+		addons:           make(clusters.Addons),
+		addonConcurrency: DefaultAddonDeployConcurrency,
 	}
 }
 
@@ -52,6 +63,30 @@ func (b *Builder) WithAddons(addons ...clusters.Addon) *Builder {
 	return b
 }
 
+// WithRegisteredAddon includes an addon from the addon registry (see
+// clusters.RegisterAddon) by name, constructed with the provided options when
+// the environment is built.
+func (b *Builder) WithRegisteredAddon(name clusters.AddonName, options clusters.AddonOptions) *Builder {
+	b.registeredAddons = append(b.registeredAddons, registeredAddon{name: name, options: options})
+	return b
+}
+
+// WithAddonDeployConcurrency limits how many addons which don't depend on each
+// other are deployed concurrently, 1 deploys the addons one at a time and 0
+// removes the limit. See DefaultAddonDeployConcurrency for the default.
+func (b *Builder) WithAddonDeployConcurrency(concurrency int) *Builder {
+	b.addonConcurrency = concurrency
+	return b
+}
+
+// WithLogger configures the logger which the environment logs its progress to
+// (e.g. the creation of its cluster and the deployment of its addons). The
+// logger of an existing cluster is used by default (see clusters.Logger).
+func (b *Builder) WithLogger(logger logr.Logger) *Builder {
+	b.logger = logger
+	return b
+}
+
 // WithExistingCluster causes the resulting environment to re-use an existing
 // clusters.Cluster instead of creating a new one.
 func (b *Builder) WithExistingCluster(cluster clusters.Cluster) *Builder {
@@ -90,6 +125,16 @@ func (b *Builder) WithIPv6Only() *Builder {
 // entirely on the underlying clusters.Cluster implementation that was requested.
 func (b *Builder) Build(ctx context.Context) (env Environment, err error) {
 	var cluster clusters.Cluster
+	start := time.Now()
+
+	for _, registered := range b.registeredAddons {
+		addon, err := clusters.NewAddon(registered.name, registered.options)
+		if err != nil {
+			return nil, err
+		}
+		b.WithAddons(addon)
+	}
+	b.registeredAddons = nil
 
 	if b.calicoCNI && b.existingCluster != nil {
 		return nil, fmt.Errorf("trying to deploy Calico CNI on an existing cluster is not currently supported")
@@ -118,7 +163,7 @@ func (b *Builder) Build(ctx context.Context) (env Environment, err error) {
 			return nil, err
 		}
 	} else {
-		builder := kind.NewBuilder().WithName(b.Name)
+		builder := kind.NewBuilder().WithName(b.Name).WithLogger(b.logger)
 		if b.kubernetesVersion != nil {
 			builder.WithClusterVersion(*b.kubernetesVersion)
 		}
@@ -142,69 +187,44 @@ func (b *Builder) Build(ctx context.Context) (env Environment, err error) {
 		}
 	}()
 
-	// determine the addon dependencies of the cluster before building
-	requiredAddons := make(map[string][]string)
-	for _, addon := range b.addons {
-		for _, dependency := range addon.Dependencies(ctx, cluster) {
-			requiredAddons[string(dependency)] = append(requiredAddons[string(dependency)], string(addon.Name()))
-		}
+	logger := b.logger
+	if logger.GetSink() == nil {
+		logger = clusters.Logger(cluster)
 	}
+	logger = logger.WithValues("environment", b.Name)
 
 	// verify addon dependency requirements have been met
-	requiredAddonsThatAreMissing := make([]string, 0)
-	for requiredAddon, neededBy := range requiredAddons {
-		found := false
-		for _, addon := range b.addons {
-			if requiredAddon == string(addon.Name()) {
-				found = true
-				break
+	missingAddons := clusters.MissingAddonDependencies(ctx, cluster, b.addons)
+	if len(missingAddons) != 0 {
+		requiredAddonsThatAreMissing := make([]string, 0, len(missingAddons))
+		for requiredAddon, neededBy := range missingAddons {
+			names := make([]string, 0, len(neededBy))
+			for _, name := range neededBy {
+				names = append(names, string(name))
 			}
+			requiredAddonsThatAreMissing = append(requiredAddonsThatAreMissing, fmt.Sprintf("%s (needed by %s)", requiredAddon, strings.Join(names, ", ")))
 		}
-		if !found {
-			requiredAddonsThatAreMissing = append(requiredAddonsThatAreMissing, fmt.Sprintf("%s (needed by %s)", requiredAddon, strings.Join(neededBy, ", ")))
-		}
-	}
-	if len(requiredAddonsThatAreMissing) != 0 {
+		sort.Strings(requiredAddonsThatAreMissing)
 		return nil, fmt.Errorf("addon dependencies were not met, missing: %s", strings.Join(requiredAddonsThatAreMissing, ", "))
 	}
 
-	// run each addon deployment asynchronously and collect any errors that occur
-	addonDeploymentErrorQueue := make(chan error, len(b.addons))
-	for _, addon := range b.addons {
-		addonCopy := addon
-		go func() {
-			if err := cluster.DeployAddon(ctx, addonCopy); err != nil {
-				addonDeploymentErrorQueue <- fmt.Errorf("failed to deploy addon %s: %w", addonCopy.Name(), err)
-			}
-			addonDeploymentErrorQueue <- nil
-		}()
+	// deploy the addons concurrently, each one after its dependencies
+	logger.Info("deploying addons", "cluster", cluster.Name(), "addons", len(b.addons))
+	if err := clusters.DeployAddons(ctx, cluster, b.addons, b.addonConcurrency); err != nil {
+		return nil, err
 	}
+	logger.Info("built environment", "cluster", cluster.Name(), "duration", time.Since(start))
 
-	// wait for all deployments to report, and gather up any errors
-	collectedDeploymentErrorsCount := 0
-	addonDeploymentErrors := make([]error, 0)
-	for !(collectedDeploymentErrorsCount == len(b.addons)) {
-		if err := <-addonDeploymentErrorQueue; err != nil {
-			addonDeploymentErrors = append(addonDeploymentErrors, err)
-		}
-		collectedDeploymentErrorsCount++
-	}
+	return &environment{
+		name:    b.Name,
+		cluster: cluster,
+		logger:  logger,
+	}, nil
+}
 
-	// if any errors occurred during deployment, report them
-	totalFailures := len(addonDeploymentErrors)
-	switch totalFailures {
-	case 0:
-		return &environment{
-			name:    b.Name,
-			cluster: cluster,
-		}, nil
-	case 1:
-		return nil, addonDeploymentErrors[0] 
-	default:
-		errMsgs := make([]string, 0, totalFailures)
-		for _, err := range addonDeploymentErrors {
-			errMsgs = append(errMsgs, err.Error())
-		}
-		return nil, fmt.Errorf("%d addon deployments failed: %s", totalFailures, strings.Join(errMsgs, ", "))
-	}
+// registeredAddon is an addon from the addon registry to be constructed when
+// the environment is built.
+type registeredAddon struct {
+	name    clusters.AddonName
+	options clusters.AddonOptions
 }
@@ -24,6 +24,12 @@ type Environment interface {
 	// Cleanup performs teardown and cleanup on all cluster components
 	Cleanup(ctx context.Context) error
 
+	// RegisterTeardownHook registers a hook to run when the environment is
+	// cleaned up, before its cluster is. Hooks run in the reverse order of
+	// their registration, and the failure or panic of a hook doesn't prevent
+	// the others nor the cluster's cleanup from running.
+	RegisterTeardownHook(hook clusters.TeardownHook)
+
 	// Ready indicates when the environment is ready and fully deployed,
 	// or if errors occurred during provisioning of components.
 	Ready(ctx context.Context) ([]runtime.Object, bool, error)
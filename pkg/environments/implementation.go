@@ -2,9 +2,11 @@ package environments
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -26,8 +28,10 @@ const (
 
 // environment is the default KTF Environment used for testing Kubernetes ingress.
 type environment struct {
-	name    string
-	cluster clusters.Cluster
+	name          string
+	cluster       clusters.Cluster
+	teardownHooks clusters.TeardownHooks
+	logger        logr.Logger
 }
 
 func (env *environment) Name() string {
@@ -39,10 +43,23 @@ func (env *environment) Cluster() clusters.Cluster {
 }
 
 func (env *environment) Cleanup(ctx context.Context) error {
-	return env.Cluster().Cleanup(ctx)
+	hooksErr := env.teardownHooks.Run(ctx, env.Cluster())
+	return errors.Join(hooksErr, env.Cluster().Cleanup(ctx))
+}
+
+func (env *environment) RegisterTeardownHook(hook clusters.TeardownHook) {
+	env.teardownHooks.Register(hook)
 }
 
 func (env *environment) Ready(ctx context.Context) (waitForObjects []runtime.Object, ready bool, err error) {
+	waitForObjects, _, ready, err = env.readiness(ctx)
+	return
+}
+
+// readiness checks the readiness of the system components and of the addons
+// of the cluster, and provides the objects which are not ready as well as the
+// conditions of the components which are not ready.
+func (env *environment) readiness(ctx context.Context) (waitForObjects []runtime.Object, conditions []clusters.Condition, ready bool, err error) {
 	var deployments *appsv1.DeploymentList
 	var daemonsets *appsv1.DaemonSetList
 
@@ -70,16 +87,36 @@ func (env *environment) Ready(ctx context.Context) (waitForObjects []runtime.Obj
 		}
 	}
 
+	for _, obj := range waitForObjects {
+		conditions = append(conditions, clusters.ObjectCondition(obj))
+	}
+
+	addonsReady := true
 	for _, addon := range env.Cluster().ListAddons() {
 		var waitForAddonObjects []runtime.Object
-		waitForAddonObjects, ready, err = addon.Ready(ctx, env.Cluster())
+		var addonReady bool
+		waitForAddonObjects, addonReady, err = addon.Ready(ctx, env.Cluster())
 		if err != nil {
 			return
 		}
 		waitForObjects = append(waitForObjects, waitForAddonObjects...)
+		if addonReady {
+			continue
+		}
+
+		// report exactly which components of the addon are not ready
+		addonsReady = false
+		addonConditions := clusters.ReadyConditions(addon, waitForAddonObjects, addonReady)
+		if conditionsAddon, ok := addon.(clusters.ConditionsAddon); ok {
+			_, addonConditions, err = conditionsAddon.Conditions(ctx, env.Cluster())
+			if err != nil {
+				return
+			}
+		}
+		conditions = append(conditions, addonConditions...)
 	}
 
-	ready = len(waitForObjects) == 0
+	ready = addonsReady && len(waitForObjects) == 0
 	return
 }
 
@@ -87,6 +124,7 @@ func (env *environment) WaitForReady(ctx context.Context) chan error {
 	errs := make(chan error)
 
 	go func() {
+		start := time.Now()
 		// if the cluster fails to become ready after N minutes, assume it's likely stuck and dump a diagnostic bundle.
 		// this uses its own timer since we can't catch "go test" timeouts via the ctx.
 		hung := time.AfterFunc(readyHungDuration, func() {
@@ -95,30 +133,34 @@ func (env *environment) WaitForReady(ctx context.Context) chan error {
 				errs <- err
 				return
 			}
-			fmt.Printf("cluster not ready after %s, dumped diag to %s\n", readyHungDuration.String(), loc)
+			env.logger.Info("dumped diagnostics", "reason", fmt.Sprintf("cluster not ready after %s", readyHungDuration), "location", loc)
 		})
-		waitForObjects := make([]runtime.Object, 0)
+		var conditions []clusters.Condition
 		for {
 			select {
 			case <-ctx.Done():
-				errs <- fmt.Errorf("context done before environment was ready (remaining objects %+v): %w", waitForObjects, ctx.Err())
+				err := fmt.Errorf("context done before environment was ready (not ready: %s): %w", clusters.FormatConditions(conditions), ctx.Err())
+				clusters.EmitEvent(clusters.Event{Type: clusters.EventReadinessCheckFailed, Cluster: env.Cluster().Name(), Duration: time.Since(start), Err: err})
+				errs <- err
 				hung.Stop()
 				loc, err := env.Cluster().DumpDiagnostics(ctx, readyDiagnosticMeta)
 				if err != nil {
 					errs <- err
 					return
 				}
-				fmt.Printf("cluster not ready before context completed, dumped diag to %s\n", loc)
+				env.logger.Info("dumped diagnostics", "reason", "cluster not ready before context completed", "location", loc)
 				return
 			default:
 				var ready bool
 				var err error
-				waitForObjects, ready, err = env.Ready(ctx)
+				_, conditions, ready, err = env.readiness(ctx)
 				if err != nil {
+					clusters.EmitEvent(clusters.Event{Type: clusters.EventReadinessCheckFailed, Cluster: env.Cluster().Name(), Duration: time.Since(start), Err: err})
 					errs <- err
 					return
 				}
 				if ready {
+					clusters.EmitEvent(clusters.Event{Type: clusters.EventReady, Cluster: env.Cluster().Name(), Duration: time.Since(start)})
 					errs <- nil
 					hung.Stop()
 					return
@@ -0,0 +1,208 @@
+package environments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+)
+
+// -----------------------------------------------------------------------------
+// Public Types - Multi-Cluster Testing Environments
+// -----------------------------------------------------------------------------
+
+// MultiClusterEnvironment is a test environment made of several Environments,
+// each with its own cluster and addons, which are managed as one: e.g. a
+// control plane cluster and a data plane cluster, or two peered clusters.
+// Each Environment is identified by its role in the test, e.g. "cp" and "dp".
+// See ExposeService to address the Services of a cluster from another.
+type MultiClusterEnvironment interface {
+	// Name indicates the unique name of the testing environment
+	Name() string
+
+	// Roles provides the roles of the environments, in the order they were
+	// configured.
+	Roles() []string
+
+	// Environment provides the environment of the given role, or nil if there
+	// is none.
+	Environment(role string) Environment
+
+	// Cluster provides the cluster of the environment of the given role, or
+	// nil if there is none.
+	Cluster(role string) clusters.Cluster
+
+	// Cleanup performs teardown and cleanup of all environments, in the
+	// reverse order of their roles. The failure of an environment's cleanup
+	// doesn't prevent the others from being cleaned up.
+	Cleanup(ctx context.Context) error
+
+	// Ready indicates when all environments are ready, or if errors occurred
+	// during provisioning of components.
+	Ready(ctx context.Context) ([]runtime.Object, bool, error)
+
+	// WaitForReady provides a nonblocking channel which can be used to wait
+	// for readiness of all environments (see Environment.WaitForReady).
+	WaitForReady(ctx context.Context) chan error
+}
+
+// -----------------------------------------------------------------------------
+// Multi-Cluster Environment Builder
+// -----------------------------------------------------------------------------
+
+// MultiClusterBuilder is a toolkit for building a new MultiClusterEnvironment.
+type MultiClusterBuilder struct {
+	Name string
+
+	roles    []string
+	builders map[string]*Builder
+}
+
+// NewMultiClusterBuilder generates a new empty MultiClusterBuilder.
+func NewMultiClusterBuilder() *MultiClusterBuilder {
+	return &MultiClusterBuilder{
+		Name:     uuid.NewString(),
+		builders: make(map[string]*Builder),
+	}
+}
+
+// WithName indicates a custom name to provide the testing environment
+func (b *MultiClusterBuilder) WithName(name string) *MultiClusterBuilder {
+	b.Name = name
+	return b
+}
+
+// WithEnvironment includes an environment of the given role built by the
+// provided Builder, which configures its cluster and addons. Configuring a
+// role again replaces its Builder.
+func (b *MultiClusterBuilder) WithEnvironment(role string, builder *Builder) *MultiClusterBuilder {
+	if _, ok := b.builders[role]; !ok {
+		b.roles = append(b.roles, role)
+	}
+	b.builders[role] = builder
+	return b
+}
+
+// Build is a blocking call to construct the configured environments, which are
+// built concurrently. If any environment fails to build the others are cleaned
+// up (except for their existing clusters, see Builder.WithExistingCluster).
+func (b *MultiClusterBuilder) Build(ctx context.Context) (MultiClusterEnvironment, error) {
+	if len(b.roles) == 0 {
+		return nil, fmt.Errorf("multi-cluster environment %s has no environments", b.Name)
+	}
+
+	var (
+		wg   sync.WaitGroup
+		lock sync.Mutex
+		envs = make(map[string]Environment, len(b.roles))
+		errs []error
+	)
+	for _, role := range b.roles {
+		wg.Add(1)
+		go func(role string) {
+			defer wg.Done()
+			env, err := b.builders[role].Build(ctx)
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("could not build environment %s: %w", role, err))
+				return
+			}
+			envs[role] = env
+		}(role)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		for role, env := range envs {
+			if b.builders[role].existingCluster != nil {
+				continue
+			}
+			if err := env.Cleanup(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("could not clean up environment %s: %w", role, err))
+			}
+		}
+		return nil, errors.Join(errs...)
+	}
+
+	return &multiClusterEnvironment{
+		name:  b.Name,
+		roles: append([]string(nil), b.roles...),
+		envs:  envs,
+	}, nil
+}
+
+// -----------------------------------------------------------------------------
+// Multi-Cluster Environment - Implementation
+// -----------------------------------------------------------------------------
+
+type multiClusterEnvironment struct {
+	name  string
+	roles []string
+	envs  map[string]Environment
+}
+
+func (m *multiClusterEnvironment) Name() string {
+	return m.name
+}
+
+func (m *multiClusterEnvironment) Roles() []string {
+	return append([]string(nil), m.roles...)
+}
+
+func (m *multiClusterEnvironment) Environment(role string) Environment {
+	return m.envs[role]
+}
+
+func (m *multiClusterEnvironment) Cluster(role string) clusters.Cluster {
+	env, ok := m.envs[role]
+	if !ok {
+		return nil
+	}
+	return env.Cluster()
+}
+
+func (m *multiClusterEnvironment) Cleanup(ctx context.Context) error {
+	var errs []error
+	for i := len(m.roles) - 1; i >= 0; i-- {
+		if err := m.envs[m.roles[i]].Cleanup(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("could not clean up environment %s: %w", m.roles[i], err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (m *multiClusterEnvironment) Ready(ctx context.Context) ([]runtime.Object, bool, error) {
+	var waitForObjects []runtime.Object
+	ready := true
+	for _, role := range m.roles {
+		objects, envReady, err := m.envs[role].Ready(ctx)
+		if err != nil {
+			return nil, false, fmt.Errorf("could not check readiness of environment %s: %w", role, err)
+		}
+		waitForObjects = append(waitForObjects, objects...)
+		ready = ready && envReady
+	}
+	return waitForObjects, ready, nil
+}
+
+func (m *multiClusterEnvironment) WaitForReady(ctx context.Context) chan error {
+	errs := make(chan error)
+
+	go func() {
+		for _, role := range m.roles {
+			if err := <-m.envs[role].WaitForReady(ctx); err != nil {
+				errs <- fmt.Errorf("environment %s is not ready: %w", role, err)
+				return
+			}
+		}
+		errs <- nil
+	}()
+
+	return errs
+}
@@ -0,0 +1,63 @@
+package environments
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+// Test Environment - Testing Helpers
+// -----------------------------------------------------------------------------
+
+// CleanupTimeout is the maximum amount of time allowed to dump diagnostics and
+// tear down an environment registered with CleanupAfterTest.
+const CleanupTimeout = time.Minute * 5
+
+// CleanupAfterTest registers the teardown of the environment with the test (or
+// benchmark). If the test failed by then, the diagnostics of the environment's
+// cluster are dumped before teardown and their location is logged.
+func CleanupAfterTest(t testing.TB, env Environment) {
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), CleanupTimeout)
+		defer cancel()
+
+		if t.Failed() {
+			output, err := env.Cluster().DumpDiagnostics(ctx, t.Name())
+			if err != nil {
+				t.Logf("failed to dump diagnostics of environment %s: %v", env.Name(), err)
+			} else {
+				t.Logf("test failed, dumped diagnostics of environment %s to %s", env.Name(), output)
+			}
+		}
+
+		if err := env.Cleanup(ctx); err != nil {
+			t.Errorf("failed to clean up environment %s: %v", env.Name(), err)
+		}
+	})
+}
+
+// CleanupMultiClusterAfterTest registers the teardown of the multi-cluster
+// environment with the test (or benchmark), dumping the diagnostics of all its
+// clusters first if the test failed by then (see CleanupAfterTest).
+func CleanupMultiClusterAfterTest(t testing.TB, env MultiClusterEnvironment) {
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), CleanupTimeout)
+		defer cancel()
+
+		if t.Failed() {
+			for _, role := range env.Roles() {
+				output, err := env.Cluster(role).DumpDiagnostics(ctx, t.Name())
+				if err != nil {
+					t.Logf("failed to dump diagnostics of environment %s (%s): %v", env.Name(), role, err)
+				} else {
+					t.Logf("test failed, dumped diagnostics of environment %s (%s) to %s", env.Name(), role, output)
+				}
+			}
+		}
+
+		if err := env.Cleanup(ctx); err != nil {
+			t.Errorf("failed to clean up environment %s: %v", env.Name(), err)
+		}
+	})
+}
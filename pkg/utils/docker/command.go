@@ -1,10 +1,13 @@
 package docker
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
 )
 
 // RunPrivilegedCommand is a very basic and opinionated helper function which runs the
@@ -29,3 +32,49 @@ func RunPrivilegedCommand(ctx context.Context, containerID, command string, args
 	// run the command
 	return dockerc.ContainerExecStart(ctx, execID.ID, types.ExecStartCheck{})
 }
+
+// RunPrivilegedCommandWithOutput runs the given command and arguments on the given
+// container (by ID) privileged, waits for it to complete and provides its output.
+// An error is returned if the command exits with a non-zero status.
+func RunPrivilegedCommandWithOutput(ctx context.Context, containerID, command string, args ...string) ([]byte, error) {
+	// connect to the local docker env
+	dockerc, err := NewNegotiatedClientWithOpts(ctx, client.FromEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	// load the exec command for the container
+	execID, err := dockerc.ContainerExecCreate(ctx, containerID, types.ExecConfig{
+		User:         "0",
+		Privileged:   true,
+		AttachStdout: true,
+		AttachStderr: true,
+		Cmd:          append([]string{command}, args...),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// run the command and wait for its output streams to close
+	resp, err := dockerc.ContainerExecAttach(ctx, execID.ID, types.ExecStartCheck{})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	if _, err := stdcopy.StdCopy(stdout, stderr, resp.Reader); err != nil {
+		return nil, err
+	}
+
+	// verify the exit status of the command
+	inspect, err := dockerc.ContainerExecInspect(ctx, execID.ID)
+	if err != nil {
+		return nil, err
+	}
+	if inspect.ExitCode != 0 {
+		return stdout.Bytes(), fmt.Errorf("command %q exited with status %d STDERR=(%s)", command, inspect.ExitCode, stderr.String())
+	}
+
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,85 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+)
+
+// -----------------------------------------------------------------------------
+// Public Functions - Containers
+// -----------------------------------------------------------------------------
+
+// StartPrivilegedContainer creates a privileged container with the given name
+// from the image (pulling it if needed) attached to the given docker network,
+// writes the provided files (by path) to it and starts it.
+func StartPrivilegedContainer(ctx context.Context, name, image, networkName string, files map[string][]byte) error {
+	if err := EnsureImages(ctx, image); err != nil {
+		return err
+	}
+
+	dockerc, err := NewNegotiatedClientWithOpts(ctx, client.FromEnv)
+	if err != nil {
+		return err
+	}
+	defer dockerc.Close()
+
+	_, err = dockerc.ContainerCreate(ctx,
+		&container.Config{Image: image},
+		&container.HostConfig{Privileged: true},
+		&network.NetworkingConfig{
+			EndpointsConfig: map[string]*network.EndpointSettings{networkName: {}},
+		},
+		nil, name,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create container %s: %w", name, err)
+	}
+
+	for path, data := range files {
+		if err := WriteFileToContainer(ctx, name, path, 0o644, data); err != nil { //nolint:gomnd
+			return fmt.Errorf("failed to write %s to container %s: %w", path, name, err)
+		}
+	}
+
+	if err := dockerc.ContainerStart(ctx, name, container.StartOptions{}); err != nil {
+		return fmt.Errorf("failed to start container %s: %w", name, err)
+	}
+	return nil
+}
+
+// RemoveContainer forcefully removes the container with the given name,
+// tolerating it not existing.
+func RemoveContainer(ctx context.Context, name string) error {
+	dockerc, err := NewNegotiatedClientWithOpts(ctx, client.FromEnv)
+	if err != nil {
+		return err
+	}
+	defer dockerc.Close()
+
+	if err := dockerc.ContainerRemove(ctx, name, container.RemoveOptions{Force: true}); err != nil {
+		if client.IsErrNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to remove container %s: %w", name, err)
+	}
+	return nil
+}
+
+// GetContainerIP retrieves the IPv4 address of a container on the given
+// docker network.
+func GetContainerIP(containerID, networkName string) (string, error) {
+	containerJSON, err := InspectDockerContainer(containerID)
+	if err != nil {
+		return "", err
+	}
+
+	endpoint, ok := containerJSON.NetworkSettings.Networks[networkName]
+	if !ok {
+		return "", fmt.Errorf("container %s is not attached to docker network %s", containerID, networkName)
+	}
+	return endpoint.IPAddress, nil
+}
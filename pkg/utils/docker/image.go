@@ -0,0 +1,165 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/registry"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"golang.org/x/sync/errgroup"
+)
+
+// -----------------------------------------------------------------------------
+// Public Functions - Images
+// -----------------------------------------------------------------------------
+
+// EnsureImages pulls any of the provided images which aren't already present
+// in the local docker environment. Images are pulled concurrently.
+func EnsureImages(ctx context.Context, images ...string) error {
+	dockerc, err := NewNegotiatedClientWithOpts(ctx, client.FromEnv)
+	if err != nil {
+		return err
+	}
+	defer dockerc.Close()
+
+	g, ctx := errgroup.WithContext(ctx)
+	for _, image := range images {
+		image := image
+		g.Go(func() error {
+			if _, _, err := dockerc.ImageInspectWithRaw(ctx, image); err == nil {
+				return nil
+			} else if !client.IsErrNotFound(err) {
+				return fmt.Errorf("failed to inspect image %s: %w", image, err)
+			}
+
+			resp, err := dockerc.ImagePull(ctx, image, types.ImagePullOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to pull image %s: %w", image, err)
+			}
+			defer resp.Close()
+
+			// the pull is only complete once the progress stream has been consumed
+			if _, err := io.Copy(io.Discard, resp); err != nil {
+				return fmt.Errorf("failed to pull image %s: %w", image, err)
+			}
+			return nil
+		})
+	}
+
+	return g.Wait()
+}
+
+// PushImage tags the local source image as target and pushes it to the
+// target's registry, using the provided credentials (if any).
+func PushImage(ctx context.Context, source, target string, auth registry.AuthConfig) error {
+	dockerc, err := NewNegotiatedClientWithOpts(ctx, client.FromEnv)
+	if err != nil {
+		return err
+	}
+	defer dockerc.Close()
+
+	if err := dockerc.ImageTag(ctx, source, target); err != nil {
+		return fmt.Errorf("failed to tag image %s as %s: %w", source, target, err)
+	}
+
+	encodedAuth, err := registry.EncodeAuthConfig(auth)
+	if err != nil {
+		return err
+	}
+	resp, err := dockerc.ImagePush(ctx, target, types.ImagePushOptions{RegistryAuth: encodedAuth})
+	if err != nil {
+		return fmt.Errorf("failed to push image %s: %w", target, err)
+	}
+	defer resp.Close()
+
+	// push failures are only reported in the progress stream
+	if err := jsonmessage.DisplayJSONMessagesStream(resp, io.Discard, 0, false, nil); err != nil {
+		return fmt.Errorf("failed to push image %s: %w", target, err)
+	}
+	return nil
+}
+
+// LabelImage builds an image tagged as tag, which is the provided image with
+// the provided labels added. Containers inherit the labels of their image, so
+// this labels containers whose creation can't be configured (e.g. kind nodes).
+func LabelImage(ctx context.Context, image, tag string, labels map[string]string) error {
+	dockerc, err := NewNegotiatedClientWithOpts(ctx, client.FromEnv)
+	if err != nil {
+		return err
+	}
+	defer dockerc.Close()
+
+	// the build context is a tar archive holding only the Dockerfile
+	dockerfile := labelDockerfile(image, labels)
+	buildContext := new(bytes.Buffer)
+	archive := tar.NewWriter(buildContext)
+	if err := archive.WriteHeader(&tar.Header{Name: "Dockerfile", Mode: 0o644, Size: int64(len(dockerfile))}); err != nil { //nolint:gomnd
+		return err
+	}
+	if _, err := archive.Write([]byte(dockerfile)); err != nil {
+		return err
+	}
+	if err := archive.Close(); err != nil {
+		return err
+	}
+
+	resp, err := dockerc.ImageBuild(ctx, buildContext, types.ImageBuildOptions{Tags: []string{tag}, Remove: true})
+	if err != nil {
+		return fmt.Errorf("failed to build image %s: %w", tag, err)
+	}
+	defer resp.Body.Close()
+
+	// build failures are only reported in the progress stream
+	if err := jsonmessage.DisplayJSONMessagesStream(resp.Body, io.Discard, 0, false, nil); err != nil {
+		return fmt.Errorf("failed to build image %s: %w", tag, err)
+	}
+	return nil
+}
+
+// RemoveImages removes the images whose reference matches the provided
+// pattern (e.g. "ktf/kind-node:test-*").
+func RemoveImages(ctx context.Context, reference string) error {
+	dockerc, err := NewNegotiatedClientWithOpts(ctx, client.FromEnv)
+	if err != nil {
+		return err
+	}
+	defer dockerc.Close()
+
+	images, err := dockerc.ImageList(ctx, types.ImageListOptions{Filters: filters.NewArgs(filters.Arg("reference", reference))})
+	if err != nil {
+		return fmt.Errorf("failed to list images %s: %w", reference, err)
+	}
+	for _, image := range images {
+		for _, tag := range image.RepoTags {
+			if _, err := dockerc.ImageRemove(ctx, tag, types.ImageRemoveOptions{PruneChildren: true}); err != nil && !client.IsErrNotFound(err) {
+				return fmt.Errorf("failed to remove image %s: %w", tag, err)
+			}
+		}
+	}
+	return nil
+}
+
+// labelDockerfile provides the Dockerfile of the image with the labels added.
+func labelDockerfile(image string, labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	dockerfile := new(strings.Builder)
+	fmt.Fprintf(dockerfile, "FROM %s\n", image)
+	for _, key := range keys {
+		fmt.Fprintf(dockerfile, "LABEL %s=%s\n", strconv.Quote(key), strconv.Quote(labels[key]))
+	}
+	return dockerfile.String()
+}
@@ -0,0 +1,20 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelDockerfile(t *testing.T) {
+	dockerfile := labelDockerfile("kindest/node:v1.29.1", map[string]string{
+		"ktf.test-id": "TestLabels",
+		"ktf.owner":   `ktf "ci"`,
+	})
+	require.Equal(t, `FROM kindest/node:v1.29.1
+LABEL "ktf.owner"="ktf \"ci\""
+LABEL "ktf.test-id"="TestLabels"
+`, dockerfile)
+
+	require.Equal(t, "FROM kindest/node:v1.29.1\n", labelDockerfile("kindest/node:v1.29.1", nil))
+}
@@ -0,0 +1,57 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+)
+
+// -----------------------------------------------------------------------------
+// Public Functions - Networks
+// -----------------------------------------------------------------------------
+
+// EnsureNetwork creates a bridge network with the given name in the local
+// docker environment if it doesn't already exist. If a subnet (in CIDR
+// notation) is provided the network is created with that subnet, otherwise
+// docker allocates one. The provided labels are applied to created networks.
+// The subnet and labels are not validated for existing networks.
+func EnsureNetwork(ctx context.Context, name, subnet string, labels map[string]string) error {
+	dockerc, err := NewNegotiatedClientWithOpts(ctx, client.FromEnv)
+	if err != nil {
+		return err
+	}
+	defer dockerc.Close()
+
+	if _, err := dockerc.NetworkInspect(ctx, name, types.NetworkInspectOptions{}); err == nil {
+		return nil
+	} else if !client.IsErrNotFound(err) {
+		return fmt.Errorf("failed to inspect docker network %s: %w", name, err)
+	}
+
+	opts := types.NetworkCreate{
+		Driver: "bridge",
+		Labels: labels,
+		Options: map[string]string{
+			"com.docker.network.bridge.enable_ip_masquerade": "true",
+		},
+	}
+	if subnet != "" {
+		prefix, err := netip.ParsePrefix(subnet)
+		if err != nil {
+			return fmt.Errorf("invalid subnet %s for docker network %s: %w", subnet, name, err)
+		}
+		opts.EnableIPv6 = prefix.Addr().Is6()
+		opts.IPAM = &network.IPAM{
+			Config: []network.IPAMConfig{{Subnet: prefix.Masked().String()}},
+		}
+	}
+
+	if _, err := dockerc.NetworkCreate(ctx, name, opts); err != nil {
+		return fmt.Errorf("failed to create docker network %s: %w", name, err)
+	}
+	return nil
+}
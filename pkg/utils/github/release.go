@@ -34,14 +34,7 @@ func FindLatestReleaseForRepo(ctx context.Context, org, repo string) (*semver.Ve
 // FindRawLatestReleaseForRepo returns the latest release tag as a string.
 // It should be used directly for non-semver releases. Semver releases should use FindLatestReleaseForRepo
 func FindRawLatestReleaseForRepo(ctx context.Context, org, repo string) (string, error) {
-	var tc *http.Client
-	if ghToken := os.Getenv("GITHUB_TOKEN"); ghToken != "" {
-		ts := oauth2.StaticTokenSource(
-			&oauth2.Token{AccessToken: ghToken},
-		)
-		tc = oauth2.NewClient(ctx, ts)
-	}
-	client := github.NewClient(tc)
+	client := newClient(ctx)
 
 	release, _, err := client.Repositories.GetLatestRelease(context.Background(), org, repo)
 	if err != nil {
@@ -55,3 +48,46 @@ func FindRawLatestReleaseForRepo(ctx context.Context, org, repo string) (string,
 
 	return *release.TagName, nil
 }
+
+// ListReleasesForRepo returns the versions of all the published releases of a
+// Github repository given an Organization and Repository name. Drafts and
+// releases with tags which aren't semver are omitted.
+func ListReleasesForRepo(ctx context.Context, org, repo string) ([]semver.Version, error) {
+	client := newClient(ctx)
+
+	var versions []semver.Version
+	opts := &github.ListOptions{PerPage: 100} //nolint:gomnd
+	for {
+		releases, resp, err := client.Repositories.ListReleases(ctx, org, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't list %s/%s releases: %w", org, repo, err)
+		}
+		for _, release := range releases {
+			if release.GetDraft() {
+				continue
+			}
+			version, err := semver.ParseTolerant(release.GetTagName())
+			if err != nil {
+				continue
+			}
+			versions = append(versions, version)
+		}
+		if resp.NextPage == 0 {
+			return versions, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// newClient provides a Github client, authenticated if the GITHUB_TOKEN
+// environment variable is set.
+func newClient(ctx context.Context) *github.Client {
+	var tc *http.Client
+	if ghToken := os.Getenv("GITHUB_TOKEN"); ghToken != "" {
+		ts := oauth2.StaticTokenSource(
+			&oauth2.Token{AccessToken: ghToken},
+		)
+		tc = oauth2.NewClient(ctx, ts)
+	}
+	return github.NewClient(tc)
+}
@@ -0,0 +1,229 @@
+// Package helm installs, upgrades and uninstalls Helm chart releases on a
+// cluster using the Helm SDK, so that neither the helm CLI nor a kubeconfig
+// file are needed.
+package helm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/postrender"
+	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage/driver"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+)
+
+// -----------------------------------------------------------------------------
+// Helm - Releases
+// -----------------------------------------------------------------------------
+
+// Release describes a chart release to install or upgrade.
+type Release struct {
+	// Name is the name of the release.
+	Name string
+
+	// Namespace is the namespace the release is deployed to, it's created if
+	// needed.
+	Namespace string
+
+	// Chart is the name of the chart in the repository at RepoURL (e.g.
+	// "kong"), or the path of a local chart or an OCI reference (e.g.
+	// "oci://docker.io/envoyproxy/gateway-helm") if RepoURL is empty.
+	Chart string
+
+	// RepoURL is the URL of the chart repository.
+	RepoURL string
+
+	// Version pins the chart version, the latest is used when empty.
+	Version string
+
+	// Values are the values of the release.
+	Values map[string]interface{}
+
+	// SkipCRDs skips the installation of the CRDs of the chart (as with
+	// "helm install --skip-crds").
+	SkipCRDs bool
+
+	// PostRenderer modifies the rendered manifests of the chart before they're
+	// installed (as with "helm install --post-renderer"), if set.
+	PostRenderer postrender.PostRenderer
+
+	// Wait waits for the resources of the release to be ready, at most for
+	// Timeout.
+	Wait    bool
+	Timeout time.Duration
+}
+
+// -----------------------------------------------------------------------------
+// Helm - Client
+// -----------------------------------------------------------------------------
+
+// Client manages Helm chart releases on a cluster. Releases are stored as
+// secrets, like the helm CLI does, so releases are interchangeable with it.
+type Client struct {
+	cluster  clusters.Cluster
+	settings *cli.EnvSettings
+
+	// config provides the action configuration for a namespace, it's
+	// replaceable for testing.
+	config func(namespace string) (*action.Configuration, error)
+}
+
+// NewClient provides a Client for the releases on the cluster. Charts are
+// downloaded using the Helm environment settings (e.g. HELM_CACHE_HOME).
+func NewClient(cluster clusters.Cluster) *Client {
+	c := &Client{cluster: cluster, settings: cli.New()}
+	c.config = c.actionConfig
+	return c
+}
+
+// Install installs the release, failing if it exists already.
+func (c *Client) Install(ctx context.Context, rel Release) (*release.Release, error) {
+	cfg, err := c.config(rel.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	install := action.NewInstall(cfg)
+	install.ReleaseName = rel.Name
+	install.Namespace = rel.Namespace
+	install.CreateNamespace = true
+	install.Wait = rel.Wait
+	install.Timeout = rel.Timeout
+	install.RepoURL = rel.RepoURL
+	install.Version = rel.Version
+	install.SkipCRDs = rel.SkipCRDs
+	install.PostRenderer = rel.PostRenderer
+	if err := c.setRegistryClient(install.SetRegistryClient); err != nil {
+		return nil, err
+	}
+
+	chrt, err := c.loadChart(&install.ChartPathOptions, rel)
+	if err != nil {
+		return nil, err
+	}
+	installed, err := install.RunWithContext(ctx, chrt, rel.Values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to install release %s/%s: %w", rel.Namespace, rel.Name, err)
+	}
+	return installed, nil
+}
+
+// Upgrade upgrades the release, or installs it if it doesn't exist (as with
+// "helm upgrade --install").
+func (c *Client) Upgrade(ctx context.Context, rel Release) (*release.Release, error) {
+	cfg, err := c.config(rel.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	history := action.NewHistory(cfg)
+	history.Max = 1
+	if _, err := history.Run(rel.Name); err != nil {
+		if errors.Is(err, driver.ErrReleaseNotFound) {
+			return c.Install(ctx, rel)
+		}
+		return nil, fmt.Errorf("failed to get history of release %s/%s: %w", rel.Namespace, rel.Name, err)
+	}
+
+	upgrade := action.NewUpgrade(cfg)
+	upgrade.Namespace = rel.Namespace
+	upgrade.Wait = rel.Wait
+	upgrade.Timeout = rel.Timeout
+	upgrade.RepoURL = rel.RepoURL
+	upgrade.Version = rel.Version
+	upgrade.SkipCRDs = rel.SkipCRDs
+	upgrade.PostRenderer = rel.PostRenderer
+	if err := c.setRegistryClient(upgrade.SetRegistryClient); err != nil {
+		return nil, err
+	}
+
+	chrt, err := c.loadChart(&upgrade.ChartPathOptions, rel)
+	if err != nil {
+		return nil, err
+	}
+	upgraded, err := upgrade.RunWithContext(ctx, rel.Name, chrt, rel.Values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upgrade release %s/%s: %w", rel.Namespace, rel.Name, err)
+	}
+	return upgraded, nil
+}
+
+// Uninstall uninstalls the release of the given name from the namespace,
+// tolerating the release not existing.
+func (c *Client) Uninstall(_ context.Context, name, namespace string) error {
+	cfg, err := c.config(namespace)
+	if err != nil {
+		return err
+	}
+
+	uninstall := action.NewUninstall(cfg)
+	uninstall.IgnoreNotFound = true
+	if _, err := uninstall.Run(name); err != nil {
+		return fmt.Errorf("failed to uninstall release %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+// Status provides the latest revision of the release of the given name in the
+// namespace. An error wrapping driver.ErrReleaseNotFound is returned if it
+// doesn't exist.
+func (c *Client) Status(_ context.Context, name, namespace string) (*release.Release, error) {
+	cfg, err := c.config(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	rel, err := action.NewStatus(cfg).Run(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status of release %s/%s: %w", namespace, name, err)
+	}
+	return rel, nil
+}
+
+// -----------------------------------------------------------------------------
+// Helm - Private
+// -----------------------------------------------------------------------------
+
+// actionConfig provides the configuration of actions on releases in the
+// namespace of the cluster.
+func (c *Client) actionConfig(namespace string) (*action.Configuration, error) {
+	cfg := new(action.Configuration)
+	getter := &restClientGetter{cluster: c.cluster, namespace: namespace}
+	if err := cfg.Init(getter, namespace, "secret", func(string, ...interface{}) {}); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// setRegistryClient provides an action with a registry client, for charts
+// referenced by OCI references.
+func (c *Client) setRegistryClient(set func(*registry.Client)) error {
+	registryClient, err := registry.NewClient(registry.ClientOptCredentialsFile(c.settings.RegistryConfig))
+	if err != nil {
+		return err
+	}
+	set(registryClient)
+	return nil
+}
+
+// loadChart downloads (if needed) and loads the chart of the release.
+func (c *Client) loadChart(opts *action.ChartPathOptions, rel Release) (*chart.Chart, error) {
+	path, err := opts.LocateChart(rel.Chart, c.settings)
+	if err != nil {
+		return nil, fmt.Errorf("could not locate chart %s: %w", rel.Chart, err)
+	}
+	chrt, err := loader.Load(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not load chart %s: %w", rel.Chart, err)
+	}
+	return chrt, nil
+}
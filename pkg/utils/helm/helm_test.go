@@ -0,0 +1,88 @@
+package helm
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/cli"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+)
+
+// localChart writes a chart with a ConfigMap to a temporary directory.
+func localChart(t *testing.T) string {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "templates"), 0o750)) //nolint:gomnd
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Chart.yaml"),
+		[]byte("apiVersion: v2\nname: echo\nversion: 0.1.0\n"), 0o600)) //nolint:gomnd
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "templates", "configmap.yaml"),
+		[]byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: echo\ndata:\n  message: {{ .Values.message }}\n"), 0o600)) //nolint:gomnd
+	return dir
+}
+
+// fakeClient provides a Client which stores releases in memory and doesn't
+// create any objects.
+func fakeClient(t *testing.T) *Client {
+	t.Setenv("HELM_CACHE_HOME", t.TempDir())
+	t.Setenv("HELM_CONFIG_HOME", t.TempDir())
+	cfg := &action.Configuration{
+		Releases:     storage.Init(driver.NewMemory()),
+		KubeClient:   &kubefake.PrintingKubeClient{Out: io.Discard},
+		Capabilities: chartutil.DefaultCapabilities,
+		Log:          func(string, ...interface{}) {},
+	}
+	return &Client{
+		settings: cli.New(),
+		config: func(namespace string) (*action.Configuration, error) {
+			cfg.Releases.Driver.(*driver.Memory).SetNamespace(namespace)
+			return cfg, nil
+		},
+	}
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	c := fakeClient(t)
+	rel := Release{
+		Name:      "echo",
+		Namespace: "test",
+		Chart:     localChart(t),
+		Values:    map[string]interface{}{"message": "hello"},
+	}
+
+	_, err := c.Status(ctx, "echo", "test")
+	require.ErrorIs(t, err, driver.ErrReleaseNotFound)
+
+	// upgrading installs the release if it doesn't exist.
+	installed, err := c.Upgrade(ctx, rel)
+	require.NoError(t, err)
+	assert.Equal(t, 1, installed.Version)
+	assert.Contains(t, installed.Manifest, "message: hello")
+
+	_, err = c.Install(ctx, rel)
+	require.Error(t, err)
+
+	rel.Values["message"] = "bye"
+	upgraded, err := c.Upgrade(ctx, rel)
+	require.NoError(t, err)
+	assert.Equal(t, 2, upgraded.Version)
+
+	status, err := c.Status(ctx, "echo", "test")
+	require.NoError(t, err)
+	assert.Equal(t, release.StatusDeployed, status.Info.Status)
+	assert.Contains(t, status.Manifest, "message: bye")
+
+	require.NoError(t, c.Uninstall(ctx, "echo", "test"))
+	require.NoError(t, c.Uninstall(ctx, "echo", "test"))
+	_, err = c.Status(ctx, "echo", "test")
+	require.ErrorIs(t, err, driver.ErrReleaseNotFound)
+}
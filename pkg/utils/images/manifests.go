@@ -0,0 +1,87 @@
+package images
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// -----------------------------------------------------------------------------
+// Registry Mirrors - Manifests
+// -----------------------------------------------------------------------------
+
+// containerFields are the fields of pod specs which hold containers.
+var containerFields = map[string]bool{
+	"containers":          true,
+	"initContainers":      true,
+	"ephemeralContainers": true,
+}
+
+// MirrorObject rewrites the images of all the containers of the given object
+// (e.g. a Pod, a Deployment or any custom resource holding pod templates) in
+// place, to be pulled from the configured registry mirrors (see Mirror).
+// Containers with the "Never" image pull policy use images loaded into the
+// cluster, so their images are left as they are.
+func MirrorObject(obj map[string]interface{}) {
+	mirrorContainers(obj)
+}
+
+// MirrorManifest rewrites the images of all the containers of the objects of
+// a YAML or JSON manifest (which can hold multiple documents) to be pulled
+// from the configured registry mirrors (see Mirror), as MirrorObject does. The
+// manifest is provided as it is if no mirror is configured.
+func MirrorManifest(manifest []byte) ([]byte, error) {
+	if len(RegistryMirrors()) == 0 {
+		return manifest, nil
+	}
+
+	mirrored := new(bytes.Buffer)
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(manifest), 4096) //nolint:gomnd
+	for {
+		var obj map[string]interface{}
+		if err := decoder.Decode(&obj); err != nil {
+			if errors.Is(err, io.EOF) {
+				return mirrored.Bytes(), nil
+			}
+			return nil, err
+		}
+		if obj == nil { // empty documents
+			continue
+		}
+
+		mirrorContainers(obj)
+		document, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		mirrored.WriteString("---\n")
+		mirrored.Write(document)
+	}
+}
+
+// mirrorContainers rewrites the images of all the containers found in the
+// given value of an object.
+func mirrorContainers(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if containers, ok := field.([]interface{}); ok && containerFields[key] {
+				for _, container := range containers {
+					if container, ok := container.(map[string]interface{}); ok && container["imagePullPolicy"] != "Never" {
+						if image, ok := container["image"].(string); ok {
+							container["image"] = Mirror(image)
+						}
+					}
+				}
+			}
+			mirrorContainers(field)
+		}
+	case []interface{}:
+		for _, item := range v {
+			mirrorContainers(item)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package images
+
+import (
+	"os"
+	"strings"
+	"sync"
+)
+
+// -----------------------------------------------------------------------------
+// Registry Mirrors
+// -----------------------------------------------------------------------------
+
+const (
+	// EnvRegistryMirrors is the environment variable which can be used to
+	// configure registry mirrors as a comma separated list of registry=mirror
+	// pairs, e.g. "docker.io=proxy.example.com:5000,quay.io=quay.example.com".
+	EnvRegistryMirrors = "KTF_REGISTRY_MIRRORS"
+
+	// DefaultRegistry is the registry of images which don't specify one.
+	DefaultRegistry = "docker.io"
+)
+
+var (
+	mirrors     map[string]string
+	mirrorsLock sync.RWMutex
+	mirrorsInit sync.Once
+)
+
+// loadMirrors populates the mirrors from the environment, once.
+func loadMirrors() {
+	mirrorsInit.Do(func() {
+		mirrorsLock.Lock()
+		defer mirrorsLock.Unlock()
+
+		mirrors = make(map[string]string)
+		for _, pair := range strings.Split(os.Getenv(EnvRegistryMirrors), ",") {
+			registry, mirror, ok := strings.Cut(strings.TrimSpace(pair), "=")
+			if ok && registry != "" && mirror != "" {
+				mirrors[registry] = mirror
+			}
+		}
+	})
+}
+
+// SetRegistryMirror configures a mirror (a registry host, optionally with an
+// http:// or https:// scheme) to be used instead of the given registry (e.g.
+// "docker.io") for all images used by cluster builders and addons.
+// An empty mirror removes the mirror for the registry.
+func SetRegistryMirror(registry, mirror string) {
+	loadMirrors()
+	mirrorsLock.Lock()
+	defer mirrorsLock.Unlock()
+
+	if mirror == "" {
+		delete(mirrors, registry)
+		return
+	}
+	mirrors[registry] = mirror
+}
+
+// RegistryMirrors provides the configured registry mirrors keyed by the
+// registry they mirror.
+func RegistryMirrors() map[string]string {
+	loadMirrors()
+	mirrorsLock.RLock()
+	defer mirrorsLock.RUnlock()
+
+	copied := make(map[string]string, len(mirrors))
+	for registry, mirror := range mirrors {
+		copied[registry] = mirror
+	}
+	return copied
+}
+
+// Mirror provides the image reference to use for the given image, which is
+// the image from the mirror of its registry if one is configured.
+func Mirror(image string) string {
+	registry, path := SplitRegistry(image)
+	mirror, ok := RegistryMirrors()[registry]
+	if !ok {
+		return image
+	}
+
+	mirror = strings.TrimPrefix(strings.TrimPrefix(mirror, "https://"), "http://")
+	return strings.TrimSuffix(mirror, "/") + "/" + path
+}
+
+// SplitRegistry splits an image reference into its registry and the remaining
+// path, following the same rules as docker: images without a registry are
+// from docker.io, and official docker.io images are in the "library" namespace.
+func SplitRegistry(image string) (registry, path string) {
+	first, rest, found := strings.Cut(image, "/")
+	if found && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		registry, path = first, rest
+	} else {
+		registry, path = DefaultRegistry, image
+	}
+
+	if registry == "index.docker.io" {
+		registry = DefaultRegistry
+	}
+	if registry == DefaultRegistry && !strings.Contains(path, "/") {
+		path = "library/" + path
+	}
+	return registry, path
+}
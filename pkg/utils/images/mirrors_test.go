@@ -0,0 +1,92 @@
+package images
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMirror(t *testing.T) {
+	SetRegistryMirror("docker.io", "https://dockerhub.example.com")
+	SetRegistryMirror("quay.io", "quay.example.com:5000/")
+	defer SetRegistryMirror("docker.io", "")
+	defer SetRegistryMirror("quay.io", "")
+
+	for image, expected := range map[string]string{
+		"nginx":                             "dockerhub.example.com/library/nginx",
+		"kennethreitz/httpbin":              "dockerhub.example.com/kennethreitz/httpbin",
+		"docker.io/kong/kong-gateway:3.4":   "dockerhub.example.com/kong/kong-gateway:3.4",
+		"index.docker.io/library/redis:7":   "dockerhub.example.com/library/redis:7",
+		"quay.io/jetstack/cert-manager:1.0": "quay.example.com:5000/jetstack/cert-manager:1.0",
+		"gcr.io/distroless/static":          "gcr.io/distroless/static",
+		"localhost/test:latest":             "localhost/test:latest",
+	} {
+		assert.Equal(t, expected, Mirror(image), image)
+	}
+}
+
+func TestMirrorManifest(t *testing.T) {
+	manifest := []byte(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: proxy
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: init
+        image: busybox
+      containers:
+      - name: proxy
+        image: kong:3.4
+      - name: sidecar
+        image: gcr.io/distroless/static
+      - name: loaded
+        image: kong:3.4
+        imagePullPolicy: Never
+---
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+data:
+  image: kong:3.4
+`)
+
+	mirrored, err := MirrorManifest(manifest)
+	assert.NoError(t, err)
+	assert.Equal(t, manifest, mirrored, "manifests are provided as they are without mirrors")
+
+	SetRegistryMirror("docker.io", "dockerhub.example.com")
+	defer SetRegistryMirror("docker.io", "")
+	mirrored, err = MirrorManifest(manifest)
+	assert.NoError(t, err)
+	assert.Equal(t, `---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: proxy
+spec:
+  template:
+    spec:
+      containers:
+      - image: dockerhub.example.com/library/kong:3.4
+        name: proxy
+      - image: gcr.io/distroless/static
+        name: sidecar
+      - image: kong:3.4
+        imagePullPolicy: Never
+        name: loaded
+      initContainers:
+      - image: dockerhub.example.com/library/busybox
+        name: init
+---
+apiVersion: v1
+data:
+  image: kong:3.4
+kind: ConfigMap
+metadata:
+  name: config
+`, string(mirrored))
+}
@@ -0,0 +1,217 @@
+// Package events provides a Watcher which records the Kubernetes Events of a
+// namespace, so that tests can wait for and assert that an event occurred
+// instead of polling for it.
+package events
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// -----------------------------------------------------------------------------
+// Events - Filter
+// -----------------------------------------------------------------------------
+
+// Filter matches events, empty fields match any event.
+type Filter struct {
+	// Reason is the reason of the event, e.g. "BackOff".
+	Reason string
+
+	// Type is the type of the event, "Normal" or "Warning".
+	Type string
+
+	// Kind is the kind of the object the event is about, e.g. "Pod".
+	Kind string
+
+	// Name is the name of the object the event is about.
+	Name string
+
+	// Message is a substring of the message of the event.
+	Message string
+}
+
+// Matches indicates whether the event matches the filter.
+func (f Filter) Matches(event corev1.Event) bool {
+	return (f.Reason == "" || f.Reason == event.Reason) &&
+		(f.Type == "" || f.Type == event.Type) &&
+		(f.Kind == "" || f.Kind == event.InvolvedObject.Kind) &&
+		(f.Name == "" || f.Name == event.InvolvedObject.Name) &&
+		(f.Message == "" || strings.Contains(event.Message, f.Message))
+}
+
+// String describes the events the filter matches, e.g.
+// "Warning event with reason BackOff for Pod echo-1".
+func (f Filter) String() string {
+	description := "event"
+	if f.Type != "" {
+		description = f.Type + " " + description
+	}
+	if f.Reason != "" {
+		description += " with reason " + f.Reason
+	}
+	if f.Kind != "" || f.Name != "" {
+		description += " for " + strings.TrimSpace(f.Kind+" "+f.Name)
+	}
+	if f.Message != "" {
+		description += fmt.Sprintf(" with message containing %q", f.Message)
+	}
+	return description
+}
+
+// -----------------------------------------------------------------------------
+// Events - Watcher
+// -----------------------------------------------------------------------------
+
+// Watcher records the events of a namespace, those which exist when it starts
+// watching and those occurring until it is stopped.
+type Watcher struct {
+	namespace string
+	stop      context.CancelFunc
+
+	lock    sync.Mutex
+	events  map[string]corev1.Event
+	updated chan struct{}
+}
+
+// Watch starts recording the events of the namespace until the provided
+// context is done or the Watcher is stopped.
+func Watch(ctx context.Context, c kubernetes.Interface, namespace string) (*Watcher, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	w := &Watcher{
+		namespace: namespace,
+		stop:      cancel,
+		events:    make(map[string]corev1.Event),
+		updated:   make(chan struct{}),
+	}
+
+	factory := informers.NewSharedInformerFactoryWithOptions(c, 0, informers.WithNamespace(namespace))
+	informer := factory.Core().V1().Events().Informer()
+	if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    w.record,
+		UpdateFunc: func(_, obj interface{}) { w.record(obj) },
+	}); err != nil {
+		cancel()
+		return nil, err
+	}
+	factory.Start(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+		cancel()
+		return nil, fmt.Errorf("could not start watching events in namespace %s: %w", namespace, ctx.Err())
+	}
+	return w, nil
+}
+
+// Stop stops recording events.
+func (w *Watcher) Stop() {
+	w.stop()
+}
+
+// Events provides the recorded events, oldest first.
+func (w *Watcher) Events() []corev1.Event {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	events := make([]corev1.Event, 0, len(w.events))
+	for _, event := range w.events {
+		events = append(events, event)
+	}
+	SortByLastSeen(events)
+	return events
+}
+
+// Find provides the recorded events matching the filter, oldest first.
+func (w *Watcher) Find(filter Filter) []corev1.Event {
+	var matching []corev1.Event
+	for _, event := range w.Events() {
+		if filter.Matches(event) {
+			matching = append(matching, event)
+		}
+	}
+	return matching
+}
+
+// WaitFor waits for an event matching the filter to be recorded, or to have
+// been recorded already, until the context is done, and provides it.
+func (w *Watcher) WaitFor(ctx context.Context, filter Filter) (corev1.Event, error) {
+	for {
+		// the update channel is obtained before looking for the event, so
+		// that an event recorded in between isn't missed.
+		w.lock.Lock()
+		updated := w.updated
+		w.lock.Unlock()
+
+		if matching := w.Find(filter); len(matching) > 0 {
+			return matching[0], nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return corev1.Event{}, fmt.Errorf("no %s in namespace %s (%d events recorded): %w",
+				filter, w.namespace, len(w.Events()), ctx.Err())
+		case <-updated:
+		}
+	}
+}
+
+// RequireEvent fails the test unless an event matching the filter is
+// recorded within the given duration, and provides it.
+func (w *Watcher) RequireEvent(t testing.TB, filter Filter, within time.Duration) corev1.Event {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), within)
+	defer cancel()
+	event, err := w.WaitFor(ctx, filter)
+	if err != nil {
+		t.Fatalf("expected %s within %s: %v", filter, within, err)
+	}
+	return event
+}
+
+// -----------------------------------------------------------------------------
+// Events - Private
+// -----------------------------------------------------------------------------
+
+func (w *Watcher) record(obj interface{}) {
+	event, ok := obj.(*corev1.Event)
+	if !ok {
+		return
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.events[event.Name] = *event
+	close(w.updated)
+	w.updated = make(chan struct{})
+}
+
+// -----------------------------------------------------------------------------
+// Events - Ordering
+// -----------------------------------------------------------------------------
+
+// LastSeen provides the time the event was last seen, which is the time it
+// was emitted for events which don't report it.
+func LastSeen(event corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+// SortByLastSeen sorts events by the time they were last seen (see LastSeen),
+// keeping the order of events seen at the same time.
+func SortByLastSeen(events []corev1.Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return LastSeen(events[i]).Before(LastSeen(events[j]))
+	})
+}
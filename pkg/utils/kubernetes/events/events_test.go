@@ -0,0 +1,79 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func event(name, reason, kind, object string) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Namespace: "default", Name: name},
+		Reason:         reason,
+		Type:           corev1.EventTypeWarning,
+		Message:        "Back-off restarting failed container",
+		InvolvedObject: corev1.ObjectReference{Kind: kind, Name: object},
+	}
+}
+
+func TestFilter(t *testing.T) {
+	backOff := event("echo-1.1", "BackOff", "Pod", "echo-1")
+	assert.True(t, Filter{}.Matches(*backOff))
+	assert.True(t, Filter{Reason: "BackOff", Type: "Warning", Kind: "Pod", Name: "echo-1", Message: "restarting"}.Matches(*backOff))
+	assert.False(t, Filter{Reason: "Pulled"}.Matches(*backOff))
+	assert.False(t, Filter{Name: "echo-2"}.Matches(*backOff))
+	assert.False(t, Filter{Message: "pulling"}.Matches(*backOff))
+
+	assert.Equal(t, "Warning event with reason BackOff for Pod echo-1", Filter{Reason: "BackOff", Type: "Warning", Kind: "Pod", Name: "echo-1"}.String())
+	assert.Equal(t, `event for echo-1 with message containing "restarting"`, Filter{Name: "echo-1", Message: "restarting"}.String())
+}
+
+func TestWatcher(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	c := fake.NewSimpleClientset(event("echo-1.1", "Scheduled", "Pod", "echo-1"))
+
+	w, err := Watch(ctx, c, "default")
+	require.NoError(t, err)
+	defer w.Stop()
+
+	t.Log("verifying that existing events are recorded")
+	assert.Equal(t, "echo-1.1", w.RequireEvent(t, Filter{Reason: "Scheduled", Name: "echo-1"}, time.Second).Name)
+
+	t.Log("verifying that events occurring later are waited for")
+	go func() {
+		time.Sleep(time.Millisecond * 50)
+		_, err := c.CoreV1().Events("default").Create(ctx, event("echo-1.2", "BackOff", "Pod", "echo-1"), metav1.CreateOptions{})
+		assert.NoError(t, err)
+	}()
+	assert.Equal(t, "echo-1.2", w.RequireEvent(t, Filter{Reason: "BackOff", Kind: "Pod", Name: "echo-1"}, time.Second*5).Name)
+	assert.Len(t, w.Events(), 2)
+
+	t.Log("verifying that waiting gives up with a description of the expected event")
+	shortCtx, shortCancel := context.WithTimeout(ctx, time.Millisecond*50)
+	defer shortCancel()
+	_, err = w.WaitFor(shortCtx, Filter{Reason: "Killing", Name: "echo-1"})
+	assert.EqualError(t, err, "no event with reason Killing for echo-1 in namespace default (2 events recorded): context deadline exceeded")
+}
+
+func TestSortByLastSeen(t *testing.T) {
+	now := time.Now()
+	recorded := *event("recorded", "BackOff", "Pod", "echo")
+	recorded.LastTimestamp = metav1.NewTime(now.Add(time.Minute))
+	recorded.CreationTimestamp = metav1.NewTime(now.Add(-time.Hour))
+	emitted := *event("emitted", "Pulled", "Pod", "echo")
+	emitted.EventTime = metav1.NewMicroTime(now)
+	created := *event("created", "Scheduled", "Pod", "echo")
+	created.CreationTimestamp = metav1.NewTime(now.Add(-time.Minute))
+
+	assert.Equal(t, now.Add(time.Minute).Unix(), LastSeen(recorded).Unix())
+	sorted := []corev1.Event{recorded, emitted, created}
+	SortByLastSeen(sorted)
+	assert.Equal(t, []string{"created", "emitted", "recorded"}, []string{sorted[0].Name, sorted[1].Name, sorted[2].Name})
+}
@@ -0,0 +1,283 @@
+// Package gatewayapi provides builders for Gateway API objects and functions
+// waiting for them to be accepted and programmed by their controller.
+package gatewayapi
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// -----------------------------------------------------------------------------
+// Builders - GatewayClasses
+// -----------------------------------------------------------------------------
+
+// GatewayClassBuilder is a configuration tool for GatewayClasses.
+type GatewayClassBuilder struct {
+	gatewayClass gatewayv1.GatewayClass
+}
+
+// NewGatewayClassBuilder provides a new GatewayClassBuilder for a GatewayClass
+// of the given name managed by the given controller.
+func NewGatewayClassBuilder(name string, controllerName gatewayv1.GatewayController) *GatewayClassBuilder {
+	return &GatewayClassBuilder{gatewayClass: gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       gatewayv1.GatewayClassSpec{ControllerName: controllerName},
+	}}
+}
+
+// WithAnnotations adds annotations to the GatewayClass.
+func (b *GatewayClassBuilder) WithAnnotations(annotations map[string]string) *GatewayClassBuilder {
+	b.gatewayClass.Annotations = withAnnotations(b.gatewayClass.Annotations, annotations)
+	return b
+}
+
+// WithParametersRef references the controller specific configuration of the
+// GatewayClass.
+func (b *GatewayClassBuilder) WithParametersRef(ref gatewayv1.ParametersReference) *GatewayClassBuilder {
+	b.gatewayClass.Spec.ParametersRef = &ref
+	return b
+}
+
+// Build provides the GatewayClass.
+func (b *GatewayClassBuilder) Build() *gatewayv1.GatewayClass {
+	return b.gatewayClass.DeepCopy()
+}
+
+// -----------------------------------------------------------------------------
+// Builders - Gateways
+// -----------------------------------------------------------------------------
+
+// GatewayBuilder is a configuration tool for Gateways.
+type GatewayBuilder struct {
+	gateway              gatewayv1.Gateway
+	routesFromNamespaces gatewayv1.FromNamespaces
+}
+
+// NewGatewayBuilder provides a new GatewayBuilder for a Gateway of the given
+// namespace and name and of the given GatewayClass. The Gateway has no
+// listeners until some are added.
+func NewGatewayBuilder(namespace, name, gatewayClassName string) *GatewayBuilder {
+	return &GatewayBuilder{gateway: gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       gatewayv1.GatewaySpec{GatewayClassName: gatewayv1.ObjectName(gatewayClassName)},
+	}}
+}
+
+// WithAnnotations adds annotations to the Gateway.
+func (b *GatewayBuilder) WithAnnotations(annotations map[string]string) *GatewayBuilder {
+	b.gateway.Annotations = withAnnotations(b.gateway.Annotations, annotations)
+	return b
+}
+
+// WithHTTPListener adds a plain text HTTP listener on the given port.
+func (b *GatewayBuilder) WithHTTPListener(name string, port int32) *GatewayBuilder {
+	return b.WithListener(gatewayv1.Listener{
+		Name:     gatewayv1.SectionName(name),
+		Protocol: gatewayv1.HTTPProtocolType,
+		Port:     gatewayv1.PortNumber(port),
+	})
+}
+
+// WithHTTPSListener adds an HTTPS listener on the given port for the given
+// hostname (all hostnames if empty), terminating TLS with the certificate of
+// the Secret of the given name in the namespace of the Gateway.
+func (b *GatewayBuilder) WithHTTPSListener(name, hostname string, port int32, certificateSecret string) *GatewayBuilder {
+	listener := gatewayv1.Listener{
+		Name:     gatewayv1.SectionName(name),
+		Protocol: gatewayv1.HTTPSProtocolType,
+		Port:     gatewayv1.PortNumber(port),
+		TLS: &gatewayv1.GatewayTLSConfig{
+			Mode: ptr.To(gatewayv1.TLSModeTerminate),
+			CertificateRefs: []gatewayv1.SecretObjectReference{{
+				Name: gatewayv1.ObjectName(certificateSecret),
+			}},
+		},
+	}
+	if hostname != "" {
+		listener.Hostname = ptr.To(gatewayv1.Hostname(hostname))
+	}
+	return b.WithListener(listener)
+}
+
+// WithTCPListener adds a TCP listener on the given port, for TCPRoutes.
+func (b *GatewayBuilder) WithTCPListener(name string, port int32) *GatewayBuilder {
+	return b.WithListener(gatewayv1.Listener{
+		Name:     gatewayv1.SectionName(name),
+		Protocol: gatewayv1.TCPProtocolType,
+		Port:     gatewayv1.PortNumber(port),
+	})
+}
+
+// WithListener adds a listener to the Gateway, e.g. one which can't be
+// configured by the other options.
+func (b *GatewayBuilder) WithListener(listener gatewayv1.Listener) *GatewayBuilder {
+	b.gateway.Spec.Listeners = append(b.gateway.Spec.Listeners, listener)
+	return b
+}
+
+// WithRoutesFromAllNamespaces allows routes of all namespaces to attach to the
+// listeners of the Gateway which don't configure allowed routes. By default
+// only routes of the namespace of the Gateway can attach.
+func (b *GatewayBuilder) WithRoutesFromAllNamespaces() *GatewayBuilder {
+	b.routesFromNamespaces = gatewayv1.NamespacesFromAll
+	return b
+}
+
+// Build provides the Gateway.
+func (b *GatewayBuilder) Build() *gatewayv1.Gateway {
+	gateway := b.gateway.DeepCopy()
+	if b.routesFromNamespaces != "" {
+		for i := range gateway.Spec.Listeners {
+			if gateway.Spec.Listeners[i].AllowedRoutes == nil {
+				gateway.Spec.Listeners[i].AllowedRoutes = &gatewayv1.AllowedRoutes{
+					Namespaces: &gatewayv1.RouteNamespaces{From: ptr.To(b.routesFromNamespaces)},
+				}
+			}
+		}
+	}
+	return gateway
+}
+
+// -----------------------------------------------------------------------------
+// Builders - HTTPRoutes
+// -----------------------------------------------------------------------------
+
+// HTTPRouteBuilder is a configuration tool for HTTPRoutes.
+type HTTPRouteBuilder struct {
+	route gatewayv1.HTTPRoute
+}
+
+// NewHTTPRouteBuilder provides a new HTTPRouteBuilder for an HTTPRoute of the
+// given namespace and name.
+func NewHTTPRouteBuilder(namespace, name string) *HTTPRouteBuilder {
+	return &HTTPRouteBuilder{route: gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+	}}
+}
+
+// WithAnnotations adds annotations to the HTTPRoute.
+func (b *HTTPRouteBuilder) WithAnnotations(annotations map[string]string) *HTTPRouteBuilder {
+	b.route.Annotations = withAnnotations(b.route.Annotations, annotations)
+	return b
+}
+
+// WithParent attaches the HTTPRoute to the Gateway of the given namespace and
+// name, or only to its listener of the given name if not empty.
+func (b *HTTPRouteBuilder) WithParent(namespace, name, sectionName string) *HTTPRouteBuilder {
+	b.route.Spec.ParentRefs = append(b.route.Spec.ParentRefs, parentRef(namespace, name, sectionName))
+	return b
+}
+
+// WithHostnames limits the HTTPRoute to the given hostnames.
+func (b *HTTPRouteBuilder) WithHostnames(hostnames ...string) *HTTPRouteBuilder {
+	for _, hostname := range hostnames {
+		b.route.Spec.Hostnames = append(b.route.Spec.Hostnames, gatewayv1.Hostname(hostname))
+	}
+	return b
+}
+
+// WithPathPrefixRule adds a rule routing requests matching the path prefix to
+// the port of the Service of the given name, in the namespace of the HTTPRoute.
+func (b *HTTPRouteBuilder) WithPathPrefixRule(path, serviceName string, port int32) *HTTPRouteBuilder {
+	return b.WithRule(gatewayv1.HTTPRouteRule{
+		Matches: []gatewayv1.HTTPRouteMatch{{
+			Path: &gatewayv1.HTTPPathMatch{
+				Type:  ptr.To(gatewayv1.PathMatchPathPrefix),
+				Value: ptr.To(path),
+			},
+		}},
+		BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: backendRef(serviceName, port)}},
+	})
+}
+
+// WithRule adds a rule to the HTTPRoute, e.g. one which can't be configured by
+// the other options.
+func (b *HTTPRouteBuilder) WithRule(rule gatewayv1.HTTPRouteRule) *HTTPRouteBuilder {
+	b.route.Spec.Rules = append(b.route.Spec.Rules, rule)
+	return b
+}
+
+// Build provides the HTTPRoute.
+func (b *HTTPRouteBuilder) Build() *gatewayv1.HTTPRoute {
+	return b.route.DeepCopy()
+}
+
+// -----------------------------------------------------------------------------
+// Builders - TCPRoutes
+// -----------------------------------------------------------------------------
+
+// TCPRouteBuilder is a configuration tool for TCPRoutes.
+type TCPRouteBuilder struct {
+	route gatewayv1alpha2.TCPRoute
+}
+
+// NewTCPRouteBuilder provides a new TCPRouteBuilder for a TCPRoute of the
+// given namespace and name.
+func NewTCPRouteBuilder(namespace, name string) *TCPRouteBuilder {
+	return &TCPRouteBuilder{route: gatewayv1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+	}}
+}
+
+// WithAnnotations adds annotations to the TCPRoute.
+func (b *TCPRouteBuilder) WithAnnotations(annotations map[string]string) *TCPRouteBuilder {
+	b.route.Annotations = withAnnotations(b.route.Annotations, annotations)
+	return b
+}
+
+// WithParent attaches the TCPRoute to the Gateway of the given namespace and
+// name, or only to its listener of the given name if not empty.
+func (b *TCPRouteBuilder) WithParent(namespace, name, sectionName string) *TCPRouteBuilder {
+	b.route.Spec.ParentRefs = append(b.route.Spec.ParentRefs, parentRef(namespace, name, sectionName))
+	return b
+}
+
+// WithBackend adds a rule routing connections to the port of the Service of
+// the given name, in the namespace of the TCPRoute.
+func (b *TCPRouteBuilder) WithBackend(serviceName string, port int32) *TCPRouteBuilder {
+	b.route.Spec.Rules = append(b.route.Spec.Rules, gatewayv1alpha2.TCPRouteRule{
+		BackendRefs: []gatewayv1alpha2.BackendRef{backendRef(serviceName, port)},
+	})
+	return b
+}
+
+// Build provides the TCPRoute.
+func (b *TCPRouteBuilder) Build() *gatewayv1alpha2.TCPRoute {
+	return b.route.DeepCopy()
+}
+
+// -----------------------------------------------------------------------------
+// Builders - Private Functions
+// -----------------------------------------------------------------------------
+
+func withAnnotations(current, annotations map[string]string) map[string]string {
+	if current == nil {
+		current = make(map[string]string, len(annotations))
+	}
+	for k, v := range annotations {
+		current[k] = v
+	}
+	return current
+}
+
+func parentRef(namespace, name, sectionName string) gatewayv1.ParentReference {
+	ref := gatewayv1.ParentReference{Name: gatewayv1.ObjectName(name)}
+	if namespace != "" {
+		ref.Namespace = ptr.To(gatewayv1.Namespace(namespace))
+	}
+	if sectionName != "" {
+		ref.SectionName = ptr.To(gatewayv1.SectionName(sectionName))
+	}
+	return ref
+}
+
+func backendRef(serviceName string, port int32) gatewayv1.BackendRef {
+	return gatewayv1.BackendRef{
+		BackendObjectReference: gatewayv1.BackendObjectReference{
+			Name: gatewayv1.ObjectName(serviceName),
+			Port: ptr.To(gatewayv1.PortNumber(port)),
+		},
+	}
+}
@@ -0,0 +1,157 @@
+package gatewayapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayfake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
+)
+
+// shortly provides a context which gives up waiting quickly.
+func shortly(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func condition(conditionType string, status metav1.ConditionStatus, observedGeneration int64) metav1.Condition {
+	return metav1.Condition{Type: conditionType, Status: status, ObservedGeneration: observedGeneration, Reason: "Testing"}
+}
+
+func TestBuilders(t *testing.T) {
+	gatewayClass := NewGatewayClassBuilder("kong", "konghq.com/gateway-operator").
+		WithAnnotations(map[string]string{"a": "b"}).
+		Build()
+	assert.Equal(t, gatewayv1.GatewayController("konghq.com/gateway-operator"), gatewayClass.Spec.ControllerName)
+	assert.Equal(t, map[string]string{"a": "b"}, gatewayClass.Annotations)
+
+	gateway := NewGatewayBuilder("kong", "gateway", "kong").
+		WithHTTPListener("http", 80).
+		WithHTTPSListener("https", "example.com", 443, "example-tls").
+		WithTCPListener("tcp", 8888).
+		WithRoutesFromAllNamespaces().
+		Build()
+	assert.Equal(t, gatewayv1.ObjectName("kong"), gateway.Spec.GatewayClassName)
+	require.Len(t, gateway.Spec.Listeners, 3)
+	https := gateway.Spec.Listeners[1]
+	assert.Equal(t, gatewayv1.HTTPSProtocolType, https.Protocol)
+	assert.Equal(t, gatewayv1.Hostname("example.com"), *https.Hostname)
+	assert.Equal(t, gatewayv1.ObjectName("example-tls"), https.TLS.CertificateRefs[0].Name)
+	assert.Equal(t, gatewayv1.TCPProtocolType, gateway.Spec.Listeners[2].Protocol)
+	for _, listener := range gateway.Spec.Listeners {
+		assert.Equal(t, gatewayv1.NamespacesFromAll, *listener.AllowedRoutes.Namespaces.From)
+	}
+
+	httpRoute := NewHTTPRouteBuilder("default", "echo").
+		WithParent("kong", "gateway", "http").
+		WithHostnames("example.com").
+		WithPathPrefixRule("/echo", "echo", 1027).
+		Build()
+	require.Len(t, httpRoute.Spec.ParentRefs, 1)
+	assert.Equal(t, gatewayv1.Namespace("kong"), *httpRoute.Spec.ParentRefs[0].Namespace)
+	assert.Equal(t, gatewayv1.SectionName("http"), *httpRoute.Spec.ParentRefs[0].SectionName)
+	assert.Equal(t, []gatewayv1.Hostname{"example.com"}, httpRoute.Spec.Hostnames)
+	assert.Equal(t, "/echo", *httpRoute.Spec.Rules[0].Matches[0].Path.Value)
+	assert.Equal(t, gatewayv1.PortNumber(1027), *httpRoute.Spec.Rules[0].BackendRefs[0].Port)
+
+	tcpRoute := NewTCPRouteBuilder("default", "echo").
+		WithParent("kong", "gateway", "").
+		WithBackend("echo", 1028).
+		Build()
+	assert.Nil(t, tcpRoute.Spec.ParentRefs[0].SectionName)
+	assert.Equal(t, gatewayv1.ObjectName("echo"), tcpRoute.Spec.Rules[0].BackendRefs[0].Name)
+}
+
+func TestBuildersBuildCopies(t *testing.T) {
+	builder := NewGatewayBuilder("kong", "gateway", "kong").WithHTTPListener("http", 80)
+	first := builder.Build()
+	second := builder.WithTCPListener("tcp", 8888).Build()
+	assert.Len(t, first.Spec.Listeners, 1)
+	assert.Len(t, second.Spec.Listeners, 2)
+}
+
+func TestWaitForGatewayConditions(t *testing.T) {
+	gatewayClass := NewGatewayClassBuilder("kong", "konghq.com/gateway-operator").Build()
+	gatewayClass.Status.Conditions = []metav1.Condition{condition("Accepted", metav1.ConditionTrue, 0)}
+
+	gateway := NewGatewayBuilder("kong", "gateway", "kong").WithHTTPListener("http", 80).Build()
+	gateway.Generation = 2
+	gateway.Status.Conditions = []metav1.Condition{
+		condition("Accepted", metav1.ConditionTrue, 2),
+		condition("Programmed", metav1.ConditionFalse, 2),
+	}
+	outdated := NewGatewayBuilder("kong", "outdated", "kong").Build()
+	outdated.Generation = 3
+	outdated.Status.Conditions = []metav1.Condition{condition("Accepted", metav1.ConditionTrue, 2)}
+
+	// v1beta1 aliases the v1 types, so the fake clientset can't be seeded with
+	// them (they'd be tracked as v1beta1 objects), they're created instead.
+	c := gatewayfake.NewSimpleClientset()
+	_, err := c.GatewayV1().GatewayClasses().Create(context.Background(), gatewayClass, metav1.CreateOptions{})
+	require.NoError(t, err)
+	for _, gw := range []*gatewayv1.Gateway{gateway, outdated} {
+		_, err := c.GatewayV1().Gateways("kong").Create(context.Background(), gw, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	_, err = WaitForGatewayClassAccepted(shortly(t), c, "kong")
+	require.NoError(t, err)
+
+	accepted, err := WaitForGatewayAccepted(shortly(t), c, "kong", "gateway")
+	require.NoError(t, err)
+	assert.Equal(t, "gateway", accepted.Name)
+
+	_, err = WaitForGatewayProgrammed(shortly(t), c, "kong", "gateway")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "Gateway kong/gateway to be Programmed (Programmed condition is False (Testing)")
+
+	_, err = WaitForGatewayAccepted(shortly(t), c, "kong", "outdated")
+	assert.ErrorContains(t, err, "Accepted condition observed generation 2, not 3")
+
+	_, err = WaitForGatewayAccepted(shortly(t), c, "kong", "missing")
+	assert.ErrorContains(t, err, "(not found)")
+}
+
+func TestWaitForRoutesAccepted(t *testing.T) {
+	accepted := NewHTTPRouteBuilder("default", "accepted").
+		WithParent("kong", "gateway", "").
+		WithParent("kong", "other", "").
+		Build()
+	for _, name := range []gatewayv1.ObjectName{"gateway", "other"} {
+		accepted.Status.Parents = append(accepted.Status.Parents, gatewayv1.RouteParentStatus{
+			ParentRef:  gatewayv1.ParentReference{Name: name},
+			Conditions: []metav1.Condition{condition("Accepted", metav1.ConditionTrue, 0)},
+		})
+	}
+	pending := NewHTTPRouteBuilder("default", "pending").
+		WithParent("kong", "gateway", "").
+		WithParent("kong", "other", "").
+		Build()
+	pending.Status.Parents = accepted.Status.Parents[:1]
+
+	tcpRoute := NewTCPRouteBuilder("default", "tcp").WithParent("kong", "gateway", "").Build()
+	tcpRoute.Status.Parents = []gatewayv1.RouteParentStatus{{
+		ParentRef:  gatewayv1.ParentReference{Name: "gateway"},
+		Conditions: []metav1.Condition{condition("Accepted", metav1.ConditionFalse, 0)},
+	}}
+
+	c := gatewayfake.NewSimpleClientset(tcpRoute)
+	for _, route := range []*gatewayv1.HTTPRoute{accepted, pending} {
+		_, err := c.GatewayV1().HTTPRoutes("default").Create(context.Background(), route, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	_, err := WaitForHTTPRouteAccepted(shortly(t), c, "default", "accepted")
+	require.NoError(t, err)
+
+	_, err = WaitForHTTPRouteAccepted(shortly(t), c, "default", "pending")
+	assert.ErrorContains(t, err, "1 of 2 parents reported a status")
+
+	_, err = WaitForTCPRouteAccepted(shortly(t), c, "default", "tcp")
+	assert.ErrorContains(t, err, "parent gateway: Accepted condition is False")
+}
@@ -0,0 +1,142 @@
+package gatewayapi
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayclient "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/wait"
+)
+
+// -----------------------------------------------------------------------------
+// Wait - GatewayClasses and Gateways
+// -----------------------------------------------------------------------------
+
+// WaitForGatewayClassAccepted waits for a GatewayClass to be accepted by its
+// controller, and provides it.
+func WaitForGatewayClassAccepted(ctx context.Context, c gatewayclient.Interface, name string) (*gatewayv1.GatewayClass, error) {
+	var gatewayClass *gatewayv1.GatewayClass
+	err := wait.Until(ctx, fmt.Sprintf("GatewayClass %s to be accepted", name), func(ctx context.Context) (bool, string, error) {
+		var err error
+		gatewayClass, err = c.GatewayV1().GatewayClasses().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return false, "not found", nil
+			}
+			return false, "", err
+		}
+		return conditionMet(gatewayClass.Status.Conditions, string(gatewayv1.GatewayClassConditionStatusAccepted), gatewayClass.Generation)
+	})
+	return gatewayClass, err
+}
+
+// WaitForGatewayAccepted waits for a Gateway to be accepted by the controller
+// of its GatewayClass, and provides it. The Gateway may not serve traffic yet,
+// see WaitForGatewayProgrammed.
+func WaitForGatewayAccepted(ctx context.Context, c gatewayclient.Interface, namespace, name string) (*gatewayv1.Gateway, error) {
+	return waitForGatewayCondition(ctx, c, namespace, name, gatewayv1.GatewayConditionAccepted)
+}
+
+// WaitForGatewayProgrammed waits for a Gateway to be programmed in its data
+// plane, meaning it serves traffic, and provides it. Its addresses are then
+// provided by its status.
+func WaitForGatewayProgrammed(ctx context.Context, c gatewayclient.Interface, namespace, name string) (*gatewayv1.Gateway, error) {
+	return waitForGatewayCondition(ctx, c, namespace, name, gatewayv1.GatewayConditionProgrammed)
+}
+
+func waitForGatewayCondition(ctx context.Context, c gatewayclient.Interface, namespace, name string, conditionType gatewayv1.GatewayConditionType) (*gatewayv1.Gateway, error) {
+	var gateway *gatewayv1.Gateway
+	description := fmt.Sprintf("Gateway %s/%s to be %s", namespace, name, conditionType)
+	err := wait.Until(ctx, description, func(ctx context.Context) (bool, string, error) {
+		var err error
+		gateway, err = c.GatewayV1().Gateways(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return false, "not found", nil
+			}
+			return false, "", err
+		}
+		return conditionMet(gateway.Status.Conditions, string(conditionType), gateway.Generation)
+	})
+	return gateway, err
+}
+
+// -----------------------------------------------------------------------------
+// Wait - Routes
+// -----------------------------------------------------------------------------
+
+// WaitForHTTPRouteAccepted waits for an HTTPRoute to be accepted by all its
+// parents, and provides it.
+func WaitForHTTPRouteAccepted(ctx context.Context, c gatewayclient.Interface, namespace, name string) (*gatewayv1.HTTPRoute, error) {
+	var route *gatewayv1.HTTPRoute
+	err := wait.Until(ctx, fmt.Sprintf("HTTPRoute %s/%s to be accepted", namespace, name), func(ctx context.Context) (bool, string, error) {
+		var err error
+		route, err = c.GatewayV1().HTTPRoutes(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return false, "not found", nil
+			}
+			return false, "", err
+		}
+		return routeAccepted(route.Spec.ParentRefs, route.Status.RouteStatus, route.Generation)
+	})
+	return route, err
+}
+
+// WaitForTCPRouteAccepted waits for a TCPRoute to be accepted by all its
+// parents, and provides it.
+func WaitForTCPRouteAccepted(ctx context.Context, c gatewayclient.Interface, namespace, name string) (*gatewayv1alpha2.TCPRoute, error) {
+	var route *gatewayv1alpha2.TCPRoute
+	err := wait.Until(ctx, fmt.Sprintf("TCPRoute %s/%s to be accepted", namespace, name), func(ctx context.Context) (bool, string, error) {
+		var err error
+		route, err = c.GatewayV1alpha2().TCPRoutes(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return false, "not found", nil
+			}
+			return false, "", err
+		}
+		return routeAccepted(route.Spec.ParentRefs, route.Status.RouteStatus, route.Generation)
+	})
+	return route, err
+}
+
+// -----------------------------------------------------------------------------
+// Wait - Private Functions
+// -----------------------------------------------------------------------------
+
+// conditionMet checks whether the condition of the given type is true and was
+// observed for the current generation of its object.
+func conditionMet(conditions []metav1.Condition, conditionType string, generation int64) (bool, string, error) {
+	condition := meta.FindStatusCondition(conditions, conditionType)
+	if condition == nil {
+		return false, fmt.Sprintf("no %s condition", conditionType), nil
+	}
+	if condition.ObservedGeneration < generation {
+		return false, fmt.Sprintf("%s condition observed generation %d, not %d", conditionType, condition.ObservedGeneration, generation), nil
+	}
+	if condition.Status != metav1.ConditionTrue {
+		return false, fmt.Sprintf("%s condition is %s (%s): %s", conditionType, condition.Status, condition.Reason, condition.Message), nil
+	}
+	return true, "", nil
+}
+
+// routeAccepted checks whether all the parents of a route accepted it.
+func routeAccepted(parentRefs []gatewayv1.ParentReference, status gatewayv1.RouteStatus, generation int64) (bool, string, error) {
+	if len(status.Parents) < len(parentRefs) {
+		return false, fmt.Sprintf("%d of %d parents reported a status", len(status.Parents), len(parentRefs)), nil
+	}
+	for _, parent := range status.Parents {
+		ok, state, err := conditionMet(parent.Conditions, string(gatewayv1.RouteConditionAccepted), generation)
+		if !ok || err != nil {
+			return ok, fmt.Sprintf("parent %s: %s", parent.ParentRef.Name, state), err
+		}
+	}
+	return true, "", nil
+}
@@ -2,17 +2,20 @@ package generators
 
 import (
 	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/images"
 )
 
 // -----------------------------------------------------------------------------
 // Public Functions - corev1.Container Helpers
 // -----------------------------------------------------------------------------
 
-// NewContainer creates a minimal and opinionated corev1.Container object for testing
+// NewContainer creates a minimal and opinionated corev1.Container object for testing.
+// The image is pulled from the configured registry mirror, if any.
 func NewContainer(name, image string, port int32) corev1.Container {
 	return corev1.Container{
 		Name:  name,
-		Image: image,
+		Image: images.Mirror(image),
 		Ports: []corev1.ContainerPort{{ContainerPort: port}},
 	}
 }
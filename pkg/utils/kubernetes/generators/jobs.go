@@ -8,6 +8,8 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/images"
 )
 
 var (
@@ -52,7 +54,7 @@ func GenerateBashJob(image, imageTag string, commands ...string) (*corev1.Config
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{{
 						Name:    jobName,
-						Image:   fmt.Sprintf("%s:%s", image, imageTag),
+						Image:   images.Mirror(fmt.Sprintf("%s:%s", image, imageTag)),
 						Command: []string{"/bin/bash", mountPath},
 						VolumeMounts: []corev1.VolumeMount{{
 							Name:      jobName,
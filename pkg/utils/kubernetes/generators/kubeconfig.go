@@ -14,21 +14,43 @@ func NewKubeConfigForRestConfig(name string, restcfg *rest.Config) ([]byte, erro
 }
 
 // NewClientConfigForRestConfig provides the *clientcmdapi.Config for a cluster
-// given a valid *rest.Config for the target cluster.
+// given a valid *rest.Config for the target cluster. Credentials provided as
+// files or by an exec plugin are referenced as such.
 func NewClientConfigForRestConfig(name string, restcfg *rest.Config) *clientcmdapi.Config {
 	// configure the cluster
 	cluster := clientcmdapi.NewCluster()
 	cluster.CertificateAuthorityData = restcfg.CAData
+	cluster.CertificateAuthority = restcfg.CAFile
+	cluster.InsecureSkipTLSVerify = restcfg.Insecure
+	cluster.TLSServerName = restcfg.ServerName
 	cluster.Server = restcfg.Host
 
 	// configure the authdata
 	authinfo := clientcmdapi.NewAuthInfo()
 	authinfo.AuthProvider = restcfg.AuthProvider
 	authinfo.ClientCertificateData = restcfg.CertData
+	authinfo.ClientCertificate = restcfg.CertFile
 	authinfo.ClientKeyData = restcfg.KeyData
+	authinfo.ClientKey = restcfg.KeyFile
 	authinfo.Username = restcfg.Username
 	authinfo.Password = restcfg.Password
 	authinfo.Token = restcfg.BearerToken
+	authinfo.TokenFile = restcfg.BearerTokenFile
+	authinfo.Impersonate = restcfg.Impersonate.UserName
+	authinfo.ImpersonateUID = restcfg.Impersonate.UID
+	authinfo.ImpersonateGroups = restcfg.Impersonate.Groups
+	authinfo.ImpersonateUserExtra = restcfg.Impersonate.Extra
+	if exec := restcfg.ExecProvider; exec != nil {
+		authinfo.Exec = &clientcmdapi.ExecConfig{
+			Command:            exec.Command,
+			Args:               exec.Args,
+			Env:                exec.Env,
+			APIVersion:         exec.APIVersion,
+			InstallHint:        exec.InstallHint,
+			ProvideClusterInfo: exec.ProvideClusterInfo,
+			InteractiveMode:    exec.InteractiveMode,
+		}
+	}
 
 	// configure the current context
 	context := clientcmdapi.NewContext()
@@ -0,0 +1,153 @@
+package generators
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/images"
+)
+
+// -----------------------------------------------------------------------------
+// Public Functions - Objects With Options
+// -----------------------------------------------------------------------------
+
+// appLabel is the label selecting the pods of generated Deployments.
+const appLabel = "app"
+
+// NewDeployment provides a Deployment of the given name, running a container
+// of the same name configured by the options (see Option).
+func NewDeployment(name string, opts ...Option) *appsv1.Deployment {
+	o := newOptions(opts)
+
+	ports := make([]corev1.ContainerPort, 0, len(o.ports))
+	for _, port := range o.ports {
+		ports = append(ports, corev1.ContainerPort{Name: portName(port), ContainerPort: port, Protocol: corev1.ProtocolTCP})
+	}
+	selector := map[string]string{appLabel: name}
+
+	return &appsv1.Deployment{
+		ObjectMeta: o.objectMeta(name),
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(o.replicas),
+			Selector: &metav1.LabelSelector{MatchLabels: selector},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: o.labelsFor(name)},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  name,
+						Image: images.Mirror(o.image),
+						Args:  o.args,
+						Env:   o.env,
+						Ports: ports,
+					}},
+				},
+			},
+		},
+	}
+}
+
+// NewService provides a Service of the given name exposing the pods of the
+// Deployment of the same name (see NewDeployment), configured by the options.
+func NewService(name string, opts ...Option) *corev1.Service {
+	o := newOptions(opts)
+
+	ports := make([]corev1.ServicePort, 0, len(o.ports))
+	for _, port := range o.ports {
+		ports = append(ports, corev1.ServicePort{
+			Name:       portName(port),
+			Protocol:   corev1.ProtocolTCP,
+			Port:       port,
+			TargetPort: intstr.FromInt32(port),
+		})
+	}
+
+	return &corev1.Service{
+		ObjectMeta: o.objectMeta(name),
+		Spec: corev1.ServiceSpec{
+			Type:     o.serviceType,
+			Selector: map[string]string{appLabel: name},
+			Ports:    ports,
+		},
+	}
+}
+
+// NewIngress provides an Ingress of the given name routing requests to the
+// first port of the Service of the same name (see NewService), configured by
+// the options.
+func NewIngress(name string, opts ...Option) *netv1.Ingress {
+	o := newOptions(opts)
+	backend := name
+	if o.ingressBackend != "" {
+		backend = o.ingressBackend
+	}
+
+	ingress := &netv1.Ingress{
+		ObjectMeta: o.objectMeta(name),
+		Spec: netv1.IngressSpec{
+			Rules: []netv1.IngressRule{{
+				Host: o.host,
+				IngressRuleValue: netv1.IngressRuleValue{
+					HTTP: &netv1.HTTPIngressRuleValue{
+						Paths: []netv1.HTTPIngressPath{{
+							Path:     o.path,
+							PathType: ptr.To(netv1.PathTypePrefix),
+							Backend: netv1.IngressBackend{
+								Service: &netv1.IngressServiceBackend{
+									Name: backend,
+									Port: netv1.ServiceBackendPort{Number: o.ports[0]},
+								},
+							},
+						}},
+					},
+				},
+			}},
+		},
+	}
+	if o.ingressClass != "" {
+		ingress.Spec.IngressClassName = ptr.To(o.ingressClass)
+	}
+	if o.tlsSecret != "" {
+		tls := netv1.IngressTLS{SecretName: o.tlsSecret}
+		if o.host != "" {
+			tls.Hosts = []string{o.host}
+		}
+		ingress.Spec.TLS = []netv1.IngressTLS{tls}
+	}
+	return ingress
+}
+
+// -----------------------------------------------------------------------------
+// Private Functions - Objects With Options
+// -----------------------------------------------------------------------------
+
+// objectMeta provides the metadata of a generated object of the given name.
+func (o *options) objectMeta(name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        name,
+		Namespace:   o.namespace,
+		Labels:      o.labelsFor(name),
+		Annotations: o.annotations,
+	}
+}
+
+// labelsFor provides the labels of the generated objects of the given name,
+// including the label selecting the pods of their Deployment.
+func (o *options) labelsFor(name string) map[string]string {
+	labels := make(map[string]string, len(o.labels)+1)
+	for k, v := range o.labels {
+		labels[k] = v
+	}
+	labels[appLabel] = name
+	return labels
+}
+
+// portName provides the name of a container and service port.
+func portName(port int32) string {
+	return fmt.Sprintf("port-%d", port)
+}
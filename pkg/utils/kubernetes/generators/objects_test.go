@@ -0,0 +1,70 @@
+package generators
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestObjectsWithOptions(t *testing.T) {
+	opts := []Option{
+		WithNamespace("echo"),
+		WithImage("kong/go-echo:0.3.0"),
+		WithEnv("POD_NAME", "echo"),
+		WithReplicas(2),
+		WithPorts(1027, 1028),
+		WithLabels(map[string]string{"team": "gateway"}),
+		WithAnnotations(map[string]string{"konghq.com/strip-path": "true"}),
+		WithServiceType(corev1.ServiceTypeLoadBalancer),
+		WithIngressClass("kong"),
+		WithHost("echo.example.com"),
+		WithPath("/echo"),
+		WithTLSSecret("echo-tls"),
+	}
+
+	deployment := NewDeployment("echo", opts...)
+	assert.Equal(t, "echo", deployment.Namespace)
+	assert.Equal(t, map[string]string{"app": "echo", "team": "gateway"}, deployment.Labels)
+	assert.Equal(t, int32(2), *deployment.Spec.Replicas)
+	assert.Equal(t, map[string]string{"app": "echo"}, deployment.Spec.Selector.MatchLabels)
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "kong/go-echo:0.3.0", container.Image)
+	assert.Equal(t, []corev1.EnvVar{{Name: "POD_NAME", Value: "echo"}}, container.Env)
+	require.Len(t, container.Ports, 2)
+	assert.Equal(t, int32(1028), container.Ports[1].ContainerPort)
+
+	service := NewService("echo", opts...)
+	assert.Equal(t, corev1.ServiceTypeLoadBalancer, service.Spec.Type)
+	assert.Equal(t, deployment.Spec.Selector.MatchLabels, service.Spec.Selector)
+	require.Len(t, service.Spec.Ports, 2)
+	assert.Equal(t, intstr.FromInt32(1027), service.Spec.Ports[0].TargetPort)
+
+	ingress := NewIngress("echo", opts...)
+	assert.Equal(t, "kong", *ingress.Spec.IngressClassName)
+	assert.Equal(t, map[string]string{"konghq.com/strip-path": "true"}, ingress.Annotations)
+	assert.Equal(t, []netv1.IngressTLS{{Hosts: []string{"echo.example.com"}, SecretName: "echo-tls"}}, ingress.Spec.TLS)
+	rule := ingress.Spec.Rules[0]
+	assert.Equal(t, "echo.example.com", rule.Host)
+	assert.Equal(t, "/echo", rule.HTTP.Paths[0].Path)
+	assert.Equal(t, netv1.ServiceBackendPort{Number: 1027}, rule.HTTP.Paths[0].Backend.Service.Port)
+}
+
+func TestObjectsDefaults(t *testing.T) {
+	deployment := NewDeployment("httpbin")
+	assert.Equal(t, DefaultImage, deployment.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, int32(1), *deployment.Spec.Replicas)
+
+	service := NewService("httpbin")
+	assert.Equal(t, corev1.ServiceTypeClusterIP, service.Spec.Type)
+	assert.Equal(t, DefaultPort, service.Spec.Ports[0].Port)
+
+	ingress := NewIngress("httpbin", WithPorts())
+	assert.Nil(t, ingress.Spec.IngressClassName)
+	assert.Empty(t, ingress.Spec.TLS)
+	assert.Equal(t, "/", ingress.Spec.Rules[0].HTTP.Paths[0].Path)
+	assert.Equal(t, DefaultPort, ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port.Number)
+}
@@ -0,0 +1,156 @@
+package generators
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// -----------------------------------------------------------------------------
+// Public Types - Generator Options
+// -----------------------------------------------------------------------------
+
+const (
+	// DefaultImage is the image of generated Deployments which don't set one,
+	// an HTTP server useful as a backend of ingress tests.
+	DefaultImage = "kennethreitz/httpbin"
+
+	// DefaultPort is the port of generated objects which don't set any.
+	DefaultPort int32 = 80
+)
+
+// Option configures the objects generated by NewDeployment, NewService and
+// NewIngress. Each generator applies the options relevant to its object and
+// ignores the others, so the same options can describe a whole application.
+type Option func(*options)
+
+// options are the configuration of generated objects.
+type options struct {
+	namespace   string
+	labels      map[string]string
+	annotations map[string]string
+
+	image    string
+	args     []string
+	env      []corev1.EnvVar
+	replicas int32
+	ports    []int32
+
+	serviceType corev1.ServiceType
+
+	ingressClass string
+	host         string
+	path         string
+	tlsSecret    string
+
+	// ingressBackend is the Service Ingresses route to, if it isn't the
+	// Service of the same name, see Route.Ingress.
+	ingressBackend string
+}
+
+// newOptions provides the configuration resulting from the options.
+func newOptions(opts []Option) *options {
+	o := &options{
+		image:       DefaultImage,
+		replicas:    1,
+		ports:       []int32{DefaultPort},
+		serviceType: corev1.ServiceTypeClusterIP,
+		path:        "/",
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithNamespace sets the namespace of generated objects.
+func WithNamespace(namespace string) Option {
+	return func(o *options) { o.namespace = namespace }
+}
+
+// WithLabels adds labels to generated objects, in addition to the "app" label
+// selecting the pods of a Deployment.
+func WithLabels(labels map[string]string) Option {
+	return func(o *options) {
+		if o.labels == nil {
+			o.labels = make(map[string]string, len(labels))
+		}
+		for k, v := range labels {
+			o.labels[k] = v
+		}
+	}
+}
+
+// WithAnnotations adds annotations to generated objects, e.g. to configure
+// the ingress controller.
+func WithAnnotations(annotations map[string]string) Option {
+	return func(o *options) {
+		if o.annotations == nil {
+			o.annotations = make(map[string]string, len(annotations))
+		}
+		for k, v := range annotations {
+			o.annotations[k] = v
+		}
+	}
+}
+
+// WithImage sets the image of Deployments, DefaultImage by default. The image
+// is pulled from the configured registry mirror, if any.
+func WithImage(image string) Option {
+	return func(o *options) { o.image = image }
+}
+
+// WithArgs sets the arguments of the container of Deployments.
+func WithArgs(args ...string) Option {
+	return func(o *options) { o.args = args }
+}
+
+// WithEnv adds an environment variable to the container of Deployments.
+func WithEnv(name, value string) Option {
+	return func(o *options) { o.env = append(o.env, corev1.EnvVar{Name: name, Value: value}) }
+}
+
+// WithReplicas sets the number of replicas of Deployments, 1 by default.
+func WithReplicas(replicas int32) Option {
+	return func(o *options) { o.replicas = replicas }
+}
+
+// WithPorts sets the container ports of Deployments, which are exposed by
+// Services on the same ports. The first port is the backend of Ingresses.
+// DefaultPort is used by default, or if no ports are provided.
+func WithPorts(ports ...int32) Option {
+	return func(o *options) {
+		if len(ports) > 0 {
+			o.ports = ports
+		}
+	}
+}
+
+// WithServiceType sets the type of Services, ClusterIP by default.
+func WithServiceType(serviceType corev1.ServiceType) Option {
+	return func(o *options) { o.serviceType = serviceType }
+}
+
+// WithIngressClass sets the class of Ingresses.
+func WithIngressClass(class string) Option {
+	return func(o *options) { o.ingressClass = class }
+}
+
+// WithHost limits Ingresses to the given host, they match all hosts by default.
+func WithHost(host string) Option {
+	return func(o *options) { o.host = host }
+}
+
+// WithPath sets the path prefix matched by Ingresses, "/" by default.
+func WithPath(path string) Option {
+	return func(o *options) { o.path = path }
+}
+
+// withIngressBackend sets the Service Ingresses route to.
+func withIngressBackend(serviceName string) Option {
+	return func(o *options) { o.ingressBackend = serviceName }
+}
+
+// WithTLSSecret terminates TLS for the host of Ingresses with the certificate
+// of the Secret of the given name.
+func WithTLSSecret(secretName string) Option {
+	return func(o *options) { o.tlsSecret = secretName }
+}
@@ -0,0 +1,127 @@
+package generators
+
+import (
+	"fmt"
+
+	netv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/kubernetes/gatewayapi"
+)
+
+// -----------------------------------------------------------------------------
+// Public Types - Routes
+// -----------------------------------------------------------------------------
+
+// Route describes an HTTP route to a Service independently of the API
+// expressing it, so that the same test can run against both Ingresses and
+// Gateway API HTTPRoutes.
+type Route struct {
+	// Name and Namespace are the name and namespace of the route object.
+	Name      string
+	Namespace string
+
+	// Host limits the route to the given host, it matches all hosts if empty.
+	Host string
+
+	// Path is the path prefix matched by the route, "/" if empty.
+	Path string
+
+	// ServiceName and ServicePort are the Service (in the namespace of the
+	// route) and port requests are routed to.
+	ServiceName string
+	ServicePort int32
+
+	// Annotations are the annotations of the route object.
+	Annotations map[string]string
+}
+
+// RouteTarget selects the API expressing a Route and what implements it: an
+// ingress class for Ingresses, or a Gateway for HTTPRoutes.
+type RouteTarget struct {
+	// IngressClass is the class of Ingresses, if the target is an Ingress.
+	IngressClass string
+
+	// GatewayNamespace and GatewayName are the Gateway HTTPRoutes are
+	// attached to, if the target is a Gateway.
+	GatewayNamespace string
+	GatewayName      string
+}
+
+// IngressTarget targets Ingresses of the given class.
+func IngressTarget(ingressClass string) RouteTarget {
+	return RouteTarget{IngressClass: ingressClass}
+}
+
+// GatewayTarget targets HTTPRoutes attached to the given Gateway, which is in
+// the namespace of the routes if the namespace is empty.
+func GatewayTarget(namespace, name string) RouteTarget {
+	return RouteTarget{GatewayNamespace: namespace, GatewayName: name}
+}
+
+// IsGateway indicates whether the target is a Gateway.
+func (t RouteTarget) IsGateway() bool {
+	return t.GatewayName != ""
+}
+
+// String describes the target, e.g. as the name of a subtest.
+func (t RouteTarget) String() string {
+	if t.IsGateway() && t.GatewayNamespace == "" {
+		return "HTTPRoute/" + t.GatewayName
+	}
+	if t.IsGateway() {
+		return fmt.Sprintf("HTTPRoute/%s/%s", t.GatewayNamespace, t.GatewayName)
+	}
+	return "Ingress/" + t.IngressClass
+}
+
+// -----------------------------------------------------------------------------
+// Public Functions - Routes
+// -----------------------------------------------------------------------------
+
+// Object provides the route as an object of the API of the target, either an
+// *netv1.Ingress or a *gatewayv1.HTTPRoute.
+func (r Route) Object(target RouteTarget) client.Object {
+	if target.IsGateway() {
+		return r.HTTPRoute(target.GatewayNamespace, target.GatewayName)
+	}
+	return r.Ingress(target.IngressClass)
+}
+
+// Ingress provides the route as an Ingress of the given class, see NewIngress.
+func (r Route) Ingress(ingressClass string) *netv1.Ingress {
+	return NewIngress(r.Name,
+		WithNamespace(r.Namespace),
+		WithAnnotations(r.Annotations),
+		WithIngressClass(ingressClass),
+		WithHost(r.Host),
+		WithPath(r.path()),
+		WithPorts(r.ServicePort),
+		withIngressBackend(r.ServiceName),
+	)
+}
+
+// HTTPRoute provides the route as an HTTPRoute attached to the given Gateway,
+// which is in the namespace of the route if the Gateway namespace is empty.
+func (r Route) HTTPRoute(gatewayNamespace, gatewayName string) *gatewayv1.HTTPRoute {
+	builder := gatewayapi.NewHTTPRouteBuilder(r.Namespace, r.Name).
+		WithAnnotations(r.Annotations).
+		WithParent(gatewayNamespace, gatewayName, "").
+		WithPathPrefixRule(r.path(), r.ServiceName, r.ServicePort)
+	if r.Host != "" {
+		builder.WithHostnames(r.Host)
+	}
+	return builder.Build()
+}
+
+// -----------------------------------------------------------------------------
+// Private Functions - Routes
+// -----------------------------------------------------------------------------
+
+func (r Route) path() string {
+	if r.Path == "" {
+		return "/"
+	}
+	return r.Path
+}
@@ -0,0 +1,72 @@
+package generators
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	netv1 "k8s.io/api/networking/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestRouteTargets(t *testing.T) {
+	route := Route{
+		Name:        "echo",
+		Namespace:   "echo",
+		Host:        "echo.example.com",
+		Path:        "/echo",
+		ServiceName: "echo-svc",
+		ServicePort: 1027,
+	}
+
+	for _, target := range []RouteTarget{IngressTarget("kong"), GatewayTarget("kong-system", "kong")} {
+		t.Run(target.String(), func(t *testing.T) {
+			obj := route.Object(target)
+			assert.Equal(t, "echo", obj.GetName())
+			assert.Equal(t, "echo", obj.GetNamespace())
+
+			switch o := obj.(type) {
+			case *netv1.Ingress:
+				assert.False(t, target.IsGateway())
+				assert.Equal(t, "kong", *o.Spec.IngressClassName)
+				rule := o.Spec.Rules[0]
+				assert.Equal(t, "echo.example.com", rule.Host)
+				path := rule.HTTP.Paths[0]
+				assert.Equal(t, "/echo", path.Path)
+				assert.Equal(t, "echo-svc", path.Backend.Service.Name)
+				assert.Equal(t, int32(1027), path.Backend.Service.Port.Number)
+			case *gatewayv1.HTTPRoute:
+				assert.True(t, target.IsGateway())
+				require.Len(t, o.Spec.ParentRefs, 1)
+				assert.Equal(t, gatewayv1.Namespace("kong-system"), *o.Spec.ParentRefs[0].Namespace)
+				assert.Equal(t, gatewayv1.ObjectName("kong"), o.Spec.ParentRefs[0].Name)
+				assert.Equal(t, []gatewayv1.Hostname{"echo.example.com"}, o.Spec.Hostnames)
+				rule := o.Spec.Rules[0]
+				assert.Equal(t, gatewayv1.PathMatchPathPrefix, *rule.Matches[0].Path.Type)
+				assert.Equal(t, "/echo", *rule.Matches[0].Path.Value)
+				backend := rule.BackendRefs[0]
+				assert.Equal(t, gatewayv1.ObjectName("echo-svc"), backend.Name)
+				assert.Equal(t, gatewayv1.PortNumber(1027), *backend.Port)
+			default:
+				t.Fatalf("unexpected route object %T", obj)
+			}
+		})
+	}
+}
+
+func TestRouteDefaults(t *testing.T) {
+	route := Route{Name: "echo", ServiceName: "echo", ServicePort: 80}
+
+	ingress := route.Ingress("")
+	assert.Nil(t, ingress.Spec.IngressClassName)
+	assert.Equal(t, "/", ingress.Spec.Rules[0].HTTP.Paths[0].Path)
+
+	httpRoute := route.HTTPRoute("default", "gateway")
+	assert.Empty(t, httpRoute.Spec.Hostnames)
+	assert.Equal(t, "/", *httpRoute.Spec.Rules[0].Matches[0].Path.Value)
+
+	target := GatewayTarget("", "gateway")
+	assert.Equal(t, "HTTPRoute/gateway", target.String())
+	httpRoute = route.Object(target).(*gatewayv1.HTTPRoute)
+	assert.Nil(t, httpRoute.Spec.ParentRefs[0].Namespace, "the Gateway is in the namespace of the route")
+}
@@ -15,12 +15,24 @@ import (
 
 // GetKustomizedManifest takes a kustomization and any number of manifest readers. It adds the manifests to the
 // kustomization's resources and returns a reader with the rendered kustomization.
+// Absolute paths of local resources are supported, in spite of kustomize itself
+// only supporting relative ones.
 func GetKustomizedManifest(kustomization types.Kustomization, manifests ...io.Reader) (io.Reader, error) {
 	workDir, err := os.MkdirTemp("", "ktf.")
 	if err != nil {
 		return nil, err
 	}
 	defer os.RemoveAll(workDir)
+	resources := make([]string, 0, len(kustomization.Resources)+len(manifests))
+	for _, resource := range kustomization.Resources {
+		if filepath.IsAbs(resource) {
+			if resource, err = filepath.Rel(workDir, resource); err != nil {
+				return nil, err
+			}
+		}
+		resources = append(resources, resource)
+	}
+	kustomization.Resources = resources
 	for i, manifest := range manifests {
 		orig, err := io.ReadAll(manifest)
 		if err != nil {
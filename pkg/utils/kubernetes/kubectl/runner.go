@@ -0,0 +1,153 @@
+package kubectl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+)
+
+// -----------------------------------------------------------------------------
+// Kubectl Runner - Errors
+// -----------------------------------------------------------------------------
+
+// ErrNotInstalled indicates that the kubectl binary wasn't found in the PATH.
+var ErrNotInstalled = errors.New("kubectl is not installed")
+
+// Error is a kubectl command which failed, with its output.
+type Error struct {
+	// Args are the arguments kubectl was run with, excluding the kubeconfig.
+	Args []string
+
+	Stdout string
+	Stderr string
+
+	// ExitCode is the exit code of kubectl, -1 if it didn't exit (e.g. because
+	// the context was done).
+	ExitCode int
+
+	Err error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("kubectl %s failed with exit code %d STDOUT=(%s) STDERR=(%s): %s",
+		strings.Join(e.Args, " "), e.ExitCode, e.Stdout, e.Stderr, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// -----------------------------------------------------------------------------
+// Kubectl Runner
+// -----------------------------------------------------------------------------
+
+// Result is the output of a successful kubectl command.
+type Result struct {
+	Stdout string
+	Stderr string
+}
+
+// Runner runs kubectl subcommands against a cluster, for the cases where the
+// Go client is cumbersome (e.g. diff, kustomize or wait). A temporary
+// kubeconfig for the cluster is generated for each command and removed once
+// it completed.
+type Runner struct {
+	cluster   clusters.Cluster
+	namespace string
+}
+
+// NewRunner provides a Runner for kubectl subcommands against the cluster.
+func NewRunner(cluster clusters.Cluster) *Runner {
+	return &Runner{cluster: cluster}
+}
+
+// WithNamespace runs subcommands in the given namespace instead of the
+// default namespace.
+func (r *Runner) WithNamespace(namespace string) *Runner {
+	r.namespace = namespace
+	return r
+}
+
+// Run runs kubectl with the given arguments, e.g. "get", "pods". A failing
+// command provides an *Error.
+func (r *Runner) Run(ctx context.Context, args ...string) (*Result, error) {
+	return r.RunWithInput(ctx, nil, args...)
+}
+
+// RunWithInput runs kubectl with the given arguments and input on STDIN, e.g.
+// for "apply", "-f", "-". A failing command provides an *Error.
+func (r *Runner) RunWithInput(ctx context.Context, stdin io.Reader, args ...string) (*Result, error) {
+	if _, err := exec.LookPath("kubectl"); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNotInstalled, err)
+	}
+
+	kubeconfig, err := clusters.TempKubeconfig(r.cluster)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(kubeconfig.Name())
+
+	flags := []string{"--kubeconfig", kubeconfig.Name()}
+	if r.namespace != "" {
+		flags = append(flags, "--namespace", r.namespace)
+	}
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	cmd := exec.CommandContext(ctx, "kubectl", append(flags, args...)...)
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		exitCode := -1
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitCode = exitErr.ExitCode()
+		}
+		return nil, &Error{Args: args, Stdout: stdout.String(), Stderr: stderr.String(), ExitCode: exitCode, Err: err}
+	}
+
+	return &Result{Stdout: stdout.String(), Stderr: stderr.String()}, nil
+}
+
+// Diff provides the differences between the objects of the YAML manifest and
+// the live objects on the cluster, empty if there are none.
+func (r *Runner) Diff(ctx context.Context, manifest string) (string, error) {
+	_, err := r.RunWithInput(ctx, strings.NewReader(manifest), "diff", "-f", "-")
+	if err == nil {
+		return "", nil
+	}
+	// kubectl diff exits with 1 if there are differences, greater codes
+	// indicate that it failed.
+	var kubectlErr *Error
+	if errors.As(err, &kubectlErr) && kubectlErr.ExitCode == 1 {
+		return kubectlErr.Stdout, nil
+	}
+	return "", err
+}
+
+// Kustomize renders the kustomization in the directory (or URL) and provides
+// the resulting YAML manifest.
+func (r *Runner) Kustomize(ctx context.Context, dir string) (string, error) {
+	result, err := r.Run(ctx, "kustomize", dir)
+	if err != nil {
+		return "", err
+	}
+	return result.Stdout, nil
+}
+
+// Wait waits for the resources (e.g. "deployment/proxy" or "pods", "-l",
+// "app=proxy") to meet the condition (e.g. "condition=Available" or "delete",
+// as with kubectl wait --for) within the timeout.
+func (r *Runner) Wait(ctx context.Context, condition string, timeout time.Duration, resources ...string) error {
+	args := append([]string{"wait", "--for", condition, "--timeout", timeout.String()}, resources...)
+	_, err := r.Run(ctx, args...)
+	return err
+}
@@ -0,0 +1,67 @@
+package kubectl
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+)
+
+// configCluster is a Cluster which only provides its configuration.
+type configCluster struct {
+	clusters.Cluster
+}
+
+func (configCluster) Name() string         { return "test" }
+func (configCluster) Config() *rest.Config { return &rest.Config{Host: "https://127.0.0.1:6443"} }
+
+// fakeKubectl installs a kubectl script on the PATH, which prints its
+// arguments (without the kubeconfig). Diffs also print their input and exit
+// with 1, as for differences.
+func fakeKubectl(t *testing.T) {
+	dir := t.TempDir()
+	script := `#!/bin/sh
+shift 2
+echo "$@"
+case "$*" in *diff*) while read -r line; do echo "$line"; done; echo "drifted" >&2; exit 1;; esac
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "kubectl"), []byte(script), 0o700)) //nolint:gomnd
+	t.Setenv("PATH", dir)
+}
+
+func TestRunner(t *testing.T) {
+	fakeKubectl(t)
+	ctx := context.Background()
+	r := NewRunner(configCluster{}).WithNamespace("kong")
+
+	result, err := r.Run(ctx, "get", "pods")
+	require.NoError(t, err)
+	assert.Equal(t, "--namespace kong get pods\n", result.Stdout)
+
+	require.NoError(t, r.Wait(ctx, "condition=Available", time.Minute, "deployment/proxy"))
+
+	diff, err := r.Diff(ctx, "kind: ConfigMap\n")
+	require.NoError(t, err)
+	assert.Equal(t, "--namespace kong diff -f -\nkind: ConfigMap\n", diff)
+
+	_, err = NewRunner(configCluster{}).Run(ctx, "diff", "-f", "missing.yaml")
+	var kubectlErr *Error
+	require.ErrorAs(t, err, &kubectlErr)
+	assert.Equal(t, 1, kubectlErr.ExitCode)
+	assert.Equal(t, "drifted\n", kubectlErr.Stderr)
+	assert.Equal(t, []string{"diff", "-f", "missing.yaml"}, kubectlErr.Args)
+}
+
+func TestRunnerNotInstalled(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	_, err := NewRunner(configCluster{}).Run(context.Background(), "version")
+	assert.True(t, errors.Is(err, ErrNotInstalled))
+}
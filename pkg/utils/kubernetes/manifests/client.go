@@ -0,0 +1,163 @@
+package manifests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+)
+
+// -----------------------------------------------------------------------------
+// Manifests Client
+// -----------------------------------------------------------------------------
+
+// FieldManager is the field manager used when applying manifests.
+const FieldManager = "ktf"
+
+// ObjectReference identifies an object of a given resource.
+type ObjectReference struct {
+	Resource  schema.GroupVersionResource
+	Namespace string
+	Name      string
+}
+
+func (r ObjectReference) String() string {
+	if r.Namespace == "" {
+		return fmt.Sprintf("%s %s", r.Resource.GroupResource(), r.Name)
+	}
+	return fmt.Sprintf("%s %s/%s", r.Resource.GroupResource(), r.Namespace, r.Name)
+}
+
+// Client applies and deletes the objects of manifests.
+type Client struct {
+	dynamic dynamic.Interface
+	mapper  *restmapper.DeferredDiscoveryRESTMapper
+}
+
+// NewClient provides a Client for the cluster of the given configuration.
+func NewClient(cfg *rest.Config) (*Client, error) {
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		dynamic: dynamicClient,
+		mapper:  restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient)),
+	}, nil
+}
+
+// Reference maps an object to its resource, objects of namespaced resources
+// without a namespace are in the default namespace. A meta.NoKindMatchError
+// is returned if the resource type isn't served by the cluster.
+func (c *Client) Reference(obj *unstructured.Unstructured) (ObjectReference, error) {
+	gvk := obj.GroupVersionKind()
+	mapping, err := c.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return ObjectReference{}, err
+	}
+	ref := ObjectReference{
+		Resource: mapping.Resource,
+		Name:     obj.GetName(),
+	}
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		ref.Namespace = obj.GetNamespace()
+		if ref.Namespace == "" {
+			ref.Namespace = metav1.NamespaceDefault
+		}
+	}
+	return ref, nil
+}
+
+// Apply creates an object, or updates it using server side apply if it exists
+// already, and indicates whether it was created. Objects whose resource type
+// isn't served yet (e.g. for a CRD applied just before) are retried until the
+// context is done.
+func (c *Client) Apply(ctx context.Context, obj *unstructured.Unstructured) (ObjectReference, bool, error) {
+	var ref ObjectReference
+	for {
+		var err error
+		if ref, err = c.Reference(obj); err == nil {
+			break
+		}
+		if !meta.IsNoMatchError(err) {
+			return ref, false, err
+		}
+		c.mapper.Reset()
+		select {
+		case <-ctx.Done():
+			return ref, false, fmt.Errorf("%w: %w", err, ctx.Err())
+		case <-time.After(mappingRetryInterval):
+		}
+	}
+
+	resource := c.dynamic.Resource(ref.Resource).Namespace(ref.Namespace)
+	_, err := resource.Create(ctx, obj, metav1.CreateOptions{FieldManager: FieldManager})
+	if err == nil {
+		return ref, true, nil
+	}
+	if !errors.IsAlreadyExists(err) {
+		return ref, false, err
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return ref, false, err
+	}
+	force := true
+	_, err = resource.Patch(ctx, ref.Name, types.ApplyPatchType, data, metav1.PatchOptions{
+		FieldManager: FieldManager,
+		Force:        &force,
+	})
+	return ref, false, err
+}
+
+// Delete deletes an object, objects which don't exist are ignored.
+func (c *Client) Delete(ctx context.Context, ref ObjectReference) error {
+	propagation := metav1.DeletePropagationBackground
+	err := c.dynamic.Resource(ref.Resource).Namespace(ref.Namespace).Delete(ctx, ref.Name, metav1.DeleteOptions{
+		PropagationPolicy: &propagation,
+	})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// DeleteObjects deletes objects in reverse order, objects which don't exist
+// (including objects whose resource type isn't served anymore) are ignored.
+func (c *Client) DeleteObjects(ctx context.Context, objects []*unstructured.Unstructured) error {
+	for i := len(objects) - 1; i >= 0; i-- {
+		ref, err := c.Reference(objects[i])
+		if err != nil {
+			if meta.IsNoMatchError(err) {
+				continue // the resource type (e.g. a CRD) is gone already
+			}
+			return err
+		}
+		if err := c.Delete(ctx, ref); err != nil {
+			return fmt.Errorf("could not delete %s: %w", ref, err)
+		}
+	}
+	return nil
+}
+
+// mappingRetryInterval is the interval at which the resource of an object is
+// looked up again while its type (e.g. a CRD applied just before) isn't
+// served yet.
+const mappingRetryInterval = time.Second
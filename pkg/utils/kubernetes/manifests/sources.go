@@ -0,0 +1,230 @@
+// Package manifests loads Kubernetes manifests from URLs, local paths,
+// filesystems (e.g. an embed.FS), readers and strings and applies them to a
+// cluster using the Kubernetes API rather than kubectl.
+package manifests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// -----------------------------------------------------------------------------
+// Manifest Sources
+// -----------------------------------------------------------------------------
+
+// Source is a named provider of raw manifests.
+type Source struct {
+	name string
+	load func(ctx context.Context) ([][]byte, error)
+}
+
+func (s Source) String() string {
+	return s.name
+}
+
+// FromURL provides a manifest which is downloaded from the given URL.
+func FromURL(url string) Source {
+	return Source{
+		name: url,
+		load: func(ctx context.Context) ([][]byte, error) {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+			if err != nil {
+				return nil, err
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				return nil, err
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				return nil, fmt.Errorf("unexpected status %s", resp.Status)
+			}
+			manifest, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			return [][]byte{manifest}, nil
+		},
+	}
+}
+
+// FromPath provides a manifest file, or all the manifest files (.yaml, .yml
+// and .json) of a directory in lexical order.
+func FromPath(p string) Source {
+	return Source{
+		name: p,
+		load: func(context.Context) ([][]byte, error) {
+			info, err := os.Stat(p)
+			if err != nil {
+				return nil, err
+			}
+			if !info.IsDir() {
+				manifest, err := os.ReadFile(p)
+				if err != nil {
+					return nil, err
+				}
+				return [][]byte{manifest}, nil
+			}
+			return readFS(os.DirFS(p), nil, false)
+		},
+	}
+}
+
+// FromFS provides the manifest files of a filesystem, e.g. an embed.FS,
+// matching the given glob patterns in the order of the patterns. Without
+// patterns all the manifest files (.yaml, .yml and .json) of the filesystem
+// are provided in lexical order.
+func FromFS(fsys fs.FS, patterns ...string) Source {
+	return Source{
+		name: fmt.Sprintf("filesystem %v", patterns),
+		load: func(context.Context) ([][]byte, error) {
+			return readFS(fsys, patterns, true)
+		},
+	}
+}
+
+// FromReader provides a manifest read from the given reader when loaded.
+func FromReader(r io.Reader) Source {
+	return Source{
+		name: "reader",
+		load: func(context.Context) ([][]byte, error) {
+			manifest, err := io.ReadAll(r)
+			if err != nil {
+				return nil, err
+			}
+			return [][]byte{manifest}, nil
+		},
+	}
+}
+
+// FromYAML provides a raw YAML manifest, which may contain multiple documents.
+func FromYAML(manifest string) Source {
+	return Source{
+		name: "yaml",
+		load: func(context.Context) ([][]byte, error) {
+			return [][]byte{[]byte(manifest)}, nil
+		},
+	}
+}
+
+// readFS reads the files of a filesystem matching the provided glob patterns
+// in order, or all manifest files (recursively if requested) without patterns.
+func readFS(fsys fs.FS, patterns []string, recursive bool) ([][]byte, error) {
+	var names []string
+	if len(patterns) == 0 {
+		err := fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				if name != "." && !recursive {
+					return fs.SkipDir
+				}
+				return nil
+			}
+			if isManifestFile(name) {
+				names = append(names, name)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(names)
+	}
+	for _, pattern := range patterns {
+		matches, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no files match %s", pattern)
+		}
+		names = append(names, matches...)
+	}
+
+	manifests := make([][]byte, 0, len(names))
+	for _, name := range names {
+		manifest, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, manifest)
+	}
+	return manifests, nil
+}
+
+func isManifestFile(name string) bool {
+	switch filepath.Ext(path.Base(name)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+// decodeObjects decodes the objects of a multi document YAML or JSON manifest,
+// the items of lists are provided as separate objects.
+func decodeObjects(manifest []byte) ([]*unstructured.Unstructured, error) {
+	var objects []*unstructured.Unstructured
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(manifest), 4096) //nolint:gomnd
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				return objects, nil
+			}
+			return nil, err
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+		if obj.GetKind() == "" {
+			return nil, fmt.Errorf("object %q has no kind", obj.GetName())
+		}
+
+		if !obj.IsList() {
+			objects = append(objects, obj)
+			continue
+		}
+		err := obj.EachListItem(func(item runtime.Object) error {
+			objects = append(objects, item.(*unstructured.Unstructured))
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Load loads and decodes the objects of all the provided sources in order,
+// multi document manifests and lists provide multiple objects.
+func Load(ctx context.Context, sources ...Source) ([]*unstructured.Unstructured, error) {
+	var objects []*unstructured.Unstructured
+	for _, s := range sources {
+		manifests, err := s.load(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("could not load manifests from %s: %w", s.name, err)
+		}
+		for _, manifest := range manifests {
+			decoded, err := decodeObjects(manifest)
+			if err != nil {
+				return nil, fmt.Errorf("could not decode manifests from %s: %w", s.name, err)
+			}
+			objects = append(objects, decoded...)
+		}
+	}
+	return objects, nil
+}
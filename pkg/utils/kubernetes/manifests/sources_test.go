@@ -0,0 +1,127 @@
+package manifests
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testManifest = `---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: test
+---
+# only a comment
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: a
+    namespace: test
+- apiVersion: v1
+  kind: Secret
+  metadata:
+    name: b
+    namespace: test
+`
+
+func objectNames(t *testing.T, manifests ...[]byte) []string {
+	t.Helper()
+	var names []string
+	for _, manifest := range manifests {
+		objects, err := decodeObjects(manifest)
+		require.NoError(t, err)
+		for _, obj := range objects {
+			names = append(names, obj.GetKind()+"/"+obj.GetName())
+		}
+	}
+	return names
+}
+
+func TestDecodeObjects(t *testing.T) {
+	assert.Equal(t, []string{"Namespace/test", "ConfigMap/a", "Secret/b"}, objectNames(t, []byte(testManifest)))
+	assert.Equal(t, []string{"ConfigMap/c"}, objectNames(t, []byte(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "c"}}`)))
+
+	_, err := decodeObjects([]byte("metadata:\n  name: d\n"))
+	require.EqualError(t, err, `object "d" has no kind`)
+}
+
+func TestSources(t *testing.T) {
+	ctx := context.Background()
+	configMap := func(name string) []byte {
+		return []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: " + name + "\n")
+	}
+
+	t.Run("url", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/manifest.yaml" {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write(configMap("url"))
+		}))
+		defer server.Close()
+
+		manifests, err := FromURL(server.URL + "/manifest.yaml").load(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ConfigMap/url"}, objectNames(t, manifests...))
+
+		_, err = FromURL(server.URL + "/missing.yaml").load(ctx)
+		require.EqualError(t, err, "unexpected status 404 Not Found")
+	})
+
+	t.Run("path", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "b.yaml"), configMap("b"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "a.yml"), configMap("a"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# readme"), 0o600))
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "nested"), 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "nested", "c.yaml"), configMap("c"), 0o600))
+
+		manifests, err := FromPath(dir).load(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ConfigMap/a", "ConfigMap/b"}, objectNames(t, manifests...))
+
+		manifests, err = FromPath(filepath.Join(dir, "b.yaml")).load(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ConfigMap/b"}, objectNames(t, manifests...))
+	})
+
+	t.Run("reader", func(t *testing.T) {
+		manifests, err := FromReader(bytes.NewReader(configMap("reader"))).load(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ConfigMap/reader"}, objectNames(t, manifests...))
+	})
+
+	t.Run("fs", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"manifests/b.yaml":      {Data: configMap("b")},
+			"manifests/a.json":      {Data: []byte(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "a"}}`)},
+			"manifests/crds/c.yaml": {Data: configMap("c")},
+			"other.txt":             {Data: []byte("other")},
+		}
+
+		manifests, err := FromFS(fsys).load(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ConfigMap/a", "ConfigMap/b", "ConfigMap/c"}, objectNames(t, manifests...))
+
+		manifests, err = FromFS(fsys, "manifests/crds/*.yaml", "manifests/b.yaml").load(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ConfigMap/c", "ConfigMap/b"}, objectNames(t, manifests...))
+
+		_, err = FromFS(fsys, "missing/*.yaml").load(ctx)
+		require.EqualError(t, err, "no files match missing/*.yaml")
+	})
+}
@@ -0,0 +1,78 @@
+package nettest
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+// Eventually - TCP & UDP
+// -----------------------------------------------------------------------------
+
+// udpReadTimeout bounds waiting for UDP responses, which may never come.
+const udpReadTimeout = 2 * time.Second
+
+// EventuallyTCPEcho connects to the address until the payload sent to it is
+// echoed back, e.g. by a TCP echo server behind a proxy. Anything else the
+// server sends (e.g. a greeting) is ignored.
+func EventuallyTCPEcho(ctx context.Context, t testing.TB, address, payload string, opts Options) {
+	t.Helper()
+	opts = opts.withDefaults()
+
+	eventually(ctx, t, "TCP echo of "+address, opts, func(ctx context.Context) error {
+		return echo(ctx, "tcp", address, payload)
+	})
+}
+
+// EventuallyUDPEcho sends the payload to the address until it's echoed back,
+// e.g. by a UDP echo server behind a proxy.
+func EventuallyUDPEcho(ctx context.Context, t testing.TB, address, payload string, opts Options) {
+	t.Helper()
+	opts = opts.withDefaults()
+
+	eventually(ctx, t, "UDP echo of "+address, opts, func(ctx context.Context) error {
+		return echo(ctx, "udp", address, payload)
+	})
+}
+
+// echo sends the payload to the address and reads until the payload is
+// received or the context is done.
+func echo(ctx context.Context, network, address, payload string) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// UDP responses may be lost, so the server isn't waited for as long.
+	deadline, ok := ctx.Deadline()
+	if network == "udp" && (!ok || time.Until(deadline) > udpReadTimeout) {
+		deadline, ok = time.Now().Add(udpReadTimeout), true
+	}
+	if ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+	if _, err := conn.Write([]byte(payload)); err != nil {
+		return err
+	}
+
+	var received []byte
+	buf := make([]byte, 1024) //nolint:gomnd
+	for !bytes.Contains(received, []byte(payload)) {
+		n, err := conn.Read(buf)
+		received = append(received, buf[:n]...)
+		if err != nil {
+			if len(received) == 0 {
+				return err
+			}
+			return errUnexpected("received %q instead of the echo of %q: %v", received, payload, err)
+		}
+	}
+	return nil
+}
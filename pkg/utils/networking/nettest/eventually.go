@@ -0,0 +1,130 @@
+// Package nettest provides test helpers asserting that network endpoints
+// eventually become reachable and respond as expected, retrying with backoff
+// and failing the test with the last observed result otherwise.
+package nettest
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+// Eventually - Options
+// -----------------------------------------------------------------------------
+
+const (
+	// DefaultTimeout is the maximum amount of time endpoints are retried for
+	// when neither the options nor the context provide one.
+	DefaultTimeout = 3 * time.Minute
+
+	// DefaultInterval is the initial interval between attempts, which doubles
+	// after each failed attempt up to DefaultMaxInterval.
+	DefaultInterval = 500 * time.Millisecond
+
+	// DefaultMaxInterval is the maximum interval between attempts.
+	DefaultMaxInterval = 5 * time.Second
+
+	// attemptTimeout is the maximum duration of a single attempt.
+	attemptTimeout = 10 * time.Second
+)
+
+// Options configure how endpoints are retried and requested.
+type Options struct {
+	// Timeout is the maximum amount of time the endpoint is retried for, or
+	// until the context is done if it has a deadline, DefaultTimeout otherwise.
+	Timeout time.Duration
+
+	// Interval is the initial interval between attempts, DefaultInterval if
+	// not set. It doubles after each failed attempt up to MaxInterval.
+	Interval time.Duration
+
+	// MaxInterval is the maximum interval between attempts, DefaultMaxInterval
+	// if not set.
+	MaxInterval time.Duration
+
+	// TLSConfig configures TLS for HTTP requests (unless HTTPClient is set)
+	// and gRPC connections, which are in plaintext if it's nil.
+	TLSConfig *tls.Config
+
+	// HTTPClient is the client of HTTP requests, a client with a timeout of
+	// 10 seconds is used if not set.
+	HTTPClient *http.Client
+
+	// Header is sent with HTTP requests, e.g. the Host header to match routes.
+	Header http.Header
+
+	// BodyContains is required to be part of the body of HTTP responses, in
+	// addition to the expected status.
+	BodyContains string
+
+	// GRPCService is the name of the service whose health is checked by
+	// EventuallyGRPCHealthy, the overall health of the server if empty.
+	GRPCService string
+}
+
+// withDefaults provides the options with defaults for unset fields.
+func (o Options) withDefaults() Options {
+	if o.Interval <= 0 {
+		o.Interval = DefaultInterval
+	}
+	if o.MaxInterval <= 0 {
+		o.MaxInterval = DefaultMaxInterval
+	}
+	if o.HTTPClient == nil {
+		o.HTTPClient = &http.Client{
+			Timeout:   attemptTimeout,
+			Transport: &http.Transport{TLSClientConfig: o.TLSConfig, Proxy: http.ProxyFromEnvironment},
+		}
+	}
+	return o
+}
+
+// -----------------------------------------------------------------------------
+// Eventually - Private
+// -----------------------------------------------------------------------------
+
+// eventually retries the attempt with backoff until it succeeds, or fails the
+// test with the number of attempts and the last failure once the timeout is
+// reached. The description of the attempt completes "waiting for ...".
+func eventually(ctx context.Context, t testing.TB, description string, opts Options, attempt func(ctx context.Context) error) {
+	t.Helper()
+
+	timeout := opts.Timeout
+	if _, ok := ctx.Deadline(); !ok && timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	interval := opts.Interval
+	for attempts := 1; ; attempts++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, attemptTimeout)
+		err := attempt(attemptCtx)
+		cancel()
+		if err == nil {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			t.Fatalf("gave up waiting for %s after %d attempts in %s: last attempt failed: %v",
+				description, attempts, time.Since(start).Round(time.Millisecond), err)
+			return
+		case <-time.After(interval):
+		}
+		interval = min(interval*2, opts.MaxInterval) //nolint:gomnd
+	}
+}
+
+// errUnexpected describes an unexpected response.
+func errUnexpected(format string, args ...interface{}) error {
+	return fmt.Errorf("unexpected response: "+format, args...)
+}
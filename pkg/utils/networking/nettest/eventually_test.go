@@ -0,0 +1,118 @@
+package nettest
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// fatalRecorder is a test which records its fatal failure.
+type fatalRecorder struct {
+	testing.TB
+	failure string
+}
+
+func (r *fatalRecorder) Helper() {}
+
+func (r *fatalRecorder) Fatalf(format string, args ...interface{}) {
+	r.failure = fmt.Sprintf(format, args...)
+}
+
+// fastOptions retry quickly.
+var fastOptions = Options{Interval: time.Millisecond, MaxInterval: 10 * time.Millisecond, Timeout: 5 * time.Second}
+
+func TestEventuallyHTTPGet(t *testing.T) {
+	ctx := context.Background()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintf(w, "hello %s", r.Host)
+	}))
+	defer server.Close()
+
+	opts := fastOptions
+	opts.Header = http.Header{"Host": []string{"example.com"}}
+	opts.BodyContains = "hello"
+	body := EventuallyHTTPGet(ctx, t, server.URL, http.StatusOK, opts)
+	assert.Equal(t, "hello example.com", string(body))
+	assert.Equal(t, int32(3), requests.Load())
+
+	recorder := &fatalRecorder{TB: t}
+	opts = fastOptions
+	opts.Timeout = 50 * time.Millisecond
+	EventuallyHTTPGet(ctx, recorder, server.URL, http.StatusTeapot, opts)
+	assert.Contains(t, recorder.failure, "gave up waiting for GET "+server.URL+" to respond with I'm a teapot after")
+	assert.Contains(t, recorder.failure, `last attempt failed: unexpected response: status 200 with body "hello `)
+}
+
+func TestEventuallyTCPEcho(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				buf := make([]byte, 1024)
+				n, _ := conn.Read(buf)
+				_, _ = conn.Write(append([]byte("Welcome! "), buf[:n]...))
+			}()
+		}
+	}()
+
+	EventuallyTCPEcho(context.Background(), t, listener.Addr().String(), "ping", fastOptions)
+}
+
+func TestEventuallyUDPEcho(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			_, _ = conn.WriteTo(buf[:n], addr)
+		}
+	}()
+
+	EventuallyUDPEcho(context.Background(), t, conn.LocalAddr().String(), "ping", fastOptions)
+}
+
+func TestEventuallyGRPCHealthy(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("echo", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	opts := fastOptions
+	opts.GRPCService = "echo"
+	time.AfterFunc(50*time.Millisecond, func() {
+		healthServer.SetServingStatus("echo", healthpb.HealthCheckResponse_SERVING)
+	})
+	EventuallyGRPCHealthy(context.Background(), t, listener.Addr().String(), opts)
+}
@@ -0,0 +1,45 @@
+package nettest
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// -----------------------------------------------------------------------------
+// Eventually - gRPC
+// -----------------------------------------------------------------------------
+
+// EventuallyGRPCHealthy checks the health of the gRPC server at the address
+// (of opts.GRPCService, if set) using the standard health checking protocol
+// until it's serving.
+func EventuallyGRPCHealthy(ctx context.Context, t testing.TB, address string, opts Options) {
+	t.Helper()
+	opts = opts.withDefaults()
+
+	creds := insecure.NewCredentials()
+	if opts.TLSConfig != nil {
+		creds = credentials.NewTLS(opts.TLSConfig)
+	}
+
+	eventually(ctx, t, "gRPC health of "+address, opts, func(ctx context.Context) error {
+		conn, err := grpc.DialContext(ctx, address, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: opts.GRPCService})
+		if err != nil {
+			return err
+		}
+		if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			return errUnexpected("status %s", resp.GetStatus())
+		}
+		return nil
+	})
+}
@@ -0,0 +1,62 @@
+package nettest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// -----------------------------------------------------------------------------
+// Eventually - HTTP
+// -----------------------------------------------------------------------------
+
+// maxReportedBody is the maximum length of response bodies reported in
+// failure messages.
+const maxReportedBody = 512
+
+// EventuallyHTTPGet requests the URL until it responds with the expected
+// status (and a body containing opts.BodyContains, if set), and provides the
+// body of that response. The test fails with the last response or error if
+// that doesn't happen in time.
+func EventuallyHTTPGet(ctx context.Context, t testing.TB, url string, expectStatus int, opts Options) []byte {
+	t.Helper()
+	opts = opts.withDefaults()
+
+	var body []byte
+	eventually(ctx, t, "GET "+url+" to respond with "+http.StatusText(expectStatus), opts, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		for key, values := range opts.Header {
+			for _, value := range values {
+				req.Header.Add(key, value)
+			}
+		}
+		if host := opts.Header.Get("Host"); host != "" {
+			req.Host = host
+		}
+
+		resp, err := opts.HTTPClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		body, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode != expectStatus || !strings.Contains(string(body), opts.BodyContains) {
+			reported := string(body)
+			if len(reported) > maxReportedBody {
+				reported = reported[:maxReportedBody] + "..."
+			}
+			return errUnexpected("status %d with body %q", resp.StatusCode, reported)
+		}
+		return nil
+	})
+	return body
+}
@@ -0,0 +1,25 @@
+package slo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+)
+
+// Assert evaluates the objectives over the window and fails the test with the
+// report if any of them is violated (or if they can't be evaluated).
+func Assert(t testing.TB, ctx context.Context, cluster clusters.Cluster, querier Querier, window Window, objectives ...Objective) Report {
+	t.Helper()
+
+	report, err := Evaluate(ctx, cluster, querier, window, objectives...)
+	if err != nil {
+		t.Fatalf("failed to evaluate SLOs: %v", err)
+	}
+	if !report.Met() {
+		t.Errorf("SLOs violated:\n%s", report)
+	} else {
+		t.Log(report)
+	}
+	return report
+}
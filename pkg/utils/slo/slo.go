@@ -0,0 +1,205 @@
+// Package slo provides helpers to evaluate service level objectives, as
+// PromQL expressions, over the window of a test (e.g. a soak test) using the
+// Prometheus addon, and to fail the test with a report when they're violated:
+//
+//	window := slo.Window{Start: time.Now()}
+//	// ... generate traffic ...
+//	window.End = time.Now()
+//	slo.Assert(t, ctx, env.Cluster(), prometheusAddon, window,
+//		slo.Availability("availability", "kong_http_requests_total", "", 0.999),
+//		slo.LatencyQuantile("p99 latency", "kong_request_latency_ms", "", 0.99, 100),
+//	)
+package slo
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/prometheus"
+)
+
+// -----------------------------------------------------------------------------
+// SLO - Objectives
+// -----------------------------------------------------------------------------
+
+// WindowPlaceholder is replaced in the query of an Objective by the duration
+// of the evaluated Window (e.g. "[$window]" becomes "[300s]").
+const WindowPlaceholder = "$window"
+
+// Comparison indicates how the value of an Objective's query is compared to
+// its target.
+type Comparison string
+
+const (
+	// AtLeast requires the value to be greater than or equal to the target.
+	AtLeast Comparison = ">="
+
+	// AtMost requires the value to be less than or equal to the target.
+	AtMost Comparison = "<="
+)
+
+// Objective is a service level objective: a PromQL expression evaluating to
+// a single value over a Window, and the target that value needs to meet.
+type Objective struct {
+	// Name describes the objective in reports.
+	Name string
+
+	// Query is the PromQL expression, which can refer to the duration of the
+	// window with WindowPlaceholder.
+	Query string
+
+	// Comparison indicates how the value is compared to the Target.
+	Comparison Comparison
+
+	// Target is the value the objective needs to meet.
+	Target float64
+}
+
+// Availability generates an Objective requiring the ratio of requests which
+// didn't fail with a 5xx status, according to a counter of requests with a
+// "code" label (e.g. Kong's "kong_http_requests_total"), to be at least the
+// target (e.g. 0.999). The selector (e.g. `service="default.echo.80"`)
+// restricts the requests which are considered, and can be empty.
+func Availability(name, counter, selector string, target float64) Objective {
+	errors := fmt.Sprintf(`sum(increase(%s[%s])) or vector(0)`, series(counter, selector, `code=~"5.."`), WindowPlaceholder)
+	total := fmt.Sprintf(`sum(increase(%s[%s]))`, series(counter, selector), WindowPlaceholder)
+	return Objective{
+		Name:       name,
+		Query:      fmt.Sprintf(`1 - ((%s) / %s)`, errors, total),
+		Comparison: AtLeast,
+		Target:     target,
+	}
+}
+
+// LatencyQuantile generates an Objective requiring the given quantile (e.g.
+// 0.99) of a histogram (e.g. Kong's "kong_request_latency_ms", without the
+// "_bucket" suffix) to be at most the target, in the unit of the histogram.
+// The selector restricts the observations which are considered, and can be
+// empty.
+func LatencyQuantile(name, histogram, selector string, quantile, target float64) Objective {
+	return Objective{
+		Name: name,
+		Query: fmt.Sprintf(`histogram_quantile(%g, sum by (le) (rate(%s[%s])))`,
+			quantile, series(histogram+"_bucket", selector), WindowPlaceholder),
+		Comparison: AtMost,
+		Target:     target,
+	}
+}
+
+// series generates a PromQL series selector.
+func series(metric string, matchers ...string) string {
+	nonEmpty := make([]string, 0, len(matchers))
+	for _, m := range matchers {
+		if m != "" {
+			nonEmpty = append(nonEmpty, m)
+		}
+	}
+	if len(nonEmpty) == 0 {
+		return metric
+	}
+	return fmt.Sprintf("%s{%s}", metric, strings.Join(nonEmpty, ","))
+}
+
+// -----------------------------------------------------------------------------
+// SLO - Evaluation
+// -----------------------------------------------------------------------------
+
+// Window is the period of a test which Objectives are evaluated over.
+type Window struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Duration provides the duration of the window, rounded up to the second as
+// needed for PromQL range selectors.
+func (w Window) Duration() time.Duration {
+	d := w.End.Sub(w.Start)
+	return time.Duration(math.Ceil(d.Seconds())) * time.Second
+}
+
+// Querier evaluates instant PromQL queries, which prometheus.Addon does.
+type Querier interface {
+	QueryAt(ctx context.Context, cluster clusters.Cluster, query string, at time.Time) ([]prometheus.Sample, error)
+}
+
+// Result is the outcome of evaluating an Objective.
+type Result struct {
+	Objective Objective
+
+	// Value is the value of the objective's query, NaN if there was no data.
+	Value float64
+
+	// Met indicates whether the value meets the objective's target. An
+	// objective without data is not met.
+	Met bool
+}
+
+// Report are the results of evaluating Objectives over a Window.
+type Report struct {
+	Window  Window
+	Results []Result
+}
+
+// Met indicates whether all the objectives were met.
+func (r Report) Met() bool {
+	for _, result := range r.Results {
+		if !result.Met {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats the report with a line per objective.
+func (r Report) String() string {
+	out := new(strings.Builder)
+	fmt.Fprintf(out, "SLO report for %s window starting %s:\n", r.Window.Duration(), r.Window.Start.Format(time.RFC3339))
+	for _, result := range r.Results {
+		status := "MET"
+		if !result.Met {
+			status = "VIOLATED"
+		}
+		value := "no data"
+		if !math.IsNaN(result.Value) {
+			value = fmt.Sprintf("%g", result.Value)
+		}
+		fmt.Fprintf(out, "  [%s] %s: %s (target %s %g)\n", status, result.Objective.Name, value, result.Objective.Comparison, result.Objective.Target)
+	}
+	return out.String()
+}
+
+// Evaluate evaluates the objectives over the window, at the end of the window.
+// Errors are only returned if the queries fail, not if objectives are violated.
+func Evaluate(ctx context.Context, cluster clusters.Cluster, querier Querier, window Window, objectives ...Objective) (Report, error) {
+	report := Report{Window: window}
+	windowDuration := fmt.Sprintf("%ds", int64(window.Duration().Seconds()))
+	for _, objective := range objectives {
+		query := strings.ReplaceAll(objective.Query, WindowPlaceholder, windowDuration)
+		samples, err := querier.QueryAt(ctx, cluster, query, window.End)
+		if err != nil {
+			return report, fmt.Errorf("could not evaluate objective %q: %w", objective.Name, err)
+		}
+		if len(samples) > 1 {
+			return report, fmt.Errorf("objective %q query returned %d series, expected a single one", objective.Name, len(samples))
+		}
+
+		result := Result{Objective: objective, Value: math.NaN()}
+		if len(samples) == 1 && !math.IsNaN(samples[0].Value) {
+			result.Value = samples[0].Value
+			switch objective.Comparison {
+			case AtLeast:
+				result.Met = result.Value >= objective.Target
+			case AtMost:
+				result.Met = result.Value <= objective.Target
+			default:
+				return report, fmt.Errorf("objective %q has unsupported comparison %q", objective.Name, objective.Comparison)
+			}
+		}
+		report.Results = append(report.Results, result)
+	}
+	return report, nil
+}
@@ -0,0 +1,73 @@
+package slo
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/prometheus"
+)
+
+type fakeQuerier struct {
+	results map[string][]prometheus.Sample
+	queries []string
+	at      []time.Time
+}
+
+func (f *fakeQuerier) QueryAt(_ context.Context, _ clusters.Cluster, query string, at time.Time) ([]prometheus.Sample, error) {
+	f.queries = append(f.queries, query)
+	f.at = append(f.at, at)
+	samples, ok := f.results[query]
+	if !ok {
+		return nil, fmt.Errorf("unexpected query %s", query)
+	}
+	return samples, nil
+}
+
+func TestObjectiveQueries(t *testing.T) {
+	assert.Equal(t,
+		`1 - ((sum(increase(kong_http_requests_total{service="echo",code=~"5.."}[$window])) or vector(0)) / sum(increase(kong_http_requests_total{service="echo"}[$window])))`,
+		Availability("availability", "kong_http_requests_total", `service="echo"`, 0.999).Query,
+	)
+	assert.Equal(t,
+		`histogram_quantile(0.99, sum by (le) (rate(kong_request_latency_ms_bucket[$window])))`,
+		LatencyQuantile("p99 latency", "kong_request_latency_ms", "", 0.99, 100).Query,
+	)
+}
+
+func TestEvaluate(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	window := Window{Start: start, End: start.Add(90*time.Second + time.Millisecond)}
+	assert.Equal(t, 91*time.Second, window.Duration())
+
+	querier := &fakeQuerier{results: map[string][]prometheus.Sample{
+		"availability[91s]": {{Value: 0.9995}},
+		"latency[91s]":      {{Value: 250}},
+		"nodata[91s]":       {},
+	}}
+	report, err := Evaluate(context.Background(), nil, querier, window,
+		Objective{Name: "availability", Query: "availability[$window]", Comparison: AtLeast, Target: 0.999},
+		Objective{Name: "latency", Query: "latency[$window]", Comparison: AtMost, Target: 100},
+		Objective{Name: "nodata", Query: "nodata[$window]", Comparison: AtMost, Target: 1},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{window.End, window.End, window.End}, querier.at)
+	require.Len(t, report.Results, 3)
+	assert.True(t, report.Results[0].Met)
+	assert.False(t, report.Results[1].Met)
+	assert.False(t, report.Results[2].Met)
+	assert.True(t, math.IsNaN(report.Results[2].Value))
+	assert.False(t, report.Met())
+	assert.Contains(t, report.String(), "[MET] availability: 0.9995 (target >= 0.999)")
+	assert.Contains(t, report.String(), "[VIOLATED] latency: 250 (target <= 100)")
+	assert.Contains(t, report.String(), "[VIOLATED] nodata: no data (target <= 1)")
+
+	_, err = Evaluate(context.Background(), nil, querier, window, Objective{Name: "unknown", Query: "unknown"})
+	assert.Error(t, err)
+}
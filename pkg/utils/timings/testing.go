@@ -0,0 +1,31 @@
+package timings
+
+import (
+	"os"
+	"testing"
+)
+
+// ReportPathEnvVar is the environment variable which configures the path of
+// the JSON report written by CollectForTest, e.g. for CI to archive it.
+const ReportPathEnvVar = "KTF_TIMINGS_REPORT"
+
+// CollectForTest collects the timings of the named cluster (see
+// CollectForCluster) until the end of the test, then logs them and writes
+// their report to the file at the path of ReportPathEnvVar (if set).
+func CollectForTest(t testing.TB, cluster string) *Collector {
+	t.Helper()
+
+	c := CollectForCluster(cluster)
+	t.Cleanup(func() {
+		c.Stop()
+		for _, timing := range c.Timings() {
+			t.Log(timing)
+		}
+		if path := os.Getenv(ReportPathEnvVar); path != "" {
+			if err := c.WriteReport(path); err != nil {
+				t.Errorf("failed to write timings report to %s: %v", path, err)
+			}
+		}
+	})
+	return c
+}
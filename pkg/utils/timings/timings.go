@@ -0,0 +1,196 @@
+// Package timings collects the durations of cluster builds, addon deployments
+// and readiness waits from the events of the clusters package, to report them
+// (e.g. as a JSON file) and track the startup time of environments over time:
+//
+//	collector := timings.CollectForCluster(name)
+//	env, err := environments.NewBuilder().WithName(name).Build(ctx)
+//	// ... wait for the environment to be ready ...
+//	collector.Stop()
+//	err = collector.WriteReport("timings.json")
+package timings
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+)
+
+// -----------------------------------------------------------------------------
+// Timings
+// -----------------------------------------------------------------------------
+
+// Kind is the kind of operation which was timed.
+type Kind string
+
+const (
+	// ClusterBuild is the creation of a cluster by its builder.
+	ClusterBuild Kind = "ClusterBuild"
+
+	// AddonDeploy is the deployment of an addon to a cluster.
+	AddonDeploy Kind = "AddonDeploy"
+
+	// ReadinessWait is the wait for a cluster and its addons to be ready.
+	ReadinessWait Kind = "ReadinessWait"
+)
+
+// Timing is the duration of an operation.
+type Timing struct {
+	// Kind is the kind of the operation.
+	Kind Kind `json:"kind"`
+
+	// Cluster is the name of the cluster of the operation.
+	Cluster string `json:"cluster"`
+
+	// Addon is the name of the addon of the operation, for AddonDeploy.
+	Addon clusters.AddonName `json:"addon,omitempty"`
+
+	// Start is when the operation started.
+	Start time.Time `json:"start"`
+
+	// Duration is how long the operation took.
+	Duration time.Duration `json:"duration"`
+
+	// Error is the error which failed the operation, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// MarshalJSON encodes the timing with its duration in seconds as well, which
+// is easier to read and to graph than nanoseconds.
+func (t Timing) MarshalJSON() ([]byte, error) {
+	type timing Timing
+	return json.Marshal(struct {
+		timing
+		Seconds float64 `json:"seconds"`
+	}{timing(t), t.Duration.Seconds()})
+}
+
+// String provides a human readable summary of the timing.
+func (t Timing) String() string {
+	s := fmt.Sprintf("%s %s", t.Kind, t.Cluster)
+	if t.Addon != "" {
+		s += "/" + string(t.Addon)
+	}
+	s += " took " + t.Duration.String()
+	if t.Error != "" {
+		s += " (failed: " + t.Error + ")"
+	}
+	return s
+}
+
+// timingForEvent provides the timing reported by the event, if any.
+func timingForEvent(event clusters.Event) (Timing, bool) {
+	var kind Kind
+	switch event.Type {
+	case clusters.EventClusterCreated:
+		kind = ClusterBuild
+	case clusters.EventAddonDeployed:
+		kind = AddonDeploy
+	case clusters.EventReady, clusters.EventReadinessCheckFailed:
+		kind = ReadinessWait
+	default:
+		return Timing{}, false
+	}
+
+	timing := Timing{
+		Kind:     kind,
+		Cluster:  event.Cluster,
+		Addon:    event.Addon,
+		Start:    event.Time.Add(-event.Duration),
+		Duration: event.Duration,
+	}
+	if event.Err != nil {
+		timing.Error = event.Err.Error()
+	}
+	return timing, true
+}
+
+// -----------------------------------------------------------------------------
+// Timings - Collector
+// -----------------------------------------------------------------------------
+
+// Collector collects the timings of the operations done while it's collecting.
+type Collector struct {
+	lock        sync.Mutex
+	timings     []Timing
+	unsubscribe func()
+}
+
+// Collect starts collecting the timings of the operations reported to
+// clusters.Events for all clusters, until the collector is stopped.
+func Collect() *Collector {
+	c := &Collector{}
+	c.unsubscribe = clusters.SubscribeEvents(c.handle)
+	return c
+}
+
+// CollectForCluster starts collecting the timings of the operations on the
+// named cluster, until the collector is stopped. The operations on other
+// clusters (e.g. the ones of tests running in parallel) aren't collected.
+func CollectForCluster(cluster string) *Collector {
+	c := &Collector{}
+	c.unsubscribe = clusters.SubscribeClusterEvents(cluster, c.handle)
+	return c
+}
+
+// Stop stops collecting timings. The timings collected so far are kept.
+func (c *Collector) Stop() {
+	c.unsubscribe()
+}
+
+// Timings provides the timings collected so far, in the order the operations
+// finished.
+func (c *Collector) Timings() []Timing {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return append([]Timing(nil), c.timings...)
+}
+
+// Report provides the report of the timings collected so far.
+func (c *Collector) Report() Report {
+	return Report{Timings: c.Timings()}
+}
+
+// WriteReport writes the report of the timings collected so far to the file
+// at the given path, as JSON.
+func (c *Collector) WriteReport(path string) error {
+	b, err := json.MarshalIndent(c.Report(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o600) //nolint:gomnd
+}
+
+func (c *Collector) handle(event clusters.Event) {
+	timing, ok := timingForEvent(event)
+	if !ok {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.timings = append(c.timings, timing)
+}
+
+// -----------------------------------------------------------------------------
+// Timings - Report
+// -----------------------------------------------------------------------------
+
+// Report is the report of collected timings.
+type Report struct {
+	// Timings are the collected timings.
+	Timings []Timing `json:"timings"`
+}
+
+// Total provides the sum of the durations of the timings of the given kind.
+func (r Report) Total(kind Kind) time.Duration {
+	var total time.Duration
+	for _, timing := range r.Timings {
+		if timing.Kind == kind {
+			total += timing.Duration
+		}
+	}
+	return total
+}
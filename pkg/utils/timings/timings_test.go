@@ -0,0 +1,60 @@
+package timings
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+)
+
+func TestCollector(t *testing.T) {
+	now := time.Now()
+	c := Collect()
+	clusters.EmitEvent(clusters.Event{Type: clusters.EventClusterCreating, Cluster: "test"})
+	clusters.EmitEvent(clusters.Event{Type: clusters.EventClusterCreated, Cluster: "test", Time: now, Duration: time.Minute})
+	clusters.EmitEvent(clusters.Event{Type: clusters.EventAddonDeployed, Cluster: "test", Addon: "kong", Time: now, Duration: 30 * time.Second})
+	clusters.EmitEvent(clusters.Event{Type: clusters.EventAddonDeployed, Cluster: "test", Addon: "metallb", Time: now, Duration: 10 * time.Second, Err: errors.New("boom")})
+	clusters.EmitEvent(clusters.Event{Type: clusters.EventReady, Cluster: "test", Time: now, Duration: 5 * time.Second})
+	c.Stop()
+	clusters.EmitEvent(clusters.Event{Type: clusters.EventClusterCreated, Cluster: "after-stop", Duration: time.Minute})
+
+	timings := c.Timings()
+	require.Len(t, timings, 4)
+	assert.Equal(t, Timing{Kind: ClusterBuild, Cluster: "test", Start: now.Add(-time.Minute), Duration: time.Minute}, timings[0])
+	assert.Equal(t, "AddonDeploy test/metallb took 10s (failed: boom)", timings[2].String())
+	assert.Equal(t, ReadinessWait, timings[3].Kind)
+
+	report := c.Report()
+	assert.Equal(t, 40*time.Second, report.Total(AddonDeploy))
+	assert.Equal(t, time.Minute, report.Total(ClusterBuild))
+
+	path := filepath.Join(t.TempDir(), "timings.json")
+	require.NoError(t, c.WriteReport(path))
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var decoded struct {
+		Timings []map[string]interface{} `json:"timings"`
+	}
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	require.Len(t, decoded.Timings, 4)
+	assert.Equal(t, "kong", decoded.Timings[1]["addon"])
+	assert.Equal(t, float64(30), decoded.Timings[1]["seconds"])
+}
+
+func TestCollectForCluster(t *testing.T) {
+	c := CollectForCluster("test")
+	clusters.EmitEvent(clusters.Event{Type: clusters.EventClusterCreated, Cluster: "test", Duration: time.Minute})
+	clusters.EmitEvent(clusters.Event{Type: clusters.EventClusterCreated, Cluster: "parallel", Duration: time.Minute})
+	c.Stop()
+
+	timings := c.Timings()
+	require.Len(t, timings, 1)
+	assert.Equal(t, "test", timings[0].Cluster)
+}
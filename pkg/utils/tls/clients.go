@@ -0,0 +1,40 @@
+package tls
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+// TLS - Clients
+// -----------------------------------------------------------------------------
+
+// ClientConfig provides a configuration for clients which only trust the given
+// certificate authority, and present the client certificate if it's not nil.
+func ClientConfig(ca *Certificate, clientCert *Certificate) (*tls.Config, error) {
+	cfg := &tls.Config{
+		RootCAs:    ca.CertPool(),
+		MinVersion: tls.VersionTLS12,
+	}
+	if clientCert != nil {
+		cert, err := clientCert.TLSCertificate()
+		if err != nil {
+			return nil, err
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+// HTTPClient provides an HTTP client which trusts the given certificate
+// authority, and presents the client certificate for mTLS if it's not nil.
+func HTTPClient(ca *Certificate, clientCert *Certificate) (*http.Client, error) {
+	cfg, err := ClientConfig(ca, clientCert)
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = cfg
+	return &http.Client{Transport: transport, Timeout: time.Second * 10}, nil //nolint:gomnd
+}
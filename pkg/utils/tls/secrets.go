@@ -0,0 +1,45 @@
+package tls
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+)
+
+// -----------------------------------------------------------------------------
+// TLS - Secrets
+// -----------------------------------------------------------------------------
+
+// Secret provides a Secret of type kubernetes.io/tls with the certificate and
+// its key, along with the certificate of its issuer as "ca.crt" (like
+// cert-manager does), e.g. for TLS termination by ingress controllers.
+func (c *Certificate) Secret(namespace, name string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       c.CertPEM,
+			corev1.TLSPrivateKeyKey: c.KeyPEM,
+			"ca.crt":                c.CAPEM,
+		},
+	}
+}
+
+// CreateSecret creates the Secret of the certificate (see Certificate.Secret)
+// in the namespace, replacing it if it exists already.
+func CreateSecret(ctx context.Context, cluster clusters.Cluster, namespace, name string, cert *Certificate) (*corev1.Secret, error) {
+	secrets := cluster.Client().CoreV1().Secrets(namespace)
+	secret, err := secrets.Create(ctx, cert.Secret(namespace, name), metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		secret, err = secrets.Update(ctx, cert.Secret(namespace, name), metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not create secret %s/%s: %w", namespace, name, err)
+	}
+	return secret, nil
+}
@@ -0,0 +1,152 @@
+// Package tls generates certificate authorities and the server and client
+// certificates they issue, for TLS termination and mTLS tests, and provides
+// them as Kubernetes Secrets and as the configuration of HTTP clients.
+package tls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+// TLS - Certificates
+// -----------------------------------------------------------------------------
+
+// DefaultValidity is the validity of generated certificates, which only need
+// to outlive a test run.
+const DefaultValidity = 24 * time.Hour * 365
+
+// serialNumberLimit bounds the random serial numbers of certificates.
+var serialNumberLimit = new(big.Int).Lsh(big.NewInt(1), 128) //nolint:gomnd
+
+// Certificate is a generated certificate along with its private key.
+type Certificate struct {
+	// Cert is the parsed certificate.
+	Cert *x509.Certificate
+
+	// Key is the private key of the certificate.
+	Key *ecdsa.PrivateKey
+
+	// CertPEM and KeyPEM are the PEM encoded certificate and private key.
+	CertPEM []byte
+	KeyPEM  []byte
+
+	// CAPEM is the PEM encoded certificate of the certificate authority which
+	// issued the certificate, which is the certificate itself for CAs.
+	CAPEM []byte
+}
+
+// NewCA generates a self-signed certificate authority with the given common
+// name.
+func NewCA(commonName string) (*Certificate, error) {
+	template := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: commonName},
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	return issue(template, nil)
+}
+
+// IssueServerCert issues a certificate for servers reachable at the given
+// hosts, each of which is either a DNS name or an IP address. The first host
+// is the common name of the certificate.
+func (ca *Certificate) IssueServerCert(hosts ...string) (*Certificate, error) {
+	if len(hosts) == 0 {
+		return nil, fmt.Errorf("a server certificate needs at least one host")
+	}
+	template := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: hosts[0]},
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+	return issue(template, ca)
+}
+
+// IssueClientCert issues a certificate for clients authenticating with the
+// given common name, e.g. for mTLS.
+func (ca *Certificate) IssueClientCert(commonName string) (*Certificate, error) {
+	template := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: commonName},
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	return issue(template, ca)
+}
+
+// TLSCertificate provides the certificate for a tls.Config, e.g. for servers
+// or for clients presenting it.
+func (c *Certificate) TLSCertificate() (tls.Certificate, error) {
+	return tls.X509KeyPair(c.CertPEM, c.KeyPEM)
+}
+
+// CertPool provides a pool trusting the certificate authority which issued
+// the certificate.
+func (c *Certificate) CertPool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(c.CAPEM)
+	return pool
+}
+
+// issue generates a key and a certificate for it from the template, issued by
+// the given certificate authority or self-signed if it's nil.
+func issue(template *x509.Certificate, ca *Certificate) (*Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	template.SerialNumber = serialNumber
+	template.NotBefore = now.Add(-time.Hour)
+	template.NotAfter = now.Add(DefaultValidity)
+
+	parent, parentKey := template, key
+	if ca != nil {
+		parent, parentKey = ca.Cert, ca.Key
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		return nil, fmt.Errorf("could not create certificate for %s: %w", template.Subject.CommonName, err)
+	}
+	cert, err := x509.ParseCertificate(certDER)
+	if err != nil {
+		return nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	caPEM := certPEM
+	if ca != nil {
+		caPEM = ca.CertPEM
+	}
+	return &Certificate{
+		Cert:    cert,
+		Key:     key,
+		CertPEM: certPEM,
+		KeyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		CAPEM:   caPEM,
+	}, nil
+}
@@ -0,0 +1,81 @@
+package tls
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestMutualTLS(t *testing.T) {
+	ca, err := NewCA("ktf-test-ca")
+	require.NoError(t, err)
+	assert.True(t, ca.Cert.IsCA)
+	assert.Equal(t, ca.CertPEM, ca.CAPEM)
+
+	serverCert, err := ca.IssueServerCert("localhost", "127.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"localhost"}, serverCert.Cert.DNSNames)
+	require.Len(t, serverCert.Cert.IPAddresses, 1)
+	clientCert, err := ca.IssueClientCert("tester")
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, r.TLS.PeerCertificates[0].Subject.CommonName)
+	}))
+	serverTLSCert, err := serverCert.TLSCertificate()
+	require.NoError(t, err)
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverTLSCert},
+		ClientCAs:    ca.CertPool(),
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	client, err := HTTPClient(ca, clientCert)
+	require.NoError(t, err)
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "tester", string(body))
+
+	// clients without a certificate are rejected.
+	client, err = HTTPClient(ca, nil)
+	require.NoError(t, err)
+	req, err = http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err = client.Do(req)
+	if err == nil {
+		resp.Body.Close()
+	}
+	require.Error(t, err)
+
+	_, err = ca.IssueServerCert()
+	require.Error(t, err)
+}
+
+func TestSecret(t *testing.T) {
+	ca, err := NewCA("ktf-test-ca")
+	require.NoError(t, err)
+	cert, err := ca.IssueServerCert("example.com")
+	require.NoError(t, err)
+
+	secret := cert.Secret("kong", "example-tls")
+	assert.Equal(t, corev1.SecretTypeTLS, secret.Type)
+	assert.Equal(t, "kong", secret.Namespace)
+	assert.Equal(t, cert.CertPEM, secret.Data[corev1.TLSCertKey])
+	assert.Equal(t, cert.KeyPEM, secret.Data[corev1.TLSPrivateKeyKey])
+	assert.Equal(t, ca.CertPEM, secret.Data["ca.crt"])
+}
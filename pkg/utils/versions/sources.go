@@ -0,0 +1,96 @@
+package versions
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/github"
+)
+
+// -----------------------------------------------------------------------------
+// Version Sources
+// -----------------------------------------------------------------------------
+
+// Source provides the available versions of a component.
+type Source interface {
+	Versions(ctx context.Context) ([]semver.Version, error)
+}
+
+// SourceFunc is a function implementing Source.
+type SourceFunc func(ctx context.Context) ([]semver.Version, error)
+
+// Versions provides the available versions.
+func (f SourceFunc) Versions(ctx context.Context) ([]semver.Version, error) {
+	return f(ctx)
+}
+
+// GithubReleases provides the versions of the releases of a Github repository
+// given an Organization and Repository name.
+func GithubReleases(org, repo string) Source {
+	return SourceFunc(func(ctx context.Context) ([]semver.Version, error) {
+		return github.ListReleasesForRepo(ctx, org, repo)
+	})
+}
+
+// HelmChart provides the versions of a chart in the index of a Helm chart
+// repository given the repository URL and the name of the chart (without the
+// repository name prefix).
+func HelmChart(repoURL, chart string) Source {
+	return SourceFunc(func(ctx context.Context) ([]semver.Version, error) {
+		url := strings.TrimSuffix(repoURL, "/") + "/index.yaml"
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't fetch helm repository index %s: %w", url, err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("couldn't fetch helm repository index %s: %s", url, resp.Status)
+		}
+		raw, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		return parseHelmIndex(raw, chart)
+	})
+}
+
+// helmIndex is the subset of a Helm repository index needed to list the
+// versions of charts.
+type helmIndex struct {
+	Entries map[string][]struct {
+		Version string `json:"version"`
+	} `json:"entries"`
+}
+
+// parseHelmIndex provides the versions of a chart in a Helm repository index,
+// versions which aren't semver are omitted.
+func parseHelmIndex(raw []byte, chart string) ([]semver.Version, error) {
+	index := helmIndex{}
+	if err := yaml.Unmarshal(raw, &index); err != nil {
+		return nil, fmt.Errorf("invalid helm repository index: %w", err)
+	}
+	entries, ok := index.Entries[chart]
+	if !ok {
+		return nil, fmt.Errorf("chart %s not found in helm repository index", chart)
+	}
+
+	versions := make([]semver.Version, 0, len(entries))
+	for _, entry := range entries {
+		version, err := semver.ParseTolerant(entry.Version)
+		if err != nil {
+			continue
+		}
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
@@ -0,0 +1,104 @@
+// Package versions provides the version selection shared by addons: addon
+// builders provide WithVersion to pin an exact version and WithMinorVersion to
+// select the latest patch release of a minor version, similar to the cluster
+// version options of the GKE cluster builder. Selectors which aren't exact are
+// resolved against a Source, such as Github releases or a Helm chart index.
+package versions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blang/semver/v4"
+)
+
+// -----------------------------------------------------------------------------
+// Version Selectors
+// -----------------------------------------------------------------------------
+
+// Selector selects a version amongst the available versions of a component.
+// The zero value selects the latest version.
+type Selector struct {
+	exact     *semver.Version
+	minorOnly bool
+	major     uint64
+	minor     uint64
+}
+
+// Latest selects the latest (non pre-release) version.
+func Latest() Selector {
+	return Selector{}
+}
+
+// Exact selects the given version.
+func Exact(version semver.Version) Selector {
+	return Selector{exact: &version}
+}
+
+// LatestPatch selects the latest (non pre-release) patch version of the given
+// major and minor version.
+func LatestPatch(major, minor uint64) Selector {
+	return Selector{minorOnly: true, major: major, minor: minor}
+}
+
+// IsLatest indicates whether the latest version is selected.
+func (s Selector) IsLatest() bool {
+	return s.exact == nil && !s.minorOnly
+}
+
+// IsExact indicates whether an exact version is selected, which doesn't
+// require resolution.
+func (s Selector) IsExact() bool {
+	return s.exact != nil
+}
+
+func (s Selector) String() string {
+	switch {
+	case s.exact != nil:
+		return s.exact.String()
+	case s.minorOnly:
+		return fmt.Sprintf("%d.%d.x", s.major, s.minor)
+	default:
+		return "latest"
+	}
+}
+
+// Select selects the version amongst the provided versions. An exact version
+// is selected even if it's not amongst them.
+func (s Selector) Select(versions []semver.Version) (semver.Version, error) {
+	if s.exact != nil {
+		return *s.exact, nil
+	}
+
+	var selected *semver.Version
+	for i := range versions {
+		v := versions[i]
+		if len(v.Pre) != 0 {
+			continue
+		}
+		if s.minorOnly && (v.Major != s.major || v.Minor != s.minor) {
+			continue
+		}
+		if selected == nil || v.GT(*selected) {
+			selected = &v
+		}
+	}
+	if selected == nil {
+		return semver.Version{}, fmt.Errorf("no version matching %s available", s)
+	}
+	return *selected, nil
+}
+
+// Resolve resolves the selected version using the versions available from the
+// provided source, exact versions are resolved without using the source.
+func (s Selector) Resolve(ctx context.Context, source Source) (semver.Version, error) {
+	if s.exact != nil {
+		return *s.exact, nil
+	}
+
+	versions, err := source.Versions(ctx)
+	if err != nil {
+		return semver.Version{}, fmt.Errorf("could not resolve version %s: %w", s, err)
+	}
+	return s.Select(versions)
+}
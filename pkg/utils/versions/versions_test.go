@@ -0,0 +1,110 @@
+package versions
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelector(t *testing.T) {
+	available := []semver.Version{
+		semver.MustParse("1.1.0"),
+		semver.MustParse("1.2.3"),
+		semver.MustParse("1.2.10"),
+		semver.MustParse("1.3.0-rc.1"),
+		semver.MustParse("1.2.11-beta.1"),
+		semver.MustParse("0.9.0"),
+	}
+
+	for _, tc := range []struct {
+		selector Selector
+		name     string
+		expected string
+		err      string
+	}{
+		{selector: Latest(), name: "latest", expected: "1.2.10"},
+		{selector: Selector{}, name: "latest", expected: "1.2.10"},
+		{selector: LatestPatch(1, 2), name: "1.2.x", expected: "1.2.10"},
+		{selector: LatestPatch(0, 9), name: "0.9.x", expected: "0.9.0"},
+		{selector: LatestPatch(1, 3), name: "1.3.x", err: "no version matching 1.3.x available"},
+		{selector: Exact(semver.MustParse("2.0.0")), name: "2.0.0", expected: "2.0.0"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.name, tc.selector.String())
+			selected, err := tc.selector.Select(available)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, selected.String())
+		})
+	}
+
+	assert.True(t, Latest().IsLatest())
+	assert.False(t, LatestPatch(1, 2).IsLatest())
+	assert.True(t, Exact(semver.MustParse("1.0.0")).IsExact())
+	assert.False(t, LatestPatch(1, 2).IsExact())
+}
+
+func TestResolve(t *testing.T) {
+	ctx := context.Background()
+	failing := SourceFunc(func(context.Context) ([]semver.Version, error) {
+		return nil, errors.New("unavailable")
+	})
+
+	// exact versions don't need the source
+	version, err := Exact(semver.MustParse("1.0.0")).Resolve(ctx, failing)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", version.String())
+
+	_, err = Latest().Resolve(ctx, failing)
+	require.EqualError(t, err, "could not resolve version latest: unavailable")
+}
+
+const testHelmIndex = `apiVersion: v1
+entries:
+  kong:
+  - name: kong
+    version: 2.33.3
+  - name: kong
+    version: 2.34.0
+  - name: kong
+    version: 2.33.1
+  ingress:
+  - name: ingress
+    version: 0.10.2
+generated: "2024-01-01T00:00:00Z"
+`
+
+func TestHelmChart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/charts/index.yaml" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(testHelmIndex))
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	version, err := LatestPatch(2, 33).Resolve(ctx, HelmChart(server.URL+"/charts/", "kong"))
+	require.NoError(t, err)
+	assert.Equal(t, "2.33.3", version.String())
+
+	version, err = Latest().Resolve(ctx, HelmChart(server.URL+"/charts", "ingress"))
+	require.NoError(t, err)
+	assert.Equal(t, "0.10.2", version.String())
+
+	_, err = Latest().Resolve(ctx, HelmChart(server.URL+"/charts", "missing"))
+	require.EqualError(t, err, "could not resolve version latest: chart missing not found in helm repository index")
+
+	_, err = Latest().Resolve(ctx, HelmChart(server.URL, "kong"))
+	require.ErrorContains(t, err, "404 Not Found")
+}
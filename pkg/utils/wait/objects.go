@@ -0,0 +1,177 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// -----------------------------------------------------------------------------
+// Wait - Workloads
+// -----------------------------------------------------------------------------
+
+// WaitForDeploymentReady waits for a deployment to exist, to have rolled out its
+// latest generation and to have all its replicas available.
+func WaitForDeploymentReady(ctx context.Context, c kubernetes.Interface, namespace, name string) (*appsv1.Deployment, error) {
+	var deployment *appsv1.Deployment
+	err := Until(ctx, fmt.Sprintf("deployment %s/%s to be ready", namespace, name), func(ctx context.Context) (bool, string, error) {
+		var err error
+		deployment, err = c.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return false, "not found", nil
+			}
+			return false, "", err
+		}
+		return deploymentReady(deployment)
+	})
+	return deployment, err
+}
+
+// WaitForPodsReady waits for at least one pod to match the label selector in
+// the namespace and for all matching pods to be ready, and provides them.
+func WaitForPodsReady(ctx context.Context, c kubernetes.Interface, namespace, selector string) ([]corev1.Pod, error) {
+	var pods []corev1.Pod
+	err := Until(ctx, fmt.Sprintf("pods %q in namespace %s to be ready", selector, namespace), func(ctx context.Context) (bool, string, error) {
+		list, err := c.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return false, "", err
+		}
+		pods = list.Items
+		return podsReady(pods)
+	})
+	return pods, err
+}
+
+// -----------------------------------------------------------------------------
+// Wait - APIs
+// -----------------------------------------------------------------------------
+
+// WaitForCRDEstablished waits for a CustomResourceDefinition (given its name,
+// e.g. "kongplugins.configuration.konghq.com") to be established, meaning its
+// resources are served.
+func WaitForCRDEstablished(ctx context.Context, c apiextensionsclient.Interface, name string) error {
+	return Until(ctx, fmt.Sprintf("CRD %s to be established", name), func(ctx context.Context) (bool, string, error) {
+		crd, err := c.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return false, "not found", nil
+			}
+			return false, "", err
+		}
+		for _, condition := range crd.Status.Conditions {
+			if condition.Type == apiextensionsv1.Established {
+				if condition.Status == apiextensionsv1.ConditionTrue {
+					return true, "", nil
+				}
+				return false, condition.Message, nil
+			}
+		}
+		return false, "not established", nil
+	})
+}
+
+// -----------------------------------------------------------------------------
+// Wait - Networking
+// -----------------------------------------------------------------------------
+
+// WaitForServiceAddress waits for a LoadBalancer service to be provisioned an
+// address and provides it (an IP or a hostname).
+func WaitForServiceAddress(ctx context.Context, c kubernetes.Interface, namespace, name string) (string, error) {
+	var address string
+	err := Until(ctx, fmt.Sprintf("service %s/%s to have an address", namespace, name), func(ctx context.Context) (bool, string, error) {
+		service, err := c.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return false, "not found", nil
+			}
+			return false, "", err
+		}
+		if service.Spec.Type != corev1.ServiceTypeLoadBalancer {
+			return false, "", fmt.Errorf("service is of type %s, not %s", service.Spec.Type, corev1.ServiceTypeLoadBalancer)
+		}
+		address = loadBalancerAddress(service.Status.LoadBalancer.Ingress)
+		return address != "", "no load balancer address provisioned", nil
+	})
+	return address, err
+}
+
+// WaitForIngressAddress waits for an Ingress to be provisioned an address and
+// provides it (an IP or a hostname).
+func WaitForIngressAddress(ctx context.Context, c kubernetes.Interface, namespace, name string) (string, error) {
+	var address string
+	err := Until(ctx, fmt.Sprintf("ingress %s/%s to have an address", namespace, name), func(ctx context.Context) (bool, string, error) {
+		ingress, err := c.NetworkingV1().Ingresses(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return false, "not found", nil
+			}
+			return false, "", err
+		}
+		for _, lb := range ingress.Status.LoadBalancer.Ingress {
+			if lb.IP != "" {
+				address = lb.IP
+				return true, "", nil
+			}
+			if lb.Hostname != "" {
+				address = lb.Hostname
+				return true, "", nil
+			}
+		}
+		return false, "no address provisioned", nil
+	})
+	return address, err
+}
+
+// -----------------------------------------------------------------------------
+// Wait - Private
+// -----------------------------------------------------------------------------
+
+func deploymentReady(deployment *appsv1.Deployment) (bool, string, error) {
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return false, "latest generation not observed yet", nil
+	}
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	if deployment.Status.UpdatedReplicas < replicas || deployment.Status.AvailableReplicas < replicas {
+		return false, fmt.Sprintf("%d/%d replicas updated, %d/%d available",
+			deployment.Status.UpdatedReplicas, replicas, deployment.Status.AvailableReplicas, replicas), nil
+	}
+	return true, "", nil
+}
+
+func podsReady(pods []corev1.Pod) (bool, string, error) {
+	if len(pods) == 0 {
+		return false, "no pods found", nil
+	}
+	var ready int
+	for _, pod := range pods {
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+				ready++
+			}
+		}
+	}
+	return ready == len(pods), fmt.Sprintf("%d/%d pods ready", ready, len(pods)), nil
+}
+
+func loadBalancerAddress(ingresses []corev1.LoadBalancerIngress) string {
+	for _, ingress := range ingresses {
+		if ingress.IP != "" {
+			return ingress.IP
+		}
+		if ingress.Hostname != "" {
+			return ingress.Hostname
+		}
+	}
+	return ""
+}
@@ -0,0 +1,113 @@
+package wait
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+// shortly provides a context which gives up waiting quickly.
+func shortly(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestWaitForDeploymentReady(t *testing.T) {
+	deployment := func(name string, available int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kong", Name: name, Generation: 2},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(2))},
+			Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, UpdatedReplicas: 2, AvailableReplicas: available},
+		}
+	}
+	c := fake.NewSimpleClientset(deployment("ready", 2), deployment("unavailable", 1))
+
+	ready, err := WaitForDeploymentReady(shortly(t), c, "kong", "ready")
+	require.NoError(t, err)
+	assert.Equal(t, "ready", ready.Name)
+
+	_, err = WaitForDeploymentReady(shortly(t), c, "kong", "unavailable")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "gave up waiting for deployment kong/unavailable to be ready (2/2 replicas updated, 1/2 available)")
+
+	_, err = WaitForDeploymentReady(shortly(t), c, "kong", "missing")
+	assert.ErrorContains(t, err, "(not found)")
+}
+
+func TestWaitForPodsReady(t *testing.T) {
+	pod := func(name string, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Labels: map[string]string{"app": name[:len(name)-2]}},
+			Status:     corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}}},
+		}
+	}
+	c := fake.NewSimpleClientset(pod("echo-1", corev1.ConditionTrue), pod("echo-2", corev1.ConditionTrue),
+		pod("grpc-1", corev1.ConditionTrue), pod("grpc-2", corev1.ConditionFalse))
+
+	pods, err := WaitForPodsReady(shortly(t), c, "default", "app=echo")
+	require.NoError(t, err)
+	assert.Len(t, pods, 2)
+
+	_, err = WaitForPodsReady(shortly(t), c, "default", "app=grpc")
+	assert.ErrorContains(t, err, `gave up waiting for pods "app=grpc" in namespace default to be ready (1/2 pods ready)`)
+
+	_, err = WaitForPodsReady(shortly(t), c, "default", "app=none")
+	assert.ErrorContains(t, err, "(no pods found)")
+}
+
+func TestWaitForCRDEstablished(t *testing.T) {
+	crd := func(name string, established apiextensionsv1.ConditionStatus) *apiextensionsv1.CustomResourceDefinition {
+		return &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: apiextensionsv1.CustomResourceDefinitionStatus{Conditions: []apiextensionsv1.CustomResourceDefinitionCondition{
+				{Type: apiextensionsv1.Established, Status: established, Message: "installing"},
+			}},
+		}
+	}
+	c := apiextensionsfake.NewSimpleClientset(crd("established.example.com", apiextensionsv1.ConditionTrue),
+		crd("pending.example.com", apiextensionsv1.ConditionFalse))
+
+	require.NoError(t, WaitForCRDEstablished(shortly(t), c, "established.example.com"))
+	assert.ErrorContains(t, WaitForCRDEstablished(shortly(t), c, "pending.example.com"), "(installing)")
+}
+
+func TestWaitForAddresses(t *testing.T) {
+	c := fake.NewSimpleClientset(
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kong", Name: "proxy"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+			Status:     corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{{IP: "172.18.0.100"}}}},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kong", Name: "admin"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP},
+		},
+		&netv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "echo"},
+			Status:     netv1.IngressStatus{LoadBalancer: netv1.IngressLoadBalancerStatus{Ingress: []netv1.IngressLoadBalancerIngress{{Hostname: "echo.example.com"}}}},
+		},
+	)
+
+	address, err := WaitForServiceAddress(shortly(t), c, "kong", "proxy")
+	require.NoError(t, err)
+	assert.Equal(t, "172.18.0.100", address)
+
+	_, err = WaitForServiceAddress(shortly(t), c, "kong", "admin")
+	assert.EqualError(t, err, "failed waiting for service kong/admin to have an address: service is of type ClusterIP, not LoadBalancer")
+
+	address, err = WaitForIngressAddress(shortly(t), c, "default", "echo")
+	require.NoError(t, err)
+	assert.Equal(t, "echo.example.com", address)
+}
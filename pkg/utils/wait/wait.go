@@ -0,0 +1,67 @@
+// Package wait provides context aware functions which wait for common
+// Kubernetes objects to reach a desired state, replacing the ad hoc polling
+// loops (e.g. assert.Eventually) of tests. All functions poll at Interval and,
+// unless the provided context already has a deadline, give up after
+// DefaultTimeout. When giving up the error describes the last observed state.
+package wait
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+// Wait - Timeouts
+// -----------------------------------------------------------------------------
+
+const (
+	// DefaultTimeout is the maximum amount of time waited for when the provided
+	// context doesn't have a deadline.
+	DefaultTimeout = time.Minute * 3
+
+	// Interval is the interval at which conditions are checked.
+	Interval = time.Millisecond * 500
+)
+
+// -----------------------------------------------------------------------------
+// Wait - Conditions
+// -----------------------------------------------------------------------------
+
+// ConditionFunc checks whether a condition is met, if it isn't it describes the
+// observed state for error messages. Returning an error stops waiting.
+type ConditionFunc func(ctx context.Context) (done bool, state string, err error)
+
+// Until waits for a condition to be met. The condition is checked immediately
+// and then at Interval until the context is done, or DefaultTimeout passed if
+// the context has no deadline. The description is used in error messages, e.g.
+// "deployment kong/proxy to be ready".
+func Until(ctx context.Context, description string, condition ConditionFunc) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultTimeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(Interval)
+	defer ticker.Stop()
+
+	for {
+		done, state, err := condition(ctx)
+		if err != nil {
+			return fmt.Errorf("failed waiting for %s: %w", description, err)
+		}
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if state == "" {
+				return fmt.Errorf("gave up waiting for %s: %w", description, ctx.Err())
+			}
+			return fmt.Errorf("gave up waiting for %s (%s): %w", description, state, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
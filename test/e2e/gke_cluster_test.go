@@ -116,7 +116,7 @@ func testGKECluster(t *testing.T, createSubnet bool) {
 	require.NoError(t, <-env.WaitForReady(ctx))
 
 	t.Log("validating kubernetes cluster version")
-	kubernetesVersion, err := env.Cluster().Version()
+	kubernetesVersion, err := env.Cluster().Version(ctx)
 	require.NoError(t, err)
 	require.Equal(t, uint64(1), kubernetesVersion.Major)
 	require.Equal(t, uint64(24), kubernetesVersion.Minor)
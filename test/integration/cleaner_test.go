@@ -131,13 +131,9 @@ func TestCleaner(t *testing.T) {
 	require.NoError(t, cleaner.Cleanup(context.Background()))
 
 	t.Log("verify objects actually got removed")
-	// TODO: Cleaner should also clean the config map but its Kind and APIVersion are also empty.
-	// Possibly related:
-	// - https://github.com/kubernetes/kubernetes/issues/3030
-	// - https://github.com/kubernetes/kubernetes/issues/80609
-	// cfg, err = cluster.Client().CoreV1().ConfigMaps(ns.Name).Get(ctx, cfg.Name, metav1.GetOptions{})
-	// require.Error(t, err)
-	// require.Truef(t, errors.IsNotFound(err), "configmap should be deleted at this point by the cleaner: %v", err)
+	cfg, err = cluster.Client().CoreV1().ConfigMaps(ns.Name).Get(ctx, cfg.Name, metav1.GetOptions{})
+	require.Error(t, err)
+	require.Truef(t, errors.IsNotFound(err), "configmap should be deleted at this point by the cleaner: %v", err)
 
 	gwc, err = gatewayClient.GatewayV1().GatewayClasses().Get(ctx, gwc.Name, metav1.GetOptions{})
 	require.Error(t, err)
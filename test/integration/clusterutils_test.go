@@ -10,7 +10,6 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
-	apiextclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -123,7 +122,7 @@ func TestClusterUtils(t *testing.T) {
 	require.True(t, strings.Contains(err.Error(), "unable to find"))
 
 	t.Log("verifying that KIC CRDs can be deployed to the cluster via Kustomize")
-	apiext, err := apiextclient.NewForConfig(env.Cluster().Config())
+	apiext, err := env.Cluster().APIExtensionsClient()
 	require.NoError(t, err)
 	_, err = apiext.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, "udpingresses.configuration.konghq.com", metav1.GetOptions{})
 	require.Error(t, err)
@@ -148,4 +147,13 @@ data:
 	cfgmap, err := env.Cluster().Client().CoreV1().ConfigMaps(corev1.NamespaceDefault).Get(ctx, "test-cluster-apply", metav1.GetOptions{})
 	require.NoError(t, err)
 	require.Equal(t, "batman", cfgmap.Data["message"])
+
+	t.Log("verifying that test namespaces are removed once the test completed")
+	var testNamespace *corev1.Namespace
+	t.Run("namespace for test", func(t *testing.T) {
+		testNamespace = clusters.NamespaceForTest(ctx, t, env.Cluster())
+		require.Equal(t, "testclusterutils-namespace-for-test", testNamespace.Labels[clusters.TestResourceLabel])
+	})
+	_, err = env.Cluster().Client().CoreV1().Namespaces().Get(ctx, testNamespace.Name, metav1.GetOptions{})
+	require.True(t, errors.IsNotFound(err))
 }
@@ -9,15 +9,19 @@ import (
 	"testing"
 	"time"
 
+	"github.com/docker/docker/client"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
 	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/httpbin"
 	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/kong"
 	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/metallb"
 	"github.com/kong/kubernetes-testing-framework/pkg/clusters/types/kind"
 	environment "github.com/kong/kubernetes-testing-framework/pkg/environments"
-	"github.com/kong/kubernetes-testing-framework/pkg/utils/networking"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/docker"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/networking/nettest"
 )
 
 func TestKindClusterBasics(t *testing.T) {
@@ -57,15 +61,7 @@ func TestKindClusterBasics(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Log("verifying the kong proxy is returning its default 404 response")
-	httpc := http.Client{Timeout: time.Second * 10}
-	require.Eventually(t, func() bool {
-		resp, err := httpc.Get(proxyURL.String())
-		if err != nil {
-			return false
-		}
-		defer resp.Body.Close()
-		return resp.StatusCode == http.StatusNotFound
-	}, time.Minute*3, time.Second)
+	nettest.EventuallyHTTPGet(ctx, t, proxyURL.String(), http.StatusNotFound, nettest.Options{Timeout: time.Minute * 3})
 
 	t.Log("verifying that the kong addon deployed both proxy and controller")
 	kongDeployment, err := env.Cluster().Client().AppsV1().Deployments(kongAddonRaw.Namespace()).Get(ctx, "ingress-controller-kong", metav1.GetOptions{})
@@ -83,7 +79,7 @@ func TestKindClusterBasics(t *testing.T) {
 
 	t.Log("accessing httpbin via ingress to validate that the kong proxy is functioning")
 	httpbinURL := fmt.Sprintf("%s/%s/status/418", proxyURL.String(), httpbinAddon.Path())
-	require.NoError(t, <-networking.WaitForHTTP(ctx, httpbinURL, 418))
+	nettest.EventuallyHTTPGet(ctx, t, httpbinURL, http.StatusTeapot, nettest.Options{})
 }
 
 func TestKindClusterCustomConfigReader(t *testing.T) {
@@ -161,3 +157,106 @@ func TestKindClusterProxyOnly(t *testing.T) {
 	require.Len(t, kongDeployment.Spec.Template.Spec.Containers, 1)
 	require.Equal(t, kongDeployment.Spec.Template.Spec.Containers[0].Name, "proxy")
 }
+
+func TestKindClusterScaleWorkerNodes(t *testing.T) {
+	t.Parallel()
+
+	t.Log("building a kind cluster with resource limits and preloaded images")
+	cluster, err := kind.NewBuilder().
+		WithNodeResources(2, 2*1024*1024*1024).
+		WithPreloadedImages(httpbin.Image).
+		Build(ctx)
+	require.NoError(t, err)
+	defer func() {
+		t.Logf("cleaning up cluster %s", cluster.Name())
+		require.NoError(t, cluster.Cleanup(ctx))
+	}()
+
+	t.Log("scaling the cluster up to 2 worker nodes")
+	require.NoError(t, clusters.ScaleNodes(ctx, cluster, 2))
+	nodes, err := cluster.Client().CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, nodes.Items, 3)
+	for _, node := range nodes.Items {
+		require.True(t, nodeReady(node), "node %s should be Ready", node.Name)
+	}
+
+	t.Log("verifying that the workers were configured like the control plane node")
+	for _, worker := range []string{cluster.Name() + "-worker", cluster.Name() + "-worker2"} {
+		container, err := docker.InspectDockerContainer(worker)
+		require.NoError(t, err)
+		require.Equal(t, int64(2e9), container.HostConfig.NanoCPUs)
+		require.Equal(t, int64(2*1024*1024*1024), container.HostConfig.Memory)
+		images, err := docker.RunPrivilegedCommandWithOutput(ctx, worker, "crictl", "images")
+		require.NoError(t, err)
+		require.Contains(t, string(images), httpbin.Image)
+	}
+
+	t.Log("scaling the cluster down to no worker nodes")
+	require.NoError(t, clusters.ScaleNodes(ctx, cluster, 0))
+	nodes, err = cluster.Client().CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, nodes.Items, 1)
+	require.True(t, nodeReady(nodes.Items[0]))
+}
+
+func TestKindClusterOwnershipLabels(t *testing.T) {
+	t.Parallel()
+
+	t.Log("building a kind cluster with a test ID and custom labels")
+	cluster, err := kind.NewBuilder().
+		WithTestID(t.Name()).
+		WithLabels(map[string]string{"team": "gateway"}).
+		Build(ctx)
+	require.NoError(t, err)
+	cleanedUp := false
+	defer func() {
+		if !cleanedUp {
+			require.NoError(t, cluster.Cleanup(ctx))
+		}
+	}()
+
+	t.Log("verifying that the node container is labelled")
+	container, err := docker.InspectDockerContainer(docker.GetKindContainerID(cluster.Name()))
+	require.NoError(t, err)
+	require.Equal(t, kind.DefaultOwner, container.Config.Labels[kind.OwnerLabel])
+	require.Equal(t, t.Name(), container.Config.Labels[kind.TestIDLabel])
+	require.Equal(t, "gateway", container.Config.Labels["team"])
+	require.Equal(t, t.Name(), cluster.Metadata().Labels[kind.TestIDLabel])
+
+	t.Log("verifying that the cluster is listed as an orphan once it's old enough")
+	orphans, err := kind.ListOrphans(ctx, -time.Minute)
+	require.NoError(t, err)
+	var orphan *kind.Orphan
+	for i := range orphans {
+		if orphans[i].ClusterName == cluster.Name() {
+			orphan = &orphans[i]
+		}
+	}
+	require.NotNil(t, orphan, "cluster %s should be listed as an orphan", cluster.Name())
+	require.Equal(t, t.Name(), orphan.Labels[kind.TestIDLabel])
+	orphans, err = kind.ListOrphans(ctx, time.Hour)
+	require.NoError(t, err)
+	for _, orphan := range orphans {
+		require.NotEqual(t, cluster.Name(), orphan.ClusterName)
+	}
+
+	t.Logf("cleaning up cluster %s", cluster.Name())
+	require.NoError(t, cluster.Cleanup(ctx))
+	cleanedUp = true
+	t.Log("verifying that the labelled node image was removed")
+	dockerc, err := docker.NewNegotiatedClientWithOpts(ctx, client.FromEnv)
+	require.NoError(t, err)
+	defer dockerc.Close()
+	_, _, err = dockerc.ImageInspectWithRaw(ctx, container.Config.Image)
+	require.True(t, client.IsErrNotFound(err), "image %s should be removed: %v", container.Config.Image, err)
+}
+
+func nodeReady(node corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
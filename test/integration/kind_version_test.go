@@ -37,7 +37,7 @@ func TestKindWithClusterVersion(t *testing.T) {
 	}, time.Minute, time.Second)
 
 	t.Logf("verifying that the created cluster is kubernetes version %s", clusterVersion)
-	serverVersion, err := cluster.Version()
+	serverVersion, err := cluster.Version(ctx)
 	require.NoError(t, err)
 	require.Equal(t, clusterVersion.String(), serverVersion.String())
 	require.True(t, clusterVersion.EQ(serverVersion))
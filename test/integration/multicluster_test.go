@@ -0,0 +1,47 @@
+//go:build integration_tests
+
+package integration
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	kongaddon "github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/kong"
+	metallbaddon "github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/metallb"
+	environment "github.com/kong/kubernetes-testing-framework/pkg/environments"
+)
+
+func TestMultiClusterEnvironment(t *testing.T) {
+	t.Parallel()
+
+	t.Log("configuring a multi-cluster testing environment with a kong cluster and a client cluster")
+	kong := kongaddon.New()
+	builder := environment.NewMultiClusterBuilder().
+		WithEnvironment("kong", environment.NewBuilder().WithAddons(kong, metallbaddon.New())).
+		WithEnvironment("client", environment.NewBuilder())
+
+	t.Log("building the testing environments and Kubernetes clusters")
+	env, err := builder.Build(ctx)
+	require.NoError(t, err)
+	environment.CleanupMultiClusterAfterTest(t, env)
+	require.Equal(t, []string{"kong", "client"}, env.Roles())
+	require.NotEqual(t, env.Cluster("kong").Name(), env.Cluster("client").Name())
+
+	t.Log("waiting for the test environments to be ready for use")
+	require.NoError(t, <-env.WaitForReady(ctx))
+
+	t.Log("exposing the kong proxy of the kong cluster to the client cluster")
+	_, err = environment.ExposeService(ctx, env.Cluster("kong"), kong.Namespace(), kong.ProxyServiceName(), env.Cluster("client"))
+	require.NoError(t, err)
+
+	t.Log("reaching the kong proxy from the client cluster by its service name")
+	url := fmt.Sprintf("http://%s.%s.svc/", kong.ProxyServiceName(), kong.Namespace())
+	result, err := clusters.RunJob(ctx, env.Cluster("client"), "curlimages/curl", []string{"curl", "-s", "-o", "/dev/null", "-w", "%{http_code}", url}, clusters.JobOptions{})
+	require.NoError(t, err)
+	assert.True(t, result.Succeeded, result.Logs)
+	assert.Equal(t, "404", result.Logs)
+}
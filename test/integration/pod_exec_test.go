@@ -0,0 +1,53 @@
+//go:build integration_tests
+
+package integration
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters"
+	"github.com/kong/kubernetes-testing-framework/pkg/environments"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/kubernetes/generators"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/wait"
+)
+
+func TestExecInPod(t *testing.T) {
+	t.Parallel()
+
+	t.Log("creating a test environment to test pod exec")
+	env, err := environments.NewBuilder().Build(ctx)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, env.Cleanup(ctx)) }()
+
+	t.Log("waiting for the test environment to be ready")
+	require.NoError(t, <-env.WaitForReady(ctx))
+
+	t.Log("deploying httpbin to exec commands in")
+	namespace, err := clusters.GenerateNamespace(ctx, env.Cluster(), uuid.NewString())
+	require.NoError(t, err)
+	container := generators.NewContainer("httpbin", "kennethreitz/httpbin", 80)
+	deployment := generators.NewDeploymentForContainer(container)
+	_, err = env.Cluster().Client().AppsV1().Deployments(namespace.Name).Create(ctx, deployment, metav1.CreateOptions{})
+	require.NoError(t, err)
+	pods, err := wait.WaitForPodsReady(ctx, env.Cluster().Client(), namespace.Name, "app=httpbin")
+	require.NoError(t, err)
+
+	t.Log("verifying that the output and exit code of commands are captured")
+	result, err := clusters.ExecInPod(ctx, env.Cluster(), namespace.Name, pods[0].Name, "", "sh", "-c", "echo out; echo err >&2; exit 3")
+	require.NoError(t, err)
+	require.Equal(t, "out\n", result.Stdout)
+	require.Equal(t, "err\n", result.Stderr)
+	require.Equal(t, 3, result.ExitCode)
+
+	t.Log("verifying that the in-cluster network can be reached from the pod")
+	result, err = clusters.ExecInPod(ctx, env.Cluster(), namespace.Name, pods[0].Name, "httpbin",
+		"python", "-c", "import urllib.request; print(urllib.request.urlopen('http://localhost/status/200').status)")
+	require.NoError(t, err)
+	require.Equal(t, 0, result.ExitCode, result.Stderr)
+	require.Equal(t, "200\n", result.Stdout)
+}
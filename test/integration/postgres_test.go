@@ -12,6 +12,7 @@ import (
 	kongaddon "github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/kong"
 	metallbaddon "github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/metallb"
 	environment "github.com/kong/kubernetes-testing-framework/pkg/environments"
+	"github.com/kong/kubernetes-testing-framework/pkg/utils/networking/nettest"
 )
 
 func TestKongWithPostgresDBMode(t *testing.T) {
@@ -41,13 +42,5 @@ func TestKongWithPostgresDBMode(t *testing.T) {
 	require.NotNil(t, proxyURL)
 
 	t.Logf("found url %s for proxy, now verifying it is routable", proxyURL)
-	httpc := http.Client{Timeout: time.Second * 3}
-	require.Eventually(t, func() bool {
-		resp, err := httpc.Get(proxyURL.String())
-		if err != nil {
-			return false
-		}
-		defer resp.Body.Close()
-		return resp.StatusCode == http.StatusNotFound
-	}, time.Minute*1, time.Second*1)
+	nettest.EventuallyHTTPGet(ctx, t, proxyURL.String(), http.StatusNotFound, nettest.Options{Timeout: time.Minute * 1})
 }
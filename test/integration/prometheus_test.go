@@ -0,0 +1,53 @@
+//go:build integration_tests
+
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/kubestatemetrics"
+	"github.com/kong/kubernetes-testing-framework/pkg/clusters/addons/prometheus"
+	environment "github.com/kong/kubernetes-testing-framework/pkg/environments"
+)
+
+func TestDeletePrometheusWithKubeStateMetricsDeployed(t *testing.T) {
+	t.Parallel()
+
+	t.Log("configuring the testing environment with prometheus and kube-state-metrics")
+	prometheusAddon := prometheus.New()
+	kubeStateMetricsAddon := kubestatemetrics.New()
+	builder := environment.NewBuilder().WithAddons(prometheusAddon, kubeStateMetricsAddon)
+
+	t.Log("building the testing environment and Kubernetes cluster")
+	env, err := builder.Build(ctx)
+	require.NoError(t, err)
+
+	t.Logf("setting up the environment cleanup for environment %s and cluster %s", env.Name(), env.Cluster().Name())
+	defer func() {
+		t.Logf("cleaning up environment %s and cluster %s", env.Name(), env.Cluster().Name())
+		require.NoError(t, env.Cleanup(ctx))
+	}()
+
+	t.Log("waiting for the test environment to be ready for use")
+	require.NoError(t, <-env.WaitForReady(ctx))
+
+	t.Log("deleting the prometheus addon")
+	require.NoError(t, env.Cluster().DeleteAddon(ctx, prometheusAddon))
+	require.Len(t, env.Cluster().ListAddons(), 1)
+
+	t.Log("verifying that the namespace shared with kube-state-metrics was kept")
+	_, err = env.Cluster().Client().CoreV1().Namespaces().Get(ctx, kubestatemetrics.DefaultNamespace, metav1.GetOptions{})
+	require.NoError(t, err)
+
+	t.Log("verifying that kube-state-metrics is still ready and serving metrics")
+	waitForObjects, ready, err := kubeStateMetricsAddon.Ready(ctx, env.Cluster())
+	require.NoError(t, err)
+	require.Empty(t, waitForObjects)
+	require.True(t, ready)
+	metrics, err := kubeStateMetricsAddon.Metrics(ctx, env.Cluster())
+	require.NoError(t, err)
+	require.Contains(t, string(metrics), "kube_namespace_created")
+}